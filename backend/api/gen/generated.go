@@ -18,24 +18,152 @@ import (
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/nullable"
 	"github.com/oapi-codegen/runtime"
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AuditEntityType.
+const (
+	AuditEntityTypeDataSource AuditEntityType = "data_source"
+	AuditEntityTypeDataType   AuditEntityType = "data_type"
+)
+
+// Defines values for AuditEventAction.
+const (
+	Create AuditEventAction = "create"
+	Delete AuditEventAction = "delete"
+	Update AuditEventAction = "update"
+)
+
+// Defines values for Compression.
+const (
+	CompressionGzip Compression = "gzip"
+	CompressionNone Compression = "none"
+	CompressionZstd Compression = "zstd"
+)
+
+// Defines values for EmptyResponsePolicy.
+const (
+	Fail       EmptyResponsePolicy = "fail"
+	RetryLater EmptyResponsePolicy = "retry_later"
+	Success    EmptyResponsePolicy = "success"
+)
+
+// Defines values for ExecutionErrorCategory.
+const (
+	Deferred      ExecutionErrorCategory = "deferred"
+	EmptyResponse ExecutionErrorCategory = "empty_response"
+	OutOfPlan     ExecutionErrorCategory = "out_of_plan"
+)
+
+// Defines values for ExecutionStatus.
+const (
+	Failed    ExecutionStatus = "failed"
+	Running   ExecutionStatus = "running"
+	Succeeded ExecutionStatus = "succeeded"
+)
+
+// Defines values for ExecutionFileContentEncoding.
+const (
+	ExecutionFileContentEncodingGzip ExecutionFileContentEncoding = "gzip"
+	ExecutionFileContentEncodingZstd ExecutionFileContentEncoding = "zstd"
+)
+
+// Defines values for ProcessingExecutionSourceZone.
+const (
+	ProcessingExecutionSourceZoneBronze  ProcessingExecutionSourceZone = "bronze"
+	ProcessingExecutionSourceZoneLanding ProcessingExecutionSourceZone = "landing"
+	ProcessingExecutionSourceZoneSilver  ProcessingExecutionSourceZone = "silver"
+)
+
+// Defines values for ProcessingExecutionTargetZone.
+const (
+	ProcessingExecutionTargetZoneBronze ProcessingExecutionTargetZone = "bronze"
+	ProcessingExecutionTargetZoneGold   ProcessingExecutionTargetZone = "gold"
+	ProcessingExecutionTargetZoneSilver ProcessingExecutionTargetZone = "silver"
+)
+
+// Defines values for RerunStrategy.
+const (
+	Append    RerunStrategy = "append"
+	Overwrite RerunStrategy = "overwrite"
+)
+
 // Defines values for ScheduleType.
 const (
 	Daily ScheduleType = "daily"
 )
 
+// Defines values for SortOrder.
+const (
+	Id        SortOrder = "id"
+	MinusId   SortOrder = "-id"
+	MinusName SortOrder = "-name"
+	Name      SortOrder = "name"
+)
+
+// AuditEntityType Kind of entity an audit event is about.
+type AuditEntityType string
+
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	Action AuditEventAction `json:"action"`
+
+	// Actor Who made the change; `api_key:<name>/<id>` for API callers, `system` otherwise.
+	Actor string `json:"actor"`
+
+	// After Fields after the change; absent for a delete. For an update, only the
+	// fields whose values changed.
+	After *map[string]interface{} `json:"after,omitempty"`
+
+	// Before Fields before the change; absent for a create. For an update, only the
+	// fields whose values changed.
+	Before   *map[string]interface{} `json:"before,omitempty"`
+	EntityId openapi_types.UUID      `json:"entityId"`
+
+	// EntityType Kind of entity an audit event is about.
+	EntityType AuditEntityType    `json:"entityType"`
+	Id         openapi_types.UUID `json:"id"`
+
+	// OccurredAt When the change was committed.
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+// AuditEventAction defines model for AuditEvent.Action.
+type AuditEventAction string
+
+// AuditEventList defines model for AuditEventList.
+type AuditEventList struct {
+	// Items Audit events on this page, newest first.
+	Items []AuditEvent `json:"items"`
+
+	// NextCursor Cursor for the next page. Absent on the last page.
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// Compression How landing files are compressed at upload. 'none' stores the response as fetched. 'gzip' and 'zstd' store it compressed, with the encoding as the object's Content-Encoding and key suffix (.gz, .zst); reads through the storage layer return the fetched bytes.
+type Compression string
+
 // CreateDataSourceRequest defines model for CreateDataSourceRequest.
 type CreateDataSourceRequest struct {
 	// Enabled Whether the data source is active for ingestion.
 	Enabled bool `json:"enabled"`
 
+	// Kind Provider kind, fixed after creation. Selects the schema that source and data type settings are validated against. Defaults to 'generic', which accepts any settings.
+	Kind *string `json:"kind,omitempty"`
+
 	// Name Name of the data source.
 	Name string `json:"name"`
 
+	// Retention When `task gc` purges superseded landing files of the data type, or of the data types of a data source that set no rule of their own. A file is kept while any rule that is set keeps it, and the current file of a target date is never purged; with no rules, every file is kept. Omitted rules are not set.
+	Retention *RetentionPolicy `json:"retention,omitempty"`
+
 	// Settings Provider-specific configuration.
 	Settings map[string]interface{} `json:"settings"`
 
@@ -48,15 +176,27 @@ type CreateDataTypeRequest struct {
 	// BackfillEnabled Whether historical data backfill is enabled.
 	BackfillEnabled bool `json:"backfillEnabled"`
 
+	// Compression How landing files are compressed at upload. 'none' stores the response as fetched. 'gzip' and 'zstd' store it compressed, with the encoding as the object's Content-Encoding and key suffix (.gz, .zst); reads through the storage layer return the fetched bytes.
+	Compression *Compression `json:"compression,omitempty"`
+
 	// DataSourceId ID of the data source this type belongs to.
 	DataSourceId openapi_types.UUID `json:"dataSourceId"`
 
+	// EmptyResponsePolicy What an extraction whose response holds zero records does. 'success' lands the empty response and succeeds. 'fail' fails the execution without landing a file. 'retry_later' does the same but marks the execution as deferred, for data that is published after the scheduled run; the target date stays a gap for the next run or backfill.
+	EmptyResponsePolicy *EmptyResponsePolicy `json:"emptyResponsePolicy,omitempty"`
+
 	// Enabled Whether this data type should be actively ingested.
 	Enabled bool `json:"enabled"`
 
 	// Name Name of the data type.
 	Name string `json:"name"`
 
+	// RerunStrategy What a re-run for an already extracted target date writes. 'append' stores a new file per run and keeps the earlier ones. 'overwrite' writes every run of a target date to the same key, replacing the previous object.
+	RerunStrategy *RerunStrategy `json:"rerunStrategy,omitempty"`
+
+	// Retention When `task gc` purges superseded landing files of the data type, or of the data types of a data source that set no rule of their own. A file is kept while any rule that is set keeps it, and the current file of a target date is never purged; with no rules, every file is kept. Omitted rules are not set.
+	Retention *RetentionPolicy `json:"retention,omitempty"`
+
 	// Schedule Defines when ingestion runs for a data type.
 	Schedule Schedule `json:"schedule"`
 
@@ -78,9 +218,15 @@ type DataSource struct {
 	// Id Unique identifier of the data source.
 	Id openapi_types.UUID `json:"id"`
 
+	// Kind Provider kind. Selects the schema that source and data type settings are validated against; 'generic' sources accept any settings.
+	Kind string `json:"kind"`
+
 	// Name Name of the data source.
 	Name string `json:"name"`
 
+	// Retention When `task gc` purges superseded landing files of the data type, or of the data types of a data source that set no rule of their own. A file is kept while any rule that is set keeps it, and the current file of a target date is never purged; with no rules, every file is kept. Omitted rules are not set.
+	Retention RetentionPolicy `json:"retention"`
+
 	// Settings Provider-specific configuration passed to the ingestion adapter.
 	Settings map[string]interface{} `json:"settings"`
 
@@ -89,6 +235,18 @@ type DataSource struct {
 
 	// UpdatedAt Time when the data source was last updated (RFC 3339).
 	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Version Version of the data source, incremented on every update. Also returned as the ETag.
+	Version int `json:"version"`
+}
+
+// DataSourceList defines model for DataSourceList.
+type DataSourceList struct {
+	// Items Data sources on this page.
+	Items []DataSource `json:"items"`
+
+	// NextCursor Cursor for the next page. Absent on the last page.
+	NextCursor *string `json:"nextCursor,omitempty"`
 }
 
 // DataType defines model for DataType.
@@ -96,12 +254,18 @@ type DataType struct {
 	// BackfillEnabled Whether historical data backfill is enabled.
 	BackfillEnabled bool `json:"backfillEnabled"`
 
+	// Compression How landing files are compressed at upload. 'none' stores the response as fetched. 'gzip' and 'zstd' store it compressed, with the encoding as the object's Content-Encoding and key suffix (.gz, .zst); reads through the storage layer return the fetched bytes.
+	Compression Compression `json:"compression"`
+
 	// CreatedAt Time when the data type was created (RFC 3339).
 	CreatedAt time.Time `json:"createdAt"`
 
 	// DataSourceId ID of the data source this data type belongs to.
 	DataSourceId openapi_types.UUID `json:"dataSourceId"`
 
+	// EmptyResponsePolicy What an extraction whose response holds zero records does. 'success' lands the empty response and succeeds. 'fail' fails the execution without landing a file. 'retry_later' does the same but marks the execution as deferred, for data that is published after the scheduled run; the target date stays a gap for the next run or backfill.
+	EmptyResponsePolicy EmptyResponsePolicy `json:"emptyResponsePolicy"`
+
 	// Enabled Whether this data type is actively ingested.
 	Enabled bool `json:"enabled"`
 
@@ -111,6 +275,12 @@ type DataType struct {
 	// Name Name of the data type.
 	Name string `json:"name"`
 
+	// RerunStrategy What a re-run for an already extracted target date writes. 'append' stores a new file per run and keeps the earlier ones. 'overwrite' writes every run of a target date to the same key, replacing the previous object.
+	RerunStrategy RerunStrategy `json:"rerunStrategy"`
+
+	// Retention When `task gc` purges superseded landing files of the data type, or of the data types of a data source that set no rule of their own. A file is kept while any rule that is set keeps it, and the current file of a target date is never purged; with no rules, every file is kept. Omitted rules are not set.
+	Retention RetentionPolicy `json:"retention"`
+
 	// Schedule Defines when ingestion runs for a data type.
 	Schedule Schedule `json:"schedule"`
 
@@ -122,14 +292,228 @@ type DataType struct {
 
 	// UpdatedAt Time when the data type was last updated (RFC 3339).
 	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Version Version of the data type, incremented on every update. Also returned as the ETag.
+	Version int `json:"version"`
+}
+
+// DataTypeDependency defines model for DataTypeDependency.
+type DataTypeDependency struct {
+	// DataTypeId ID of the data type depended on.
+	DataTypeId openapi_types.UUID `json:"dataTypeId"`
+
+	// MaxLagDays How many days before the target date a succeeded execution of the dependency may be and still satisfy it. 0 requires the same date.
+	MaxLagDays *int `json:"maxLagDays,omitempty"`
+}
+
+// DataTypeDependencyList defines model for DataTypeDependencyList.
+type DataTypeDependencyList struct {
+	// Items Data types that must have a succeeded execution for a target date before this data type is extracted for it.
+	Items []DataTypeDependency `json:"items"`
+}
+
+// DataTypeList defines model for DataTypeList.
+type DataTypeList struct {
+	// Items Data types on this page.
+	Items []DataType `json:"items"`
+
+	// NextCursor Cursor for the next page. Absent on the last page.
+	NextCursor *string `json:"nextCursor,omitempty"`
 }
 
+// EmptyResponsePolicy What an extraction whose response holds zero records does. 'success' lands the empty response and succeeds. 'fail' fails the execution without landing a file. 'retry_later' does the same but marks the execution as deferred, for data that is published after the scheduled run; the target date stays a gap for the next run or backfill.
+type EmptyResponsePolicy string
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	// Error Human-readable message describing what went wrong.
 	Error string `json:"error"`
 }
 
+// Execution defines model for Execution.
+type Execution struct {
+	// Error Why the execution failed; absent unless failed.
+	Error *string `json:"error,omitempty"`
+
+	// ErrorCategory Why the execution failed, for failures of a known category; absent otherwise. 'empty_response' is an empty response rejected by the data type's empty response policy. 'deferred' is an empty response taken to mean the data is not published yet, left for a later run. 'out_of_plan' is a target date the subscription plan of the source cannot fetch.
+	ErrorCategory *ExecutionErrorCategory `json:"errorCategory,omitempty"`
+
+	// Files Landing files written by the execution.
+	Files      []ExecutionFile `json:"files"`
+	FinishedAt *time.Time      `json:"finishedAt,omitempty"`
+	Id         int             `json:"id"`
+	StartedAt  *time.Time      `json:"startedAt,omitempty"`
+	Status     ExecutionStatus `json:"status"`
+
+	// TargetDate Start of the target date in the data source timezone.
+	TargetDate time.Time `json:"targetDate"`
+}
+
+// ExecutionErrorCategory Why the execution failed, for failures of a known category; absent otherwise. 'empty_response' is an empty response rejected by the data type's empty response policy. 'deferred' is an empty response taken to mean the data is not published yet, left for a later run. 'out_of_plan' is a target date the subscription plan of the source cannot fetch.
+type ExecutionErrorCategory string
+
+// ExecutionStatus defines model for Execution.Status.
+type ExecutionStatus string
+
+// ExecutionFile A landing file in S3. Integrity fields are absent for files recorded
+// before they were tracked; the same values are stored as S3 object metadata.
+type ExecutionFile struct {
+	// CompressedSha256 Hex-encoded SHA-256 digest of the stored object; absent when stored as fetched.
+	CompressedSha256 *string `json:"compressedSha256,omitempty"`
+
+	// CompressedSizeBytes Size of the stored object; absent when stored as fetched.
+	CompressedSizeBytes *int64 `json:"compressedSizeBytes,omitempty"`
+
+	// ContentEncoding Compression of the stored object; absent when stored as fetched.
+	ContentEncoding *ExecutionFileContentEncoding `json:"contentEncoding,omitempty"`
+	ContentType     *string                       `json:"contentType,omitempty"`
+	CreatedAt       time.Time                     `json:"createdAt"`
+	Format          *string                       `json:"format,omitempty"`
+
+	// HttpStatus Status code of the source API response the file was taken from.
+	HttpStatus *int `json:"httpStatus,omitempty"`
+
+	// Key S3 object key.
+	Key string `json:"key"`
+
+	// ManifestKey S3 object key of the manifest of the execution that wrote the file; absent for files recorded before manifests were written.
+	ManifestKey *string `json:"manifestKey,omitempty"`
+
+	// PurgedAt When the object was removed under the data type's retention policy; absent while it is kept.
+	PurgedAt *time.Time `json:"purgedAt,omitempty"`
+
+	// Sha256 Hex-encoded SHA-256 digest of the file as fetched.
+	Sha256 *string `json:"sha256,omitempty"`
+
+	// SizeBytes Size of the file as fetched, before any compression.
+	SizeBytes *int64 `json:"sizeBytes,omitempty"`
+}
+
+// ExecutionFileContentEncoding Compression of the stored object; absent when stored as fetched.
+type ExecutionFileContentEncoding string
+
+// ExecutionList defines model for ExecutionList.
+type ExecutionList struct {
+	// Items Executions on this page, newest first.
+	Items []Execution `json:"items"`
+
+	// NextCursor Cursor for the next page. Absent on the last page.
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// Lineage defines model for Lineage.
+type Lineage struct {
+	// Executions Processing executions the output was derived through: the latest one that wrote it first, then those that wrote its inputs. Executions superseded by a later one writing the same output are left out.
+	Executions []ProcessingExecution `json:"executions"`
+
+	// Key The traced output reference.
+	Key string `json:"key"`
+
+	// LandingKeys Inputs read from the landing zone, sorted.
+	LandingKeys []string `json:"landingKeys"`
+}
+
+// PatchDataSourceRequest JSON Merge Patch (RFC 7396) applied to the data source. Omitted fields are left unchanged. Keys inside settings are merged recursively; a null value removes the key.
+type PatchDataSourceRequest struct {
+	// Enabled Whether the data source is active for ingestion.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Name Name of the data source.
+	Name *string `json:"name,omitempty"`
+
+	// Retention Retention rules to merge into the current policy; null removes every rule.
+	Retention nullable.Nullable[RetentionPolicyPatch] `json:"retention,omitempty"`
+
+	// Settings Provider-specific configuration to merge into the current settings; null removes every setting.
+	Settings nullable.Nullable[map[string]interface{}] `json:"settings,omitempty"`
+
+	// Timezone IANA timezone name.
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// PatchDataTypeRequest JSON Merge Patch (RFC 7396) applied to the data type. Omitted fields are left unchanged. Keys inside settings are merged recursively; a null value removes the key.
+type PatchDataTypeRequest struct {
+	// BackfillEnabled Whether historical data backfill is enabled.
+	BackfillEnabled *bool `json:"backfillEnabled,omitempty"`
+
+	// Compression How landing files are compressed at upload. 'none' stores the response as fetched. 'gzip' and 'zstd' store it compressed, with the encoding as the object's Content-Encoding and key suffix (.gz, .zst); reads through the storage layer return the fetched bytes.
+	Compression *Compression `json:"compression,omitempty"`
+
+	// EmptyResponsePolicy What an extraction whose response holds zero records does. 'success' lands the empty response and succeeds. 'fail' fails the execution without landing a file. 'retry_later' does the same but marks the execution as deferred, for data that is published after the scheduled run; the target date stays a gap for the next run or backfill.
+	EmptyResponsePolicy *EmptyResponsePolicy `json:"emptyResponsePolicy,omitempty"`
+
+	// Enabled Whether this data type should be actively ingested.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Name Name of the data type.
+	Name *string `json:"name,omitempty"`
+
+	// RerunStrategy What a re-run for an already extracted target date writes. 'append' stores a new file per run and keeps the earlier ones. 'overwrite' writes every run of a target date to the same key, replacing the previous object.
+	RerunStrategy *RerunStrategy `json:"rerunStrategy,omitempty"`
+
+	// Retention Retention rules to merge into the current policy; null removes every rule.
+	Retention nullable.Nullable[RetentionPolicyPatch] `json:"retention,omitempty"`
+
+	// Schedule Partial schedule. Omitted fields keep their current value; times, when present, replaces the whole list.
+	Schedule *SchedulePatch `json:"schedule,omitempty"`
+
+	// Settings Data-type-specific ingestion configuration to merge into the current settings; null removes every setting.
+	Settings nullable.Nullable[map[string]interface{}] `json:"settings,omitempty"`
+
+	// StaleTimeoutMinutes Minutes after the scheduled time before a run is considered stale.
+	StaleTimeoutMinutes *int `json:"staleTimeoutMinutes,omitempty"`
+}
+
+// ProcessingExecution A succeeded batch run that moved data from one zone to the next.
+type ProcessingExecution struct {
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Id         int        `json:"id"`
+
+	// InputKeyPatterns Keys of the data read; '*' matches any sequence of characters, including '/'.
+	InputKeyPatterns []string `json:"inputKeyPatterns"`
+
+	// OutputRefs Keys or snapshot references of the data written.
+	OutputRefs     []string `json:"outputRefs"`
+	RecordsRead    int      `json:"recordsRead"`
+	RecordsWritten int      `json:"recordsWritten"`
+
+	// SourceIdentifier Input data set.
+	SourceIdentifier string                        `json:"sourceIdentifier"`
+	SourceZone       ProcessingExecutionSourceZone `json:"sourceZone"`
+	StartedAt        *time.Time                    `json:"startedAt,omitempty"`
+
+	// TargetIdentifier Output data set.
+	TargetIdentifier string                        `json:"targetIdentifier"`
+	TargetZone       ProcessingExecutionTargetZone `json:"targetZone"`
+}
+
+// ProcessingExecutionSourceZone defines model for ProcessingExecution.SourceZone.
+type ProcessingExecutionSourceZone string
+
+// ProcessingExecutionTargetZone defines model for ProcessingExecution.TargetZone.
+type ProcessingExecutionTargetZone string
+
+// RerunStrategy What a re-run for an already extracted target date writes. 'append' stores a new file per run and keeps the earlier ones. 'overwrite' writes every run of a target date to the same key, replacing the previous object.
+type RerunStrategy string
+
+// RetentionPolicy When `task gc` purges superseded landing files of the data type, or of the data types of a data source that set no rule of their own. A file is kept while any rule that is set keeps it, and the current file of a target date is never purged; with no rules, every file is kept. Omitted rules are not set.
+type RetentionPolicy struct {
+	// KeepLatest Number of newest files kept per target date, including the current one.
+	KeepLatest *int `json:"keepLatest,omitempty"`
+
+	// SupersededMaxAgeDays Days a superseded file is kept after it was written.
+	SupersededMaxAgeDays *int `json:"supersededMaxAgeDays,omitempty"`
+}
+
+// RetentionPolicyPatch Partial retention policy. Omitted rules keep their current value; a null rule is no longer set.
+type RetentionPolicyPatch struct {
+	// KeepLatest Number of newest files kept per target date, including the current one.
+	KeepLatest nullable.Nullable[int] `json:"keepLatest,omitempty"`
+
+	// SupersededMaxAgeDays Days a superseded file is kept after it was written.
+	SupersededMaxAgeDays nullable.Nullable[int] `json:"supersededMaxAgeDays,omitempty"`
+}
+
 // Schedule Defines when ingestion runs for a data type.
 type Schedule struct {
 	// Times HH:MM times (24-hour, in the data source's timezone) at which ingestion runs each day.
@@ -142,6 +526,39 @@ type Schedule struct {
 // ScheduleType Schedule cadence; currently only 'daily' is supported.
 type ScheduleType string
 
+// SchedulePatch Partial schedule. Omitted fields keep their current value; times, when present, replaces the whole list.
+type SchedulePatch struct {
+	// Times HH:MM times (24-hour, in the data source's timezone) at which ingestion runs each day.
+	Times *[]string `json:"times,omitempty"`
+
+	// Type Schedule cadence; currently only 'daily' is supported.
+	Type *string `json:"type,omitempty"`
+}
+
+// SortOrder Sort order of a list. A leading '-' sorts descending. 'id' follows creation order because IDs are UUIDv7.
+type SortOrder string
+
+// TriggerExecutionRequest defines model for TriggerExecutionRequest.
+type TriggerExecutionRequest struct {
+	// EndDate Last target date, inclusive. Requires startDate; defaults to startDate.
+	EndDate *openapi_types.Date `json:"endDate,omitempty"`
+
+	// StartDate First target date. Defaults to today in the data source's timezone.
+	StartDate *openapi_types.Date `json:"startDate,omitempty"`
+}
+
+// TriggerExecutionResponse defines model for TriggerExecutionResponse.
+type TriggerExecutionResponse struct {
+	// BlockedTargetDates Target dates not started because a data type this data type depends on has no succeeded execution for them yet.
+	BlockedTargetDates []openapi_types.Date `json:"blockedTargetDates"`
+
+	// ExecutionIds IDs of the executions started by this request.
+	ExecutionIds []int `json:"executionIds"`
+
+	// SkippedTargetDates Target dates skipped because an execution was already running.
+	SkippedTargetDates []openapi_types.Date `json:"skippedTargetDates"`
+}
+
 // UpdateDataSourceRequest defines model for UpdateDataSourceRequest.
 type UpdateDataSourceRequest struct {
 	// Enabled Whether the data source is active for ingestion.
@@ -150,6 +567,9 @@ type UpdateDataSourceRequest struct {
 	// Name Name of the data source.
 	Name string `json:"name"`
 
+	// Retention When `task gc` purges superseded landing files of the data type, or of the data types of a data source that set no rule of their own. A file is kept while any rule that is set keeps it, and the current file of a target date is never purged; with no rules, every file is kept. Omitted rules are not set.
+	Retention *RetentionPolicy `json:"retention,omitempty"`
+
 	// Settings Provider-specific configuration.
 	Settings map[string]interface{} `json:"settings"`
 
@@ -162,12 +582,24 @@ type UpdateDataTypeRequest struct {
 	// BackfillEnabled Whether historical data backfill is enabled.
 	BackfillEnabled bool `json:"backfillEnabled"`
 
+	// Compression How landing files are compressed at upload. 'none' stores the response as fetched. 'gzip' and 'zstd' store it compressed, with the encoding as the object's Content-Encoding and key suffix (.gz, .zst); reads through the storage layer return the fetched bytes.
+	Compression *Compression `json:"compression,omitempty"`
+
+	// EmptyResponsePolicy What an extraction whose response holds zero records does. 'success' lands the empty response and succeeds. 'fail' fails the execution without landing a file. 'retry_later' does the same but marks the execution as deferred, for data that is published after the scheduled run; the target date stays a gap for the next run or backfill.
+	EmptyResponsePolicy *EmptyResponsePolicy `json:"emptyResponsePolicy,omitempty"`
+
 	// Enabled Whether this data type should be actively ingested.
 	Enabled bool `json:"enabled"`
 
 	// Name Name of the data type.
 	Name string `json:"name"`
 
+	// RerunStrategy What a re-run for an already extracted target date writes. 'append' stores a new file per run and keeps the earlier ones. 'overwrite' writes every run of a target date to the same key, replacing the previous object.
+	RerunStrategy *RerunStrategy `json:"rerunStrategy,omitempty"`
+
+	// Retention When `task gc` purges superseded landing files of the data type, or of the data types of a data source that set no rule of their own. A file is kept while any rule that is set keeps it, and the current file of a target date is never purged; with no rules, every file is kept. Omitted rules are not set.
+	Retention *RetentionPolicy `json:"retention,omitempty"`
+
 	// Schedule Defines when ingestion runs for a data type.
 	Schedule Schedule `json:"schedule"`
 
@@ -178,46 +610,208 @@ type UpdateDataTypeRequest struct {
 	StaleTimeoutMinutes int `json:"staleTimeoutMinutes"`
 }
 
+// Cursor defines model for Cursor.
+type Cursor = string
+
 // DataSourceID defines model for DataSourceID.
 type DataSourceID = openapi_types.UUID
 
 // DataTypeID defines model for DataTypeID.
 type DataTypeID = openapi_types.UUID
 
+// EnabledFilter defines model for EnabledFilter.
+type EnabledFilter = bool
+
+// EntityIDFilter defines model for EntityIDFilter.
+type EntityIDFilter = openapi_types.UUID
+
+// EntityTypeFilter Kind of entity an audit event is about.
+type EntityTypeFilter = AuditEntityType
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// LineageKey defines model for LineageKey.
+type LineageKey = string
+
+// NamePrefixFilter defines model for NamePrefixFilter.
+type NamePrefixFilter = string
+
+// SinceFilter defines model for SinceFilter.
+type SinceFilter = time.Time
+
+// Sort Sort order of a list. A leading '-' sorts descending. 'id' follows creation order because IDs are UUIDv7.
+type Sort = SortOrder
+
+// UntilFilter defines model for UntilFilter.
+type UntilFilter = time.Time
+
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = ErrorResponse
+
+// ListAuditEventsParams defines parameters for ListAuditEvents.
+type ListAuditEventsParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's nextCursor. Must be used with the same sort.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// EntityType Only return events about entities of this type.
+	EntityType *EntityTypeFilter `form:"entityType,omitempty" json:"entityType,omitempty"`
+
+	// EntityId Only return events about the entity with this ID.
+	EntityId *EntityIDFilter `form:"entityId,omitempty" json:"entityId,omitempty"`
+
+	// Since Only return events that occurred at or after this time.
+	Since *SinceFilter `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only return events that occurred before this time.
+	Until *UntilFilter `form:"until,omitempty" json:"until,omitempty"`
+}
+
+// ListDataSourcesParams defines parameters for ListDataSources.
+type ListDataSourcesParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's nextCursor. Must be used with the same sort.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort Sort order. A leading '-' sorts descending.
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Enabled Only return items with this enabled flag.
+	Enabled *EnabledFilter `form:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// NamePrefix Only return items whose name starts with this prefix.
+	NamePrefix *NamePrefixFilter `form:"namePrefix,omitempty" json:"namePrefix,omitempty"`
+}
+
+// DeleteDataSourceParams defines parameters for DeleteDataSource.
+type DeleteDataSourceParams struct {
+	// IfMatch ETags from a previous read, separated by commas, or "*". ETags are compared strongly, so weak ETags never match. The request fails with 400 when the header is malformed and with 412 unless an ETag matches the current version.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PatchDataSourceParams defines parameters for PatchDataSource.
+type PatchDataSourceParams struct {
+	// IfMatch ETags from a previous read, separated by commas, or "*". ETags are compared strongly, so weak ETags never match. The request fails with 400 when the header is malformed and with 412 unless an ETag matches the current version.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateDataSourceParams defines parameters for UpdateDataSource.
+type UpdateDataSourceParams struct {
+	// IfMatch ETags from a previous read, separated by commas, or "*". ETags are compared strongly, so weak ETags never match. The request fails with 400 when the header is malformed and with 412 unless an ETag matches the current version.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
 // ListDataTypesParams defines parameters for ListDataTypes.
 type ListDataTypesParams struct {
 	DataSourceId *openapi_types.UUID `form:"dataSourceId,omitempty" json:"dataSourceId,omitempty"`
+
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's nextCursor. Must be used with the same sort.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort Sort order. A leading '-' sorts descending.
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Enabled Only return items with this enabled flag.
+	Enabled *EnabledFilter `form:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// NamePrefix Only return items whose name starts with this prefix.
+	NamePrefix *NamePrefixFilter `form:"namePrefix,omitempty" json:"namePrefix,omitempty"`
+}
+
+// DeleteDataTypeParams defines parameters for DeleteDataType.
+type DeleteDataTypeParams struct {
+	// IfMatch ETags from a previous read, separated by commas, or "*". ETags are compared strongly, so weak ETags never match. The request fails with 400 when the header is malformed and with 412 unless an ETag matches the current version.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PatchDataTypeParams defines parameters for PatchDataType.
+type PatchDataTypeParams struct {
+	// IfMatch ETags from a previous read, separated by commas, or "*". ETags are compared strongly, so weak ETags never match. The request fails with 400 when the header is malformed and with 412 unless an ETag matches the current version.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateDataTypeParams defines parameters for UpdateDataType.
+type UpdateDataTypeParams struct {
+	// IfMatch ETags from a previous read, separated by commas, or "*". ETags are compared strongly, so weak ETags never match. The request fails with 400 when the header is malformed and with 412 unless an ETag matches the current version.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ListDataTypeExecutionsParams defines parameters for ListDataTypeExecutions.
+type ListDataTypeExecutionsParams struct {
+	// Limit Maximum number of items to return.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque cursor from a previous page's nextCursor. Must be used with the same sort.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetLineageParams defines parameters for GetLineage.
+type GetLineageParams struct {
+	// Key Output reference to trace, e.g. the object key of a bronze partition. '*' is not allowed.
+	Key LineageKey `form:"key" json:"key"`
 }
 
 // CreateDataSourceJSONRequestBody defines body for CreateDataSource for application/json ContentType.
 type CreateDataSourceJSONRequestBody = CreateDataSourceRequest
 
+// PatchDataSourceApplicationMergePatchPlusJSONRequestBody defines body for PatchDataSource for application/merge-patch+json ContentType.
+type PatchDataSourceApplicationMergePatchPlusJSONRequestBody = PatchDataSourceRequest
+
 // UpdateDataSourceJSONRequestBody defines body for UpdateDataSource for application/json ContentType.
 type UpdateDataSourceJSONRequestBody = UpdateDataSourceRequest
 
 // CreateDataTypeJSONRequestBody defines body for CreateDataType for application/json ContentType.
 type CreateDataTypeJSONRequestBody = CreateDataTypeRequest
 
+// PatchDataTypeApplicationMergePatchPlusJSONRequestBody defines body for PatchDataType for application/merge-patch+json ContentType.
+type PatchDataTypeApplicationMergePatchPlusJSONRequestBody = PatchDataTypeRequest
+
 // UpdateDataTypeJSONRequestBody defines body for UpdateDataType for application/json ContentType.
 type UpdateDataTypeJSONRequestBody = UpdateDataTypeRequest
 
+// ReplaceDataTypeDependenciesJSONRequestBody defines body for ReplaceDataTypeDependencies for application/json ContentType.
+type ReplaceDataTypeDependenciesJSONRequestBody = DataTypeDependencyList
+
+// TriggerDataTypeExecutionJSONRequestBody defines body for TriggerDataTypeExecution for application/json ContentType.
+type TriggerDataTypeExecutionJSONRequestBody = TriggerExecutionRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List changes to data sources and data types
+	// (GET /api/v1/audit-events)
+	ListAuditEvents(ctx echo.Context, params ListAuditEventsParams) error
 	// List data sources
 	// (GET /api/v1/data-sources)
-	ListDataSources(ctx echo.Context) error
+	ListDataSources(ctx echo.Context, params ListDataSourcesParams) error
 	// Create a new data source
 	// (POST /api/v1/data-sources)
 	CreateDataSource(ctx echo.Context) error
 	// Delete a data source
 	// (DELETE /api/v1/data-sources/{id})
-	DeleteDataSource(ctx echo.Context, id DataSourceID) error
+	DeleteDataSource(ctx echo.Context, id DataSourceID, params DeleteDataSourceParams) error
 	// Get a data source by ID
 	// (GET /api/v1/data-sources/{id})
 	GetDataSource(ctx echo.Context, id DataSourceID) error
+	// Partially update a data source
+	// (PATCH /api/v1/data-sources/{id})
+	PatchDataSource(ctx echo.Context, id DataSourceID, params PatchDataSourceParams) error
 	// Update a data source
 	// (PUT /api/v1/data-sources/{id})
-	UpdateDataSource(ctx echo.Context, id DataSourceID) error
+	UpdateDataSource(ctx echo.Context, id DataSourceID, params UpdateDataSourceParams) error
 	// List data types
 	// (GET /api/v1/data-types)
 	ListDataTypes(ctx echo.Context, params ListDataTypesParams) error
@@ -226,13 +820,31 @@ type ServerInterface interface {
 	CreateDataType(ctx echo.Context) error
 	// Delete a data type
 	// (DELETE /api/v1/data-types/{id})
-	DeleteDataType(ctx echo.Context, id DataTypeID) error
+	DeleteDataType(ctx echo.Context, id DataTypeID, params DeleteDataTypeParams) error
 	// Get a data type by ID
 	// (GET /api/v1/data-types/{id})
 	GetDataType(ctx echo.Context, id DataTypeID) error
+	// Partially update a data type
+	// (PATCH /api/v1/data-types/{id})
+	PatchDataType(ctx echo.Context, id DataTypeID, params PatchDataTypeParams) error
 	// Update a data type
 	// (PUT /api/v1/data-types/{id})
-	UpdateDataType(ctx echo.Context, id DataTypeID) error
+	UpdateDataType(ctx echo.Context, id DataTypeID, params UpdateDataTypeParams) error
+	// List the data types a data type depends on
+	// (GET /api/v1/data-types/{id}/dependencies)
+	ListDataTypeDependencies(ctx echo.Context, id DataTypeID) error
+	// Replace the data types a data type depends on
+	// (PUT /api/v1/data-types/{id}/dependencies)
+	ReplaceDataTypeDependencies(ctx echo.Context, id DataTypeID) error
+	// List extraction executions of a data type with their landing files
+	// (GET /api/v1/data-types/{id}/executions)
+	ListDataTypeExecutions(ctx echo.Context, id DataTypeID, params ListDataTypeExecutionsParams) error
+	// Trigger an extraction for a data type
+	// (POST /api/v1/data-types/{id}/executions)
+	TriggerDataTypeExecution(ctx echo.Context, id DataTypeID) error
+	// Trace an output back to the landing files it was derived from
+	// (GET /api/v1/lineage)
+	GetLineage(ctx echo.Context, params GetLineageParams) error
 	// Check API server health
 	// (GET /health)
 	HealthCheck(ctx echo.Context) error
@@ -243,505 +855,1797 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
-// ListDataSources converts echo context to params.
-func (w *ServerInterfaceWrapper) ListDataSources(ctx echo.Context) error {
+// ListAuditEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) ListAuditEvents(ctx echo.Context) error {
 	var err error
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListDataSources(ctx)
-	return err
-}
+	ctx.Set(BearerAuthScopes, []string{"read"})
 
-// CreateDataSource converts echo context to params.
-func (w *ServerInterfaceWrapper) CreateDataSource(ctx echo.Context) error {
-	var err error
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAuditEventsParams
+	// ------------- Optional query parameter "limit" -------------
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateDataSource(ctx)
-	return err
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
 
-// DeleteDataSource converts echo context to params.
-func (w *ServerInterfaceWrapper) DeleteDataSource(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "id" -------------
-	var id DataSourceID
+	// ------------- Optional query parameter "cursor" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
 	}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteDataSource(ctx, id)
-	return err
-}
-
-// GetDataSource converts echo context to params.
-func (w *ServerInterfaceWrapper) GetDataSource(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "id" -------------
-	var id DataSourceID
+	// ------------- Optional query parameter "entityType" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "entityType", ctx.QueryParams(), &params.EntityType)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter entityType: %s", err))
 	}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetDataSource(ctx, id)
-	return err
-}
+	// ------------- Optional query parameter "entityId" -------------
 
-// UpdateDataSource converts echo context to params.
-func (w *ServerInterfaceWrapper) UpdateDataSource(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "id" -------------
-	var id DataSourceID
+	err = runtime.BindQueryParameter("form", true, false, "entityId", ctx.QueryParams(), &params.EntityId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter entityId: %s", err))
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since: %s", err))
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", ctx.QueryParams(), &params.Until)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter until: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListAuditEvents(ctx, params)
+	return err
+}
+
+// ListDataSources converts echo context to params.
+func (w *ServerInterfaceWrapper) ListDataSources(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDataSourcesParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "enabled" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "enabled", ctx.QueryParams(), &params.Enabled)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter enabled: %s", err))
+	}
+
+	// ------------- Optional query parameter "namePrefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namePrefix", ctx.QueryParams(), &params.NamePrefix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namePrefix: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListDataSources(ctx, params)
+	return err
+}
+
+// CreateDataSource converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateDataSource(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateDataSource(ctx)
+	return err
+}
+
+// DeleteDataSource converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteDataSource(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataSourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteDataSourceParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteDataSource(ctx, id, params)
+	return err
+}
+
+// GetDataSource converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDataSource(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataSourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetDataSource(ctx, id)
+	return err
+}
+
+// PatchDataSource converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchDataSource(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataSourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchDataSourceParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchDataSource(ctx, id, params)
+	return err
+}
+
+// UpdateDataSource converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateDataSource(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataSourceID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateDataSourceParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.UpdateDataSource(ctx, id)
+	err = w.Handler.UpdateDataSource(ctx, id, params)
 	return err
 }
 
-// ListDataTypes converts echo context to params.
-func (w *ServerInterfaceWrapper) ListDataTypes(ctx echo.Context) error {
-	var err error
+// ListDataTypes converts echo context to params.
+func (w *ServerInterfaceWrapper) ListDataTypes(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDataTypesParams
+	// ------------- Optional query parameter "dataSourceId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dataSourceId", ctx.QueryParams(), &params.DataSourceId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter dataSourceId: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "enabled" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "enabled", ctx.QueryParams(), &params.Enabled)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter enabled: %s", err))
+	}
+
+	// ------------- Optional query parameter "namePrefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "namePrefix", ctx.QueryParams(), &params.NamePrefix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter namePrefix: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListDataTypes(ctx, params)
+	return err
+}
+
+// CreateDataType converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateDataType(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateDataType(ctx)
+	return err
+}
+
+// DeleteDataType converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteDataType(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataTypeID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteDataTypeParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteDataType(ctx, id, params)
+	return err
+}
+
+// GetDataType converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDataType(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataTypeID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetDataType(ctx, id)
+	return err
+}
+
+// PatchDataType converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchDataType(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataTypeID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchDataTypeParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchDataType(ctx, id, params)
+	return err
+}
+
+// UpdateDataType converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateDataType(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataTypeID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateDataTypeParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateDataType(ctx, id, params)
+	return err
+}
+
+// ListDataTypeDependencies converts echo context to params.
+func (w *ServerInterfaceWrapper) ListDataTypeDependencies(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataTypeID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListDataTypeDependencies(ctx, id)
+	return err
+}
+
+// ReplaceDataTypeDependencies converts echo context to params.
+func (w *ServerInterfaceWrapper) ReplaceDataTypeDependencies(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataTypeID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ReplaceDataTypeDependencies(ctx, id)
+	return err
+}
+
+// ListDataTypeExecutions converts echo context to params.
+func (w *ServerInterfaceWrapper) ListDataTypeExecutions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataTypeID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDataTypeExecutionsParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListDataTypeExecutions(ctx, id, params)
+	return err
+}
+
+// TriggerDataTypeExecution converts echo context to params.
+func (w *ServerInterfaceWrapper) TriggerDataTypeExecution(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DataTypeID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.TriggerDataTypeExecution(ctx, id)
+	return err
+}
+
+// GetLineage converts echo context to params.
+func (w *ServerInterfaceWrapper) GetLineage(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLineageParams
+	// ------------- Required query parameter "key" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "key", ctx.QueryParams(), &params.Key)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter key: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetLineage(ctx, params)
+	return err
+}
+
+// HealthCheck converts echo context to params.
+func (w *ServerInterfaceWrapper) HealthCheck(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.HealthCheck(ctx)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/api/v1/audit-events", wrapper.ListAuditEvents)
+	router.GET(baseURL+"/api/v1/data-sources", wrapper.ListDataSources)
+	router.POST(baseURL+"/api/v1/data-sources", wrapper.CreateDataSource)
+	router.DELETE(baseURL+"/api/v1/data-sources/:id", wrapper.DeleteDataSource)
+	router.GET(baseURL+"/api/v1/data-sources/:id", wrapper.GetDataSource)
+	router.PATCH(baseURL+"/api/v1/data-sources/:id", wrapper.PatchDataSource)
+	router.PUT(baseURL+"/api/v1/data-sources/:id", wrapper.UpdateDataSource)
+	router.GET(baseURL+"/api/v1/data-types", wrapper.ListDataTypes)
+	router.POST(baseURL+"/api/v1/data-types", wrapper.CreateDataType)
+	router.DELETE(baseURL+"/api/v1/data-types/:id", wrapper.DeleteDataType)
+	router.GET(baseURL+"/api/v1/data-types/:id", wrapper.GetDataType)
+	router.PATCH(baseURL+"/api/v1/data-types/:id", wrapper.PatchDataType)
+	router.PUT(baseURL+"/api/v1/data-types/:id", wrapper.UpdateDataType)
+	router.GET(baseURL+"/api/v1/data-types/:id/dependencies", wrapper.ListDataTypeDependencies)
+	router.PUT(baseURL+"/api/v1/data-types/:id/dependencies", wrapper.ReplaceDataTypeDependencies)
+	router.GET(baseURL+"/api/v1/data-types/:id/executions", wrapper.ListDataTypeExecutions)
+	router.POST(baseURL+"/api/v1/data-types/:id/executions", wrapper.TriggerDataTypeExecution)
+	router.GET(baseURL+"/api/v1/lineage", wrapper.GetLineage)
+	router.GET(baseURL+"/health", wrapper.HealthCheck)
+
+}
+
+type ForbiddenJSONResponse ErrorResponse
+
+type UnauthorizedResponseHeaders struct {
+	WWWAuthenticate string
+}
+type UnauthorizedJSONResponse struct {
+	Body ErrorResponse
+
+	Headers UnauthorizedResponseHeaders
+}
+
+type ListAuditEventsRequestObject struct {
+	Params ListAuditEventsParams
+}
+
+type ListAuditEventsResponseObject interface {
+	VisitListAuditEventsResponse(w http.ResponseWriter) error
+}
+
+type ListAuditEvents200JSONResponse AuditEventList
+
+func (response ListAuditEvents200JSONResponse) VisitListAuditEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAuditEvents400JSONResponse ErrorResponse
+
+func (response ListAuditEvents400JSONResponse) VisitListAuditEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAuditEvents401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListAuditEvents401JSONResponse) VisitListAuditEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListAuditEvents403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListAuditEvents403JSONResponse) VisitListAuditEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAuditEvents422JSONResponse ErrorResponse
+
+func (response ListAuditEvents422JSONResponse) VisitListAuditEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataSourcesRequestObject struct {
+	Params ListDataSourcesParams
+}
+
+type ListDataSourcesResponseObject interface {
+	VisitListDataSourcesResponse(w http.ResponseWriter) error
+}
+
+type ListDataSources200JSONResponse DataSourceList
+
+func (response ListDataSources200JSONResponse) VisitListDataSourcesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataSources400JSONResponse ErrorResponse
+
+func (response ListDataSources400JSONResponse) VisitListDataSourcesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataSources401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListDataSources401JSONResponse) VisitListDataSourcesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListDataSources403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListDataSources403JSONResponse) VisitListDataSourcesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataSources422JSONResponse ErrorResponse
+
+func (response ListDataSources422JSONResponse) VisitListDataSourcesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDataSourceRequestObject struct {
+	Body *CreateDataSourceJSONRequestBody
+}
+
+type CreateDataSourceResponseObject interface {
+	VisitCreateDataSourceResponse(w http.ResponseWriter) error
+}
+
+type CreateDataSource201JSONResponse DataSource
+
+func (response CreateDataSource201JSONResponse) VisitCreateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDataSource400JSONResponse ErrorResponse
+
+func (response CreateDataSource400JSONResponse) VisitCreateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDataSource401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateDataSource401JSONResponse) VisitCreateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateDataSource403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateDataSource403JSONResponse) VisitCreateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDataSource422JSONResponse ErrorResponse
+
+func (response CreateDataSource422JSONResponse) VisitCreateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDataSourceRequestObject struct {
+	Id     DataSourceID `json:"id"`
+	Params DeleteDataSourceParams
+}
+
+type DeleteDataSourceResponseObject interface {
+	VisitDeleteDataSourceResponse(w http.ResponseWriter) error
+}
+
+type DeleteDataSource204Response struct {
+}
+
+func (response DeleteDataSource204Response) VisitDeleteDataSourceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteDataSource400JSONResponse ErrorResponse
+
+func (response DeleteDataSource400JSONResponse) VisitDeleteDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDataSource401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteDataSource401JSONResponse) VisitDeleteDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteDataSource403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteDataSource403JSONResponse) VisitDeleteDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDataSource404JSONResponse ErrorResponse
+
+func (response DeleteDataSource404JSONResponse) VisitDeleteDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDataSource412JSONResponse ErrorResponse
+
+func (response DeleteDataSource412JSONResponse) VisitDeleteDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDataSourceRequestObject struct {
+	Id DataSourceID `json:"id"`
+}
+
+type GetDataSourceResponseObject interface {
+	VisitGetDataSourceResponse(w http.ResponseWriter) error
+}
+
+type GetDataSource200ResponseHeaders struct {
+	ETag string
+}
+
+type GetDataSource200JSONResponse struct {
+	Body    DataSource
+	Headers GetDataSource200ResponseHeaders
+}
+
+func (response GetDataSource200JSONResponse) VisitGetDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDataSource401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetDataSource401JSONResponse) VisitGetDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDataSource403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetDataSource403JSONResponse) VisitGetDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDataSource404JSONResponse ErrorResponse
+
+func (response GetDataSource404JSONResponse) VisitGetDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDataSourceRequestObject struct {
+	Id     DataSourceID `json:"id"`
+	Params PatchDataSourceParams
+	Body   *PatchDataSourceApplicationMergePatchPlusJSONRequestBody
+}
+
+type PatchDataSourceResponseObject interface {
+	VisitPatchDataSourceResponse(w http.ResponseWriter) error
+}
+
+type PatchDataSource200ResponseHeaders struct {
+	ETag string
+}
+
+type PatchDataSource200JSONResponse struct {
+	Body    DataSource
+	Headers PatchDataSource200ResponseHeaders
+}
+
+func (response PatchDataSource200JSONResponse) VisitPatchDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchDataSource400JSONResponse ErrorResponse
+
+func (response PatchDataSource400JSONResponse) VisitPatchDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDataSource401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response PatchDataSource401JSONResponse) VisitPatchDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchDataSource403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PatchDataSource403JSONResponse) VisitPatchDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDataSource404JSONResponse ErrorResponse
+
+func (response PatchDataSource404JSONResponse) VisitPatchDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDataSource409JSONResponse ErrorResponse
+
+func (response PatchDataSource409JSONResponse) VisitPatchDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDataSource412JSONResponse ErrorResponse
+
+func (response PatchDataSource412JSONResponse) VisitPatchDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDataSource422JSONResponse ErrorResponse
+
+func (response PatchDataSource422JSONResponse) VisitPatchDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateDataSourceRequestObject struct {
+	Id     DataSourceID `json:"id"`
+	Params UpdateDataSourceParams
+	Body   *UpdateDataSourceJSONRequestBody
+}
+
+type UpdateDataSourceResponseObject interface {
+	VisitUpdateDataSourceResponse(w http.ResponseWriter) error
+}
+
+type UpdateDataSource200ResponseHeaders struct {
+	ETag string
+}
+
+type UpdateDataSource200JSONResponse struct {
+	Body    DataSource
+	Headers UpdateDataSource200ResponseHeaders
+}
+
+func (response UpdateDataSource200JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateDataSource400JSONResponse ErrorResponse
+
+func (response UpdateDataSource400JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateDataSource401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateDataSource401JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateDataSource403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateDataSource403JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateDataSource404JSONResponse ErrorResponse
+
+func (response UpdateDataSource404JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateDataSource409JSONResponse ErrorResponse
+
+func (response UpdateDataSource409JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateDataSource412JSONResponse ErrorResponse
+
+func (response UpdateDataSource412JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateDataSource422JSONResponse ErrorResponse
+
+func (response UpdateDataSource422JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataTypesRequestObject struct {
+	Params ListDataTypesParams
+}
+
+type ListDataTypesResponseObject interface {
+	VisitListDataTypesResponse(w http.ResponseWriter) error
+}
+
+type ListDataTypes200JSONResponse DataTypeList
+
+func (response ListDataTypes200JSONResponse) VisitListDataTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataTypes400JSONResponse ErrorResponse
+
+func (response ListDataTypes400JSONResponse) VisitListDataTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataTypes401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListDataTypes401JSONResponse) VisitListDataTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListDataTypes403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListDataTypes403JSONResponse) VisitListDataTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataTypes422JSONResponse ErrorResponse
+
+func (response ListDataTypes422JSONResponse) VisitListDataTypesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDataTypeRequestObject struct {
+	Body *CreateDataTypeJSONRequestBody
+}
+
+type CreateDataTypeResponseObject interface {
+	VisitCreateDataTypeResponse(w http.ResponseWriter) error
+}
+
+type CreateDataType201JSONResponse DataType
+
+func (response CreateDataType201JSONResponse) VisitCreateDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDataType400JSONResponse ErrorResponse
+
+func (response CreateDataType400JSONResponse) VisitCreateDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDataType401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateDataType401JSONResponse) VisitCreateDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateDataType403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateDataType403JSONResponse) VisitCreateDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDataType422JSONResponse ErrorResponse
+
+func (response CreateDataType422JSONResponse) VisitCreateDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDataTypeRequestObject struct {
+	Id     DataTypeID `json:"id"`
+	Params DeleteDataTypeParams
+}
+
+type DeleteDataTypeResponseObject interface {
+	VisitDeleteDataTypeResponse(w http.ResponseWriter) error
+}
+
+type DeleteDataType204Response struct {
+}
+
+func (response DeleteDataType204Response) VisitDeleteDataTypeResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteDataType400JSONResponse ErrorResponse
+
+func (response DeleteDataType400JSONResponse) VisitDeleteDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDataType401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteDataType401JSONResponse) VisitDeleteDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteDataType403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteDataType403JSONResponse) VisitDeleteDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDataType404JSONResponse ErrorResponse
+
+func (response DeleteDataType404JSONResponse) VisitDeleteDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDataType412JSONResponse ErrorResponse
+
+func (response DeleteDataType412JSONResponse) VisitDeleteDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDataTypeRequestObject struct {
+	Id DataTypeID `json:"id"`
+}
+
+type GetDataTypeResponseObject interface {
+	VisitGetDataTypeResponse(w http.ResponseWriter) error
+}
+
+type GetDataType200ResponseHeaders struct {
+	ETag string
+}
+
+type GetDataType200JSONResponse struct {
+	Body    DataType
+	Headers GetDataType200ResponseHeaders
+}
+
+func (response GetDataType200JSONResponse) VisitGetDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDataType401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetDataType401JSONResponse) VisitGetDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDataType403JSONResponse struct{ ForbiddenJSONResponse }
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListDataTypesParams
-	// ------------- Optional query parameter "dataSourceId" -------------
+func (response GetDataType403JSONResponse) VisitGetDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-	err = runtime.BindQueryParameter("form", true, false, "dataSourceId", ctx.QueryParams(), &params.DataSourceId)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter dataSourceId: %s", err))
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListDataTypes(ctx, params)
-	return err
+type GetDataType404JSONResponse ErrorResponse
+
+func (response GetDataType404JSONResponse) VisitGetDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// CreateDataType converts echo context to params.
-func (w *ServerInterfaceWrapper) CreateDataType(ctx echo.Context) error {
-	var err error
+type PatchDataTypeRequestObject struct {
+	Id     DataTypeID `json:"id"`
+	Params PatchDataTypeParams
+	Body   *PatchDataTypeApplicationMergePatchPlusJSONRequestBody
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateDataType(ctx)
-	return err
+type PatchDataTypeResponseObject interface {
+	VisitPatchDataTypeResponse(w http.ResponseWriter) error
 }
 
-// DeleteDataType converts echo context to params.
-func (w *ServerInterfaceWrapper) DeleteDataType(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "id" -------------
-	var id DataTypeID
+type PatchDataType200ResponseHeaders struct {
+	ETag string
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
-	}
+type PatchDataType200JSONResponse struct {
+	Body    DataType
+	Headers PatchDataType200ResponseHeaders
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteDataType(ctx, id)
-	return err
+func (response PatchDataType200JSONResponse) VisitPatchDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-// GetDataType converts echo context to params.
-func (w *ServerInterfaceWrapper) GetDataType(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "id" -------------
-	var id DataTypeID
+type PatchDataType400JSONResponse ErrorResponse
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
-	}
+func (response PatchDataType400JSONResponse) VisitPatchDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetDataType(ctx, id)
-	return err
+	return json.NewEncoder(w).Encode(response)
 }
 
-// UpdateDataType converts echo context to params.
-func (w *ServerInterfaceWrapper) UpdateDataType(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "id" -------------
-	var id DataTypeID
+type PatchDataType401JSONResponse struct{ UnauthorizedJSONResponse }
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
-	}
+func (response PatchDataType401JSONResponse) VisitPatchDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.UpdateDataType(ctx, id)
-	return err
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-// HealthCheck converts echo context to params.
-func (w *ServerInterfaceWrapper) HealthCheck(ctx echo.Context) error {
-	var err error
+type PatchDataType403JSONResponse struct{ ForbiddenJSONResponse }
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.HealthCheck(ctx)
-	return err
+func (response PatchDataType403JSONResponse) VisitPatchDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+type PatchDataType404JSONResponse ErrorResponse
+
+func (response PatchDataType404JSONResponse) VisitPatchDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+type PatchDataType409JSONResponse ErrorResponse
+
+func (response PatchDataType409JSONResponse) VisitPatchDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+type PatchDataType412JSONResponse ErrorResponse
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
-	}
+func (response PatchDataType412JSONResponse) VisitPatchDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
 
-	router.GET(baseURL+"/api/v1/data-sources", wrapper.ListDataSources)
-	router.POST(baseURL+"/api/v1/data-sources", wrapper.CreateDataSource)
-	router.DELETE(baseURL+"/api/v1/data-sources/:id", wrapper.DeleteDataSource)
-	router.GET(baseURL+"/api/v1/data-sources/:id", wrapper.GetDataSource)
-	router.PUT(baseURL+"/api/v1/data-sources/:id", wrapper.UpdateDataSource)
-	router.GET(baseURL+"/api/v1/data-types", wrapper.ListDataTypes)
-	router.POST(baseURL+"/api/v1/data-types", wrapper.CreateDataType)
-	router.DELETE(baseURL+"/api/v1/data-types/:id", wrapper.DeleteDataType)
-	router.GET(baseURL+"/api/v1/data-types/:id", wrapper.GetDataType)
-	router.PUT(baseURL+"/api/v1/data-types/:id", wrapper.UpdateDataType)
-	router.GET(baseURL+"/health", wrapper.HealthCheck)
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDataType422JSONResponse ErrorResponse
+
+func (response PatchDataType422JSONResponse) VisitPatchDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
 
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDataSourcesRequestObject struct {
+type UpdateDataTypeRequestObject struct {
+	Id     DataTypeID `json:"id"`
+	Params UpdateDataTypeParams
+	Body   *UpdateDataTypeJSONRequestBody
 }
 
-type ListDataSourcesResponseObject interface {
-	VisitListDataSourcesResponse(w http.ResponseWriter) error
+type UpdateDataTypeResponseObject interface {
+	VisitUpdateDataTypeResponse(w http.ResponseWriter) error
 }
 
-type ListDataSources200JSONResponse []DataSource
+type UpdateDataType200ResponseHeaders struct {
+	ETag string
+}
 
-func (response ListDataSources200JSONResponse) VisitListDataSourcesResponse(w http.ResponseWriter) error {
+type UpdateDataType200JSONResponse struct {
+	Body    DataType
+	Headers UpdateDataType200ResponseHeaders
+}
+
+func (response UpdateDataType200JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type ListDataSources400JSONResponse ErrorResponse
+type UpdateDataType400JSONResponse ErrorResponse
 
-func (response ListDataSources400JSONResponse) VisitListDataSourcesResponse(w http.ResponseWriter) error {
+func (response UpdateDataType400JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDataSourceRequestObject struct {
-	Body *CreateDataSourceJSONRequestBody
+type UpdateDataType401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateDataType401JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CreateDataSourceResponseObject interface {
-	VisitCreateDataSourceResponse(w http.ResponseWriter) error
+type UpdateDataType403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateDataType403JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDataSource201JSONResponse DataSource
+type UpdateDataType404JSONResponse ErrorResponse
 
-func (response CreateDataSource201JSONResponse) VisitCreateDataSourceResponse(w http.ResponseWriter) error {
+func (response UpdateDataType404JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDataSource400JSONResponse ErrorResponse
+type UpdateDataType409JSONResponse ErrorResponse
 
-func (response CreateDataSource400JSONResponse) VisitCreateDataSourceResponse(w http.ResponseWriter) error {
+func (response UpdateDataType409JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDataSource422JSONResponse ErrorResponse
+type UpdateDataType412JSONResponse ErrorResponse
 
-func (response CreateDataSource422JSONResponse) VisitCreateDataSourceResponse(w http.ResponseWriter) error {
+func (response UpdateDataType412JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateDataType422JSONResponse ErrorResponse
+
+func (response UpdateDataType422JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDataSourceRequestObject struct {
-	Id DataSourceID `json:"id"`
+type ListDataTypeDependenciesRequestObject struct {
+	Id DataTypeID `json:"id"`
 }
 
-type DeleteDataSourceResponseObject interface {
-	VisitDeleteDataSourceResponse(w http.ResponseWriter) error
+type ListDataTypeDependenciesResponseObject interface {
+	VisitListDataTypeDependenciesResponse(w http.ResponseWriter) error
 }
 
-type DeleteDataSource204Response struct {
+type ListDataTypeDependencies200JSONResponse DataTypeDependencyList
+
+func (response ListDataTypeDependencies200JSONResponse) VisitListDataTypeDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response DeleteDataSource204Response) VisitDeleteDataSourceResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+type ListDataTypeDependencies401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListDataTypeDependencies401JSONResponse) VisitListDataTypeDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type DeleteDataSource404JSONResponse ErrorResponse
+type ListDataTypeDependencies403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response DeleteDataSource404JSONResponse) VisitDeleteDataSourceResponse(w http.ResponseWriter) error {
+func (response ListDataTypeDependencies403JSONResponse) VisitListDataTypeDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataTypeDependencies404JSONResponse ErrorResponse
+
+func (response ListDataTypeDependencies404JSONResponse) VisitListDataTypeDependenciesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDataSourceRequestObject struct {
-	Id DataSourceID `json:"id"`
+type ReplaceDataTypeDependenciesRequestObject struct {
+	Id   DataTypeID `json:"id"`
+	Body *ReplaceDataTypeDependenciesJSONRequestBody
 }
 
-type GetDataSourceResponseObject interface {
-	VisitGetDataSourceResponse(w http.ResponseWriter) error
+type ReplaceDataTypeDependenciesResponseObject interface {
+	VisitReplaceDataTypeDependenciesResponse(w http.ResponseWriter) error
 }
 
-type GetDataSource200JSONResponse DataSource
+type ReplaceDataTypeDependencies200JSONResponse DataTypeDependencyList
 
-func (response GetDataSource200JSONResponse) VisitGetDataSourceResponse(w http.ResponseWriter) error {
+func (response ReplaceDataTypeDependencies200JSONResponse) VisitReplaceDataTypeDependenciesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDataSource404JSONResponse ErrorResponse
+type ReplaceDataTypeDependencies400JSONResponse ErrorResponse
 
-func (response GetDataSource404JSONResponse) VisitGetDataSourceResponse(w http.ResponseWriter) error {
+func (response ReplaceDataTypeDependencies400JSONResponse) VisitReplaceDataTypeDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDataTypeDependencies401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ReplaceDataTypeDependencies401JSONResponse) VisitReplaceDataTypeDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReplaceDataTypeDependencies403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ReplaceDataTypeDependencies403JSONResponse) VisitReplaceDataTypeDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDataTypeDependencies404JSONResponse ErrorResponse
+
+func (response ReplaceDataTypeDependencies404JSONResponse) VisitReplaceDataTypeDependenciesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateDataSourceRequestObject struct {
-	Id   DataSourceID `json:"id"`
-	Body *UpdateDataSourceJSONRequestBody
+type ReplaceDataTypeDependencies422JSONResponse ErrorResponse
+
+func (response ReplaceDataTypeDependencies422JSONResponse) VisitReplaceDataTypeDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateDataSourceResponseObject interface {
-	VisitUpdateDataSourceResponse(w http.ResponseWriter) error
+type ListDataTypeExecutionsRequestObject struct {
+	Id     DataTypeID `json:"id"`
+	Params ListDataTypeExecutionsParams
+}
+
+type ListDataTypeExecutionsResponseObject interface {
+	VisitListDataTypeExecutionsResponse(w http.ResponseWriter) error
+}
+
+type ListDataTypeExecutions200JSONResponse ExecutionList
+
+func (response ListDataTypeExecutions200JSONResponse) VisitListDataTypeExecutionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDataTypeExecutions400JSONResponse ErrorResponse
+
+func (response ListDataTypeExecutions400JSONResponse) VisitListDataTypeExecutionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateDataSource200JSONResponse DataSource
+type ListDataTypeExecutions401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response UpdateDataSource200JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+func (response ListDataTypeExecutions401JSONResponse) VisitListDataTypeExecutionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type UpdateDataSource400JSONResponse ErrorResponse
+type ListDataTypeExecutions403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response UpdateDataSource400JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+func (response ListDataTypeExecutions403JSONResponse) VisitListDataTypeExecutionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateDataSource404JSONResponse ErrorResponse
+type ListDataTypeExecutions404JSONResponse ErrorResponse
 
-func (response UpdateDataSource404JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+func (response ListDataTypeExecutions404JSONResponse) VisitListDataTypeExecutionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateDataSource422JSONResponse ErrorResponse
+type ListDataTypeExecutions422JSONResponse ErrorResponse
 
-func (response UpdateDataSource422JSONResponse) VisitUpdateDataSourceResponse(w http.ResponseWriter) error {
+func (response ListDataTypeExecutions422JSONResponse) VisitListDataTypeExecutionsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDataTypesRequestObject struct {
-	Params ListDataTypesParams
+type TriggerDataTypeExecutionRequestObject struct {
+	Id   DataTypeID `json:"id"`
+	Body *TriggerDataTypeExecutionJSONRequestBody
 }
 
-type ListDataTypesResponseObject interface {
-	VisitListDataTypesResponse(w http.ResponseWriter) error
+type TriggerDataTypeExecutionResponseObject interface {
+	VisitTriggerDataTypeExecutionResponse(w http.ResponseWriter) error
 }
 
-type ListDataTypes200JSONResponse []DataType
+type TriggerDataTypeExecution202JSONResponse TriggerExecutionResponse
 
-func (response ListDataTypes200JSONResponse) VisitListDataTypesResponse(w http.ResponseWriter) error {
+func (response TriggerDataTypeExecution202JSONResponse) VisitTriggerDataTypeExecutionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(202)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDataTypes400JSONResponse ErrorResponse
+type TriggerDataTypeExecution400JSONResponse ErrorResponse
 
-func (response ListDataTypes400JSONResponse) VisitListDataTypesResponse(w http.ResponseWriter) error {
+func (response TriggerDataTypeExecution400JSONResponse) VisitTriggerDataTypeExecutionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDataTypeRequestObject struct {
-	Body *CreateDataTypeJSONRequestBody
-}
-
-type CreateDataTypeResponseObject interface {
-	VisitCreateDataTypeResponse(w http.ResponseWriter) error
-}
-
-type CreateDataType201JSONResponse DataType
+type TriggerDataTypeExecution401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response CreateDataType201JSONResponse) VisitCreateDataTypeResponse(w http.ResponseWriter) error {
+func (response TriggerDataTypeExecution401JSONResponse) VisitTriggerDataTypeExecutionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CreateDataType400JSONResponse ErrorResponse
+type TriggerDataTypeExecution403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response CreateDataType400JSONResponse) VisitCreateDataTypeResponse(w http.ResponseWriter) error {
+func (response TriggerDataTypeExecution403JSONResponse) VisitTriggerDataTypeExecutionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDataType422JSONResponse ErrorResponse
+type TriggerDataTypeExecution404JSONResponse ErrorResponse
 
-func (response CreateDataType422JSONResponse) VisitCreateDataTypeResponse(w http.ResponseWriter) error {
+func (response TriggerDataTypeExecution404JSONResponse) VisitTriggerDataTypeExecutionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDataTypeRequestObject struct {
-	Id DataTypeID `json:"id"`
-}
-
-type DeleteDataTypeResponseObject interface {
-	VisitDeleteDataTypeResponse(w http.ResponseWriter) error
-}
+type TriggerDataTypeExecution409JSONResponse ErrorResponse
 
-type DeleteDataType204Response struct {
-}
+func (response TriggerDataTypeExecution409JSONResponse) VisitTriggerDataTypeExecutionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
 
-func (response DeleteDataType204Response) VisitDeleteDataTypeResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDataType404JSONResponse ErrorResponse
+type TriggerDataTypeExecution422JSONResponse ErrorResponse
 
-func (response DeleteDataType404JSONResponse) VisitDeleteDataTypeResponse(w http.ResponseWriter) error {
+func (response TriggerDataTypeExecution422JSONResponse) VisitTriggerDataTypeExecutionResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDataTypeRequestObject struct {
-	Id DataTypeID `json:"id"`
+type GetLineageRequestObject struct {
+	Params GetLineageParams
 }
 
-type GetDataTypeResponseObject interface {
-	VisitGetDataTypeResponse(w http.ResponseWriter) error
+type GetLineageResponseObject interface {
+	VisitGetLineageResponse(w http.ResponseWriter) error
 }
 
-type GetDataType200JSONResponse DataType
+type GetLineage200JSONResponse Lineage
 
-func (response GetDataType200JSONResponse) VisitGetDataTypeResponse(w http.ResponseWriter) error {
+func (response GetLineage200JSONResponse) VisitGetLineageResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDataType404JSONResponse ErrorResponse
+type GetLineage400JSONResponse ErrorResponse
 
-func (response GetDataType404JSONResponse) VisitGetDataTypeResponse(w http.ResponseWriter) error {
+func (response GetLineage400JSONResponse) VisitGetLineageResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateDataTypeRequestObject struct {
-	Id   DataTypeID `json:"id"`
-	Body *UpdateDataTypeJSONRequestBody
-}
-
-type UpdateDataTypeResponseObject interface {
-	VisitUpdateDataTypeResponse(w http.ResponseWriter) error
-}
-
-type UpdateDataType200JSONResponse DataType
+type GetLineage401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response UpdateDataType200JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
+func (response GetLineage401JSONResponse) VisitGetLineageResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type UpdateDataType400JSONResponse ErrorResponse
+type GetLineage403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response UpdateDataType400JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
+func (response GetLineage403JSONResponse) VisitGetLineageResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateDataType404JSONResponse ErrorResponse
+type GetLineage404JSONResponse ErrorResponse
 
-func (response UpdateDataType404JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
+func (response GetLineage404JSONResponse) VisitGetLineageResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type UpdateDataType422JSONResponse ErrorResponse
+type GetLineage422JSONResponse ErrorResponse
 
-func (response UpdateDataType422JSONResponse) VisitUpdateDataTypeResponse(w http.ResponseWriter) error {
+func (response GetLineage422JSONResponse) VisitGetLineageResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(422)
 
@@ -768,6 +2672,9 @@ func (response HealthCheck200JSONResponse) VisitHealthCheckResponse(w http.Respo
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// List changes to data sources and data types
+	// (GET /api/v1/audit-events)
+	ListAuditEvents(ctx context.Context, request ListAuditEventsRequestObject) (ListAuditEventsResponseObject, error)
 	// List data sources
 	// (GET /api/v1/data-sources)
 	ListDataSources(ctx context.Context, request ListDataSourcesRequestObject) (ListDataSourcesResponseObject, error)
@@ -780,6 +2687,9 @@ type StrictServerInterface interface {
 	// Get a data source by ID
 	// (GET /api/v1/data-sources/{id})
 	GetDataSource(ctx context.Context, request GetDataSourceRequestObject) (GetDataSourceResponseObject, error)
+	// Partially update a data source
+	// (PATCH /api/v1/data-sources/{id})
+	PatchDataSource(ctx context.Context, request PatchDataSourceRequestObject) (PatchDataSourceResponseObject, error)
 	// Update a data source
 	// (PUT /api/v1/data-sources/{id})
 	UpdateDataSource(ctx context.Context, request UpdateDataSourceRequestObject) (UpdateDataSourceResponseObject, error)
@@ -795,9 +2705,27 @@ type StrictServerInterface interface {
 	// Get a data type by ID
 	// (GET /api/v1/data-types/{id})
 	GetDataType(ctx context.Context, request GetDataTypeRequestObject) (GetDataTypeResponseObject, error)
+	// Partially update a data type
+	// (PATCH /api/v1/data-types/{id})
+	PatchDataType(ctx context.Context, request PatchDataTypeRequestObject) (PatchDataTypeResponseObject, error)
 	// Update a data type
 	// (PUT /api/v1/data-types/{id})
 	UpdateDataType(ctx context.Context, request UpdateDataTypeRequestObject) (UpdateDataTypeResponseObject, error)
+	// List the data types a data type depends on
+	// (GET /api/v1/data-types/{id}/dependencies)
+	ListDataTypeDependencies(ctx context.Context, request ListDataTypeDependenciesRequestObject) (ListDataTypeDependenciesResponseObject, error)
+	// Replace the data types a data type depends on
+	// (PUT /api/v1/data-types/{id}/dependencies)
+	ReplaceDataTypeDependencies(ctx context.Context, request ReplaceDataTypeDependenciesRequestObject) (ReplaceDataTypeDependenciesResponseObject, error)
+	// List extraction executions of a data type with their landing files
+	// (GET /api/v1/data-types/{id}/executions)
+	ListDataTypeExecutions(ctx context.Context, request ListDataTypeExecutionsRequestObject) (ListDataTypeExecutionsResponseObject, error)
+	// Trigger an extraction for a data type
+	// (POST /api/v1/data-types/{id}/executions)
+	TriggerDataTypeExecution(ctx context.Context, request TriggerDataTypeExecutionRequestObject) (TriggerDataTypeExecutionResponseObject, error)
+	// Trace an output back to the landing files it was derived from
+	// (GET /api/v1/lineage)
+	GetLineage(ctx context.Context, request GetLineageRequestObject) (GetLineageResponseObject, error)
 	// Check API server health
 	// (GET /health)
 	HealthCheck(ctx context.Context, request HealthCheckRequestObject) (HealthCheckResponseObject, error)
@@ -815,10 +2743,37 @@ type strictHandler struct {
 	middlewares []StrictMiddlewareFunc
 }
 
+// ListAuditEvents operation middleware
+func (sh *strictHandler) ListAuditEvents(ctx echo.Context, params ListAuditEventsParams) error {
+	var request ListAuditEventsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAuditEvents(ctx.Request().Context(), request.(ListAuditEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAuditEvents")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListAuditEventsResponseObject); ok {
+		return validResponse.VisitListAuditEventsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // ListDataSources operation middleware
-func (sh *strictHandler) ListDataSources(ctx echo.Context) error {
+func (sh *strictHandler) ListDataSources(ctx echo.Context, params ListDataSourcesParams) error {
 	var request ListDataSourcesRequestObject
 
+	request.Params = params
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.ListDataSources(ctx.Request().Context(), request.(ListDataSourcesRequestObject))
 	}
@@ -868,10 +2823,11 @@ func (sh *strictHandler) CreateDataSource(ctx echo.Context) error {
 }
 
 // DeleteDataSource operation middleware
-func (sh *strictHandler) DeleteDataSource(ctx echo.Context, id DataSourceID) error {
+func (sh *strictHandler) DeleteDataSource(ctx echo.Context, id DataSourceID, params DeleteDataSourceParams) error {
 	var request DeleteDataSourceRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteDataSource(ctx.Request().Context(), request.(DeleteDataSourceRequestObject))
@@ -917,11 +2873,44 @@ func (sh *strictHandler) GetDataSource(ctx echo.Context, id DataSourceID) error
 	return nil
 }
 
+// PatchDataSource operation middleware
+func (sh *strictHandler) PatchDataSource(ctx echo.Context, id DataSourceID, params PatchDataSourceParams) error {
+	var request PatchDataSourceRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	var body PatchDataSourceApplicationMergePatchPlusJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchDataSource(ctx.Request().Context(), request.(PatchDataSourceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchDataSource")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PatchDataSourceResponseObject); ok {
+		return validResponse.VisitPatchDataSourceResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // UpdateDataSource operation middleware
-func (sh *strictHandler) UpdateDataSource(ctx echo.Context, id DataSourceID) error {
+func (sh *strictHandler) UpdateDataSource(ctx echo.Context, id DataSourceID, params UpdateDataSourceParams) error {
 	var request UpdateDataSourceRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	var body UpdateDataSourceJSONRequestBody
 	if err := ctx.Bind(&body); err != nil {
@@ -1003,10 +2992,11 @@ func (sh *strictHandler) CreateDataType(ctx echo.Context) error {
 }
 
 // DeleteDataType operation middleware
-func (sh *strictHandler) DeleteDataType(ctx echo.Context, id DataTypeID) error {
+func (sh *strictHandler) DeleteDataType(ctx echo.Context, id DataTypeID, params DeleteDataTypeParams) error {
 	var request DeleteDataTypeRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteDataType(ctx.Request().Context(), request.(DeleteDataTypeRequestObject))
@@ -1052,11 +3042,44 @@ func (sh *strictHandler) GetDataType(ctx echo.Context, id DataTypeID) error {
 	return nil
 }
 
+// PatchDataType operation middleware
+func (sh *strictHandler) PatchDataType(ctx echo.Context, id DataTypeID, params PatchDataTypeParams) error {
+	var request PatchDataTypeRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	var body PatchDataTypeApplicationMergePatchPlusJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchDataType(ctx.Request().Context(), request.(PatchDataTypeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchDataType")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PatchDataTypeResponseObject); ok {
+		return validResponse.VisitPatchDataTypeResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // UpdateDataType operation middleware
-func (sh *strictHandler) UpdateDataType(ctx echo.Context, id DataTypeID) error {
+func (sh *strictHandler) UpdateDataType(ctx echo.Context, id DataTypeID, params UpdateDataTypeParams) error {
 	var request UpdateDataTypeRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	var body UpdateDataTypeJSONRequestBody
 	if err := ctx.Bind(&body); err != nil {
@@ -1083,6 +3106,144 @@ func (sh *strictHandler) UpdateDataType(ctx echo.Context, id DataTypeID) error {
 	return nil
 }
 
+// ListDataTypeDependencies operation middleware
+func (sh *strictHandler) ListDataTypeDependencies(ctx echo.Context, id DataTypeID) error {
+	var request ListDataTypeDependenciesRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDataTypeDependencies(ctx.Request().Context(), request.(ListDataTypeDependenciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDataTypeDependencies")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListDataTypeDependenciesResponseObject); ok {
+		return validResponse.VisitListDataTypeDependenciesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ReplaceDataTypeDependencies operation middleware
+func (sh *strictHandler) ReplaceDataTypeDependencies(ctx echo.Context, id DataTypeID) error {
+	var request ReplaceDataTypeDependenciesRequestObject
+
+	request.Id = id
+
+	var body ReplaceDataTypeDependenciesJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceDataTypeDependencies(ctx.Request().Context(), request.(ReplaceDataTypeDependenciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceDataTypeDependencies")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ReplaceDataTypeDependenciesResponseObject); ok {
+		return validResponse.VisitReplaceDataTypeDependenciesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListDataTypeExecutions operation middleware
+func (sh *strictHandler) ListDataTypeExecutions(ctx echo.Context, id DataTypeID, params ListDataTypeExecutionsParams) error {
+	var request ListDataTypeExecutionsRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDataTypeExecutions(ctx.Request().Context(), request.(ListDataTypeExecutionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDataTypeExecutions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListDataTypeExecutionsResponseObject); ok {
+		return validResponse.VisitListDataTypeExecutionsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// TriggerDataTypeExecution operation middleware
+func (sh *strictHandler) TriggerDataTypeExecution(ctx echo.Context, id DataTypeID) error {
+	var request TriggerDataTypeExecutionRequestObject
+
+	request.Id = id
+
+	var body TriggerDataTypeExecutionJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.TriggerDataTypeExecution(ctx.Request().Context(), request.(TriggerDataTypeExecutionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "TriggerDataTypeExecution")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(TriggerDataTypeExecutionResponseObject); ok {
+		return validResponse.VisitTriggerDataTypeExecutionResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetLineage operation middleware
+func (sh *strictHandler) GetLineage(ctx echo.Context, params GetLineageParams) error {
+	var request GetLineageRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLineage(ctx.Request().Context(), request.(GetLineageRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLineage")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetLineageResponseObject); ok {
+		return validResponse.VisitGetLineageResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // HealthCheck operation middleware
 func (sh *strictHandler) HealthCheck(ctx echo.Context) error {
 	var request HealthCheckRequestObject
@@ -1109,36 +3270,119 @@ func (sh *strictHandler) HealthCheck(ctx echo.Context) error {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaa2/bOBb9KwR3gbaAHMmJ0221n9Km22bRFNnG3QW2CAa0dG2xkUiFj2Q0gf/7gKRs",
-	"65XYTu04UwTIl9ik7uucc69I3+KIZzlnwJTE4S3OiSAZKBD2v2OiyDnXIoKTY/M/ZTjEOVEJ9jAjGeAQ",
-	"0xh7WMCVpgJiHCqhwcMySiAjZseYi4woHGKt7UpV5GaXVIKyCZ5OPWtjWORbszCdLbYRvRdAFCzi+gpX",
-	"GqSyoQueg1AU7EJgZJQae7c4BhkJmivKjXP/S0AlIJBKAMVEESTtgxCViESKXgMac4Eom4A0O/awh+F3",
-	"kuUpzHwvPRxxngJheDqLtGnpC8kA8XHTUu2J+N+9/2hiiufhjLLPwCYqwWG/lQcPS1CKsomNjsQxNVZI",
-	"elaJ2rlXd+JM8Gsag+jJHCI6phGKOBvTiRakFd7tdG6Wj35ApIxZRTP4g7OO+E6Ovhyh2dfI5KAe2pGk",
-	"xB/yy4IvC25aBch3l05vXsKKD5UsXHT4ukCHQeSd2BiR6HJM0/TDMowkVCouaERSV8DZRgOW0ruV8BEv",
-	"eNhh7eS4AyVIJVQi8yg0gpSziUSK1/N7eBjAm0EQ9GD/7ag36MeDHvlH/3VvMHj9+vBwMAiCIMDeMoJ5",
-	"q1CFSueb9UcmXKcxGkFJmLQo6bJiNlZki9lYD1gqHl3+lgsawSp8iRKItcU1/ruAMQ7x3/yFWvqlqvjn",
-	"s3UP5pgBXM/YX5Bsrh/r0k0qksKQZsC1OqVMKwfZusHyC0TGqlSyWbCxJSQawZgLQAQJzQxYI86kUQGI",
-	"kTVQ8+MgsLmkmc5wGMx9okzBBESLnTUwe22yzvPutYjWHd4SUi/Evs3kyBI+PlLtHBkj6CYB1mLWDZGo",
-	"3Ihefv3Xe3RwcPD2VR1q+8H+oBf0e0F/GASh/ft/lUsxUdAzqf5JQm2g+dAOQ98YvdKAaAxM0TEFsbQP",
-	"bUpMNtMKH6f5oZxIaSjDrWMLzpKY5ArEZpsj0qUtQyyRC1AN1sp72mcrITqPH4b8lEiFyt3bgH9DLWin",
-	"RnQ1dK/C5mp8d2nC0H64896+ngTZBrp1AXrwvLHw8akNHXOFXHfaWF8e24PHCkH3NyiOSyef51lnW7PO",
-	"usI65/MuZHWbk9g6avxBCC6+gsw5kx2SDObrdjY/6YywngASG5dQBlKSCSC3aETZBN0kRKEbYArdCM4m",
-	"9VxWtYtxhcZcs3hp3pwvXUGcVyjUgDyMKQPpKr8Au9BM2nGN1FlbD9429o7gP4Wnp67ro5f7g17CtfAQ",
-	"bbXsF3I+RLxCJhsJjZKmE0CiBMWkqCXoOw7ehlaL+4fhQWBipgqyDl/cnGKMO68cHOduWQy3FCej7MQ9",
-	"bvHuRYQgBZ4zvWlnlmEUkRhYBP9EkRYCmEoLxFlaoBcxoWnxwpBY6jznYqbxzLDVvHnQtDCBVFFgPlpW",
-	"dfutV9aiq/jfLL6fj5Wej5XuR8fTOlZ6Prh5HmbWOLjZ8kmNMUfZmJuoUxpBOQuUtwCnJ0MzR4gUhzhR",
-	"Kpeh7/McWCleXEz8cpP0zVorEcqi59ygBw05T9HR2Qn28DUI6fLZ3wv2ArPWPIrkFIf4YC/YOzBtmKjE",
-	"VsAnOfWv+77BZs+Zs59PwHLYIMSW2Lwq4c9UqkUbkPbmws01ds9+ENhjJ84UMLud5HlKI/sA/4c0Tt1W",
-	"LjfmHfc+HFcOuKbNXmqS2uiiOopAyrFO0cw1s22wpmf3OVSf5zp8eEdiJEoZtBc0OsuIKMr8VbuSNL7l",
-	"XHakunmVU94SgVTveFxsLJi7boymdXoYeZi2qt3fmBvVIrcT6pyMd1tIDw/29x/P9n9JSmN3Duem8jqS",
-	"XEoQQQxuqoiyy7o47d/SeOokNwUFbcAd289rgKtel37vjmexxK9dp04vWmgZdL07GJtlYQePl9wv8zei",
-	"eladP7NXFjlXnU4x/Ahqi9kKHolbdwrmEyjHR1D1WqBRgUy6PJzrjoo031M2UZTNS+5db1MrSe7OYbFD",
-	"+d0RJJ+W8Dv0NCWqKfpmTFo+xg3tqhZJ7K9UrjSIYvEzlcZh2uo/ULl4rBnRXnj8QhOiK+EK8+HQHd9s",
-	"dzqsvtrvYDZ0xX2eDB80GSqXvC6JWGMqLGG2fkMtf/72K0yEqtSY++bBLeQpeBQu/UUmQXf/uuIcuJli",
-	"bHMGXFtYdwyG5/nvKc1/C2lPgKQquXPk+2S/fp9AdPmzp3b1Y32piNLu8md+oM0vl945ldu6z0kbUARx",
-	"DQJRiVyMRbPhmaDQ0dkJkm5lmQr342j7kaN9/bGfub1hgGtIeZ4BU+X22jls6PupWZdwqcI3wZsATy+m",
-	"fwYAAP//iXYEY1YuAAA=",
+	"H4sIAAAAAAAC/+x9e3PbtrL4V8Hw95vx6TnUW3ZiZ+4fbpyeuo2bNnZP7mnTiSFyKaGmABYAbSsdf/c7",
+	"C4BvypIc23Fa3bkntSSCWOx7F4vFn14g5ongwLXyDv70EirpHDRI8+llKpWQ+FcIKpAs0Uxw78B7k9A/",
+	"UiCB+ZlEUswJJYmESyZSRRI6hR1FOFxr+4IuOUmVJhMgqYKQXDE9I3oGRNE5ECWk7nq+x/DFf6QgF57v",
+	"cToH78CzE3i+p4IZzCkCAtd0nsT4Iyy++3j8u2D03U/s9cvvkl9eHu8d/354/ebsv9f/Pfvh4vXZ4dXJ",
+	"0aH+4ePh1cnL/vjk6PDKfVf87/S7fc/39CLBNyotGZ96Nze+d0Q1PRWpDOD4CKc10CVUzwrgWOj5noQ/",
+	"UiYh9A60TKEMaCTknGrvwEtTFi6d42yRPOAMrzidxBB+w2INbWTk8YJI0KnkhGmYq4w0TBGwQ0kU0+ky",
+	"8rhn2uljoXUwTYSIgXIHlGZ6cXy0DlRwiYxJ6ESk2rAMmMElOI+PlkNn5lkCntcf7O8N6Cjs9Pv9fucZ",
+	"/vMc/+kX/zfw/LWQjPMgITdekQGRgSIisqvBt9++HpynsqL/LyHyDrz/1ysEuWd/Vb3DNGS6gM+Aexyd",
+	"UB3MmlC+OqNT1RBmCTT0iQJUDBpCMlmQQMznVPlESPLe++d7r0vsUCoBf0uohJAoLQWfxgufKEGugF64",
+	"hzhcgiRzBKFLzmZAkL9BaRJRFjsGHPf75GoG3FB8BjQESZgicxojNSAklDstMh4MScpjUIpQbmawrwZl",
+	"xgaplMA1uQSpmOA5Zu07C9QeRx2LlHZWee+N3nuttH/N5kw3UXlCr9k8nROezicgkbpWvLRwbLCMxrF5",
+	"XxmKECKaxto72O37BUj4YW4n8Q4GffzEuPuUw8m4hilIBygHOoXvYdHCnqlOUk0kRCCBB4BgakkD8Al0",
+	"p12DSTH5HQJNLmCBq6FkIgX/CCShEjlY8C7Z+ecOEokLTWgciysIly3yAha36rUC8XaW3u9/pJRr9SGk",
+	"LF58+CMVGlQvpBr+Z9gf7nUG/c5gDz/TbkLlHynoVlr9QOfwo4SIXW+gD2dCAeHGTmkqdVlDJuZdyxbJ",
+	"89mWMJVdTCukp4wHmygTPaOaiMBwe0jwb0lopEE6pcLmS5WKwqmWQJghtz846/cPzP//UtaISIEOvrx9",
+	"EUK2SAZ+S4QMQXbJIYmBhoxPyU5nx/gBiuDTwPHLpRDje9dVgDjdG5zNgPQz1yy+M14nEAkJq1Ga4iy3",
+	"onRwJ5Te+J4ElQiuwDhn3wg5YWEIHD8EgmvgBuE0SWIWUFxW73clzM/rYeuVlEK+dXPYGas4MvpaxGDt",
+	"FZDDH4+NSggFlCTfokgkIA0QnsE8TfVMSPYRwscFNwMR7QdTivGpXxgSn6T8gosrjhIj4VJcGG/GmgeD",
+	"5Hfv3nUOUz1D4xtQDVXoCsJ+DVSCbKMaguUgxiF1k9zgw+8ZDxG/ztOhnFAcYnmSMOc6IPMBR33/KzIN",
+	"/aCMs+r59pOB4je/om6yrxsw+g6oS0eRRCLtNLNsRgML2J/5fIEExITvpUlo/wghBl2bMP+xMRsNdFtQ",
+	"8W4myJyGYC33jPIpvCDnNGEfLmBx8D7t90cBipj5C3r2Cxbaj+ckEtIQO6BxDFL55FwtlIb5ORF6BvKK",
+	"KSOxBYDZmwPWW+0LDvutK4mcKqFhaMwgjX8sIc8atuoqv2EQhypXz8VK6UQhgXEZlFiEdsk3+IkTi0uf",
+	"CNRRegbveWRfY+3TJY1TUO5NYfc9L6/zz9xNP4horOAmX4e16bgOq9rutJBcKy5ZiWWW+14JAtS2kNzn",
+	"fwBX3y974Js63r7HNgZq0F8HqMw+Heo2kXJOtEUouaLKeO9Ma+ueNY394PlZf39jY184c7/aqLUEVSbw",
+	"fqZK/Gook9PstxaCFprpNVMt2sm4ac2FHxYqUxHBncNGp+ATDlcm3GBS2bxD9obV9MTXeQXbUSnpAj8X",
+	"mY4mJC9djkRYacdHDSBdcmjFRFgKxVS5Hzy/Jcnx+l18ccyuTLLjDTu+Ojm7GJ68e/Xxl5f9K5vYCPC/",
+	"+s2RSXZUkxxnhyz6aTXdDCLaiPBSzBMJSjk7kIckHhfcqv/yir8VVySmxocjEYuhCAzxHdZBTZNY0LBL",
+	"dvANO0RpIV3Ilrk4hCoSAYZy+Nj0I0t2TNi381Hp0I0gTJfe6xeJJeCBMPNTVYpedhR5af2Ozqv8AR4a",
+	"90ClUcSuyT+6048+6X5U+qsXJvTF8VKkU/tenJVOkVgLkJmziD84SMlkoUGVbbPDEMLv+R7CXrWS7veG",
+	"VL80arPIQr21UXJTAHKV2CL7aPcMeGj8ifUQjAcRaHYJhicZn4LSLjZekbvxvQvGwyoHTIGDZEGDCX6U",
+	"4pKFIAkO8UnEriF0Rs9YBJyRnEIMgbYkspJmXW4HKdLGQI6AEAVaM+7SDJc0ZqHJR9ApZVzpLjmyMJkg",
+	"e8eBteOTqxkLZoQGASQakwSL/EVVQXMxpmci6dfAp3pWjqULylgvv45tDC4zr7iE7eoc33V+WnMSCcim",
+	"Tt5uU0xvswd/FDELjDbK1reZOc8I1lEJBCxiAQkEj9g0tU58ZSF/tpletA0fBW/BzfHhD4ck+9kE01W0",
+	"HCpGe2fiYiFWIaamsQwp/FIaMoehhIVWhZZLF9qgpbI1ocFFxOL41SoZmzHUDCygsSV+NpAUidS15Cuo",
+	"KtrbCF/WyTfW63f56hY4j49aeLPIOJIJxAJFS4sqZXZ3+/B83O93YLg/6YwH4bhDnw32OuPx3t7u7niM",
+	"XspajtM80YssUnOcuiq4axly46+j7pgqa42ZSGOM3Z3SixdO5a1JkTWlPcvbFqhTWgQXHxLJAlhL3mXK",
+	"T7WkGqaL1TJffvhTtQXarTRe6dGeZs/dWcOguHVw5YWKya3PpspGaRrDGZuDSPUJ46mGFifQ/VCKtbLF",
+	"hkYdZbELJTLlKKqB4Ap1oElf07hK0VE5ydpvTbKWdVNFIP2mqsrx7jfUTPvyVqi0wlVo6jEbg7VGCDhJ",
+	"kWsvawcTK9iB5B9vv3lJRqPR/leNoGHc6d8pnbWRKN+D68JaJvqZM9zGZCEKRcRspv5WC35fCrFwpJY6",
+	"TffqHr0oXCI3XDmfaC2X6IGcoKfv9pCEmpBFC7OoQl/RkCYa5P26RXZ7XAuCSkUmEnRNY6lbHKcGMm2q",
+	"5Q5Sb0JRN/phRN/txzUB+4/9oYWJfMJ4IGEOHKESJkcvFw7MLjmMVba3hlxvhQb3A6s6fOU+WSORYSTV",
+	"v9XVLLi2xIXFIv2S/i1T5XYtvlG646jAUzXdsXaCo5j4MyU4HqSKY730RhYHfNEBwGY23piMB7fwdw5K",
+	"Chj/upEJU3cMSTb3ZJrRyRroWysjfl/h0TYg+usFRJt6ILlOemL+B47+XN7HA0SRVclq14BVQ3TvHg4a",
+	"2yNIgIfAg0XT7IZZYeRqq2GYJrTvQsJUmWSNjb/hOmpuTq9f0+kRXahK9rvftvUxx4AqpIvK1qimcgoa",
+	"QUbpUmkQACDAcA1Bqsscl6OFzOnC5K04Ch/6EopqpqIFYbpL+sTxiioqaQ0vlte/ecbCYX09wm3uouJL",
+	"XVnNPFWazOjlMnzY3eMy3sr1NxUzCtda0gAFEwcxvZHTW+PFhvO7sRd5V6zc2W3PNpj/Xk77q3bHLZdN",
+	"zzCVUo2dqXfIfZRnXIO8ZmsP8p3HmcCChI8gUacHQobKFDh1yY57547Z37SSZ9RnMdZIq2VnHIBFrTuu",
+	"tNU8nfM37lViCXC2U0rNXmmX7EjQcvEhphrkjpm3EPBJqsmcyov6u6giIUQgJeBWm5BOPHChyFDpJGZq",
+	"lu+/Ve2+TPmLhopSGvUXJVOaVHkE/QIh8/CmvNNZ4BuXa41GtpDqnmfxZEPRVku6GlIE+HOTm79N55R3",
+	"JNAQTR+Zg1K4S2sfmiB2rxAXV8jYV1iWXOXmcvjBBRaupDxcyZoWllbWzAiz9gLezRY1kiIOIcxraVyN",
+	"s/22Cr39DrNGdlMd/zodHaDvxsHyt4QoVdAe/yBAL9EXEHKxPmCWzfDvVNrydUpsIV3g3pWDXlRfkR0j",
+	"LB8yYTH1wiiJVRGSgIi0peYVO7+j6o8mRu67ZCdj/yWv1PQCOCJmDrTkcbpy5UJAFqB9EkOUFS8Z5kWm",
+	"75IdkeoPIvqQxJTbWSoSY4QqneS4I/hcZtQdbwWUG/YCrH0vSU4VK0Zh2dV4vleatVbPVzzTIKqpumgS",
+	"83WlKONKMq2Bk0mNwmvbnpzNv2FxqwGKGDdotf5/S6nRHpYajfoH/eH6rnutjGo8bAs8TK32OhNvEDMo",
+	"TXWqynWQMuUcf/S93IFx2g/CFo3nfm+82LLRkSsurZVL40IyNirzG2umbrOM5JK6rr08TvqXKfD6hOKu",
+	"EsQ5XjKuu1UhGk5pFmtVyoVwaaejLjlGckosgXUVglRCubTQcrE10BC+54W3vSBXgH9JGlygDs3tp6su",
+	"xBeZ+iETqp2OssMNc9DUnCIwZYe1LbS8zOh0Roe7ey1GCK47pu4IQnL67WFnuLtHQobhfK4G7Jx2tlw/",
+	"miC4ACereaqma8LxYNwf0kkwngzps73J/rPBfrg/GPQHz4Ld/SGFfRhPRtEgisZRuLtPB9EujII9Oo4m",
+	"4YgO29iutCL2Eb5etGYM8KdPBn/QHz/ffVZiN8b13thrE1xXF56VZ7U4rkVceme4nPgurcky37RizACX",
+	"pYrLlcS1Eva2weX87D3pwuyp8guXzT/TOjnNVVhDy+gUsz0h1EwWFlQXVnQGVkIxSWNNKp4Wq9B62G/N",
+	"BF20nTsqJO8CFpW3eE4hZCd/ehNJedhDZPUG/d5gz/w56Busjfr9/i8fBnQ4GQXjsLsMAXPKWQRKf78S",
+	"lAwH2Yjsc+EEGdf6SgpdIOXFct2URa7Z+5TVT84Aty/8Q/7wchzk8HTGw6XLTlI5XVEc7FaOVJUwF5cQ",
+	"kpSH5dJB533laSDneJWEzShuE25cQKLbTNDA8Pho01SdurO6Nay6TKP2n4fDvd3h3l4fomejQRCMng8n",
+	"dBeCETyfDCMaPg+i5zQa7g/Gw3CPRuEYBrv7z0bRqB89nzwLWkFdT4/W4PLzrCtfkFLarUWF7q2hQ2v2",
+	"2p61K5TPreZ5o9RFPupeCqzzt32GTMYv//uKvfn9p+tPqpF2Byyb6MulVLUWfARgDiUVysVVLNszmVcm",
+	"tJcMZdIVIh+45WjD6hzKyog5tPv4DK5bqNrvijCepFp1SYl+Kk1AKpOAmyzysEdwq6MQutyFcnBRCTZO",
+	"ckeR1qJxsdpbqd1qK85m1qGDMAMhP65aJej9HRn1M2X8PSxaaHds8GiKxO2haUsWM4KgH+6bI401xfNr",
+	"w7RVICxpdxrHVqn/VkJvA8Rbk5VW+EsMWF1SGxv/iMegW8vO2/e1zJGieortu9M3P5ATkFMg5n12E+fZ",
+	"aH/vK2J8paKiplwmRN7Ygyllh98wWcqzA0EEASfM7EpVS57mOF2INjeVyuyqviCU8DSOrdvvTJuVLudv",
+	"PEIlvcPPnQtL77GMnMbxm8g7+HWjHVFDP+/mtzqJ88eITNHXMbkVJDjjjrLZ2fvMVTCkyIhgt89wKC4N",
+	"f0Lc5+e6HqaWazmM2XytULofq3uw5vRgvo3oXQ69m/o6Pl+N/HKprpW7P6BMmy3/zyzRX1D5ztOvkP9U",
+	"TfaESuS/JE24YTWKg/LhS1LuV5sCDxPBuPYOvJ5liIpftJZyfZpnApq6uMULbkmJFnviE6NuERy7cW4C",
+	"dCNSxu1Ee2GMhhZ5BNRUhWvk4gc2PTy6cy7+WVvmx4Qb3wOKjwbZFgQZA1DWFBJo+ML0k8l6+dga9T9S",
+	"051GRHic2ez4S2UKc+LUdhHp7Tyir232ZpJUv4Vo6aIkUZwmaiZK4Up1rW1JoF/vHsRsBL/b3H4LtLan",
+	"MhoO2kjpnn9nQV5riHK1S1lZ4pIwyvm6oFsPIFRVQVvixczyi3OssvyuI77nO3R6vqdYfFnfjS4ea9vy",
+	"kXodmdkgn2X3Tm7DiGvHdCtKPqxCiZ2mjpI6InxvKuJa9jt/Zo2NoBLiK1O2EL5l5S3KoSJUVQ5t8F9b",
+	"+Pq27iQU9SA0ScBs67eVgxAJHdSwke1SQWPUQYtSZVF57w1l1pSD2Ffmx9cppr5sei+x28bueDkkrmKD",
+	"ypjZzAoOF5cgzbt23CtzZ4DbHfXypFoUSZgLWPhEQhLTIEvO5P3aLC7K+xz5wvP5qgTPf28wUb06drOI",
+	"weSXzzVVF2QanBOTiK7kmqpNApoll6JZxexKDaoF49S4HIQL40a5MUwSccWxyZTdVLSJaZepRntins1q",
+	"ZHC8pRPTvqFa2aExb2hQhGXN7MzKwhe2/YCDQvmOmOXZi3jIPGKiHC50JuVVi43gvDZZvhbnOu8rlydb",
+	"Y7BzGNYrgVk2kOU11TeKVxSn+l5BuBN6fTiFohqy6kCawqESlSvot24Xs4nNNtt3F4+q1VHfjFd/pFIz",
+	"Gjd2OOoUQ6I47sp7C2IsmkemhqtMXQnBgwsgnyJxl7jSn53Yq+BqI/5pKUCqQQcR41jognqoiGJkylXW",
+	"Zakcl1YJZE7btew6fXtwcmKP4pF/DMedmUil31KMsaPyZM5XhGrXhaIGBNBghpXCNecvq84Y7B6M+hWP",
+	"rh4j4BQ4uYXK+h85WKZevrkLyvixfd2g6RDq1oZkGYZJQEPgAbzI2Cxe2DZOO8YZMUVRKk2SIumd9ydj",
+	"8aLejQy/WuVjZO3KDC1+u4X4nyLyWfzXSFYtl3UDkG8ZK5GggOvMHrt01NVMxEBipvQTZK0vmaXW4KAm",
+	"l+RNICsOIWs6g0V3SmvuDQFX9akkOyzcIZHA1ocq727jXjOBgKYKyPGRNfc//3x8dPmsLB0GjA4rHe/o",
+	"mP9W5MX90sD9mWTTKcg8k3BLm6CwvcLsNVW6xaRgwrVL3mZnDEwkhC94QcJSn53862U1Z/WwaGmU1Q7c",
+	"N0xWoau2+dEipIvbRaQdsOrhtnbA2jipie5llcqTWGDx2VleKdcibWfFumwhqos3c6YpWah61taeEzFb",
+	"7zOKo5ceotAzmJMFVLdp70qrunjnUx2HbVukR6pRO6OKVS7solwD6Hb42us762CoC5Ykm2DbDSgwzcul",
+	"+VTlEaCr8XwA5NVsXQWTrSvy25iqzSr+bA5gPbXeYdu+WV9+36yCs/4qfbO2nam2nan+Zp2pHrgVlaFC",
+	"kEqmF0gZF+tMgEqQ2Em7JerIW3SrNLse5jyIGaEJ6+Q/nNvrIlQgsjQgL3qMv+e4KnvMi2llm5SbI6ZT",
+	"Sbk+IOdozj9gbHGOcyn7vf363CfnNJwzfv6eN34zycBzkzY9twcDDN8ZYaz1/J5pndg25IxHAtcZswCc",
+	"e2jF1Ts5PvN8L5Wxe14d9HoiAe7MnpDTnhukevisMRDayO0pyi05EyLGsuzS8esDb9Dtd/v4LL6KJsw7",
+	"8Ebdfnfk+eZGGUOBHk1Y73LQM93EO7Y1Ln4/BaPBc1TioWsPK0GLnrfK8ysXAy3ZOC8e6dl7MW78lQ+6",
+	"ss41nmxcs7L2mOOj9UeUr15Y4/HyjQJYGlBp0D/s9++t132tD3JLs/tTe6oySuO8Yh85YnyPQKxsuP81",
+	"DTN33s49WPbKHFG9ytUAZtBo9aDi7gMcMRw+3hL/Y1uroWWwhznLCs+IRlnV/eqhEvF+Q95Q6XxO5cJJ",
+	"l2vJbeLYsNy+qdLWTZnXZ6KLX3fcc7eKbuH7PwnRNVeBrCWu5euq1hjQuNLlQWWw1pxrK4NfvAyWBQ9X",
+	"kQjVIlH1Xtzu8iJQ+msRLu5t2ctaft9UHThXGFbj8sEDcHkb6i2Q4ZarPz9Xu638Kltb+rhShBJ7L7Uj",
+	"vT9ZeGN98hg0NLn/yHxf4f7NDErlKsU1lHp2QV2LLh+37fIheH8bhuyPH2+JP+S9MHDmwSOKQnYfX3Gx",
+	"lKkEbLvT705SYpmmWkiCi2x1qP4N+t64/3Hck3Vdk8pNV9iurPV4XRnbS1K1m1yaePPXl5bNvZF/g66V",
+	"NU0WxKhLL2m/rfMQV2KKzm47mdF2ygqzKPnZCpXGpgCq6BidV5ld0QXBnRASYWGLa3Xn+TXpqB0Ye2Tr",
+	"sI4XZtbaMWj812Y0X3IYbi2H7G8q11sL/HAWuL//eDO/FLwoyZiLkEXMnQ7mpqFTBfNP1jX4Ej14V5cU",
+	"Z91Fm15KkrZ4KfVN3yeoiDfD9rJd7K323Wrfrfbdat+H0b4/t+rceu7EpuZXZeDPzFMNPdx2Z3Stz3OB",
+	"kRWtiW/8bT7/k1R73rF3m83/a2Tz3a7Z6ly+u332YTP55TKpz5DHN2vcZvH/All8vUiW2aENMviO5zcP",
+	"DHDgNnu/zd4/wey9du3vb8vdfzLfP4Yb8kjxZVYQus3Z31/O3l5Vde8Ze0Oqh8rXP7oteKRc/cYu199Q",
+	"kreWdpsn2uaJHiJLn3kjK3L0T1L53jU/v9W4W4271bhbjfuYmflV+ZBefpEeWzNTf1Qe8NRjxdolfJsk",
+	"r7dRXDNzXOsxRVsP2i81629t54+HYaT7N9y38dDjW+5P4OStTX04m/o5zUO5qxUt34gaLIIY7mQ8nIiu",
+	"K+i32JXqdQ4rrUpxy8IDu/sbb/M+pJmq3iiylemtTN/ZNJeuaYXSjTNRRXbNkXHbqqvSVHL5PrDr5NMQ",
+	"06dosJc1ebpxJrsixMMHnHY5uV8VpKFBAMl2T+8vFvf+ICq9T4Osd4lr5uS3d1JijUZK7opuYv5VpByl",
+	"5e1Q56C/zBDVSUztdular8mKdxEXt0Yt27/MLpba/By3GYfX3T2orc/g21r5z6cVSs3fkpYrxUpXFdr2",
+	"2l+kO3Am0YOn3K3BNIvKtkurraRZ9eI0vCbBSt0MaKxnS6XtW/PzyxkEF94nSky1IVbp7uA8XywuVvY/",
+	"dcNaeuw0RQ0ktqJmitg1LuoYrtYX4RLN5Z7KjrODvJvaoFvIYsZZRVRrKClMOy+4hFgkc3sfiLTN5ou2",
+	"Nwe9XozPzYTSB8/7z/vezW83/zcAbiHPnIyuAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+
+	api "stock-tool/api/gen"
+	"stock-tool/internal/usecase"
+
+	"github.com/samber/lo"
+)
+
+// AuditEventUseCase defines the operations the handler delegates to the usecase layer.
+type AuditEventUseCase interface {
+	List(ctx context.Context, req *usecase.ListAuditEventsRequest) (*usecase.AuditEventListResponse, error)
+}
+
+type AuditEventHandler struct {
+	uc AuditEventUseCase
+}
+
+func (h *AuditEventHandler) ListAuditEvents(
+	ctx context.Context, request api.ListAuditEventsRequestObject,
+) (api.ListAuditEventsResponseObject, error) {
+	list, err := h.uc.List(ctx, &usecase.ListAuditEventsRequest{
+		EntityType: string(lo.FromPtr(request.Params.EntityType)),
+		EntityID:   request.Params.EntityId,
+		Since:      request.Params.Since,
+		Until:      request.Params.Until,
+		Page: usecase.PageRequest{
+			Limit:  lo.FromPtr(request.Params.Limit),
+			Cursor: lo.FromPtr(request.Params.Cursor),
+		},
+	})
+	if err != nil {
+		if msg, ok := validationErrorMessage(err); ok {
+			return api.ListAuditEvents422JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+	return api.ListAuditEvents200JSONResponse{
+		Items: lo.Map(list.Items, func(e *usecase.AuditEventResponse, _ int) api.AuditEvent {
+			return toAuditEventResponse(e)
+		}),
+		NextCursor: list.NextCursor,
+	}, nil
+}
+
+func toAuditEventResponse(r *usecase.AuditEventResponse) api.AuditEvent {
+	return api.AuditEvent{
+		Id:         r.ID,
+		OccurredAt: r.OccurredAt,
+		Actor:      r.Actor,
+		Action:     api.AuditEventAction(r.Action),
+		EntityType: api.AuditEntityType(r.EntityType),
+		EntityId:   r.EntityID,
+		Before:     mapPtrOrNil(r.Before),
+		After:      mapPtrOrNil(r.After),
+	}
+}
+
+// mapPtrOrNil returns nil for a nil map so that the field is omitted.
+func mapPtrOrNil(m map[string]any) *map[string]any {
+	if m == nil {
+		return nil
+	}
+	return &m
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "stock-tool/api/gen"
+	"stock-tool/internal/usecase"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditEventUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *AuditEventUseCaseMock) List(
+	ctx context.Context,
+	req *usecase.ListAuditEventsRequest,
+) (*usecase.AuditEventListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.AuditEventListResponse), args.Error(1)
+}
+
+type AuditEventHandlerTestSuite struct {
+	suite.Suite
+	ucMock  *AuditEventUseCaseMock
+	handler *AuditEventHandler
+}
+
+func TestAuditEventHandler(t *testing.T) {
+	suite.Run(t, new(AuditEventHandlerTestSuite))
+}
+
+func (s *AuditEventHandlerTestSuite) SetupTest() {
+	s.ucMock = new(AuditEventUseCaseMock)
+	s.handler = &AuditEventHandler{uc: s.ucMock}
+}
+
+func (s *AuditEventHandlerTestSuite) TestListAuditEvents() {
+	now := time.Now()
+	since := now.Add(-time.Hour)
+	id := uuid.Must(uuid.NewV7())
+	entityID := uuid.Must(uuid.NewV7())
+	expectedReq := &usecase.ListAuditEventsRequest{
+		EntityType: "data_type",
+		EntityID:   &entityID,
+		Since:      &since,
+		Page:       usecase.PageRequest{Limit: 1, Cursor: "prev"},
+	}
+	s.ucMock.On("List", mock.Anything, expectedReq).Return(&usecase.AuditEventListResponse{
+		Items: []*usecase.AuditEventResponse{
+			{
+				ID:         id,
+				OccurredAt: now,
+				Actor:      "system",
+				Action:     "update",
+				EntityType: "data_type",
+				EntityID:   entityID,
+				Before:     map[string]any{"enabled": true},
+				After:      map[string]any{"enabled": false},
+			},
+		},
+		NextCursor: lo.ToPtr("next"),
+	}, nil)
+
+	resp, err := s.handler.ListAuditEvents(context.Background(), api.ListAuditEventsRequestObject{
+		Params: api.ListAuditEventsParams{
+			Limit:      lo.ToPtr(1),
+			Cursor:     lo.ToPtr("prev"),
+			EntityType: lo.ToPtr(api.AuditEntityTypeDataType),
+			EntityId:   &entityID,
+			Since:      &since,
+		},
+	})
+
+	expected := api.ListAuditEvents200JSONResponse{
+		Items: []api.AuditEvent{
+			{
+				Id:         id,
+				OccurredAt: now,
+				Actor:      "system",
+				Action:     api.Update,
+				EntityType: api.AuditEntityTypeDataType,
+				EntityId:   entityID,
+				Before:     &map[string]any{"enabled": true},
+				After:      &map[string]any{"enabled": false},
+			},
+		},
+		NextCursor: lo.ToPtr("next"),
+	}
+	s.NoError(err)
+	s.Require().IsType(api.ListAuditEvents200JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.ListAuditEvents200JSONResponse)), cmp.Diff(expected, resp.(api.ListAuditEvents200JSONResponse)))
+}
+
+func (s *AuditEventHandlerTestSuite) TestListAuditEvents_ValidationError() {
+	s.ucMock.On("List", mock.Anything, mock.Anything).Return(nil, &usecase.ValidationError{Message: "since must be before until"})
+
+	resp, err := s.handler.ListAuditEvents(context.Background(), api.ListAuditEventsRequestObject{})
+
+	s.NoError(err)
+	s.Equal(api.ListAuditEvents422JSONResponse{Error: "since must be before until"}, resp)
+}
+
+func (s *AuditEventHandlerTestSuite) TestListAuditEvents_CreateOmitsBefore() {
+	now := time.Now()
+	id := uuid.Must(uuid.NewV7())
+	s.ucMock.On("List", mock.Anything, &usecase.ListAuditEventsRequest{}).Return(&usecase.AuditEventListResponse{
+		Items: []*usecase.AuditEventResponse{
+			{
+				ID:         id,
+				OccurredAt: now,
+				Actor:      "system",
+				Action:     "create",
+				EntityType: "data_source",
+				EntityID:   id,
+				After:      map[string]any{"name": "jquants"},
+			},
+		},
+	}, nil)
+
+	resp, err := s.handler.ListAuditEvents(context.Background(), api.ListAuditEventsRequestObject{})
+
+	s.NoError(err)
+	s.Require().IsType(api.ListAuditEvents200JSONResponse{}, resp)
+	items := resp.(api.ListAuditEvents200JSONResponse).Items
+	s.Require().Len(items, 1)
+	s.Nil(items[0].Before)
+	s.Equal(&map[string]any{"name": "jquants"}, items[0].After)
+}
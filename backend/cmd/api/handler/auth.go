@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	api "stock-tool/api/gen"
+	"stock-tool/internal/domain/audit"
+	"stock-tool/internal/domain/auth"
+	"stock-tool/internal/usecase"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/labstack/echo/v4"
+	oapimiddleware "github.com/oapi-codegen/echo-middleware"
+)
+
+// SecuritySchemeBearer is the name of the API key security scheme in openapi.yaml.
+const SecuritySchemeBearer = "bearerAuth"
+
+// Authenticator resolves the API key presented by a caller.
+type Authenticator interface {
+	// Authenticate returns the active key matching plaintext, or an error
+	// wrapping usecase.ErrUnauthenticated.
+	Authenticate(ctx context.Context, plaintext string) (*auth.APIKey, error)
+}
+
+// BearerAuth returns echo middleware that authenticates an
+// "Authorization: Bearer <key>" header and stores the key in the request
+// context, which also attributes audited changes to it. Requests without the
+// header pass through; whether an operation requires a key is decided by
+// Authorize from its OpenAPI security.
+func BearerAuth(a Authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if header == "" {
+				return next(c)
+			}
+			scheme, token, ok := strings.Cut(header, " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+				return unauthorized(c, "authorization header must use the Bearer scheme")
+			}
+			key, err := a.Authenticate(c.Request().Context(), token)
+			if errors.Is(err, usecase.ErrUnauthenticated) {
+				return unauthorized(c, err.Error())
+			}
+			if err != nil {
+				return err
+			}
+			ctx := audit.WithActor(auth.WithAPIKey(c.Request().Context(), key), key.Actor())
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// Authorize is an openapi3filter.AuthenticationFunc for the request
+// validator. It rejects operations secured by SecuritySchemeBearer unless
+// BearerAuth stored a key whose role grants every required scope.
+func Authorize(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+	if input.SecuritySchemeName != SecuritySchemeBearer {
+		return errors.New("unsupported security scheme " + input.SecuritySchemeName)
+	}
+	key := auth.APIKeyFromContext(input.RequestValidationInput.Request.Context())
+	if key == nil {
+		return unauthorized(oapimiddleware.GetEchoContext(ctx), "API key is required")
+	}
+	if !key.Role().Grants(input.Scopes) {
+		return newErrorResponse(
+			http.StatusForbidden,
+			"role "+string(key.Role())+" does not allow scopes: "+strings.Join(input.Scopes, ", "),
+		)
+	}
+	return nil
+}
+
+// newErrorResponse returns an echo error rendered with the ErrorResponse schema.
+func newErrorResponse(code int, message string) *echo.HTTPError {
+	return echo.NewHTTPError(code, api.ErrorResponse{Error: message})
+}
+
+func unauthorized(c echo.Context, message string) *echo.HTTPError {
+	if c != nil {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	}
+	return newErrorResponse(http.StatusUnauthorized, message)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "stock-tool/api/gen"
+	"stock-tool/internal/domain/auth"
+	"stock-tool/internal/usecase"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	oapimiddleware "github.com/oapi-codegen/echo-middleware"
+)
+
+type stubAuthenticator map[string]*auth.APIKey
+
+func (a stubAuthenticator) Authenticate(_ context.Context, plaintext string) (*auth.APIKey, error) {
+	if key, ok := a[plaintext]; ok {
+		return key, nil
+	}
+	return nil, usecase.ErrUnauthenticated
+}
+
+func newAuthTestServer(t *testing.T) *echo.Echo {
+	t.Helper()
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = nil
+
+	authenticator := stubAuthenticator{
+		"stk_reader": auth.NewAPIKeyDirectly(uuid.Nil, "reader", auth.RoleReadOnly, "", time.Time{}, nil),
+		"stk_admin":  auth.NewAPIKeyDirectly(uuid.Nil, "admin", auth.RoleAdmin, "", time.Time{}, nil),
+	}
+	e := echo.New()
+	e.Use(BearerAuth(authenticator))
+	e.Use(oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapimiddleware.Options{
+		Options: openapi3filter.Options{AuthenticationFunc: Authorize},
+	}))
+	ok := func(c echo.Context) error {
+		if auth.APIKeyFromContext(c.Request().Context()) == nil && c.Path() != "/health" {
+			t.Errorf("no API key in context for %s", c.Path())
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+	e.GET("/health", ok)
+	e.GET("/api/v1/data-sources", ok)
+	e.DELETE("/api/v1/data-sources/:id", ok)
+	return e
+}
+
+func TestAuth(t *testing.T) {
+	dataSourcePath := "/api/v1/data-sources/" + uuid.Must(uuid.NewV7()).String()
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		expectCode    int
+		expectBody    string
+	}{
+		{
+			name:       "health needs no key",
+			method:     http.MethodGet,
+			path:       "/health",
+			expectCode: http.StatusNoContent,
+		},
+		{
+			name:       "missing key",
+			method:     http.MethodGet,
+			path:       "/api/v1/data-sources",
+			expectCode: http.StatusUnauthorized,
+			expectBody: `{"error":"API key is required"}`,
+		},
+		{
+			name:          "unknown key",
+			method:        http.MethodGet,
+			path:          "/api/v1/data-sources",
+			authorization: "Bearer stk_unknown",
+			expectCode:    http.StatusUnauthorized,
+			expectBody:    `{"error":"invalid or revoked API key"}`,
+		},
+		{
+			name:          "not a bearer token",
+			method:        http.MethodGet,
+			path:          "/api/v1/data-sources",
+			authorization: "Basic dXNlcjpwYXNz",
+			expectCode:    http.StatusUnauthorized,
+			expectBody:    `{"error":"authorization header must use the Bearer scheme"}`,
+		},
+		{
+			name:          "read-only key reads",
+			method:        http.MethodGet,
+			path:          "/api/v1/data-sources",
+			authorization: "Bearer stk_reader",
+			expectCode:    http.StatusNoContent,
+		},
+		{
+			name:          "read-only key cannot delete",
+			method:        http.MethodDelete,
+			path:          dataSourcePath,
+			authorization: "Bearer stk_reader",
+			expectCode:    http.StatusForbidden,
+			expectBody:    `{"error":"role read_only does not allow scopes: write"}`,
+		},
+		{
+			name:          "admin key deletes",
+			method:        http.MethodDelete,
+			path:          dataSourcePath,
+			authorization: "bearer stk_admin",
+			expectCode:    http.StatusNoContent,
+		},
+	}
+	e := newAuthTestServer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectCode {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.expectCode, rec.Body.String())
+			}
+			if tt.expectBody != "" {
+				if got := rec.Body.String(); got != tt.expectBody+"\n" {
+					t.Errorf("body = %s, want %s", got, tt.expectBody)
+				}
+			}
+			if tt.expectCode == http.StatusUnauthorized && rec.Header().Get(echo.HeaderWWWAuthenticate) != "Bearer" {
+				t.Errorf("WWW-Authenticate = %q, want Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Binder extends echo's DefaultBinder to decode request bodies sent with a
+// structured JSON media type such as application/merge-patch+json, which the
+// default binder rejects as unsupported.
+type Binder struct {
+	echo.DefaultBinder
+}
+
+func (b *Binder) Bind(i any, c echo.Context) error {
+	mediaType, _, _ := strings.Cut(c.Request().Header.Get(echo.HeaderContentType), ";")
+	if !strings.HasSuffix(strings.TrimSpace(mediaType), "+json") {
+		return b.DefaultBinder.Bind(i, c)
+	}
+	if c.Request().ContentLength == 0 {
+		return nil
+	}
+	if err := c.Echo().JSONSerializer.Deserialize(c, i); err != nil {
+		var he *echo.HTTPError
+		if errors.As(err, &he) {
+			return err
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	api "stock-tool/api/gen"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/nullable"
+	"github.com/samber/lo"
+)
+
+func TestBinder_Bind(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    api.PatchDataSourceRequest
+		expectCode  int
+	}{
+		{
+			name:        "merge patch keeps null members",
+			contentType: "application/merge-patch+json",
+			body:        `{"enabled":false,"settings":{"token":null}}`,
+			expected: api.PatchDataSourceRequest{
+				Enabled:  lo.ToPtr(false),
+				Settings: nullable.NewNullableWithValue(map[string]any{"token": nil}),
+			},
+		},
+		{
+			name:        "merge patch keeps null objects and rules",
+			contentType: "application/merge-patch+json",
+			body:        `{"retention":{"keepLatest":null},"settings":null}`,
+			expected: api.PatchDataSourceRequest{
+				Retention: nullable.NewNullableWithValue(api.RetentionPolicyPatch{KeepLatest: nullable.NewNullNullable[int]()}),
+				Settings:  nullable.NewNullNullable[map[string]any](),
+			},
+		},
+		{
+			name:        "media type parameters ignored",
+			contentType: "application/merge-patch+json; charset=utf-8",
+			body:        `{"name":"src"}`,
+			expected:    api.PatchDataSourceRequest{Name: lo.ToPtr("src")},
+		},
+		{
+			name:        "plain json delegates to default binder",
+			contentType: echo.MIMEApplicationJSON,
+			body:        `{"name":"src"}`,
+			expected:    api.PatchDataSourceRequest{Name: lo.ToPtr("src")},
+		},
+		{
+			name:        "malformed body",
+			contentType: "application/merge-patch+json",
+			body:        `{"name":`,
+			expectCode:  http.StatusBadRequest,
+		},
+		{
+			name:        "unsupported media type",
+			contentType: "text/plain",
+			body:        `name=src`,
+			expectCode:  http.StatusUnsupportedMediaType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			var got api.PatchDataSourceRequest
+			err := (&Binder{}).Bind(&got, c)
+
+			if tt.expectCode != 0 {
+				var he *echo.HTTPError
+				if !errors.As(err, &he) || he.Code != tt.expectCode {
+					t.Fatalf("expected HTTP %d error, got %v", tt.expectCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(tt.expected, got) {
+				t.Error(cmp.Diff(tt.expected, got))
+			}
+		})
+	}
+}
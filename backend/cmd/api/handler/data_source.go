@@ -14,9 +14,10 @@ import (
 type DataSourceUseCase interface {
 	Create(ctx context.Context, req *usecase.CreateDataSourceRequest) (*usecase.DataSourceResponse, error)
 	Get(ctx context.Context, id uuid.UUID) (*usecase.DataSourceResponse, error)
-	List(ctx context.Context) ([]*usecase.DataSourceResponse, error)
+	List(ctx context.Context, req *usecase.ListDataSourcesRequest) (*usecase.DataSourceListResponse, error)
 	Update(ctx context.Context, req *usecase.UpdateDataSourceRequest) (*usecase.DataSourceResponse, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Patch(ctx context.Context, req *usecase.PatchDataSourceRequest) (*usecase.DataSourceResponse, error)
+	Delete(ctx context.Context, id uuid.UUID, ifMatch *usecase.IfMatch) (bool, error)
 }
 
 type DataSourceHandler struct {
@@ -24,25 +25,41 @@ type DataSourceHandler struct {
 }
 
 func (h *DataSourceHandler) ListDataSources(
-	ctx context.Context, _ api.ListDataSourcesRequestObject,
+	ctx context.Context, request api.ListDataSourcesRequestObject,
 ) (api.ListDataSourcesResponseObject, error) {
-	list, err := h.uc.List(ctx)
+	list, err := h.uc.List(ctx, &usecase.ListDataSourcesRequest{
+		Enabled:    request.Params.Enabled,
+		NamePrefix: lo.FromPtr(request.Params.NamePrefix),
+		Page: usecase.PageRequest{
+			Limit:  lo.FromPtr(request.Params.Limit),
+			Cursor: lo.FromPtr(request.Params.Cursor),
+			Sort:   string(lo.FromPtr(request.Params.Sort)),
+		},
+	})
 	if err != nil {
+		if msg, ok := validationErrorMessage(err); ok {
+			return api.ListDataSources422JSONResponse{Error: msg}, nil
+		}
 		return nil, err
 	}
-	return api.ListDataSources200JSONResponse(lo.Map(list, func(s *usecase.DataSourceResponse, _ int) api.DataSource {
-		return toDataSourceResponse(s)
-	})), nil
+	return api.ListDataSources200JSONResponse{
+		Items: lo.Map(list.Items, func(s *usecase.DataSourceResponse, _ int) api.DataSource {
+			return toDataSourceResponse(s)
+		}),
+		NextCursor: list.NextCursor,
+	}, nil
 }
 
 func (h *DataSourceHandler) CreateDataSource(
 	ctx context.Context, request api.CreateDataSourceRequestObject,
 ) (api.CreateDataSourceResponseObject, error) {
 	resp, err := h.uc.Create(ctx, &usecase.CreateDataSourceRequest{
-		Name:     request.Body.Name,
-		Enabled:  request.Body.Enabled,
-		Timezone: request.Body.Timezone,
-		Settings: request.Body.Settings,
+		Kind:      lo.FromPtr(request.Body.Kind),
+		Name:      request.Body.Name,
+		Enabled:   request.Body.Enabled,
+		Timezone:  request.Body.Timezone,
+		Retention: toRetentionInput(lo.FromPtr(request.Body.Retention)),
+		Settings:  request.Body.Settings,
 	})
 	if err != nil {
 		if msg, ok := validationErrorMessage(err); ok {
@@ -63,47 +80,117 @@ func (h *DataSourceHandler) GetDataSource(
 	if resp == nil {
 		return api.GetDataSource404JSONResponse{Error: "data source not found"}, nil
 	}
-	return api.GetDataSource200JSONResponse(toDataSourceResponse(resp)), nil
+	return api.GetDataSource200JSONResponse{
+		Body:    toDataSourceResponse(resp),
+		Headers: api.GetDataSource200ResponseHeaders{ETag: formatETag(resp.Version)},
+	}, nil
 }
 
 func (h *DataSourceHandler) UpdateDataSource(
 	ctx context.Context, request api.UpdateDataSourceRequestObject,
 ) (api.UpdateDataSourceResponseObject, error) {
+	ifMatch, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		return api.UpdateDataSource400JSONResponse{Error: err.Error()}, nil
+	}
 	resp, err := h.uc.Update(ctx, &usecase.UpdateDataSourceRequest{
-		ID:       request.Id,
-		Name:     request.Body.Name,
-		Enabled:  request.Body.Enabled,
-		Timezone: request.Body.Timezone,
-		Settings: request.Body.Settings,
+		ID:        request.Id,
+		Name:      request.Body.Name,
+		Enabled:   request.Body.Enabled,
+		Timezone:  request.Body.Timezone,
+		Retention: toRetentionInput(lo.FromPtr(request.Body.Retention)),
+		Settings:  request.Body.Settings,
+		IfMatch:   ifMatch,
 	})
 	if err != nil {
 		if msg, ok := validationErrorMessage(err); ok {
 			return api.UpdateDataSource422JSONResponse{Error: msg}, nil
 		}
+		if msg, ok := preconditionFailedErrorMessage(err); ok {
+			return api.UpdateDataSource412JSONResponse{Error: msg}, nil
+		}
+		if msg, ok := conflictErrorMessage(err); ok {
+			return api.UpdateDataSource409JSONResponse{Error: msg}, nil
+		}
 		return nil, err
 	}
 	if resp == nil {
 		return api.UpdateDataSource404JSONResponse{Error: "data source not found"}, nil
 	}
-	return api.UpdateDataSource200JSONResponse(toDataSourceResponse(resp)), nil
+	return api.UpdateDataSource200JSONResponse{
+		Body:    toDataSourceResponse(resp),
+		Headers: api.UpdateDataSource200ResponseHeaders{ETag: formatETag(resp.Version)},
+	}, nil
+}
+
+func (h *DataSourceHandler) PatchDataSource(
+	ctx context.Context, request api.PatchDataSourceRequestObject,
+) (api.PatchDataSourceResponseObject, error) {
+	ifMatch, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		return api.PatchDataSource400JSONResponse{Error: err.Error()}, nil
+	}
+	req := &usecase.PatchDataSourceRequest{
+		ID:        request.Id,
+		Name:      request.Body.Name,
+		Enabled:   request.Body.Enabled,
+		Timezone:  request.Body.Timezone,
+		Retention: toRetentionPatch(request.Body.Retention),
+		IfMatch:   ifMatch,
+	}
+	req.Settings, req.ClearSettings = toSettingsPatch(request.Body.Settings)
+	resp, err := h.uc.Patch(ctx, req)
+	if err != nil {
+		if msg, ok := validationErrorMessage(err); ok {
+			return api.PatchDataSource422JSONResponse{Error: msg}, nil
+		}
+		if msg, ok := preconditionFailedErrorMessage(err); ok {
+			return api.PatchDataSource412JSONResponse{Error: msg}, nil
+		}
+		if msg, ok := conflictErrorMessage(err); ok {
+			return api.PatchDataSource409JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return api.PatchDataSource404JSONResponse{Error: "data source not found"}, nil
+	}
+	return api.PatchDataSource200JSONResponse{
+		Body:    toDataSourceResponse(resp),
+		Headers: api.PatchDataSource200ResponseHeaders{ETag: formatETag(resp.Version)},
+	}, nil
 }
 
 func (h *DataSourceHandler) DeleteDataSource(
 	ctx context.Context, request api.DeleteDataSourceRequestObject,
 ) (api.DeleteDataSourceResponseObject, error) {
-	if err := h.uc.Delete(ctx, request.Id); err != nil {
+	ifMatch, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		return api.DeleteDataSource400JSONResponse{Error: err.Error()}, nil
+	}
+	deleted, err := h.uc.Delete(ctx, request.Id, ifMatch)
+	if err != nil {
+		if msg, ok := preconditionFailedErrorMessage(err); ok {
+			return api.DeleteDataSource412JSONResponse{Error: msg}, nil
+		}
 		return nil, err
 	}
+	if !deleted {
+		return api.DeleteDataSource404JSONResponse{Error: "data source not found"}, nil
+	}
 	return api.DeleteDataSource204Response{}, nil
 }
 
 func toDataSourceResponse(r *usecase.DataSourceResponse) api.DataSource {
 	return api.DataSource{
 		Id:        r.ID,
+		Kind:      r.Kind,
 		Name:      r.Name,
 		Enabled:   r.Enabled,
 		Timezone:  r.Timezone,
+		Retention: toRetentionAPI(r.Retention),
 		Settings:  r.Settings,
+		Version:   r.Version,
 		CreatedAt: r.CreatedAt,
 		UpdatedAt: r.UpdatedAt,
 	}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -37,12 +39,15 @@ func (m *DataSourceUseCaseMock) Get(ctx context.Context, id uuid.UUID) (*usecase
 	return args.Get(0).(*usecase.DataSourceResponse), args.Error(1)
 }
 
-func (m *DataSourceUseCaseMock) List(ctx context.Context) ([]*usecase.DataSourceResponse, error) {
-	args := m.Called(ctx)
+func (m *DataSourceUseCaseMock) List(
+	ctx context.Context,
+	req *usecase.ListDataSourcesRequest,
+) (*usecase.DataSourceListResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*usecase.DataSourceResponse), args.Error(1)
+	return args.Get(0).(*usecase.DataSourceListResponse), args.Error(1)
 }
 
 func (m *DataSourceUseCaseMock) Update(
@@ -56,8 +61,20 @@ func (m *DataSourceUseCaseMock) Update(
 	return args.Get(0).(*usecase.DataSourceResponse), args.Error(1)
 }
 
-func (m *DataSourceUseCaseMock) Delete(ctx context.Context, id uuid.UUID) error {
-	return m.Called(ctx, id).Error(0)
+func (m *DataSourceUseCaseMock) Patch(
+	ctx context.Context,
+	req *usecase.PatchDataSourceRequest,
+) (*usecase.DataSourceResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.DataSourceResponse), args.Error(1)
+}
+
+func (m *DataSourceUseCaseMock) Delete(ctx context.Context, id uuid.UUID, ifMatch *usecase.IfMatch) (bool, error) {
+	args := m.Called(ctx, id, ifMatch)
+	return args.Bool(0), args.Error(1)
 }
 
 type DataSourceHandlerTestSuite struct {
@@ -78,46 +95,102 @@ func (s *DataSourceHandlerTestSuite) SetupTest() {
 func (s *DataSourceHandlerTestSuite) TestListDataSources() {
 	now := time.Now()
 	id1 := uuid.Must(uuid.NewV7())
-	s.ucMock.On("List", mock.Anything).Return([]*usecase.DataSourceResponse{
-		{
-			ID:        id1,
-			Name:      "src1",
-			Enabled:   true,
-			Timezone:  "UTC",
-			Settings:  map[string]any{},
-			CreatedAt: now,
-			UpdatedAt: now,
+	s.ucMock.On("List", mock.Anything, &usecase.ListDataSourcesRequest{}).Return(&usecase.DataSourceListResponse{
+		Items: []*usecase.DataSourceResponse{
+			{
+				ID:        id1,
+				Name:      "src1",
+				Enabled:   true,
+				Timezone:  "UTC",
+				Settings:  map[string]any{},
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
 		},
 	}, nil)
 
 	resp, err := s.handler.ListDataSources(context.Background(), api.ListDataSourcesRequestObject{})
 
 	expected := api.ListDataSources200JSONResponse{
-		{Id: id1, Name: "src1", Enabled: true, Timezone: "UTC", Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now},
+		Items: []api.DataSource{
+			{Id: id1, Name: "src1", Enabled: true, Timezone: "UTC", Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	s.NoError(err)
+	s.Require().IsType(api.ListDataSources200JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.ListDataSources200JSONResponse)), cmp.Diff(expected, resp.(api.ListDataSources200JSONResponse)))
+}
+
+func (s *DataSourceHandlerTestSuite) TestListDataSources_WithParams() {
+	now := time.Now()
+	id1 := uuid.Must(uuid.NewV7())
+	expectedReq := &usecase.ListDataSourcesRequest{
+		Enabled:    lo.ToPtr(true),
+		NamePrefix: "j",
+		Page:       usecase.PageRequest{Limit: 1, Cursor: "prev", Sort: "-name"},
+	}
+	s.ucMock.On("List", mock.Anything, expectedReq).Return(&usecase.DataSourceListResponse{
+		Items: []*usecase.DataSourceResponse{
+			{ID: id1, Name: "j-quants", Enabled: true, Timezone: "UTC", Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now},
+		},
+		NextCursor: lo.ToPtr("next"),
+	}, nil)
+
+	resp, err := s.handler.ListDataSources(context.Background(), api.ListDataSourcesRequestObject{
+		Params: api.ListDataSourcesParams{
+			Limit:      lo.ToPtr(1),
+			Cursor:     lo.ToPtr("prev"),
+			Sort:       lo.ToPtr(api.MinusName),
+			Enabled:    lo.ToPtr(true),
+			NamePrefix: lo.ToPtr("j"),
+		},
+	})
+
+	expected := api.ListDataSources200JSONResponse{
+		Items: []api.DataSource{
+			{Id: id1, Name: "j-quants", Enabled: true, Timezone: "UTC", Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now},
+		},
+		NextCursor: lo.ToPtr("next"),
 	}
 	s.NoError(err)
 	s.Require().IsType(api.ListDataSources200JSONResponse{}, resp)
 	s.True(cmp.Equal(expected, resp.(api.ListDataSources200JSONResponse)), cmp.Diff(expected, resp.(api.ListDataSources200JSONResponse)))
 }
 
+func (s *DataSourceHandlerTestSuite) TestListDataSources_ValidationError() {
+	expectedReq := &usecase.ListDataSourcesRequest{Page: usecase.PageRequest{Cursor: "broken"}}
+	s.ucMock.On("List", mock.Anything, expectedReq).Return(nil, &usecase.ValidationError{Message: "invalid cursor"})
+
+	resp, err := s.handler.ListDataSources(context.Background(), api.ListDataSourcesRequestObject{
+		Params: api.ListDataSourcesParams{Cursor: lo.ToPtr("broken")},
+	})
+
+	expected := api.ListDataSources422JSONResponse{Error: "invalid cursor"}
+	s.NoError(err)
+	s.Require().IsType(api.ListDataSources422JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.ListDataSources422JSONResponse)), cmp.Diff(expected, resp.(api.ListDataSources422JSONResponse)))
+}
+
 func (s *DataSourceHandlerTestSuite) TestCreateDataSource_Success() {
 	now := time.Now()
 	id1 := uuid.Must(uuid.NewV7())
-	expectedReq := &usecase.CreateDataSourceRequest{Name: "new-src", Enabled: true, Timezone: "UTC", Settings: map[string]any{}}
+	settings := map[string]any{"plan": "free"}
+	expectedReq := &usecase.CreateDataSourceRequest{Kind: "jquants", Name: "new-src", Enabled: true, Timezone: "UTC", Settings: settings}
 	s.ucMock.On("Create", mock.Anything, expectedReq).Return(&usecase.DataSourceResponse{
 		ID:        id1,
+		Kind:      "jquants",
 		Name:      "new-src",
 		Enabled:   true,
 		Timezone:  "UTC",
-		Settings:  map[string]any{},
+		Settings:  settings,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil)
 
-	body := &api.CreateDataSourceRequest{Name: "new-src", Enabled: true, Timezone: "UTC", Settings: map[string]any{}}
+	body := &api.CreateDataSourceRequest{Kind: lo.ToPtr("jquants"), Name: "new-src", Enabled: true, Timezone: "UTC", Settings: settings}
 	resp, err := s.handler.CreateDataSource(context.Background(), api.CreateDataSourceRequestObject{Body: body})
 
-	expected := api.CreateDataSource201JSONResponse{Id: id1, Name: "new-src", Enabled: true, Timezone: "UTC", Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now}
+	expected := api.CreateDataSource201JSONResponse{Id: id1, Kind: "jquants", Name: "new-src", Enabled: true, Timezone: "UTC", Settings: settings, CreatedAt: now, UpdatedAt: now}
 	s.NoError(err)
 	s.Require().IsType(api.CreateDataSource201JSONResponse{}, resp)
 	s.True(cmp.Equal(expected, resp.(api.CreateDataSource201JSONResponse)), cmp.Diff(expected, resp.(api.CreateDataSource201JSONResponse)))
@@ -146,13 +219,17 @@ func (s *DataSourceHandlerTestSuite) TestGetDataSource_Found() {
 		Enabled:   true,
 		Timezone:  "UTC",
 		Settings:  map[string]any{},
+		Version:   3,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil)
 
 	resp, err := s.handler.GetDataSource(context.Background(), api.GetDataSourceRequestObject{Id: id1})
 
-	expected := api.GetDataSource200JSONResponse{Id: id1, Name: "src", Enabled: true, Timezone: "UTC", Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now}
+	expected := api.GetDataSource200JSONResponse{
+		Body:    api.DataSource{Id: id1, Name: "src", Enabled: true, Timezone: "UTC", Settings: map[string]any{}, Version: 3, CreatedAt: now, UpdatedAt: now},
+		Headers: api.GetDataSource200ResponseHeaders{ETag: `"3"`},
+	}
 	s.NoError(err)
 	s.Require().IsType(api.GetDataSource200JSONResponse{}, resp)
 	s.True(cmp.Equal(expected, resp.(api.GetDataSource200JSONResponse)), cmp.Diff(expected, resp.(api.GetDataSource200JSONResponse)))
@@ -173,21 +250,31 @@ func (s *DataSourceHandlerTestSuite) TestGetDataSource_NotFound() {
 func (s *DataSourceHandlerTestSuite) TestUpdateDataSource_Success() {
 	now := time.Now()
 	id1 := uuid.Must(uuid.NewV7())
-	expectedReq := &usecase.UpdateDataSourceRequest{ID: id1, Name: "updated", Enabled: false, Timezone: "Asia/Tokyo", Settings: map[string]any{}}
+	expectedReq := &usecase.UpdateDataSourceRequest{
+		ID: id1, Name: "updated", Enabled: false, Timezone: "Asia/Tokyo", Settings: map[string]any{}, IfMatch: usecase.IfMatchVersion(2),
+	}
 	s.ucMock.On("Update", mock.Anything, expectedReq).Return(&usecase.DataSourceResponse{
 		ID:        id1,
 		Name:      "updated",
 		Enabled:   false,
 		Timezone:  "Asia/Tokyo",
 		Settings:  map[string]any{},
+		Version:   3,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil)
 
 	body := &api.UpdateDataSourceRequest{Name: "updated", Enabled: false, Timezone: "Asia/Tokyo", Settings: map[string]any{}}
-	resp, err := s.handler.UpdateDataSource(context.Background(), api.UpdateDataSourceRequestObject{Id: id1, Body: body})
-
-	expected := api.UpdateDataSource200JSONResponse{Id: id1, Name: "updated", Enabled: false, Timezone: "Asia/Tokyo", Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now}
+	resp, err := s.handler.UpdateDataSource(context.Background(), api.UpdateDataSourceRequestObject{
+		Id:     id1,
+		Params: api.UpdateDataSourceParams{IfMatch: lo.ToPtr(`"2"`)},
+		Body:   body,
+	})
+
+	expected := api.UpdateDataSource200JSONResponse{
+		Body:    api.DataSource{Id: id1, Name: "updated", Enabled: false, Timezone: "Asia/Tokyo", Settings: map[string]any{}, Version: 3, CreatedAt: now, UpdatedAt: now},
+		Headers: api.UpdateDataSource200ResponseHeaders{ETag: `"3"`},
+	}
 	s.NoError(err)
 	s.Require().IsType(api.UpdateDataSource200JSONResponse{}, resp)
 	s.True(cmp.Equal(expected, resp.(api.UpdateDataSource200JSONResponse)), cmp.Diff(expected, resp.(api.UpdateDataSource200JSONResponse)))
@@ -207,9 +294,172 @@ func (s *DataSourceHandlerTestSuite) TestUpdateDataSource_NotFound() {
 	s.True(cmp.Equal(expected, resp.(api.UpdateDataSource404JSONResponse)), cmp.Diff(expected, resp.(api.UpdateDataSource404JSONResponse)))
 }
 
+func (s *DataSourceHandlerTestSuite) TestUpdateDataSource_VersionErrors() {
+	type testCase struct {
+		name            string
+		ifMatch         *string
+		expectedIfMatch *usecase.IfMatch
+		ucErr           error
+		expected        api.UpdateDataSourceResponseObject
+	}
+	tests := []testCase{
+		{
+			name:            "stale If-Match",
+			ifMatch:         lo.ToPtr(`"1"`),
+			expectedIfMatch: usecase.IfMatchVersion(1),
+			ucErr:           &usecase.PreconditionFailedError{Message: "If-Match does not match current version 2"},
+			expected:        api.UpdateDataSource412JSONResponse{Error: "If-Match does not match current version 2"},
+		},
+		{
+			name:     "malformed If-Match",
+			ifMatch:  lo.ToPtr("1"),
+			expected: api.UpdateDataSource400JSONResponse{Error: "invalid If-Match header: 1"},
+		},
+		{
+			name:     "concurrent update without If-Match",
+			ucErr:    &usecase.ConflictError{Message: "version mismatch"},
+			expected: api.UpdateDataSource409JSONResponse{Error: "version mismatch"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			id := uuid.Must(uuid.NewV7())
+			expectedReq := &usecase.UpdateDataSourceRequest{
+				ID: id, Name: "x", Enabled: true, Timezone: "UTC", Settings: map[string]any{}, IfMatch: tt.expectedIfMatch,
+			}
+			s.ucMock.On("Update", mock.Anything, expectedReq).Return(nil, tt.ucErr)
+
+			body := &api.UpdateDataSourceRequest{Name: "x", Enabled: true, Timezone: "UTC", Settings: map[string]any{}}
+			resp, err := s.handler.UpdateDataSource(context.Background(), api.UpdateDataSourceRequestObject{
+				Id:     id,
+				Params: api.UpdateDataSourceParams{IfMatch: tt.ifMatch},
+				Body:   body,
+			})
+
+			s.NoError(err)
+			s.True(cmp.Equal(tt.expected, resp), cmp.Diff(tt.expected, resp))
+		})
+	}
+}
+
+func (s *DataSourceHandlerTestSuite) TestPatchDataSource_Success() {
+	now := time.Now()
+	id1 := uuid.Must(uuid.NewV7())
+	expectedReq := &usecase.PatchDataSourceRequest{
+		ID:       id1,
+		Enabled:  lo.ToPtr(false),
+		Settings: map[string]any{"token": nil},
+	}
+	s.ucMock.On("Patch", mock.Anything, expectedReq).Return(&usecase.DataSourceResponse{
+		ID:        id1,
+		Name:      "src",
+		Enabled:   false,
+		Timezone:  "UTC",
+		Settings:  map[string]any{},
+		Version:   2,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil)
+
+	body := &api.PatchDataSourceRequest{
+		Enabled:  lo.ToPtr(false),
+		Settings: nullable.NewNullableWithValue(map[string]any{"token": nil}),
+	}
+	resp, err := s.handler.PatchDataSource(context.Background(), api.PatchDataSourceRequestObject{Id: id1, Body: body})
+
+	expected := api.PatchDataSource200JSONResponse{
+		Body:    api.DataSource{Id: id1, Name: "src", Enabled: false, Timezone: "UTC", Settings: map[string]any{}, Version: 2, CreatedAt: now, UpdatedAt: now},
+		Headers: api.PatchDataSource200ResponseHeaders{ETag: `"2"`},
+	}
+	s.NoError(err)
+	s.Require().IsType(api.PatchDataSource200JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.PatchDataSource200JSONResponse)), cmp.Diff(expected, resp.(api.PatchDataSource200JSONResponse)))
+}
+
+func (s *DataSourceHandlerTestSuite) TestPatchDataSource_Errors() {
+	type testCase struct {
+		name     string
+		ucResp   *usecase.DataSourceResponse
+		ucErr    error
+		expected api.PatchDataSourceResponseObject
+	}
+	tests := []testCase{
+		{
+			name:     "not found",
+			expected: api.PatchDataSource404JSONResponse{Error: "data source not found"},
+		},
+		{
+			name:     "invalid merged result",
+			ucErr:    &usecase.ValidationError{Message: "invalid timezone"},
+			expected: api.PatchDataSource422JSONResponse{Error: "invalid timezone"},
+		},
+		{
+			name:     "stale If-Match",
+			ucErr:    &usecase.PreconditionFailedError{Message: "version mismatch"},
+			expected: api.PatchDataSource412JSONResponse{Error: "version mismatch"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			id := uuid.Must(uuid.NewV7())
+			expectedReq := &usecase.PatchDataSourceRequest{ID: id, Timezone: lo.ToPtr("Bad/Zone")}
+			s.ucMock.On("Patch", mock.Anything, expectedReq).Return(tt.ucResp, tt.ucErr)
+
+			body := &api.PatchDataSourceRequest{Timezone: lo.ToPtr("Bad/Zone")}
+			resp, err := s.handler.PatchDataSource(context.Background(), api.PatchDataSourceRequestObject{Id: id, Body: body})
+
+			s.NoError(err)
+			s.True(cmp.Equal(tt.expected, resp), cmp.Diff(tt.expected, resp))
+		})
+	}
+}
+
+func (s *DataSourceHandlerTestSuite) TestDeleteDataSource_PreconditionFailed() {
+	id1 := uuid.Must(uuid.NewV7())
+	s.ucMock.On("Delete", mock.Anything, id1, usecase.IfMatchVersion(4)).Return(false, &usecase.PreconditionFailedError{Message: "version mismatch"})
+
+	resp, err := s.handler.DeleteDataSource(context.Background(), api.DeleteDataSourceRequestObject{
+		Id:     id1,
+		Params: api.DeleteDataSourceParams{IfMatch: lo.ToPtr(`"4"`)},
+	})
+
+	expected := api.DeleteDataSource412JSONResponse{Error: "version mismatch"}
+	s.NoError(err)
+	s.Require().IsType(api.DeleteDataSource412JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.DeleteDataSource412JSONResponse)), cmp.Diff(expected, resp.(api.DeleteDataSource412JSONResponse)))
+}
+
+func (s *DataSourceHandlerTestSuite) TestDeleteDataSource_NotFound() {
+	id1 := uuid.Must(uuid.NewV7())
+	s.ucMock.On("Delete", mock.Anything, id1, &usecase.IfMatch{Any: true}).Return(false, nil)
+
+	resp, err := s.handler.DeleteDataSource(context.Background(), api.DeleteDataSourceRequestObject{
+		Id:     id1,
+		Params: api.DeleteDataSourceParams{IfMatch: lo.ToPtr("*")},
+	})
+
+	expected := api.DeleteDataSource404JSONResponse{Error: "data source not found"}
+	s.NoError(err)
+	s.Require().IsType(api.DeleteDataSource404JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.DeleteDataSource404JSONResponse)), cmp.Diff(expected, resp.(api.DeleteDataSource404JSONResponse)))
+}
+
+func (s *DataSourceHandlerTestSuite) TestDeleteDataSource_InvalidIfMatch() {
+	resp, err := s.handler.DeleteDataSource(context.Background(), api.DeleteDataSourceRequestObject{
+		Id:     uuid.Must(uuid.NewV7()),
+		Params: api.DeleteDataSourceParams{IfMatch: lo.ToPtr("4")},
+	})
+
+	expected := api.DeleteDataSource400JSONResponse{Error: "invalid If-Match header: 4"}
+	s.NoError(err)
+	s.Require().IsType(api.DeleteDataSource400JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.DeleteDataSource400JSONResponse)), cmp.Diff(expected, resp.(api.DeleteDataSource400JSONResponse)))
+	s.ucMock.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (s *DataSourceHandlerTestSuite) TestDeleteDataSource() {
 	id1 := uuid.Must(uuid.NewV7())
-	s.ucMock.On("Delete", mock.Anything, id1).Return(nil)
+	s.ucMock.On("Delete", mock.Anything, id1, (*usecase.IfMatch)(nil)).Return(true, nil)
 
 	resp, err := s.handler.DeleteDataSource(context.Background(), api.DeleteDataSourceRequestObject{Id: id1})
 
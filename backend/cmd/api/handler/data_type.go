@@ -2,13 +2,13 @@ package handler
 
 import (
 	"context"
-	"errors"
 
 	api "stock-tool/api/gen"
 	"stock-tool/internal/domain/ingestion"
 	"stock-tool/internal/usecase"
 
 	"github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
 	"github.com/samber/lo"
 )
 
@@ -16,9 +16,15 @@ import (
 type DataTypeUseCase interface {
 	Create(ctx context.Context, req *usecase.CreateDataTypeRequest) (*usecase.DataTypeResponse, error)
 	Get(ctx context.Context, id uuid.UUID) (*usecase.DataTypeResponse, error)
-	List(ctx context.Context, dataSourceID uuid.UUID) ([]*usecase.DataTypeResponse, error)
+	List(ctx context.Context, req *usecase.ListDataTypesRequest) (*usecase.DataTypeListResponse, error)
 	Update(ctx context.Context, req *usecase.UpdateDataTypeRequest) (*usecase.DataTypeResponse, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	Patch(ctx context.Context, req *usecase.PatchDataTypeRequest) (*usecase.DataTypeResponse, error)
+	Delete(ctx context.Context, id uuid.UUID, ifMatch *usecase.IfMatch) (bool, error)
+	ListDependencies(ctx context.Context, id uuid.UUID) ([]*usecase.DependencyResponse, error)
+	ReplaceDependencies(
+		ctx context.Context,
+		req *usecase.ReplaceDependenciesRequest,
+	) ([]*usecase.DependencyResponse, error)
 }
 
 type DataTypeHandler struct {
@@ -29,16 +35,28 @@ func (h *DataTypeHandler) ListDataTypes(
 	ctx context.Context,
 	request api.ListDataTypesRequestObject,
 ) (api.ListDataTypesResponseObject, error) {
-	if request.Params.DataSourceId == nil {
-		return nil, errors.New("dataSourceId query parameter is required")
-	}
-	list, err := h.uc.List(ctx, *request.Params.DataSourceId)
+	list, err := h.uc.List(ctx, &usecase.ListDataTypesRequest{
+		DataSourceID: request.Params.DataSourceId,
+		Enabled:      request.Params.Enabled,
+		NamePrefix:   lo.FromPtr(request.Params.NamePrefix),
+		Page: usecase.PageRequest{
+			Limit:  lo.FromPtr(request.Params.Limit),
+			Cursor: lo.FromPtr(request.Params.Cursor),
+			Sort:   string(lo.FromPtr(request.Params.Sort)),
+		},
+	})
 	if err != nil {
+		if msg, ok := validationErrorMessage(err); ok {
+			return api.ListDataTypes422JSONResponse{Error: msg}, nil
+		}
 		return nil, err
 	}
-	return api.ListDataTypes200JSONResponse(lo.Map(list, func(dt *usecase.DataTypeResponse, _ int) api.DataType {
-		return toDataTypeResponse(dt)
-	})), nil
+	return api.ListDataTypes200JSONResponse{
+		Items: lo.Map(list.Items, func(dt *usecase.DataTypeResponse, _ int) api.DataType {
+			return toDataTypeResponse(dt)
+		}),
+		NextCursor: list.NextCursor,
+	}, nil
 }
 
 func (h *DataTypeHandler) CreateDataType(
@@ -55,6 +73,10 @@ func (h *DataTypeHandler) CreateDataType(
 		},
 		BackfillEnabled:     request.Body.BackfillEnabled,
 		StaleTimeoutMinutes: request.Body.StaleTimeoutMinutes,
+		RerunStrategy:       string(lo.FromPtr(request.Body.RerunStrategy)),
+		EmptyResponsePolicy: string(lo.FromPtr(request.Body.EmptyResponsePolicy)),
+		Compression:         string(lo.FromPtr(request.Body.Compression)),
+		Retention:           toRetentionInput(lo.FromPtr(request.Body.Retention)),
 		Settings:            request.Body.Settings,
 	})
 	if err != nil {
@@ -76,12 +98,19 @@ func (h *DataTypeHandler) GetDataType(
 	if resp == nil {
 		return api.GetDataType404JSONResponse{Error: "data type not found"}, nil
 	}
-	return api.GetDataType200JSONResponse(toDataTypeResponse(resp)), nil
+	return api.GetDataType200JSONResponse{
+		Body:    toDataTypeResponse(resp),
+		Headers: api.GetDataType200ResponseHeaders{ETag: formatETag(resp.Version)},
+	}, nil
 }
 
 func (h *DataTypeHandler) UpdateDataType(
 	ctx context.Context, request api.UpdateDataTypeRequestObject,
 ) (api.UpdateDataTypeResponseObject, error) {
+	ifMatch, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		return api.UpdateDataType400JSONResponse{Error: err.Error()}, nil
+	}
 	resp, err := h.uc.Update(ctx, &usecase.UpdateDataTypeRequest{
 		ID:      request.Id,
 		Name:    request.Body.Name,
@@ -92,29 +121,141 @@ func (h *DataTypeHandler) UpdateDataType(
 		},
 		BackfillEnabled:     request.Body.BackfillEnabled,
 		StaleTimeoutMinutes: request.Body.StaleTimeoutMinutes,
+		RerunStrategy:       string(lo.FromPtr(request.Body.RerunStrategy)),
+		EmptyResponsePolicy: string(lo.FromPtr(request.Body.EmptyResponsePolicy)),
+		Compression:         string(lo.FromPtr(request.Body.Compression)),
+		Retention:           toRetentionInput(lo.FromPtr(request.Body.Retention)),
 		Settings:            request.Body.Settings,
+		IfMatch:             ifMatch,
 	})
 	if err != nil {
 		if msg, ok := validationErrorMessage(err); ok {
 			return api.UpdateDataType422JSONResponse{Error: msg}, nil
 		}
+		if msg, ok := preconditionFailedErrorMessage(err); ok {
+			return api.UpdateDataType412JSONResponse{Error: msg}, nil
+		}
+		if msg, ok := conflictErrorMessage(err); ok {
+			return api.UpdateDataType409JSONResponse{Error: msg}, nil
+		}
 		return nil, err
 	}
 	if resp == nil {
 		return api.UpdateDataType404JSONResponse{Error: "data type not found"}, nil
 	}
-	return api.UpdateDataType200JSONResponse(toDataTypeResponse(resp)), nil
+	return api.UpdateDataType200JSONResponse{
+		Body:    toDataTypeResponse(resp),
+		Headers: api.UpdateDataType200ResponseHeaders{ETag: formatETag(resp.Version)},
+	}, nil
+}
+
+func (h *DataTypeHandler) PatchDataType(
+	ctx context.Context, request api.PatchDataTypeRequestObject,
+) (api.PatchDataTypeResponseObject, error) {
+	ifMatch, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		return api.PatchDataType400JSONResponse{Error: err.Error()}, nil
+	}
+	req := &usecase.PatchDataTypeRequest{
+		ID:                  request.Id,
+		Name:                request.Body.Name,
+		Enabled:             request.Body.Enabled,
+		BackfillEnabled:     request.Body.BackfillEnabled,
+		StaleTimeoutMinutes: request.Body.StaleTimeoutMinutes,
+		Retention:           toRetentionPatch(request.Body.Retention),
+		IfMatch:             ifMatch,
+	}
+	req.Settings, req.ClearSettings = toSettingsPatch(request.Body.Settings)
+	if strategy := request.Body.RerunStrategy; strategy != nil {
+		req.RerunStrategy = lo.ToPtr(string(*strategy))
+	}
+	if policy := request.Body.EmptyResponsePolicy; policy != nil {
+		req.EmptyResponsePolicy = lo.ToPtr(string(*policy))
+	}
+	if compression := request.Body.Compression; compression != nil {
+		req.Compression = lo.ToPtr(string(*compression))
+	}
+	if sched := request.Body.Schedule; sched != nil {
+		req.Schedule = &usecase.SchedulePatch{Type: sched.Type, Times: lo.FromPtr(sched.Times)}
+	}
+	resp, err := h.uc.Patch(ctx, req)
+	if err != nil {
+		if msg, ok := validationErrorMessage(err); ok {
+			return api.PatchDataType422JSONResponse{Error: msg}, nil
+		}
+		if msg, ok := preconditionFailedErrorMessage(err); ok {
+			return api.PatchDataType412JSONResponse{Error: msg}, nil
+		}
+		if msg, ok := conflictErrorMessage(err); ok {
+			return api.PatchDataType409JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return api.PatchDataType404JSONResponse{Error: "data type not found"}, nil
+	}
+	return api.PatchDataType200JSONResponse{
+		Body:    toDataTypeResponse(resp),
+		Headers: api.PatchDataType200ResponseHeaders{ETag: formatETag(resp.Version)},
+	}, nil
 }
 
 func (h *DataTypeHandler) DeleteDataType(
 	ctx context.Context, request api.DeleteDataTypeRequestObject,
 ) (api.DeleteDataTypeResponseObject, error) {
-	if err := h.uc.Delete(ctx, request.Id); err != nil {
+	ifMatch, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		return api.DeleteDataType400JSONResponse{Error: err.Error()}, nil
+	}
+	deleted, err := h.uc.Delete(ctx, request.Id, ifMatch)
+	if err != nil {
+		if msg, ok := preconditionFailedErrorMessage(err); ok {
+			return api.DeleteDataType412JSONResponse{Error: msg}, nil
+		}
 		return nil, err
 	}
+	if !deleted {
+		return api.DeleteDataType404JSONResponse{Error: "data type not found"}, nil
+	}
 	return api.DeleteDataType204Response{}, nil
 }
 
+func (h *DataTypeHandler) ListDataTypeDependencies(
+	ctx context.Context,
+	request api.ListDataTypeDependenciesRequestObject,
+) (api.ListDataTypeDependenciesResponseObject, error) {
+	deps, err := h.uc.ListDependencies(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	if deps == nil {
+		return api.ListDataTypeDependencies404JSONResponse{Error: "data type not found"}, nil
+	}
+	return api.ListDataTypeDependencies200JSONResponse(toDependencyListAPI(deps)), nil
+}
+
+func (h *DataTypeHandler) ReplaceDataTypeDependencies(
+	ctx context.Context,
+	request api.ReplaceDataTypeDependenciesRequestObject,
+) (api.ReplaceDataTypeDependenciesResponseObject, error) {
+	deps, err := h.uc.ReplaceDependencies(ctx, &usecase.ReplaceDependenciesRequest{
+		DataTypeID: request.Id,
+		Dependencies: lo.Map(request.Body.Items, func(d api.DataTypeDependency, _ int) usecase.DependencyInput {
+			return usecase.DependencyInput{DataTypeID: d.DataTypeId, MaxLagDays: lo.FromPtr(d.MaxLagDays)}
+		}),
+	})
+	if err != nil {
+		if msg, ok := validationErrorMessage(err); ok {
+			return api.ReplaceDataTypeDependencies422JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+	if deps == nil {
+		return api.ReplaceDataTypeDependencies404JSONResponse{Error: "data type not found"}, nil
+	}
+	return api.ReplaceDataTypeDependencies200JSONResponse(toDependencyListAPI(deps)), nil
+}
+
 func toDataTypeResponse(r *usecase.DataTypeResponse) api.DataType {
 	return api.DataType{
 		Id:                  r.ID,
@@ -124,13 +265,75 @@ func toDataTypeResponse(r *usecase.DataTypeResponse) api.DataType {
 		Schedule:            toScheduleAPI(r.Schedule),
 		BackfillEnabled:     r.BackfillEnabled,
 		StaleTimeoutMinutes: r.StaleTimeoutMinutes,
+		RerunStrategy:       api.RerunStrategy(r.RerunStrategy),
+		EmptyResponsePolicy: api.EmptyResponsePolicy(r.EmptyResponsePolicy),
+		Compression:         api.Compression(r.Compression),
+		Retention:           toRetentionAPI(r.Retention),
 		Settings:            r.Settings,
+		Version:             r.Version,
 		CreatedAt:           r.CreatedAt,
 		UpdatedAt:           r.UpdatedAt,
 	}
 }
 
+func toDependencyListAPI(deps []*usecase.DependencyResponse) api.DataTypeDependencyList {
+	return api.DataTypeDependencyList{
+		Items: lo.Map(deps, func(d *usecase.DependencyResponse, _ int) api.DataTypeDependency {
+			return api.DataTypeDependency{DataTypeId: d.DataTypeID, MaxLagDays: lo.ToPtr(d.MaxLagDays)}
+		}),
+	}
+}
+
 func toScheduleAPI(s ingestion.Schedule) api.Schedule {
 	times := lo.Map(s.Times(), func(t ingestion.TimeOfDay, _ int) string { return string(t) })
 	return api.Schedule{Type: api.Daily, Times: times}
 }
+
+func toRetentionAPI(p ingestion.RetentionPolicy) api.RetentionPolicy {
+	return api.RetentionPolicy{KeepLatest: p.KeepLatest(), SupersededMaxAgeDays: p.SupersededMaxAgeDays()}
+}
+
+func toRetentionInput(r api.RetentionPolicy) usecase.RetentionInput {
+	return usecase.RetentionInput{KeepLatest: r.KeepLatest, SupersededMaxAgeDays: r.SupersededMaxAgeDays}
+}
+
+// toRetentionPatch converts the retention member of a merge patch. A null
+// member removes every rule.
+func toRetentionPatch(r nullable.Nullable[api.RetentionPolicyPatch]) *usecase.RetentionPatch {
+	if !r.IsSpecified() {
+		return nil
+	}
+	if r.IsNull() {
+		return &usecase.RetentionPatch{
+			KeepLatest:           usecase.RulePatch{Set: true},
+			SupersededMaxAgeDays: usecase.RulePatch{Set: true},
+		}
+	}
+	p := r.MustGet()
+	return &usecase.RetentionPatch{
+		KeepLatest:           toRulePatch(p.KeepLatest),
+		SupersededMaxAgeDays: toRulePatch(p.SupersededMaxAgeDays),
+	}
+}
+
+func toRulePatch(r nullable.Nullable[int]) usecase.RulePatch {
+	switch {
+	case !r.IsSpecified():
+		return usecase.RulePatch{}
+	case r.IsNull():
+		return usecase.RulePatch{Set: true}
+	}
+	return usecase.RulePatch{Set: true, Value: lo.ToPtr(r.MustGet())}
+}
+
+// toSettingsPatch converts the settings member of a merge patch into the
+// settings to merge and whether it was null.
+func toSettingsPatch(s nullable.Nullable[map[string]any]) (map[string]any, bool) {
+	switch {
+	case !s.IsSpecified():
+		return nil, false
+	case s.IsNull():
+		return nil, true
+	}
+	return s.MustGet(), false
+}
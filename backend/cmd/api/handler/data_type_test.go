@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"github.com/oapi-codegen/nullable"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -44,13 +45,13 @@ func (m *DataTypeUseCaseMock) Get(
 
 func (m *DataTypeUseCaseMock) List(
 	ctx context.Context,
-	dataSourceID uuid.UUID,
-) ([]*usecase.DataTypeResponse, error) {
-	args := m.Called(ctx, dataSourceID)
+	req *usecase.ListDataTypesRequest,
+) (*usecase.DataTypeListResponse, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*usecase.DataTypeResponse), args.Error(1)
+	return args.Get(0).(*usecase.DataTypeListResponse), args.Error(1)
 }
 
 func (m *DataTypeUseCaseMock) Update(
@@ -64,8 +65,39 @@ func (m *DataTypeUseCaseMock) Update(
 	return args.Get(0).(*usecase.DataTypeResponse), args.Error(1)
 }
 
-func (m *DataTypeUseCaseMock) Delete(ctx context.Context, id uuid.UUID) error {
-	return m.Called(ctx, id).Error(0)
+func (m *DataTypeUseCaseMock) Patch(
+	ctx context.Context,
+	req *usecase.PatchDataTypeRequest,
+) (*usecase.DataTypeResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.DataTypeResponse), args.Error(1)
+}
+
+func (m *DataTypeUseCaseMock) Delete(ctx context.Context, id uuid.UUID, ifMatch *usecase.IfMatch) (bool, error) {
+	args := m.Called(ctx, id, ifMatch)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *DataTypeUseCaseMock) ListDependencies(ctx context.Context, id uuid.UUID) ([]*usecase.DependencyResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*usecase.DependencyResponse), args.Error(1)
+}
+
+func (m *DataTypeUseCaseMock) ReplaceDependencies(
+	ctx context.Context,
+	req *usecase.ReplaceDependenciesRequest,
+) ([]*usecase.DependencyResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*usecase.DependencyResponse), args.Error(1)
 }
 
 type DataTypeHandlerTestSuite struct {
@@ -92,61 +124,114 @@ func (s *DataTypeHandlerTestSuite) TestListDataTypes() {
 	now := time.Now()
 	dsID := uuid.Must(uuid.NewV7())
 	dtID := uuid.Must(uuid.NewV7())
-	s.ucMock.On("List", mock.Anything, dsID).Return([]*usecase.DataTypeResponse{
-		{
-			ID: dtID, DataSourceID: dsID, Name: "dt1", Enabled: true,
-			Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})),
-			Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+	expectedReq := &usecase.ListDataTypesRequest{
+		DataSourceID: lo.ToPtr(dsID),
+		Enabled:      lo.ToPtr(false),
+		NamePrefix:   "dt",
+		Page:         usecase.PageRequest{Limit: 10, Cursor: "prev", Sort: "name"},
+	}
+	s.ucMock.On("List", mock.Anything, expectedReq).Return(&usecase.DataTypeListResponse{
+		Items: []*usecase.DataTypeResponse{
+			{
+				ID: dtID, DataSourceID: dsID, Name: "dt1", Enabled: false,
+				Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})),
+				Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+			},
 		},
+		NextCursor: lo.ToPtr("next"),
 	}, nil)
 
 	resp, err := s.handler.ListDataTypes(context.Background(), api.ListDataTypesRequestObject{
-		Params: api.ListDataTypesParams{DataSourceId: lo.ToPtr(dsID)},
+		Params: api.ListDataTypesParams{
+			DataSourceId: lo.ToPtr(dsID),
+			Limit:        lo.ToPtr(10),
+			Cursor:       lo.ToPtr("prev"),
+			Sort:         lo.ToPtr(api.Name),
+			Enabled:      lo.ToPtr(false),
+			NamePrefix:   lo.ToPtr("dt"),
+		},
 	})
 
 	times := []string{"18:00"}
 	expected := api.ListDataTypes200JSONResponse{
-		{
-			Id: dtID, DataSourceId: dsID, Name: "dt1", Enabled: true,
-			Schedule: api.Schedule{Type: api.Daily, Times: times},
-			Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+		Items: []api.DataType{
+			{
+				Id: dtID, DataSourceId: dsID, Name: "dt1", Enabled: false,
+				Schedule: api.Schedule{Type: api.Daily, Times: times},
+				Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+			},
 		},
+		NextCursor: lo.ToPtr("next"),
 	}
 	s.NoError(err)
 	s.Require().IsType(api.ListDataTypes200JSONResponse{}, resp)
 	s.True(cmp.Equal(expected, resp.(api.ListDataTypes200JSONResponse)), cmp.Diff(expected, resp.(api.ListDataTypes200JSONResponse)))
 }
 
+func (s *DataTypeHandlerTestSuite) TestListDataTypes_ValidationError() {
+	expectedReq := &usecase.ListDataTypesRequest{Page: usecase.PageRequest{Sort: "-id", Cursor: "issued-for-name"}}
+	s.ucMock.On("List", mock.Anything, expectedReq).Return(
+		nil, &usecase.ValidationError{Message: `cursor was issued for sort "name", not "-id"`})
+
+	resp, err := s.handler.ListDataTypes(context.Background(), api.ListDataTypesRequestObject{
+		Params: api.ListDataTypesParams{
+			Cursor: lo.ToPtr("issued-for-name"),
+			Sort:   lo.ToPtr(api.MinusId),
+		},
+	})
+
+	expected := api.ListDataTypes422JSONResponse{Error: `cursor was issued for sort "name", not "-id"`}
+	s.NoError(err)
+	s.Require().IsType(api.ListDataTypes422JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.ListDataTypes422JSONResponse)), cmp.Diff(expected, resp.(api.ListDataTypes422JSONResponse)))
+}
+
 func (s *DataTypeHandlerTestSuite) TestCreateDataType_Success() {
 	now := time.Now()
 	dsID := uuid.Must(uuid.NewV7())
 	dtID := uuid.Must(uuid.NewV7())
 	expectedReq := &usecase.CreateDataTypeRequest{
 		DataSourceID: dsID, Name: "dt", Enabled: true,
-		Schedule: usecase.ScheduleInput{Type: "daily", Times: []string{"18:00"}},
-		Settings: map[string]any{},
+		Schedule:            usecase.ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+		RerunStrategy:       "overwrite",
+		EmptyResponsePolicy: "retry_later",
+		Compression:         "zstd",
+		Retention:           usecase.RetentionInput{KeepLatest: lo.ToPtr(3)},
+		Settings:            map[string]any{},
 	}
 	sched := s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"}))
+	retention, err := ingestion.NewRetentionPolicy(lo.ToPtr(3), nil)
+	s.Require().NoError(err)
 	s.ucMock.On("Create", mock.Anything, expectedReq).Return(
 		&usecase.DataTypeResponse{
 			ID: dtID, DataSourceID: dsID, Name: "dt", Enabled: true,
-			Schedule: sched,
-			Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+			Schedule: sched, RerunStrategy: ingestion.RerunStrategyOverwrite,
+			EmptyResponsePolicy: ingestion.EmptyResponsePolicyRetryLater,
+			Compression:         ingestion.CompressionZstd,
+			Retention:           retention,
+			Settings:            map[string]any{}, Version: 1, CreatedAt: now, UpdatedAt: now,
 		}, nil)
 
 	times := []string{"18:00"}
 	body := &api.CreateDataTypeRequest{
 		DataSourceId: dsID, Name: "dt", Enabled: true,
-		Schedule: api.Schedule{Type: api.Daily, Times: times},
-		Settings: map[string]any{},
+		Schedule:            api.Schedule{Type: api.Daily, Times: times},
+		RerunStrategy:       lo.ToPtr(api.Overwrite),
+		EmptyResponsePolicy: lo.ToPtr(api.RetryLater),
+		Compression:         lo.ToPtr(api.CompressionZstd),
+		Retention:           &api.RetentionPolicy{KeepLatest: lo.ToPtr(3)},
+		Settings:            map[string]any{},
 	}
 	resp, err := s.handler.CreateDataType(context.Background(), api.CreateDataTypeRequestObject{Body: body})
 
 	expectedTimes := []string{"18:00"}
 	expected := api.CreateDataType201JSONResponse{
 		Id: dtID, DataSourceId: dsID, Name: "dt", Enabled: true,
-		Schedule: api.Schedule{Type: api.Daily, Times: expectedTimes},
-		Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+		Schedule: api.Schedule{Type: api.Daily, Times: expectedTimes}, RerunStrategy: api.Overwrite,
+		EmptyResponsePolicy: api.RetryLater,
+		Compression:         api.CompressionZstd,
+		Retention:           api.RetentionPolicy{KeepLatest: lo.ToPtr(3)},
+		Settings:            map[string]any{}, Version: 1, CreatedAt: now, UpdatedAt: now,
 	}
 	s.NoError(err)
 	s.Require().IsType(api.CreateDataType201JSONResponse{}, resp)
@@ -184,16 +269,19 @@ func (s *DataTypeHandlerTestSuite) TestGetDataType_Found() {
 		&usecase.DataTypeResponse{
 			ID: dtID, DataSourceID: dsID, Name: "dt", Enabled: true,
 			Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})),
-			Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+			Settings: map[string]any{}, Version: 2, CreatedAt: now, UpdatedAt: now,
 		}, nil)
 
 	resp, err := s.handler.GetDataType(context.Background(), api.GetDataTypeRequestObject{Id: dtID})
 
 	times := []string{"18:00"}
 	expected := api.GetDataType200JSONResponse{
-		Id: dtID, DataSourceId: dsID, Name: "dt", Enabled: true,
-		Schedule: api.Schedule{Type: api.Daily, Times: times},
-		Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+		Body: api.DataType{
+			Id: dtID, DataSourceId: dsID, Name: "dt", Enabled: true,
+			Schedule: api.Schedule{Type: api.Daily, Times: times},
+			Settings: map[string]any{}, Version: 2, CreatedAt: now, UpdatedAt: now,
+		},
+		Headers: api.GetDataType200ResponseHeaders{ETag: `"2"`},
 	}
 	s.NoError(err)
 	s.Require().IsType(api.GetDataType200JSONResponse{}, resp)
@@ -220,13 +308,14 @@ func (s *DataTypeHandlerTestSuite) TestUpdateDataType_Success() {
 		ID: dtID, Name: "updated", Enabled: false,
 		Schedule: usecase.ScheduleInput{Type: "daily", Times: []string{"09:00", "15:00"}},
 		Settings: map[string]any{},
+		IfMatch:  usecase.IfMatchVersion(1),
 	}
 	sched := s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"09:00", "15:00"}))
 	s.ucMock.On("Update", mock.Anything, expectedReq).Return(
 		&usecase.DataTypeResponse{
 			ID: dtID, DataSourceID: dsID, Name: "updated", Enabled: false,
 			Schedule: sched,
-			Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+			Settings: map[string]any{}, Version: 2, CreatedAt: now, UpdatedAt: now,
 		}, nil)
 
 	times := []string{"09:00", "15:00"}
@@ -235,13 +324,20 @@ func (s *DataTypeHandlerTestSuite) TestUpdateDataType_Success() {
 		Schedule: api.Schedule{Type: api.Daily, Times: times},
 		Settings: map[string]any{},
 	}
-	resp, err := s.handler.UpdateDataType(context.Background(), api.UpdateDataTypeRequestObject{Id: dtID, Body: body})
+	resp, err := s.handler.UpdateDataType(context.Background(), api.UpdateDataTypeRequestObject{
+		Id:     dtID,
+		Params: api.UpdateDataTypeParams{IfMatch: lo.ToPtr(`"1"`)},
+		Body:   body,
+	})
 
 	expectedTimes := []string{"09:00", "15:00"}
 	expected := api.UpdateDataType200JSONResponse{
-		Id: dtID, DataSourceId: dsID, Name: "updated", Enabled: false,
-		Schedule: api.Schedule{Type: api.Daily, Times: expectedTimes},
-		Settings: map[string]any{}, CreatedAt: now, UpdatedAt: now,
+		Body: api.DataType{
+			Id: dtID, DataSourceId: dsID, Name: "updated", Enabled: false,
+			Schedule: api.Schedule{Type: api.Daily, Times: expectedTimes},
+			Settings: map[string]any{}, Version: 2, CreatedAt: now, UpdatedAt: now,
+		},
+		Headers: api.UpdateDataType200ResponseHeaders{ETag: `"2"`},
 	}
 	s.NoError(err)
 	s.Require().IsType(api.UpdateDataType200JSONResponse{}, resp)
@@ -271,9 +367,200 @@ func (s *DataTypeHandlerTestSuite) TestUpdateDataType_NotFound() {
 	s.True(cmp.Equal(expected, resp.(api.UpdateDataType404JSONResponse)), cmp.Diff(expected, resp.(api.UpdateDataType404JSONResponse)))
 }
 
+func (s *DataTypeHandlerTestSuite) TestUpdateDataType_PreconditionFailed() {
+	dtID := uuid.Must(uuid.NewV7())
+	expectedReq := &usecase.UpdateDataTypeRequest{
+		ID: dtID, Name: "x", Enabled: true,
+		Schedule: usecase.ScheduleInput{Type: "daily", Times: []string{"09:00"}},
+		Settings: map[string]any{},
+		IfMatch:  usecase.IfMatchVersion(1),
+	}
+	s.ucMock.On("Update", mock.Anything, expectedReq).Return(nil, &usecase.PreconditionFailedError{Message: "version mismatch"})
+
+	times := []string{"09:00"}
+	body := &api.UpdateDataTypeRequest{
+		Name: "x", Enabled: true,
+		Schedule: api.Schedule{Type: api.Daily, Times: times},
+		Settings: map[string]any{},
+	}
+	resp, err := s.handler.UpdateDataType(context.Background(), api.UpdateDataTypeRequestObject{
+		Id:     dtID,
+		Params: api.UpdateDataTypeParams{IfMatch: lo.ToPtr(`"1"`)},
+		Body:   body,
+	})
+
+	expected := api.UpdateDataType412JSONResponse{Error: "version mismatch"}
+	s.NoError(err)
+	s.Require().IsType(api.UpdateDataType412JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.UpdateDataType412JSONResponse)), cmp.Diff(expected, resp.(api.UpdateDataType412JSONResponse)))
+}
+
+func (s *DataTypeHandlerTestSuite) TestPatchDataType_Success() {
+	now := time.Now()
+	dtID := uuid.Must(uuid.NewV7())
+	dsID := uuid.Must(uuid.NewV7())
+	expectedReq := &usecase.PatchDataTypeRequest{
+		ID:       dtID,
+		Schedule: &usecase.SchedulePatch{Times: []string{"07:00"}},
+		Settings: map[string]any{"endpoint": "/v2"},
+		IfMatch:  usecase.IfMatchVersion(4),
+	}
+	s.ucMock.On("Patch", mock.Anything, expectedReq).Return(
+		&usecase.DataTypeResponse{
+			ID: dtID, DataSourceID: dsID, Name: "dt", Enabled: true,
+			Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"07:00"})),
+			Settings: map[string]any{"endpoint": "/v2"}, Version: 5, CreatedAt: now, UpdatedAt: now,
+		}, nil)
+
+	times := []string{"07:00"}
+	body := &api.PatchDataTypeRequest{
+		Schedule: &api.SchedulePatch{Times: &times},
+		Settings: nullable.NewNullableWithValue(map[string]any{"endpoint": "/v2"}),
+	}
+	resp, err := s.handler.PatchDataType(context.Background(), api.PatchDataTypeRequestObject{
+		Id:     dtID,
+		Params: api.PatchDataTypeParams{IfMatch: lo.ToPtr(`"4"`)},
+		Body:   body,
+	})
+
+	expectedTimes := []string{"07:00"}
+	expected := api.PatchDataType200JSONResponse{
+		Body: api.DataType{
+			Id: dtID, DataSourceId: dsID, Name: "dt", Enabled: true,
+			Schedule: api.Schedule{Type: api.Daily, Times: expectedTimes},
+			Settings: map[string]any{"endpoint": "/v2"}, Version: 5, CreatedAt: now, UpdatedAt: now,
+		},
+		Headers: api.PatchDataType200ResponseHeaders{ETag: `"5"`},
+	}
+	s.NoError(err)
+	s.Require().IsType(api.PatchDataType200JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.PatchDataType200JSONResponse)), cmp.Diff(expected, resp.(api.PatchDataType200JSONResponse)))
+}
+
+func (s *DataTypeHandlerTestSuite) TestPatchDataType_ValidationError() {
+	dtID := uuid.Must(uuid.NewV7())
+	expectedReq := &usecase.PatchDataTypeRequest{ID: dtID, Schedule: &usecase.SchedulePatch{Type: lo.ToPtr("weekly")}}
+	s.ucMock.On("Patch", mock.Anything, expectedReq).Return(nil, &usecase.ValidationError{Message: "invalid schedule type: weekly"})
+
+	body := &api.PatchDataTypeRequest{Schedule: &api.SchedulePatch{Type: lo.ToPtr("weekly")}}
+	resp, err := s.handler.PatchDataType(context.Background(), api.PatchDataTypeRequestObject{Id: dtID, Body: body})
+
+	expected := api.PatchDataType422JSONResponse{Error: "invalid schedule type: weekly"}
+	s.NoError(err)
+	s.Require().IsType(api.PatchDataType422JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.PatchDataType422JSONResponse)), cmp.Diff(expected, resp.(api.PatchDataType422JSONResponse)))
+}
+
+func (s *DataTypeHandlerTestSuite) TestPatchDataType_NotFound() {
+	dtID := uuid.Must(uuid.NewV7())
+	s.ucMock.On("Patch", mock.Anything, &usecase.PatchDataTypeRequest{ID: dtID, Enabled: lo.ToPtr(false)}).Return(nil, nil)
+
+	body := &api.PatchDataTypeRequest{Enabled: lo.ToPtr(false)}
+	resp, err := s.handler.PatchDataType(context.Background(), api.PatchDataTypeRequestObject{Id: dtID, Body: body})
+
+	expected := api.PatchDataType404JSONResponse{Error: "data type not found"}
+	s.NoError(err)
+	s.Require().IsType(api.PatchDataType404JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.PatchDataType404JSONResponse)), cmp.Diff(expected, resp.(api.PatchDataType404JSONResponse)))
+}
+
+func (s *DataTypeHandlerTestSuite) TestPatchDataType_NullMembers() {
+	tests := []struct {
+		name     string
+		body     *api.PatchDataTypeRequest
+		expected *usecase.PatchDataTypeRequest
+	}{
+		{
+			name: "null retention and settings",
+			body: &api.PatchDataTypeRequest{
+				Retention: nullable.NewNullNullable[api.RetentionPolicyPatch](),
+				Settings:  nullable.NewNullNullable[map[string]any](),
+			},
+			expected: &usecase.PatchDataTypeRequest{
+				Retention: &usecase.RetentionPatch{
+					KeepLatest:           usecase.RulePatch{Set: true},
+					SupersededMaxAgeDays: usecase.RulePatch{Set: true},
+				},
+				ClearSettings: true,
+			},
+		},
+		{
+			name: "null rule",
+			body: &api.PatchDataTypeRequest{
+				Retention: nullable.NewNullableWithValue(api.RetentionPolicyPatch{
+					KeepLatest:           nullable.NewNullNullable[int](),
+					SupersededMaxAgeDays: nullable.NewNullableWithValue(7),
+				}),
+			},
+			expected: &usecase.PatchDataTypeRequest{
+				Retention: &usecase.RetentionPatch{
+					KeepLatest:           usecase.RulePatch{Set: true},
+					SupersededMaxAgeDays: usecase.RulePatch{Set: true, Value: lo.ToPtr(7)},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			dtID := uuid.Must(uuid.NewV7())
+			tt.expected.ID = dtID
+			s.ucMock.On("Patch", mock.Anything, tt.expected).Return(nil, nil).Once()
+
+			resp, err := s.handler.PatchDataType(context.Background(), api.PatchDataTypeRequestObject{Id: dtID, Body: tt.body})
+
+			s.NoError(err)
+			s.IsType(api.PatchDataType404JSONResponse{}, resp)
+			s.ucMock.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *DataTypeHandlerTestSuite) TestDeleteDataType_PreconditionFailed() {
+	dtID := uuid.Must(uuid.NewV7())
+	s.ucMock.On("Delete", mock.Anything, dtID, usecase.IfMatchVersion(3)).Return(false, &usecase.PreconditionFailedError{Message: "version mismatch"})
+
+	resp, err := s.handler.DeleteDataType(context.Background(), api.DeleteDataTypeRequestObject{
+		Id:     dtID,
+		Params: api.DeleteDataTypeParams{IfMatch: lo.ToPtr(`"3"`)},
+	})
+
+	expected := api.DeleteDataType412JSONResponse{Error: "version mismatch"}
+	s.NoError(err)
+	s.Require().IsType(api.DeleteDataType412JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.DeleteDataType412JSONResponse)), cmp.Diff(expected, resp.(api.DeleteDataType412JSONResponse)))
+}
+
+func (s *DataTypeHandlerTestSuite) TestDeleteDataType_NotFound() {
+	dtID := uuid.Must(uuid.NewV7())
+	s.ucMock.On("Delete", mock.Anything, dtID, &usecase.IfMatch{Any: true}).Return(false, nil)
+
+	resp, err := s.handler.DeleteDataType(context.Background(), api.DeleteDataTypeRequestObject{
+		Id:     dtID,
+		Params: api.DeleteDataTypeParams{IfMatch: lo.ToPtr("*")},
+	})
+
+	expected := api.DeleteDataType404JSONResponse{Error: "data type not found"}
+	s.NoError(err)
+	s.Require().IsType(api.DeleteDataType404JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.DeleteDataType404JSONResponse)), cmp.Diff(expected, resp.(api.DeleteDataType404JSONResponse)))
+}
+
+func (s *DataTypeHandlerTestSuite) TestDeleteDataType_InvalidIfMatch() {
+	resp, err := s.handler.DeleteDataType(context.Background(), api.DeleteDataTypeRequestObject{
+		Id:     uuid.Must(uuid.NewV7()),
+		Params: api.DeleteDataTypeParams{IfMatch: lo.ToPtr("3")},
+	})
+
+	expected := api.DeleteDataType400JSONResponse{Error: "invalid If-Match header: 3"}
+	s.NoError(err)
+	s.Require().IsType(api.DeleteDataType400JSONResponse{}, resp)
+	s.True(cmp.Equal(expected, resp.(api.DeleteDataType400JSONResponse)), cmp.Diff(expected, resp.(api.DeleteDataType400JSONResponse)))
+	s.ucMock.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (s *DataTypeHandlerTestSuite) TestDeleteDataType() {
 	dtID := uuid.Must(uuid.NewV7())
-	s.ucMock.On("Delete", mock.Anything, dtID).Return(nil)
+	s.ucMock.On("Delete", mock.Anything, dtID, (*usecase.IfMatch)(nil)).Return(true, nil)
 
 	resp, err := s.handler.DeleteDataType(context.Background(), api.DeleteDataTypeRequestObject{Id: dtID})
 
@@ -282,3 +569,95 @@ func (s *DataTypeHandlerTestSuite) TestDeleteDataType() {
 	s.Require().IsType(api.DeleteDataType204Response{}, resp)
 	s.True(cmp.Equal(expected, resp.(api.DeleteDataType204Response)), cmp.Diff(expected, resp.(api.DeleteDataType204Response)))
 }
+
+func (s *DataTypeHandlerTestSuite) TestListDataTypeDependencies() {
+	dtID := uuid.Must(uuid.NewV7())
+	depID := uuid.Must(uuid.NewV7())
+	s.ucMock.On("ListDependencies", mock.Anything, dtID).Return(
+		[]*usecase.DependencyResponse{{DataTypeID: depID, MaxLagDays: 3}}, nil)
+
+	resp, err := s.handler.ListDataTypeDependencies(
+		context.Background(),
+		api.ListDataTypeDependenciesRequestObject{Id: dtID},
+	)
+
+	expected := api.ListDataTypeDependencies200JSONResponse{
+		Items: []api.DataTypeDependency{{DataTypeId: depID, MaxLagDays: lo.ToPtr(3)}},
+	}
+	s.NoError(err)
+	s.Require().IsType(api.ListDataTypeDependencies200JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.ListDataTypeDependencies200JSONResponse)),
+		cmp.Diff(expected, resp.(api.ListDataTypeDependencies200JSONResponse)),
+	)
+}
+
+func (s *DataTypeHandlerTestSuite) TestListDataTypeDependencies_NotFound() {
+	dtID := uuid.Must(uuid.NewV7())
+	s.ucMock.On("ListDependencies", mock.Anything, dtID).Return(nil, nil)
+
+	resp, err := s.handler.ListDataTypeDependencies(
+		context.Background(),
+		api.ListDataTypeDependenciesRequestObject{Id: dtID},
+	)
+
+	expected := api.ListDataTypeDependencies404JSONResponse{Error: "data type not found"}
+	s.NoError(err)
+	s.Require().IsType(api.ListDataTypeDependencies404JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.ListDataTypeDependencies404JSONResponse)),
+		cmp.Diff(expected, resp.(api.ListDataTypeDependencies404JSONResponse)),
+	)
+}
+
+func (s *DataTypeHandlerTestSuite) TestReplaceDataTypeDependencies() {
+	dtID := uuid.Must(uuid.NewV7())
+	depID := uuid.Must(uuid.NewV7())
+	expectedReq := &usecase.ReplaceDependenciesRequest{
+		DataTypeID:   dtID,
+		Dependencies: []usecase.DependencyInput{{DataTypeID: depID, MaxLagDays: 0}},
+	}
+	s.ucMock.On("ReplaceDependencies", mock.Anything, expectedReq).Return(
+		[]*usecase.DependencyResponse{{DataTypeID: depID, MaxLagDays: 0}}, nil)
+
+	body := &api.DataTypeDependencyList{Items: []api.DataTypeDependency{{DataTypeId: depID}}}
+	resp, err := s.handler.ReplaceDataTypeDependencies(
+		context.Background(),
+		api.ReplaceDataTypeDependenciesRequestObject{Id: dtID, Body: body},
+	)
+
+	expected := api.ReplaceDataTypeDependencies200JSONResponse{
+		Items: []api.DataTypeDependency{{DataTypeId: depID, MaxLagDays: lo.ToPtr(0)}},
+	}
+	s.NoError(err)
+	s.Require().IsType(api.ReplaceDataTypeDependencies200JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.ReplaceDataTypeDependencies200JSONResponse)),
+		cmp.Diff(expected, resp.(api.ReplaceDataTypeDependencies200JSONResponse)),
+	)
+}
+
+func (s *DataTypeHandlerTestSuite) TestReplaceDataTypeDependencies_Cycle() {
+	dtID := uuid.Must(uuid.NewV7())
+	depID := uuid.Must(uuid.NewV7())
+	expectedReq := &usecase.ReplaceDependenciesRequest{
+		DataTypeID:   dtID,
+		Dependencies: []usecase.DependencyInput{{DataTypeID: depID, MaxLagDays: 1}},
+	}
+	s.ucMock.On("ReplaceDependencies", mock.Anything, expectedReq).Return(
+		nil, &usecase.ValidationError{Message: "dependency cycle"})
+
+	body := &api.DataTypeDependencyList{Items: []api.DataTypeDependency{{DataTypeId: depID, MaxLagDays: lo.ToPtr(1)}}}
+	resp, err := s.handler.ReplaceDataTypeDependencies(
+		context.Background(),
+		api.ReplaceDataTypeDependenciesRequestObject{Id: dtID, Body: body},
+	)
+
+	expected := api.ReplaceDataTypeDependencies422JSONResponse{Error: "dependency cycle"}
+	s.NoError(err)
+	s.Require().IsType(api.ReplaceDataTypeDependencies422JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.ReplaceDataTypeDependencies422JSONResponse)),
+		cmp.Diff(expected, resp.(api.ReplaceDataTypeDependencies422JSONResponse)),
+	)
+}
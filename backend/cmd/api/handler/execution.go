@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	api "stock-tool/api/gen"
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/usecase"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+)
+
+// ExecutionUseCase defines the operations the handler delegates to the usecase layer.
+type ExecutionUseCase interface {
+	Trigger(ctx context.Context, req *usecase.TriggerExecutionRequest) (*usecase.TriggerExecutionResponse, error)
+	List(ctx context.Context, req *usecase.ListExecutionsRequest) (*usecase.ExecutionListResponse, error)
+}
+
+type ExecutionHandler struct {
+	uc ExecutionUseCase
+}
+
+func (h *ExecutionHandler) TriggerDataTypeExecution(
+	ctx context.Context,
+	request api.TriggerDataTypeExecutionRequestObject,
+) (api.TriggerDataTypeExecutionResponseObject, error) {
+	req := &usecase.TriggerExecutionRequest{DataTypeID: request.Id}
+	if request.Body != nil {
+		if request.Body.StartDate != nil {
+			req.StartDate = &request.Body.StartDate.Time
+		}
+		if request.Body.EndDate != nil {
+			req.EndDate = &request.Body.EndDate.Time
+		}
+	}
+
+	resp, err := h.uc.Trigger(ctx, req)
+	if err != nil {
+		if msg, ok := validationErrorMessage(err); ok {
+			return api.TriggerDataTypeExecution422JSONResponse{Error: msg}, nil
+		}
+		if msg, ok := conflictErrorMessage(err); ok {
+			return api.TriggerDataTypeExecution409JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return api.TriggerDataTypeExecution404JSONResponse{Error: "data type not found"}, nil
+	}
+	return api.TriggerDataTypeExecution202JSONResponse{
+		ExecutionIds:       resp.ExecutionIDs,
+		SkippedTargetDates: toAPIDates(resp.SkippedTargetDates),
+		BlockedTargetDates: toAPIDates(resp.BlockedTargetDates),
+	}, nil
+}
+
+func toAPIDates(dates []time.Time) []openapi_types.Date {
+	return lo.Map(dates, func(d time.Time, _ int) openapi_types.Date {
+		return openapi_types.Date{Time: d}
+	})
+}
+
+func (h *ExecutionHandler) ListDataTypeExecutions(
+	ctx context.Context,
+	request api.ListDataTypeExecutionsRequestObject,
+) (api.ListDataTypeExecutionsResponseObject, error) {
+	resp, err := h.uc.List(ctx, &usecase.ListExecutionsRequest{
+		DataTypeID: request.Id,
+		Limit:      lo.FromPtr(request.Params.Limit),
+		Cursor:     lo.FromPtr(request.Params.Cursor),
+	})
+	if err != nil {
+		if msg, ok := validationErrorMessage(err); ok {
+			return api.ListDataTypeExecutions422JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return api.ListDataTypeExecutions404JSONResponse{Error: "data type not found"}, nil
+	}
+	return api.ListDataTypeExecutions200JSONResponse{
+		Items: lo.Map(resp.Items, func(e *usecase.ExecutionResponse, _ int) api.Execution {
+			return toAPIExecution(e)
+		}),
+		NextCursor: resp.NextCursor,
+	}, nil
+}
+
+func toAPIExecution(e *usecase.ExecutionResponse) api.Execution {
+	return api.Execution{
+		Id:            e.ID,
+		TargetDate:    e.TargetDate,
+		Status:        api.ExecutionStatus(e.Status),
+		Error:         e.ErrorInfo,
+		ErrorCategory: (*api.ExecutionErrorCategory)(e.ErrorCategory),
+		StartedAt:     e.StartedAt,
+		FinishedAt:    e.FinishedAt,
+		Files: lo.Map(e.Files, func(f *usecase.ExecutionFileResponse, _ int) api.ExecutionFile {
+			return toAPIExecutionFile(f)
+		}),
+	}
+}
+
+func toAPIExecutionFile(f *usecase.ExecutionFileResponse) api.ExecutionFile {
+	file := api.ExecutionFile{
+		Key:         f.Key,
+		ManifestKey: lo.EmptyableToPtr(f.ManifestKey),
+		CreatedAt:   f.CreatedAt,
+		PurgedAt:    f.PurgedAt,
+	}
+	if md := f.Metadata; md != nil {
+		file.SizeBytes = &md.SizeBytes
+		file.Sha256 = &md.SHA256
+		file.Format = lo.ToPtr(string(md.Format))
+		file.ContentType = &md.ContentType
+		file.HttpStatus = lo.EmptyableToPtr(md.HTTPStatus)
+		if md.Encoding != extract.EncodingNone {
+			file.ContentEncoding = lo.ToPtr(api.ExecutionFileContentEncoding(md.Encoding))
+			file.CompressedSizeBytes = &md.CompressedSizeBytes
+			file.CompressedSha256 = &md.CompressedSHA256
+		}
+	}
+	return file
+}
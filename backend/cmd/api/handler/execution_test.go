@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "stock-tool/api/gen"
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/usecase"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExecutionUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ExecutionUseCaseMock) Trigger(
+	ctx context.Context,
+	req *usecase.TriggerExecutionRequest,
+) (*usecase.TriggerExecutionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.TriggerExecutionResponse), args.Error(1)
+}
+
+func (m *ExecutionUseCaseMock) List(
+	ctx context.Context,
+	req *usecase.ListExecutionsRequest,
+) (*usecase.ExecutionListResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ExecutionListResponse), args.Error(1)
+}
+
+type ExecutionHandlerTestSuite struct {
+	suite.Suite
+	ucMock  *ExecutionUseCaseMock
+	handler *ExecutionHandler
+}
+
+func TestExecutionHandler(t *testing.T) {
+	suite.Run(t, new(ExecutionHandlerTestSuite))
+}
+
+func (s *ExecutionHandlerTestSuite) SetupTest() {
+	s.ucMock = new(ExecutionUseCaseMock)
+	s.handler = &ExecutionHandler{uc: s.ucMock}
+}
+
+func (s *ExecutionHandlerTestSuite) TestTriggerDataTypeExecution_Accepted() {
+	dtID := uuid.Must(uuid.NewV7())
+	start := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	skipped := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	blocked := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	expectedReq := &usecase.TriggerExecutionRequest{DataTypeID: dtID, StartDate: &start, EndDate: &end}
+	s.ucMock.On("Trigger", mock.Anything, expectedReq).Return(
+		&usecase.TriggerExecutionResponse{
+			ExecutionIDs:       []int{1},
+			SkippedTargetDates: []time.Time{skipped},
+			BlockedTargetDates: []time.Time{blocked},
+		}, nil)
+
+	body := &api.TriggerExecutionRequest{
+		StartDate: &openapi_types.Date{Time: start},
+		EndDate:   &openapi_types.Date{Time: end},
+	}
+	resp, err := s.handler.TriggerDataTypeExecution(
+		context.Background(),
+		api.TriggerDataTypeExecutionRequestObject{Id: dtID, Body: body},
+	)
+
+	expected := api.TriggerDataTypeExecution202JSONResponse{
+		ExecutionIds:       []int{1},
+		SkippedTargetDates: []openapi_types.Date{{Time: skipped}},
+		BlockedTargetDates: []openapi_types.Date{{Time: blocked}},
+	}
+	s.NoError(err)
+	s.Require().IsType(api.TriggerDataTypeExecution202JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.TriggerDataTypeExecution202JSONResponse)),
+		cmp.Diff(expected, resp.(api.TriggerDataTypeExecution202JSONResponse)),
+	)
+}
+
+func (s *ExecutionHandlerTestSuite) TestTriggerDataTypeExecution_NoBody() {
+	dtID := uuid.Must(uuid.NewV7())
+	expectedReq := &usecase.TriggerExecutionRequest{DataTypeID: dtID}
+	s.ucMock.On("Trigger", mock.Anything, expectedReq).Return(
+		&usecase.TriggerExecutionResponse{
+			ExecutionIDs:       []int{1},
+			SkippedTargetDates: []time.Time{},
+			BlockedTargetDates: []time.Time{},
+		}, nil)
+
+	resp, err := s.handler.TriggerDataTypeExecution(
+		context.Background(),
+		api.TriggerDataTypeExecutionRequestObject{Id: dtID},
+	)
+
+	expected := api.TriggerDataTypeExecution202JSONResponse{
+		ExecutionIds:       []int{1},
+		SkippedTargetDates: []openapi_types.Date{},
+		BlockedTargetDates: []openapi_types.Date{},
+	}
+	s.NoError(err)
+	s.Require().IsType(api.TriggerDataTypeExecution202JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.TriggerDataTypeExecution202JSONResponse)),
+		cmp.Diff(expected, resp.(api.TriggerDataTypeExecution202JSONResponse)),
+	)
+}
+
+func (s *ExecutionHandlerTestSuite) TestTriggerDataTypeExecution_NotFound() {
+	notFoundID := uuid.Must(uuid.NewV7())
+	s.ucMock.On("Trigger", mock.Anything, &usecase.TriggerExecutionRequest{DataTypeID: notFoundID}).Return(nil, nil)
+
+	resp, err := s.handler.TriggerDataTypeExecution(
+		context.Background(),
+		api.TriggerDataTypeExecutionRequestObject{Id: notFoundID, Body: &api.TriggerExecutionRequest{}},
+	)
+
+	expected := api.TriggerDataTypeExecution404JSONResponse{Error: "data type not found"}
+	s.NoError(err)
+	s.Require().IsType(api.TriggerDataTypeExecution404JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.TriggerDataTypeExecution404JSONResponse)),
+		cmp.Diff(expected, resp.(api.TriggerDataTypeExecution404JSONResponse)),
+	)
+}
+
+func (s *ExecutionHandlerTestSuite) TestTriggerDataTypeExecution_Conflict() {
+	dtID := uuid.Must(uuid.NewV7())
+	s.ucMock.On("Trigger", mock.Anything, &usecase.TriggerExecutionRequest{DataTypeID: dtID}).Return(
+		nil, &usecase.ConflictError{Message: "execution already running for target dates: 2026-10-16"})
+
+	resp, err := s.handler.TriggerDataTypeExecution(
+		context.Background(),
+		api.TriggerDataTypeExecutionRequestObject{Id: dtID},
+	)
+
+	expected := api.TriggerDataTypeExecution409JSONResponse{
+		Error: "execution already running for target dates: 2026-10-16",
+	}
+	s.NoError(err)
+	s.Require().IsType(api.TriggerDataTypeExecution409JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.TriggerDataTypeExecution409JSONResponse)),
+		cmp.Diff(expected, resp.(api.TriggerDataTypeExecution409JSONResponse)),
+	)
+}
+
+func (s *ExecutionHandlerTestSuite) TestTriggerDataTypeExecution_ValidationError() {
+	dtID := uuid.Must(uuid.NewV7())
+	end := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	expectedReq := &usecase.TriggerExecutionRequest{DataTypeID: dtID, EndDate: &end}
+	s.ucMock.On("Trigger", mock.Anything, expectedReq).Return(
+		nil, &usecase.ValidationError{Message: "endDate requires startDate"})
+
+	resp, err := s.handler.TriggerDataTypeExecution(
+		context.Background(),
+		api.TriggerDataTypeExecutionRequestObject{
+			Id:   dtID,
+			Body: &api.TriggerExecutionRequest{EndDate: &openapi_types.Date{Time: end}},
+		},
+	)
+
+	expected := api.TriggerDataTypeExecution422JSONResponse{Error: "endDate requires startDate"}
+	s.NoError(err)
+	s.Require().IsType(api.TriggerDataTypeExecution422JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.TriggerDataTypeExecution422JSONResponse)),
+		cmp.Diff(expected, resp.(api.TriggerDataTypeExecution422JSONResponse)),
+	)
+}
+
+func (s *ExecutionHandlerTestSuite) TestListDataTypeExecutions() {
+	dtID := uuid.Must(uuid.NewV7())
+	target := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	purged := time.Date(2026, 11, 16, 3, 0, 0, 0, time.UTC)
+	errInfo := "failed to upload to S3: connection refused"
+	emptyInfo := "response holds no records"
+	md := extract.NewFileMetadata([]byte(`{"info":[]}`), extract.FormatJSON, 200)
+	gz := md.Compressed(extract.EncodingGzip, extract.FileMetadata{SizeBytes: 35, SHA256: "abc"})
+	s.ucMock.On("List", mock.Anything, &usecase.ListExecutionsRequest{DataTypeID: dtID, Limit: 3, Cursor: "c10"}).
+		Return(&usecase.ExecutionListResponse{
+			Items: []*usecase.ExecutionResponse{
+				{
+					ID:         9,
+					TargetDate: target,
+					Status:     "succeeded",
+					Files: []*usecase.ExecutionFileResponse{
+						{Key: "landing/new.json", Metadata: &md, ManifestKey: "landing/_manifests/9.json", CreatedAt: created},
+						{Key: "landing/new.json.gz", Metadata: &gz, CreatedAt: created},
+						{Key: "landing/legacy.json", PurgedAt: &purged, CreatedAt: created},
+					},
+				},
+				{ID: 8, TargetDate: target, Status: "failed", ErrorInfo: &errInfo, Files: []*usecase.ExecutionFileResponse{}},
+				{
+					ID:            7,
+					TargetDate:    target,
+					Status:        "failed",
+					ErrorInfo:     &emptyInfo,
+					ErrorCategory: lo.ToPtr(extract.ErrorCategoryDeferred),
+					Files:         []*usecase.ExecutionFileResponse{},
+				},
+			},
+			NextCursor: lo.ToPtr("c7"),
+		}, nil)
+
+	resp, err := s.handler.ListDataTypeExecutions(context.Background(), api.ListDataTypeExecutionsRequestObject{
+		Id:     dtID,
+		Params: api.ListDataTypeExecutionsParams{Limit: lo.ToPtr(3), Cursor: lo.ToPtr("c10")},
+	})
+
+	expected := api.ListDataTypeExecutions200JSONResponse{
+		Items: []api.Execution{
+			{
+				Id:         9,
+				TargetDate: target,
+				Status:     api.Succeeded,
+				Files: []api.ExecutionFile{
+					{
+						Key:         "landing/new.json",
+						ManifestKey: lo.ToPtr("landing/_manifests/9.json"),
+						SizeBytes:   lo.ToPtr(int64(11)),
+						Sha256:      &md.SHA256,
+						Format:      lo.ToPtr("json"),
+						ContentType: lo.ToPtr("application/json"),
+						HttpStatus:  lo.ToPtr(200),
+						CreatedAt:   created,
+					},
+					{
+						Key:                 "landing/new.json.gz",
+						SizeBytes:           lo.ToPtr(int64(11)),
+						Sha256:              &md.SHA256,
+						Format:              lo.ToPtr("json"),
+						ContentType:         lo.ToPtr("application/json"),
+						HttpStatus:          lo.ToPtr(200),
+						ContentEncoding:     lo.ToPtr(api.ExecutionFileContentEncodingGzip),
+						CompressedSizeBytes: lo.ToPtr(int64(35)),
+						CompressedSha256:    lo.ToPtr("abc"),
+						CreatedAt:           created,
+					},
+					{Key: "landing/legacy.json", CreatedAt: created, PurgedAt: &purged},
+				},
+			},
+			{Id: 8, TargetDate: target, Status: api.Failed, Error: &errInfo, Files: []api.ExecutionFile{}},
+			{
+				Id:            7,
+				TargetDate:    target,
+				Status:        api.Failed,
+				Error:         &emptyInfo,
+				ErrorCategory: lo.ToPtr(api.Deferred),
+				Files:         []api.ExecutionFile{},
+			},
+		},
+		NextCursor: lo.ToPtr("c7"),
+	}
+	s.NoError(err)
+	s.Require().IsType(api.ListDataTypeExecutions200JSONResponse{}, resp)
+	s.True(
+		cmp.Equal(expected, resp.(api.ListDataTypeExecutions200JSONResponse)),
+		cmp.Diff(expected, resp.(api.ListDataTypeExecutions200JSONResponse)),
+	)
+}
+
+func (s *ExecutionHandlerTestSuite) TestListDataTypeExecutions_NotFound() {
+	dtID := uuid.Must(uuid.NewV7())
+	s.ucMock.On("List", mock.Anything, &usecase.ListExecutionsRequest{DataTypeID: dtID}).Return(nil, nil)
+
+	resp, err := s.handler.ListDataTypeExecutions(
+		context.Background(),
+		api.ListDataTypeExecutionsRequestObject{Id: dtID},
+	)
+
+	s.NoError(err)
+	s.Equal(api.ListDataTypeExecutions404JSONResponse{Error: "data type not found"}, resp)
+}
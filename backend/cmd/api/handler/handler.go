@@ -2,6 +2,9 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 
 	api "stock-tool/api/gen"
 	"stock-tool/internal/usecase"
@@ -12,12 +15,24 @@ var _ api.StrictServerInterface = (*Handler)(nil)
 type Handler struct {
 	DataSourceHandler
 	DataTypeHandler
+	ExecutionHandler
+	AuditEventHandler
+	LineageHandler
 }
 
-func NewHandler(dsUC DataSourceUseCase, dtUC DataTypeUseCase) *Handler {
+func NewHandler(
+	dsUC DataSourceUseCase,
+	dtUC DataTypeUseCase,
+	execUC ExecutionUseCase,
+	auditUC AuditEventUseCase,
+	lineageUC LineageUseCase,
+) *Handler {
 	return &Handler{
 		DataSourceHandler: DataSourceHandler{uc: dsUC},
 		DataTypeHandler:   DataTypeHandler{uc: dtUC},
+		ExecutionHandler:  ExecutionHandler{uc: execUC},
+		AuditEventHandler: AuditEventHandler{uc: auditUC},
+		LineageHandler:    LineageHandler{uc: lineageUC},
 	}
 }
 
@@ -28,3 +43,63 @@ func validationErrorMessage(err error) (string, bool) {
 	}
 	return "", false
 }
+
+func conflictErrorMessage(err error) (string, bool) {
+	var ce *usecase.ConflictError
+	if errors.As(err, &ce) {
+		return ce.Message, true
+	}
+	return "", false
+}
+
+func preconditionFailedErrorMessage(err error) (string, bool) {
+	var pe *usecase.PreconditionFailedError
+	if errors.As(err, &pe) {
+		return pe.Message, true
+	}
+	return "", false
+}
+
+// formatETag renders a resource version as a strong entity tag.
+func formatETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// parseIfMatch parses an If-Match header into the precondition it sets:
+// nil when the header is absent, any version for "*", and otherwise the
+// versions of the listed ETags. ETags are compared strongly, so weak ETags
+// never match, nor do ETags not produced by formatETag. Returns an error when
+// the header is not "*" or a list of ETags.
+func parseIfMatch(header *string) (*usecase.IfMatch, error) {
+	if header == nil {
+		return nil, nil
+	}
+	value := strings.TrimSpace(*header)
+	if value == "*" {
+		return &usecase.IfMatch{Any: true}, nil
+	}
+	ifMatch := &usecase.IfMatch{}
+	rest := value
+	for {
+		weak := strings.HasPrefix(rest, "W/")
+		tag := strings.TrimPrefix(rest, "W/")
+		if !strings.HasPrefix(tag, `"`) {
+			return nil, fmt.Errorf("invalid If-Match header: %s", value)
+		}
+		end := strings.IndexByte(tag[1:], '"')
+		if end < 0 {
+			return nil, fmt.Errorf("invalid If-Match header: %s", value)
+		}
+		if version, err := strconv.Atoi(tag[1 : end+1]); err == nil && !weak {
+			ifMatch.Versions = append(ifMatch.Versions, version)
+		}
+		rest = strings.TrimLeft(tag[end+2:], " \t")
+		if rest == "" {
+			return ifMatch, nil
+		}
+		if rest[0] != ',' {
+			return nil, fmt.Errorf("invalid If-Match header: %s", value)
+		}
+		rest = strings.TrimLeft(rest[1:], " \t")
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/samber/lo"
+
+	"stock-tool/internal/usecase"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   *string
+		expected *usecase.IfMatch
+		wantErr  string
+	}{
+		{name: "absent", header: nil, expected: nil},
+		{name: "wildcard", header: lo.ToPtr(" * "), expected: &usecase.IfMatch{Any: true}},
+		{name: "strong etag", header: lo.ToPtr(`"3"`), expected: usecase.IfMatchVersion(3)},
+		{name: "list", header: lo.ToPtr(`"3" ,"5",	"8"`), expected: &usecase.IfMatch{Versions: []int{3, 5, 8}}},
+		{name: "comma in etag", header: lo.ToPtr(`"a,b", "4"`), expected: usecase.IfMatchVersion(4)},
+		// Weak etags and etags this API does not produce never match.
+		{name: "weak etag", header: lo.ToPtr(`W/"3"`), expected: &usecase.IfMatch{}},
+		{name: "weak and strong", header: lo.ToPtr(`W/"3", "4"`), expected: usecase.IfMatchVersion(4)},
+		{name: "not a version", header: lo.ToPtr(`"abc"`), expected: &usecase.IfMatch{}},
+		{name: "empty", header: lo.ToPtr(""), wantErr: "invalid If-Match header: "},
+		{name: "unquoted", header: lo.ToPtr("3"), wantErr: "invalid If-Match header: 3"},
+		{name: "unterminated", header: lo.ToPtr(`"3`), wantErr: `invalid If-Match header: "3`},
+		{name: "missing comma", header: lo.ToPtr(`"3" "4"`), wantErr: `invalid If-Match header: "3" "4"`},
+		{name: "trailing comma", header: lo.ToPtr(`"3",`), wantErr: `invalid If-Match header: "3",`},
+		{name: "wildcard in list", header: lo.ToPtr(`"3", *`), wantErr: `invalid If-Match header: "3", *`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseIfMatch(tt.header)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("parseIfMatch() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseIfMatch() error = %v", err)
+			}
+			if !cmp.Equal(tt.expected, got) {
+				t.Error(cmp.Diff(tt.expected, got))
+			}
+		})
+	}
+}
+
+func TestFormatETag(t *testing.T) {
+	if got := formatETag(7); got != `"7"` {
+		t.Errorf("formatETag(7) = %s, want %q", got, `"7"`)
+	}
+}
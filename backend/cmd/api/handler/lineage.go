@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+
+	api "stock-tool/api/gen"
+	"stock-tool/internal/usecase"
+
+	"github.com/samber/lo"
+)
+
+// LineageUseCase defines the operations the handler delegates to the usecase layer.
+type LineageUseCase interface {
+	Trace(ctx context.Context, req *usecase.TraceLineageRequest) (*usecase.LineageResponse, error)
+}
+
+type LineageHandler struct {
+	uc LineageUseCase
+}
+
+func (h *LineageHandler) GetLineage(
+	ctx context.Context,
+	request api.GetLineageRequestObject,
+) (api.GetLineageResponseObject, error) {
+	resp, err := h.uc.Trace(ctx, &usecase.TraceLineageRequest{Ref: request.Params.Key})
+	if err != nil {
+		if msg, ok := validationErrorMessage(err); ok {
+			return api.GetLineage422JSONResponse{Error: msg}, nil
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return api.GetLineage404JSONResponse{Error: "no processing execution wrote the key"}, nil
+	}
+	executions := lo.Map(resp.Executions, func(e *usecase.ProcessingExecutionResponse, _ int) api.ProcessingExecution {
+		return toAPIProcessingExecution(e)
+	})
+	return api.GetLineage200JSONResponse{
+		Key:         resp.Ref,
+		Executions:  executions,
+		LandingKeys: resp.LandingKeys,
+	}, nil
+}
+
+func toAPIProcessingExecution(e *usecase.ProcessingExecutionResponse) api.ProcessingExecution {
+	return api.ProcessingExecution{
+		Id:               e.ID,
+		SourceZone:       api.ProcessingExecutionSourceZone(e.SourceZone),
+		TargetZone:       api.ProcessingExecutionTargetZone(e.TargetZone),
+		SourceIdentifier: e.SourceIdentifier,
+		TargetIdentifier: e.TargetIdentifier,
+		InputKeyPatterns: e.InputKeyPatterns,
+		OutputRefs:       e.OutputRefs,
+		RecordsRead:      e.RecordsRead,
+		RecordsWritten:   e.RecordsWritten,
+		StartedAt:        e.StartedAt,
+		FinishedAt:       e.FinishedAt,
+	}
+}
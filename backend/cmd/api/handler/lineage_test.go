@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "stock-tool/api/gen"
+	"stock-tool/internal/usecase"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LineageUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *LineageUseCaseMock) Trace(
+	ctx context.Context,
+	req *usecase.TraceLineageRequest,
+) (*usecase.LineageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LineageResponse), args.Error(1)
+}
+
+type LineageHandlerTestSuite struct {
+	suite.Suite
+	ucMock  *LineageUseCaseMock
+	handler *LineageHandler
+}
+
+func TestLineageHandler(t *testing.T) {
+	suite.Run(t, new(LineageHandlerTestSuite))
+}
+
+func (s *LineageHandlerTestSuite) SetupTest() {
+	s.ucMock = new(LineageUseCaseMock)
+	s.handler = &LineageHandler{uc: s.ucMock}
+}
+
+func (s *LineageHandlerTestSuite) TestGetLineage() {
+	startedAt := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(3 * time.Second)
+	key := "bronze/jquants_daily_quotes/date=2026-10-16/data.parquet"
+	landing := "landing/jquants/daily_quotes/2026/10/16/all.json"
+	s.ucMock.On("Trace", mock.Anything, &usecase.TraceLineageRequest{Ref: key}).Return(&usecase.LineageResponse{
+		Ref: key,
+		Executions: []*usecase.ProcessingExecutionResponse{
+			{
+				ID:               7,
+				SourceZone:       "landing",
+				TargetZone:       "bronze",
+				SourceIdentifier: "jquants/daily_quotes",
+				TargetIdentifier: "jquants_daily_quotes",
+				InputKeyPatterns: []string{landing},
+				OutputRefs:       []string{key},
+				RecordsRead:      4321,
+				RecordsWritten:   4321,
+				StartedAt:        &startedAt,
+				FinishedAt:       &finishedAt,
+			},
+		},
+		LandingKeys: []string{landing},
+	}, nil)
+
+	resp, err := s.handler.GetLineage(context.Background(), api.GetLineageRequestObject{
+		Params: api.GetLineageParams{Key: key},
+	})
+
+	s.NoError(err)
+	s.Equal(api.GetLineage200JSONResponse{
+		Key: key,
+		Executions: []api.ProcessingExecution{
+			{
+				Id:               7,
+				SourceZone:       api.ProcessingExecutionSourceZoneLanding,
+				TargetZone:       api.ProcessingExecutionTargetZoneBronze,
+				SourceIdentifier: "jquants/daily_quotes",
+				TargetIdentifier: "jquants_daily_quotes",
+				InputKeyPatterns: []string{landing},
+				OutputRefs:       []string{key},
+				RecordsRead:      4321,
+				RecordsWritten:   4321,
+				StartedAt:        &startedAt,
+				FinishedAt:       &finishedAt,
+			},
+		},
+		LandingKeys: []string{landing},
+	}, resp)
+}
+
+func (s *LineageHandlerTestSuite) TestGetLineage_NotFound() {
+	s.ucMock.On("Trace", mock.Anything, mock.Anything).Return(nil, nil)
+
+	resp, err := s.handler.GetLineage(context.Background(), api.GetLineageRequestObject{
+		Params: api.GetLineageParams{Key: "bronze/unknown"},
+	})
+
+	s.NoError(err)
+	s.Equal(api.GetLineage404JSONResponse{Error: "no processing execution wrote the key"}, resp)
+}
+
+func (s *LineageHandlerTestSuite) TestGetLineage_ValidationError() {
+	s.ucMock.On("Trace", mock.Anything, mock.Anything).
+		Return(nil, &usecase.ValidationError{Message: "key must not contain '*'"})
+
+	resp, err := s.handler.GetLineage(context.Background(), api.GetLineageRequestObject{
+		Params: api.GetLineageParams{Key: "bronze/*"},
+	})
+
+	s.NoError(err)
+	s.Equal(api.GetLineage422JSONResponse{Error: "key must not contain '*'"}, resp)
+}
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -15,19 +22,43 @@ import (
 	api "stock-tool/api/gen"
 	"stock-tool/cmd/api/handler"
 	"stock-tool/database"
+	"stock-tool/internal/api/jquants"
+	"stock-tool/internal/domain/ingestion"
+	jquantsdomain "stock-tool/internal/domain/jquants"
 	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
 	"stock-tool/internal/usecase"
+	taskusecase "stock-tool/internal/usecase/task"
 )
 
 const envFile = "./cmd/api/.env"
 
+// shutdownTimeout bounds how long in-flight requests may take to finish once
+// the server is asked to stop. Background executions are drained after that
+// without a bound, since abandoning them would leave their rows running.
+const shutdownTimeout = 30 * time.Second
+
 type envVars struct {
-	DBHost     string `env:"DB_HOST" envDefault:"localhost"`
-	DBPort     int    `env:"DB_PORT" envDefault:"5432"`
-	DBUser     string `env:"DB_USER"`
-	DBPassword string `env:"DB_PASSWORD"`
-	DBName     string `env:"DB_NAME"`
-	Port       string `env:"PORT" envDefault:"8080"`
+	DBHost             string `env:"DB_HOST" envDefault:"localhost"`
+	DBPort             int    `env:"DB_PORT" envDefault:"5432"`
+	DBUser             string `env:"DB_USER"`
+	DBPassword         string `env:"DB_PASSWORD"`
+	DBName             string `env:"DB_NAME"`
+	Port               string `env:"PORT" envDefault:"8080"`
+	JQuantsMailAddress string `env:"JQUANTS_MAIL_ADDRESS"`
+	JQuantsPassword    string `env:"JQUANTS_PASSWORD"`
+	S3Endpoint         string `env:"S3_ENDPOINT" envDefault:"http://localhost:8333"`
+	S3Bucket           string `env:"S3_BUCKET"`
+	S3AccessKey        string `env:"S3_ACCESS_KEY"`
+	S3SecretKey        string `env:"S3_SECRET_KEY"`
+	S3Region           string `env:"S3_REGION" envDefault:"ap-northeast-1"`
+	S3ForcePathStyle   bool   `env:"S3_FORCE_PATH_STYLE" envDefault:"true"`
+	S3SSE              string `env:"S3_SSE"`
+	S3SSECustomerKey   string `env:"S3_SSE_C_KEY"`
+	S3CABundle         string `env:"S3_CA_BUNDLE"`
+	StorageBackend     string `env:"STORAGE_BACKEND" envDefault:"s3"`
+	StorageFSRoot      string `env:"STORAGE_FS_ROOT"`
+	AppEnv             string `env:"APP_ENV"`
 }
 
 var ev envVars
@@ -49,6 +80,30 @@ func init() {
 		fmt.Printf("failed to parse environment variables: %v\n", err)
 		os.Exit(1)
 	}
+	if err := storageConfig().Validate(); err != nil {
+		fmt.Printf("invalid storage configuration: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// storageConfig returns the object store configuration of the environment.
+func storageConfig() storage.Config {
+	return storage.Config{
+		Backend: ev.StorageBackend,
+		FSRoot:  ev.StorageFSRoot,
+		AppEnv:  ev.AppEnv,
+		S3: storage.S3Config{
+			Endpoint:       ev.S3Endpoint,
+			Bucket:         ev.S3Bucket,
+			AccessKey:      ev.S3AccessKey,
+			SecretKey:      ev.S3SecretKey,
+			Region:         ev.S3Region,
+			ForcePathStyle: ev.S3ForcePathStyle,
+			Encryption:     storage.SSEMode(ev.S3SSE),
+			SSECustomerKey: ev.S3SSECustomerKey,
+			CABundlePath:   ev.S3CABundle,
+		},
+	}
 }
 
 func main() {
@@ -78,9 +133,16 @@ func main() {
 		return repository.NewDataSourceRepository(gormDB), nil
 	})
 
+	do.Provide(injector, func(i *do.Injector) (ingestion.SettingsSchemas, error) {
+		return ingestion.SettingsSchemas{
+			jquantsdomain.Kind: jquantsdomain.SettingsSchema{},
+		}, nil
+	})
+
 	do.Provide(injector, func(i *do.Injector) (*usecase.DataSourceUseCase, error) {
 		repo := do.MustInvoke[*repository.DataSourceRepository](i)
-		return usecase.NewDataSourceUseCase(repo), nil
+		schemas := do.MustInvoke[ingestion.SettingsSchemas](i)
+		return usecase.NewDataSourceUseCase(repo, schemas), nil
 	})
 
 	do.Provide(injector, func(i *do.Injector) (*repository.DataTypeRepository, error) {
@@ -94,16 +156,114 @@ func main() {
 
 	do.Provide(injector, func(i *do.Injector) (*usecase.DataTypeUseCase, error) {
 		repo := do.MustInvoke[*repository.DataTypeRepository](i)
-		return usecase.NewDataTypeUseCase(repo), nil
+		dsRepo := do.MustInvoke[*repository.DataSourceRepository](i)
+		schemas := do.MustInvoke[ingestion.SettingsSchemas](i)
+		return usecase.NewDataTypeUseCase(repo, dsRepo, schemas), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*jquants.Client, error) {
+		return jquants.NewClient(ev.JQuantsMailAddress, ev.JQuantsPassword), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*jquants.BrandFetcher, error) {
+		client := do.MustInvoke[*jquants.Client](i)
+		return jquants.NewBrandFetcher(client), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (storage.ObjectStore, error) {
+		return storage.NewObjectStore(storageConfig())
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*repository.ExtractTaskRepository, error) {
+		rawDB := do.MustInvoke[*database.RawDB](i)
+		gormDB, err := rawDB.CreateGormDB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gorm DB: %w", err)
+		}
+		return repository.NewExtractTaskRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*taskusecase.ExtractTaskUseCase, error) {
+		fetcher := do.MustInvoke[*jquants.BrandFetcher](i)
+		objects := do.MustInvoke[storage.ObjectStore](i)
+		repo := do.MustInvoke[*repository.ExtractTaskRepository](i)
+		dsRepo := do.MustInvoke[*repository.DataSourceRepository](i)
+		dtRepo := do.MustInvoke[*repository.DataTypeRepository](i)
+		return taskusecase.NewExtractTaskUseCase(fetcher, objects, repo, dsRepo, dtRepo), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*usecase.ExecutionUseCase, error) {
+		dtRepo := do.MustInvoke[*repository.DataTypeRepository](i)
+		dsRepo := do.MustInvoke[*repository.DataSourceRepository](i)
+		extractor := do.MustInvoke[*taskusecase.ExtractTaskUseCase](i)
+		extractRepo := do.MustInvoke[*repository.ExtractTaskRepository](i)
+		return usecase.NewExecutionUseCase(dtRepo, dsRepo, extractor, extractRepo), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*repository.APIKeyRepository, error) {
+		rawDB := do.MustInvoke[*database.RawDB](i)
+		gormDB, err := rawDB.CreateGormDB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gorm DB: %w", err)
+		}
+		return repository.NewAPIKeyRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*usecase.APIKeyUseCase, error) {
+		repo := do.MustInvoke[*repository.APIKeyRepository](i)
+		return usecase.NewAPIKeyUseCase(repo), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*repository.AuditEventRepository, error) {
+		rawDB := do.MustInvoke[*database.RawDB](i)
+		gormDB, err := rawDB.CreateGormDB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gorm DB: %w", err)
+		}
+		return repository.NewAuditEventRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*usecase.AuditEventUseCase, error) {
+		repo := do.MustInvoke[*repository.AuditEventRepository](i)
+		return usecase.NewAuditEventUseCase(repo), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*repository.ProcessingExecutionRepository, error) {
+		rawDB := do.MustInvoke[*database.RawDB](i)
+		gormDB, err := rawDB.CreateGormDB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gorm DB: %w", err)
+		}
+		return repository.NewProcessingExecutionRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*usecase.LineageUseCase, error) {
+		repo := do.MustInvoke[*repository.ProcessingExecutionRepository](i)
+		return usecase.NewLineageUseCase(repo), nil
 	})
 
 	do.Provide(injector, func(i *do.Injector) (*handler.Handler, error) {
 		dsUC := do.MustInvoke[*usecase.DataSourceUseCase](i)
 		dtUC := do.MustInvoke[*usecase.DataTypeUseCase](i)
-		return handler.NewHandler(dsUC, dtUC), nil
+		execUC := do.MustInvoke[*usecase.ExecutionUseCase](i)
+		auditUC := do.MustInvoke[*usecase.AuditEventUseCase](i)
+		lineageUC := do.MustInvoke[*usecase.LineageUseCase](i)
+		return handler.NewHandler(dsUC, dtUC, execUC, auditUC, lineageUC), nil
 	})
 
+	// Executions write to the landing zone; refuse to serve if it cannot be written.
+	objects, err := do.Invoke[storage.ObjectStore](injector)
+	if err != nil {
+		fmt.Printf("failed to create object store: %v\n", err)
+		os.Exit(1)
+	}
+	if err := objects.CheckWritable(context.Background()); err != nil {
+		fmt.Printf("storage check failed: %v\n", err)
+		os.Exit(1)
+	}
+
 	h := do.MustInvoke[*handler.Handler](injector)
+	apiKeyUC := do.MustInvoke[*usecase.APIKeyUseCase](injector)
 
 	swagger, err := api.GetSwagger()
 	if err != nil {
@@ -113,6 +273,7 @@ func main() {
 	swagger.Servers = nil
 
 	e := echo.New()
+	e.Binder = &handler.Binder{}
 	e.Use(middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 		LogURI:    true,
 		LogStatus: true,
@@ -122,9 +283,26 @@ func main() {
 		},
 	}))
 	e.Use(middleware.Recover())
-	e.Use(oapimiddleware.OapiRequestValidator(swagger))
+	e.Use(handler.BearerAuth(apiKeyUC))
+	e.Use(oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapimiddleware.Options{
+		Options: openapi3filter.Options{AuthenticationFunc: handler.Authorize},
+	}))
 
 	api.RegisterHandlers(e, api.NewStrictHandler(h, nil))
 
-	e.Logger.Fatal(e.Start(":" + ev.Port))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		if err := e.Start(":" + ev.Port); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.Logger.Fatal(err)
+		}
+	}()
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		e.Logger.Error(err)
+	}
+	do.MustInvoke[*usecase.ExecutionUseCase](injector).Wait()
 }
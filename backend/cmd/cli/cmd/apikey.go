@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"stock-tool/database"
+	"stock-tool/internal/domain/auth"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/usecase"
+)
+
+func newAPIKeyCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "api-key",
+		Short: "management API key commands",
+		Run: func(c *cobra.Command, args []string) {
+			_ = c.Help()
+		},
+	}
+
+	c.AddCommand(newAPIKeyCmdIssue())
+	c.AddCommand(newAPIKeyCmdRevoke())
+	c.AddCommand(newAPIKeyCmdList())
+
+	return c
+}
+
+func newAPIKeyCmdIssue() *cobra.Command {
+	var name, role string
+	c := &cobra.Command{
+		Use:   "issue",
+		Short: "issue a new API key and print it once",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return newAPIKeyCommand().Issue(c, name, role)
+		},
+	}
+	c.Flags().StringVar(&name, "name", "", "name identifying the key owner")
+	c.Flags().StringVar(&role, "role", string(auth.RoleReadOnly), "role of the key ("+roleNames()+")")
+	_ = c.MarkFlagRequired("name")
+	return c
+}
+
+func newAPIKeyCmdRevoke() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <id>",
+		Short: "revoke an API key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(c *cobra.Command, args []string) error {
+			return newAPIKeyCommand().Revoke(c, args[0])
+		},
+	}
+}
+
+func newAPIKeyCmdList() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "list API keys",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return newAPIKeyCommand().List(c)
+		},
+	}
+}
+
+type apiKeyCommand struct{}
+
+func newAPIKeyCommand() *apiKeyCommand {
+	return &apiKeyCommand{}
+}
+
+func (a *apiKeyCommand) Issue(cmd *cobra.Command, name, role string) error {
+	return a.withUseCase(cmd.Context(), func(uc *usecase.APIKeyUseCase) error {
+		resp, err := uc.Issue(cmd.Context(), &usecase.IssueAPIKeyRequest{Name: name, Role: role})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "id:   %s\nrole: %s\nkey:  %s\n", resp.ID, resp.Role, resp.Key)
+		fmt.Fprintln(cmd.ErrOrStderr(), "Store the key now; it cannot be shown again.")
+		return nil
+	})
+}
+
+func (a *apiKeyCommand) Revoke(cmd *cobra.Command, rawID string) error {
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return fmt.Errorf("invalid API key ID: %w", err)
+	}
+	return a.withUseCase(cmd.Context(), func(uc *usecase.APIKeyUseCase) error {
+		resp, err := uc.Revoke(cmd.Context(), id)
+		if err != nil {
+			return err
+		}
+		if resp == nil {
+			return fmt.Errorf("API key %s not found", id)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "API key %s revoked at %s.\n", resp.ID, resp.RevokedAt.Format(time.RFC3339))
+		return nil
+	})
+}
+
+func (a *apiKeyCommand) List(cmd *cobra.Command) error {
+	return a.withUseCase(cmd.Context(), func(uc *usecase.APIKeyUseCase) error {
+		keys, err := uc.List(cmd.Context())
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tROLE\tCREATED\tREVOKED")
+		for _, k := range keys {
+			revoked := "-"
+			if k.RevokedAt != nil {
+				revoked = k.RevokedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, k.Role, k.CreatedAt.Format(time.RFC3339), revoked)
+		}
+		return w.Flush()
+	})
+}
+
+func (a *apiKeyCommand) withUseCase(ctx context.Context, fn func(uc *usecase.APIKeyUseCase) error) error {
+	config := ctx.Value(database.CTXKeyDBConfig)
+	if config == nil {
+		return errors.New("database config is nil")
+	}
+
+	db := database.NewRawDB(config.(database.Config))
+	if err := db.Connect(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Shutdown(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to shutdown database: %v\n", err)
+		}
+	}()
+
+	gormDB, err := db.CreateGormDB()
+	if err != nil {
+		return fmt.Errorf("failed to create Gorm DB: %w", err)
+	}
+	return fn(usecase.NewAPIKeyUseCase(repository.NewAPIKeyRepository(gormDB)))
+}
+
+func roleNames() string {
+	names := make([]string, len(auth.Roles))
+	for i, r := range auth.Roles {
+		names[i] = string(r)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+
+	"stock-tool/database"
+	"stock-tool/internal/domain/audit"
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/domain/jquants"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/usecase"
+)
+
+func newConfigCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "config",
+		Short: "data source and data type configuration commands",
+		Run: func(c *cobra.Command, args []string) {
+			_ = c.Help()
+		},
+	}
+
+	c.AddCommand(newConfigCmdExport())
+	c.AddCommand(newConfigCmdApply())
+
+	return c
+}
+
+func newConfigCmdExport() *cobra.Command {
+	var output string
+	c := &cobra.Command{
+		Use:   "export",
+		Short: "export all data sources and data types as YAML",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return newConfigCommand().Export(c, output)
+		},
+	}
+	c.Flags().StringVarP(&output, "output", "o", "", "file to write (default: standard output)")
+	return c
+}
+
+func newConfigCmdApply() *cobra.Command {
+	var file string
+	var dryRun bool
+	c := &cobra.Command{
+		Use:   "apply",
+		Short: "make data sources and data types match a YAML file",
+		Long: "Compares the file with the database, prints the plan of creates, updates and deletes, " +
+			"and applies it in a single transaction. Data sources and data types missing from the file are deleted.",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return newConfigCommand().Apply(c, file, dryRun)
+		},
+	}
+	c.Flags().StringVarP(&file, "file", "f", "", "YAML file to apply")
+	c.Flags().BoolVar(&dryRun, "dry-run", false, "print the plan without applying it")
+	_ = c.MarkFlagRequired("file")
+	return c
+}
+
+// configDocument is the YAML form of usecase.ConfigSpec.
+type configDocument struct {
+	DataSources []dataSourceDocument `yaml:"dataSources"`
+}
+
+type dataSourceDocument struct {
+	Name      string             `yaml:"name"`
+	Kind      string             `yaml:"kind,omitempty"`
+	Enabled   *bool              `yaml:"enabled,omitempty"`
+	Timezone  string             `yaml:"timezone"`
+	Retention retentionDocument  `yaml:"retention,omitempty"`
+	Settings  map[string]any     `yaml:"settings,omitempty"`
+	DataTypes []dataTypeDocument `yaml:"dataTypes,omitempty"`
+}
+
+type dataTypeDocument struct {
+	Name                string               `yaml:"name"`
+	Enabled             *bool                `yaml:"enabled,omitempty"`
+	Schedule            scheduleDocument     `yaml:"schedule"`
+	BackfillEnabled     bool                 `yaml:"backfillEnabled,omitempty"`
+	StaleTimeoutMinutes int                  `yaml:"staleTimeoutMinutes"`
+	RerunStrategy       string               `yaml:"rerunStrategy,omitempty"`
+	EmptyResponsePolicy string               `yaml:"emptyResponsePolicy,omitempty"`
+	Compression         string               `yaml:"compression,omitempty"`
+	Retention           retentionDocument    `yaml:"retention,omitempty"`
+	Settings            map[string]any       `yaml:"settings,omitempty"`
+	Dependencies        []dependencyDocument `yaml:"dependencies,omitempty"`
+}
+
+type dependencyDocument struct {
+	DataType   string `yaml:"dataType"`
+	MaxLagDays int    `yaml:"maxLagDays,omitempty"`
+}
+
+type scheduleDocument struct {
+	Type  string   `yaml:"type"`
+	Times []string `yaml:"times,omitempty"`
+}
+
+type retentionDocument struct {
+	KeepLatest           *int `yaml:"keepLatest,omitempty"`
+	SupersededMaxAgeDays *int `yaml:"supersededMaxAgeDays,omitempty"`
+}
+
+type configCommand struct{}
+
+func newConfigCommand() *configCommand {
+	return &configCommand{}
+}
+
+func (cc *configCommand) Export(cmd *cobra.Command, output string) error {
+	return cc.withTransaction(cmd.Context(), func(ctx context.Context, uc *usecase.ConfigUseCase) error {
+		spec, err := uc.Export(ctx)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", output, err)
+			}
+			defer f.Close()
+			out = f
+		}
+		enc := yaml.NewEncoder(out)
+		enc.SetIndent(2)
+		if err := enc.Encode(toConfigDocument(spec)); err != nil {
+			return fmt.Errorf("failed to encode configuration: %w", err)
+		}
+		return enc.Close()
+	})
+}
+
+func (cc *configCommand) Apply(cmd *cobra.Command, file string, dryRun bool) error {
+	spec, err := readConfigDocument(file)
+	if err != nil {
+		return err
+	}
+	return cc.withTransaction(cmd.Context(), func(ctx context.Context, uc *usecase.ConfigUseCase) error {
+		plan, err := uc.Plan(ctx, spec)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		printConfigPlan(out, plan)
+		if len(plan.Changes) == 0 || dryRun {
+			return nil
+		}
+		if err := uc.Apply(ctx, plan); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "Apply complete.")
+		return nil
+	})
+}
+
+// withTransaction runs fn with a ConfigUseCase whose repositories share one
+// transaction, which is committed only if fn succeeds. Changes are audited
+// as made by the local user.
+func (cc *configCommand) withTransaction(
+	ctx context.Context,
+	fn func(ctx context.Context, uc *usecase.ConfigUseCase) error,
+) error {
+	config := ctx.Value(database.CTXKeyDBConfig)
+	if config == nil {
+		return errors.New("database config is nil")
+	}
+
+	db := database.NewRawDB(config.(database.Config))
+	if err := db.Connect(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Shutdown(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to shutdown database: %v\n", err)
+		}
+	}()
+
+	gormDB, err := db.CreateGormDB()
+	if err != nil {
+		return fmt.Errorf("failed to create Gorm DB: %w", err)
+	}
+
+	ctx = audit.WithActor(ctx, cliActor())
+	return gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		schemas := ingestion.SettingsSchemas{jquants.Kind: jquants.SettingsSchema{}}
+		dsRepo := repository.NewDataSourceRepository(tx)
+		dtRepo := repository.NewDataTypeRepository(tx)
+		uc := usecase.NewConfigUseCase(
+			usecase.NewDataSourceUseCase(dsRepo, schemas),
+			usecase.NewDataTypeUseCase(dtRepo, dsRepo, schemas),
+		)
+		return fn(ctx, uc)
+	})
+}
+
+// cliActor attributes audited changes to the operating system user.
+func cliActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return "cli:" + u.Username
+	}
+	return "cli"
+}
+
+func readConfigDocument(file string) (*usecase.ConfigSpec, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	defer f.Close()
+
+	var doc configDocument
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return doc.toSpec(), nil
+}
+
+func (d *configDocument) toSpec() *usecase.ConfigSpec {
+	spec := &usecase.ConfigSpec{}
+	for _, src := range d.DataSources {
+		spec.DataSources = append(spec.DataSources, usecase.DataSourceSpec{
+			Name:      src.Name,
+			Kind:      src.Kind,
+			Enabled:   lo.FromPtrOr(src.Enabled, true),
+			Timezone:  src.Timezone,
+			Retention: usecase.RetentionInput(src.Retention),
+			Settings:  src.Settings,
+			DataTypes: lo.Map(src.DataTypes, func(dt dataTypeDocument, _ int) usecase.DataTypeSpec {
+				return usecase.DataTypeSpec{
+					Name:                dt.Name,
+					Enabled:             lo.FromPtrOr(dt.Enabled, true),
+					Schedule:            usecase.ScheduleInput{Type: dt.Schedule.Type, Times: dt.Schedule.Times},
+					BackfillEnabled:     dt.BackfillEnabled,
+					StaleTimeoutMinutes: dt.StaleTimeoutMinutes,
+					RerunStrategy:       dt.RerunStrategy,
+					EmptyResponsePolicy: dt.EmptyResponsePolicy,
+					Compression:         dt.Compression,
+					Retention:           usecase.RetentionInput(dt.Retention),
+					Settings:            dt.Settings,
+					Dependencies: lo.Map(dt.Dependencies, func(d dependencyDocument, _ int) usecase.DependencySpec {
+						return usecase.DependencySpec(d)
+					}),
+				}
+			}),
+		})
+	}
+	return spec
+}
+
+func toConfigDocument(spec *usecase.ConfigSpec) *configDocument {
+	doc := &configDocument{DataSources: []dataSourceDocument{}}
+	for _, src := range spec.DataSources {
+		doc.DataSources = append(doc.DataSources, dataSourceDocument{
+			Name:      src.Name,
+			Kind:      src.Kind,
+			Enabled:   lo.ToPtr(src.Enabled),
+			Timezone:  src.Timezone,
+			Retention: retentionDocument(src.Retention),
+			Settings:  src.Settings,
+			DataTypes: lo.Map(src.DataTypes, func(dt usecase.DataTypeSpec, _ int) dataTypeDocument {
+				return dataTypeDocument{
+					Name:                dt.Name,
+					Enabled:             lo.ToPtr(dt.Enabled),
+					Schedule:            scheduleDocument{Type: dt.Schedule.Type, Times: dt.Schedule.Times},
+					BackfillEnabled:     dt.BackfillEnabled,
+					StaleTimeoutMinutes: dt.StaleTimeoutMinutes,
+					RerunStrategy:       dt.RerunStrategy,
+					EmptyResponsePolicy: dt.EmptyResponsePolicy,
+					Compression:         dt.Compression,
+					Retention:           retentionDocument(dt.Retention),
+					Settings:            dt.Settings,
+					Dependencies: lo.Map(dt.Dependencies, func(d usecase.DependencySpec, _ int) dependencyDocument {
+						return dependencyDocument(d)
+					}),
+				}
+			}),
+		})
+	}
+	return doc
+}
+
+func printConfigPlan(w io.Writer, plan *usecase.ConfigPlan) {
+	if len(plan.Changes) == 0 {
+		fmt.Fprintln(w, "No changes. The database matches the configuration.")
+		return
+	}
+	symbols := map[usecase.ConfigChangeAction]string{
+		usecase.ConfigChangeCreate: "+",
+		usecase.ConfigChangeUpdate: "~",
+		usecase.ConfigChangeDelete: "-",
+	}
+	for _, c := range plan.Changes {
+		line := fmt.Sprintf("%s %s %s", symbols[c.Action], c.EntityType, c.Name)
+		if len(c.Fields) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(c.Fields, ", "))
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n",
+		plan.Count(usecase.ConfigChangeCreate),
+		plan.Count(usecase.ConfigChangeUpdate),
+		plan.Count(usecase.ConfigChangeDelete),
+	)
+}
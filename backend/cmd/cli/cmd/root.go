@@ -15,6 +15,9 @@ func NewRootCmd() *cobra.Command {
 
 	c.AddCommand(newInitDBCmd())
 	c.AddCommand(newMigrateCmd())
+	c.AddCommand(newAPIKeyCmd())
+	c.AddCommand(newConfigCmd())
+	c.AddCommand(newSeedCmd())
 
 	return c
 }
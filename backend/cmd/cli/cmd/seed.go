@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/domain/jquants"
+	"stock-tool/internal/usecase"
+)
+
+func newSeedCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "seed",
+		Short: "seed built-in data source catalogues",
+		Run: func(c *cobra.Command, args []string) {
+			_ = c.Help()
+		},
+	}
+
+	c.AddCommand(newSeedCmdJQuants())
+
+	return c
+}
+
+func newSeedCmdJQuants() *cobra.Command {
+	var plan string
+	var dryRun bool
+	c := &cobra.Command{
+		Use:   "jquants",
+		Short: "create the J-Quants data source and every documented data type",
+		Long: "Creates the " + jquants.DataSourceName + " data source and the data types of the J-Quants catalogue " +
+			"that do not exist yet. Existing entries keep their configuration, so the command can be rerun.",
+		RunE: func(c *cobra.Command, _ []string) error {
+			return newSeedCommand().JQuants(c, plan, dryRun)
+		},
+	}
+	c.Flags().StringVar(&plan, "plan", string(jquants.PlanFree), "J-Quants subscription plan of a new data source")
+	c.Flags().BoolVar(&dryRun, "dry-run", false, "print the plan without applying it")
+	return c
+}
+
+type seedCommand struct{}
+
+func newSeedCommand() *seedCommand {
+	return &seedCommand{}
+}
+
+func (sc *seedCommand) JQuants(cmd *cobra.Command, plan string, dryRun bool) error {
+	daily := string(ingestion.ScheduleTypeDaily)
+	src := usecase.DataSourceSpec{
+		Name:     jquants.DataSourceName,
+		Kind:     string(jquants.Kind),
+		Enabled:  true,
+		Timezone: jquants.Timezone,
+		Settings: map[string]any{"plan": plan},
+		DataTypes: lo.Map(jquants.Catalogue, func(e jquants.CatalogueEntry, _ int) usecase.DataTypeSpec {
+			return usecase.DataTypeSpec{
+				Name:                e.Name,
+				Enabled:             true,
+				Schedule:            usecase.ScheduleInput{Type: daily, Times: e.UpdateTimes},
+				BackfillEnabled:     e.BackfillEnabled,
+				StaleTimeoutMinutes: e.StaleTimeoutMinutes,
+				Settings:            e.Settings(),
+			}
+		}),
+	}
+
+	seed := func(ctx context.Context, uc *usecase.ConfigUseCase) error {
+		spec, err := uc.Export(ctx)
+		if err != nil {
+			return err
+		}
+		spec.AddMissing(src)
+		changes, err := uc.Plan(ctx, spec)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		printConfigPlan(out, changes)
+		if len(changes.Changes) == 0 || dryRun {
+			return nil
+		}
+		if err := uc.Apply(ctx, changes); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "Seed complete.")
+		return nil
+	}
+	return newConfigCommand().withTransaction(cmd.Context(), seed)
+}
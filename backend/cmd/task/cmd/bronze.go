@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+
+	infrabronze "stock-tool/internal/infra/bronze"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
+	usecase "stock-tool/internal/usecase/task"
+)
+
+func newBronzeCmd(injector *do.Injector) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "bronze",
+		Short: "convert landing files to bronze Parquet partitions",
+		Long: "Converts the current landing files of each target date, one per window, to a Parquet partition under " +
+			"bronze/{source}_{data_type}/date={yyyy-mm-dd}/ and records the landing files it was converted from " +
+			"in a manifest under bronze/_manifests/ and as a processing execution. " +
+			"Partitions already converted from the current landing files are skipped. " +
+			"The report is written to standard output as JSON.",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return newBronzeCommand(c, injector).Execute()
+		},
+	}
+
+	c.Flags().String("source", "", "source whose files to convert")
+	c.Flags().String("type", "", "type of data to convert")
+	c.Flags().String("start-date", "", "first target date to convert")
+	c.Flags().String("end-date", "", "last target date to convert (optional, defaults to start-date)")
+	c.Flags().String("timezone", "UTC", "source timezone, in which target dates are read")
+	c.Flags().Bool("force", false, "convert partitions that are already up to date")
+	_ = c.MarkFlagRequired("source")
+	_ = c.MarkFlagRequired("type")
+	_ = c.MarkFlagRequired("start-date")
+
+	return c
+}
+
+// bronzeReportDocument is the JSON form of usecase.BronzeReport.
+type bronzeReportDocument struct {
+	Converted []bronzePartitionDocument `json:"converted"`
+	UpToDate  int                       `json:"upToDate"`
+	Missing   []string                  `json:"missing"`
+}
+
+type bronzePartitionDocument struct {
+	Date        string   `json:"date"`
+	Key         string   `json:"key"`
+	Rows        int      `json:"rows"`
+	ExecutionID int      `json:"executionId"`
+	LandingKeys []string `json:"landingKeys"`
+}
+
+type bronzeCommand struct {
+	cmd      *cobra.Command
+	injector *do.Injector
+}
+
+func newBronzeCommand(cmd *cobra.Command, injector *do.Injector) *bronzeCommand {
+	return &bronzeCommand{cmd: cmd, injector: injector}
+}
+
+func (c *bronzeCommand) Execute() error {
+	flags := c.cmd.Flags()
+	source, err := flags.GetString("source")
+	if err != nil {
+		return err
+	}
+	dataType, err := flags.GetString("type")
+	if err != nil {
+		return err
+	}
+	timezone, err := flags.GetString("timezone")
+	if err != nil {
+		return err
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+	force, err := flags.GetBool("force")
+	if err != nil {
+		return err
+	}
+
+	startDate, err := c.getDateFlag("start-date", location)
+	if err != nil {
+		return err
+	}
+	endDate := startDate
+	if flags.Changed("end-date") {
+		endDate, err = c.getDateFlag("end-date", location)
+		if err != nil {
+			return err
+		}
+	}
+
+	objects := do.MustInvoke[storage.ObjectStore](c.injector)
+	extractTaskRepo := do.MustInvoke[*repository.ExtractTaskRepository](c.injector)
+	converter := do.MustInvoke[*infrabronze.Converter](c.injector)
+	processingRepo := do.MustInvoke[*repository.ProcessingExecutionRepository](c.injector)
+
+	uc := usecase.NewBronzeUseCase(objects, extractTaskRepo, converter, processingRepo)
+	report, err := uc.Convert(c.cmd.Context(), &usecase.BronzeRequest{
+		Source:    source,
+		DataType:  dataType,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Force:     force,
+	})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(c.cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(toBronzeReportDocument(report)); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+// getDateFlag parses a YYYY-MM-DD flag as midnight in location.
+func (c *bronzeCommand) getDateFlag(flag string, location *time.Location) (time.Time, error) {
+	dateStr, err := c.cmd.Flags().GetString(flag)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", dateStr, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", flag, err)
+	}
+	return date, nil
+}
+
+func toBronzeReportDocument(report *usecase.BronzeReport) *bronzeReportDocument {
+	doc := &bronzeReportDocument{
+		Converted: []bronzePartitionDocument{},
+		UpToDate:  report.UpToDate,
+		Missing:   []string{},
+	}
+	for _, p := range report.Converted {
+		doc.Converted = append(doc.Converted, bronzePartitionDocument{
+			Date:        p.Date.Format("2006-01-02"),
+			Key:         p.Key,
+			Rows:        p.Rows,
+			ExecutionID: p.ExecutionID,
+			LandingKeys: p.LandingKeys,
+		})
+	}
+	for _, d := range report.Missing {
+		doc.Missing = append(doc.Missing, d.Format("2006-01-02"))
+	}
+	return doc
+}
@@ -17,6 +17,17 @@ func newExtractCmd(injector *do.Injector) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "extract",
 		Short: "extract data from a source",
+		// Fail before fetching anything if the landing zone cannot be written.
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			objects, err := do.Invoke[storage.ObjectStore](injector)
+			if err != nil {
+				return err
+			}
+			if err := objects.CheckWritable(c.Context()); err != nil {
+				return fmt.Errorf("storage check failed: %w", err)
+			}
+			return nil
+		},
 		Run: func(c *cobra.Command, args []string) {
 			_ = c.Help()
 		},
@@ -55,47 +66,60 @@ func newExtractCommand(cmd *cobra.Command, injector *do.Injector) *extractJQuant
 }
 
 func (c *extractJQuantsCommand) Execute() error {
-	dataType, err := c.cmd.Flags().GetString("type")
+	req, err := c.request()
 	if err != nil {
 		return err
 	}
 
-	code, err := c.getOptionStringFlag("code")
+	brandFetcher := do.MustInvoke[*jquants.BrandFetcher](c.injector)
+	objectWriter := do.MustInvoke[storage.ObjectStore](c.injector)
+	extractTaskRepo := do.MustInvoke[*repository.ExtractTaskRepository](c.injector)
+	dataSourceRepo := do.MustInvoke[*repository.DataSourceRepository](c.injector)
+	dataTypeRepo := do.MustInvoke[*repository.DataTypeRepository](c.injector)
+
+	uc := usecase.NewExtractTaskUseCase(brandFetcher, objectWriter, extractTaskRepo, dataSourceRepo, dataTypeRepo)
+	resp, err := uc.Extract(c.cmd.Context(), req)
 	if err != nil {
 		return err
 	}
 
-	startDate, err := c.getOptionDateFlag("start-date")
+	fmt.Printf("Extract completed: status=%s, s3Key=%s\n", resp.Status, resp.S3Key)
+	return nil
+}
+
+// request builds the extraction request from the flags. The start date is the
+// target date of the execution, so the plan range, the dependencies and the
+// overwrite key are those of the requested date rather than of today.
+func (c *extractJQuantsCommand) request() (*usecase.ExtractTaskRequest, error) {
+	dataType, err := c.cmd.Flags().GetString("type")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	endDate, err := c.getOptionDateFlag("end-date")
+	code, err := c.getOptionStringFlag("code")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	brandFetcher := do.MustInvoke[*jquants.BrandFetcher](c.injector)
-	objectWriter := do.MustInvoke[*storage.S3Client](c.injector)
-	extractTaskRepo := do.MustInvoke[*repository.ExtractTaskRepository](c.injector)
-
-	req := &usecase.ExtractTaskRequest{
-		Source:    "jquants",
-		DataType:  dataType,
-		Timing:    "daily",
-		Code:      code,
-		StartDate: startDate,
-		EndDate:   endDate,
+	startDate, err := c.getOptionDateFlag("start-date")
+	if err != nil {
+		return nil, err
 	}
 
-	uc := usecase.NewExtractTaskUseCase(brandFetcher, objectWriter, extractTaskRepo)
-	resp, err := uc.Extract(c.cmd.Context(), req)
+	endDate, err := c.getOptionDateFlag("end-date")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Printf("Extract completed: status=%s, s3Key=%s\n", resp.Status, resp.S3Key)
-	return nil
+	return &usecase.ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   dataType,
+		Timing:     "daily",
+		TargetDate: startDate,
+		Code:       code,
+		StartDate:  startDate,
+		EndDate:    endDate,
+	}, nil
 }
 
 func (c *extractJQuantsCommand) getOptionStringFlag(flag string) (*string, error) {
@@ -125,7 +149,7 @@ func (c *extractJQuantsCommand) getOptionDateFlag(flag string) (*time.Time, erro
 		return nil, nil
 	}
 
-	date, err := time.Parse("2006-01-02", dateStr) //nolint:staticcheck // SA4006: new(expr) is valid Go 1.26 syntax
+	date, err := time.Parse("2006-01-02", dateStr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid date format: %w", err)
 	}
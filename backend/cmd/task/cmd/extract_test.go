@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	usecase "stock-tool/internal/usecase/task"
+)
+
+func TestExtractJQuantsCommand_Request(t *testing.T) {
+	startDate := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		args     []string
+		expected *usecase.ExtractTaskRequest
+		wantErr  string
+	}{
+		{
+			name:     "today",
+			args:     []string{"--type", "brand"},
+			expected: &usecase.ExtractTaskRequest{Source: "jquants", DataType: "brand", Timing: "daily"},
+		},
+		{
+			name: "start date is the target date",
+			args: []string{"--type", "brand", "--code", "86970", "--start-date", "2025-06-02", "--end-date", "2025-06-03"},
+			expected: &usecase.ExtractTaskRequest{
+				Source:     "jquants",
+				DataType:   "brand",
+				Timing:     "daily",
+				TargetDate: &startDate,
+				Code:       lo.ToPtr("86970"),
+				StartDate:  &startDate,
+				EndDate:    &endDate,
+			},
+		},
+		{
+			name:    "invalid date",
+			args:    []string{"--type", "brand", "--start-date", "2025/06/02"},
+			wantErr: `invalid date format: parsing time "2025/06/02" as "2006-01-02": cannot parse "/06/02" as "-"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			injector := do.New()
+			c := newExtractJQuantsCmd(injector)
+			if err := c.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
+			}
+
+			got, err := newExtractCommand(c, injector).request()
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("request() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("request() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("request() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+
+	"stock-tool/internal/infra/repository"
+	usecase "stock-tool/internal/usecase/task"
+)
+
+func newGapsCmd(injector *do.Injector) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "gaps",
+		Short: "list the expected target dates of a data type without a succeeded execution",
+		Long: "Compares the expected target dates of a data type with its succeeded executions. J-Quants sources " +
+			"expect weekdays inside the range of their plan, so dates the plan cannot fetch are never gaps. " +
+			"The report is written to standard output as JSON.",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return newGapsCommand(c, injector).Execute()
+		},
+	}
+
+	c.Flags().String("source", "", "source of the data type")
+	c.Flags().String("type", "", "type of data to check")
+	c.Flags().String("start-date", "", "first target date to check, in the source timezone")
+	c.Flags().String("end-date", "", "last target date to check, in the source timezone")
+	_ = c.MarkFlagRequired("source")
+	_ = c.MarkFlagRequired("type")
+	_ = c.MarkFlagRequired("start-date")
+	_ = c.MarkFlagRequired("end-date")
+
+	return c
+}
+
+// gapReportDocument is the JSON form of usecase.GapReport.
+type gapReportDocument struct {
+	Expected int      `json:"expected"`
+	Gaps     []string `json:"gaps"`
+}
+
+type gapsCommand struct {
+	cmd      *cobra.Command
+	injector *do.Injector
+}
+
+func newGapsCommand(cmd *cobra.Command, injector *do.Injector) *gapsCommand {
+	return &gapsCommand{cmd: cmd, injector: injector}
+}
+
+func (c *gapsCommand) Execute() error {
+	flags := c.cmd.Flags()
+	source, err := flags.GetString("source")
+	if err != nil {
+		return err
+	}
+	dataType, err := flags.GetString("type")
+	if err != nil {
+		return err
+	}
+	startDate, err := c.getDateFlag("start-date")
+	if err != nil {
+		return err
+	}
+	endDate, err := c.getDateFlag("end-date")
+	if err != nil {
+		return err
+	}
+
+	extractTaskRepo := do.MustInvoke[*repository.ExtractTaskRepository](c.injector)
+	dataSourceRepo := do.MustInvoke[*repository.DataSourceRepository](c.injector)
+	dataTypeRepo := do.MustInvoke[*repository.DataTypeRepository](c.injector)
+
+	uc := usecase.NewGapUseCase(extractTaskRepo, dataSourceRepo, dataTypeRepo)
+	report, err := uc.Detect(c.cmd.Context(), &usecase.GapRequest{
+		Source:    source,
+		DataType:  dataType,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	doc := &gapReportDocument{Expected: report.Expected, Gaps: []string{}}
+	for _, d := range report.Gaps {
+		doc.Gaps = append(doc.Gaps, d.Format(time.DateOnly))
+	}
+	enc := json.NewEncoder(c.cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+func (c *gapsCommand) getDateFlag(flag string) (time.Time, error) {
+	dateStr, err := c.cmd.Flags().GetString(flag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", flag, err)
+	}
+	return date, nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
+	usecase "stock-tool/internal/usecase/task"
+)
+
+func newGCCmd(injector *do.Injector) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "gc",
+		Short: "purge superseded landing files under the retention policies of their data types",
+		Long: "Deletes the superseded landing files that the retention policy of their data type, or of its data " +
+			"source when the data type sets no rule, no longer keeps and marks their extracted_data_s3s records " +
+			"purged. The current file of a target date is never purged. " +
+			"The report is written to standard output as JSON.",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return newGCCommand(c, injector).Execute()
+		},
+	}
+
+	c.Flags().String("source", "", "source whose files to collect (optional)")
+	c.Flags().String("type", "", "type of data to collect (optional)")
+	c.Flags().Bool("dry-run", false, "report the files that would be purged without deleting them")
+
+	return c
+}
+
+// gcReportDocument is the JSON form of usecase.GCReport.
+type gcReportDocument struct {
+	DryRun  bool             `json:"dryRun"`
+	Checked int              `json:"checked"`
+	Kept    int              `json:"kept"`
+	Purged  []gcFileDocument `json:"purged"`
+}
+
+type gcFileDocument struct {
+	Source         string    `json:"source"`
+	DataType       string    `json:"dataType"`
+	Key            string    `json:"key"`
+	VersionID      string    `json:"versionId,omitempty"`
+	TargetDateTime time.Time `json:"targetDateTime"`
+	ObjectDeleted  bool      `json:"objectDeleted"`
+}
+
+type gcCommand struct {
+	cmd      *cobra.Command
+	injector *do.Injector
+}
+
+func newGCCommand(cmd *cobra.Command, injector *do.Injector) *gcCommand {
+	return &gcCommand{cmd: cmd, injector: injector}
+}
+
+func (c *gcCommand) Execute() error {
+	flags := c.cmd.Flags()
+	source, err := flags.GetString("source")
+	if err != nil {
+		return err
+	}
+	dataType, err := flags.GetString("type")
+	if err != nil {
+		return err
+	}
+	dryRun, err := flags.GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
+	objects := do.MustInvoke[storage.ObjectStore](c.injector)
+	extractTaskRepo := do.MustInvoke[*repository.ExtractTaskRepository](c.injector)
+	dataTypeRepo := do.MustInvoke[*repository.DataTypeRepository](c.injector)
+
+	uc := usecase.NewGCUseCase(objects, extractTaskRepo, dataTypeRepo)
+	report, err := uc.GC(c.cmd.Context(), &usecase.GCRequest{
+		Source:   source,
+		DataType: dataType,
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(c.cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(toGCReportDocument(report)); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+func toGCReportDocument(report *usecase.GCReport) *gcReportDocument {
+	doc := &gcReportDocument{
+		DryRun:  report.DryRun,
+		Checked: report.Checked,
+		Kept:    report.Kept,
+		Purged:  []gcFileDocument{},
+	}
+	for _, f := range report.Purged {
+		doc.Purged = append(doc.Purged, gcFileDocument(f))
+	}
+	return doc
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
+	usecase "stock-tool/internal/usecase/task"
+)
+
+func newReindexCmd(injector *do.Injector) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "reindex",
+		Short: "rebuild execution records from the landing zone",
+		Long: "Recreates the extract_tasks, extract_task_executions and extracted_data_s3s records of landing files " +
+			"that have none, from their manifests or, without one, their keys and object metadata. " +
+			"Existing records are left alone and disagreements are reported as conflicts. " +
+			"The report is written to standard output as JSON.",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return newReindexCommand(c, injector).Execute()
+		},
+	}
+
+	c.Flags().String("source", "", "source whose files to reindex")
+	c.Flags().String("type", "", "type of data to reindex (optional)")
+	c.Flags().String("timing", "daily", "task timing of files without a manifest")
+	c.Flags().String("timezone", "UTC", "source timezone, in which overwrite keys without a manifest are dated")
+	c.Flags().Bool("dry-run", false, "report what would be created without writing records")
+	c.Flags().Bool("fail-on-conflicts", false, "exit with a non-zero status if any conflict is found")
+	_ = c.MarkFlagRequired("source")
+
+	return c
+}
+
+// reindexReportDocument is the JSON form of usecase.ReindexReport.
+type reindexReportDocument struct {
+	DryRun            bool                      `json:"dryRun"`
+	CreatedTasks      int                       `json:"createdTasks"`
+	CreatedExecutions int                       `json:"createdExecutions"`
+	CreatedFiles      int                       `json:"createdFiles"`
+	Existing          int                       `json:"existing"`
+	Skipped           int                       `json:"skipped"`
+	Conflicts         []reindexConflictDocument `json:"conflicts"`
+}
+
+type reindexConflictDocument struct {
+	Key    string `json:"key"`
+	Detail string `json:"detail"`
+}
+
+type reindexCommand struct {
+	cmd      *cobra.Command
+	injector *do.Injector
+}
+
+func newReindexCommand(cmd *cobra.Command, injector *do.Injector) *reindexCommand {
+	return &reindexCommand{cmd: cmd, injector: injector}
+}
+
+func (c *reindexCommand) Execute() error {
+	flags := c.cmd.Flags()
+	source, err := flags.GetString("source")
+	if err != nil {
+		return err
+	}
+	dataType, err := flags.GetString("type")
+	if err != nil {
+		return err
+	}
+	timing, err := flags.GetString("timing")
+	if err != nil {
+		return err
+	}
+	timezone, err := flags.GetString("timezone")
+	if err != nil {
+		return err
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone: %w", err)
+	}
+	dryRun, err := flags.GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+	failOnConflicts, err := flags.GetBool("fail-on-conflicts")
+	if err != nil {
+		return err
+	}
+
+	objects := do.MustInvoke[storage.ObjectStore](c.injector)
+	extractTaskRepo := do.MustInvoke[*repository.ExtractTaskRepository](c.injector)
+
+	uc := usecase.NewReindexUseCase(objects, extractTaskRepo)
+	report, err := uc.Reindex(c.cmd.Context(), &usecase.ReindexRequest{
+		Source:   source,
+		DataType: dataType,
+		Timing:   timing,
+		Location: location,
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	doc := toReindexReportDocument(report)
+	enc := json.NewEncoder(c.cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	if failOnConflicts && len(doc.Conflicts) > 0 {
+		return fmt.Errorf("reindex found %d conflict(s)", len(doc.Conflicts))
+	}
+	return nil
+}
+
+func toReindexReportDocument(report *usecase.ReindexReport) *reindexReportDocument {
+	doc := &reindexReportDocument{
+		DryRun:            report.DryRun,
+		CreatedTasks:      report.CreatedTasks,
+		CreatedExecutions: report.CreatedExecutions,
+		CreatedFiles:      report.CreatedFiles,
+		Existing:          report.Existing,
+		Skipped:           report.Skipped,
+		Conflicts:         []reindexConflictDocument{},
+	}
+	for _, c := range report.Conflicts {
+		doc.Conflicts = append(doc.Conflicts, reindexConflictDocument(c))
+	}
+	return doc
+}
@@ -15,6 +15,11 @@ func NewRootCmd(injector *do.Injector) *cobra.Command {
 	}
 
 	c.AddCommand(newExtractCmd(injector))
+	c.AddCommand(newVerifyCmd(injector))
+	c.AddCommand(newGCCmd(injector))
+	c.AddCommand(newGapsCmd(injector))
+	c.AddCommand(newReindexCmd(injector))
+	c.AddCommand(newBronzeCmd(injector))
 
 	return c
 }
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/spf13/cobra"
+
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
+	usecase "stock-tool/internal/usecase/task"
+)
+
+func newVerifyCmd(injector *do.Injector) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "verify",
+		Short: "verify landing files in S3 against extracted_data_s3s",
+		Long: "Checks that every recorded landing file exists in S3 with the recorded size and SHA-256, " +
+			"and lists objects under landing/ that have no record. The report is written to standard output as JSON.",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return newVerifyCommand(c, injector).Execute()
+		},
+	}
+
+	c.Flags().String("source", "", "source whose files to verify")
+	c.Flags().String("type", "", "type of data to verify (optional)")
+	c.Flags().String("start-date", "", "first target date to verify, in UTC (optional)")
+	c.Flags().String("end-date", "", "last target date to verify, in UTC (optional)")
+	c.Flags().String("mode", string(usecase.VerifyModeHead),
+		"head compares object metadata, get downloads and hashes every object")
+	c.Flags().Bool("fail-on-issues", false, "exit with a non-zero status if any issue is found")
+	_ = c.MarkFlagRequired("source")
+
+	return c
+}
+
+// verifyReportDocument is the JSON form of usecase.VerifyReport.
+type verifyReportDocument struct {
+	Checked    int                   `json:"checked"`
+	OK         int                   `json:"ok"`
+	Unverified int                   `json:"unverified"`
+	Missing    int                   `json:"missing"`
+	Corrupted  int                   `json:"corrupted"`
+	Orphaned   int                   `json:"orphaned"`
+	Issues     []verifyIssueDocument `json:"issues"`
+}
+
+type verifyIssueDocument struct {
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+	Detail string `json:"detail"`
+}
+
+type verifyCommand struct {
+	cmd      *cobra.Command
+	injector *do.Injector
+}
+
+func newVerifyCommand(cmd *cobra.Command, injector *do.Injector) *verifyCommand {
+	return &verifyCommand{cmd: cmd, injector: injector}
+}
+
+func (c *verifyCommand) Execute() error {
+	flags := c.cmd.Flags()
+	source, err := flags.GetString("source")
+	if err != nil {
+		return err
+	}
+	dataType, err := flags.GetString("type")
+	if err != nil {
+		return err
+	}
+	mode, err := flags.GetString("mode")
+	if err != nil {
+		return err
+	}
+	if mode != string(usecase.VerifyModeHead) && mode != string(usecase.VerifyModeGet) {
+		return fmt.Errorf("invalid mode %q: must be head or get", mode)
+	}
+	failOnIssues, err := flags.GetBool("fail-on-issues")
+	if err != nil {
+		return err
+	}
+
+	startDate, err := c.getOptionDateFlag("start-date")
+	if err != nil {
+		return err
+	}
+	endDate, err := c.getOptionDateFlag("end-date")
+	if err != nil {
+		return err
+	}
+	if endDate != nil {
+		// The end date is inclusive; the filter bound is not
+		*endDate = endDate.AddDate(0, 0, 1)
+	}
+
+	objects := do.MustInvoke[storage.ObjectStore](c.injector)
+	extractTaskRepo := do.MustInvoke[*repository.ExtractTaskRepository](c.injector)
+
+	uc := usecase.NewVerifyUseCase(objects, extractTaskRepo)
+	report, err := uc.Verify(c.cmd.Context(), &usecase.VerifyRequest{
+		Filter: extract.FileFilter{Source: source, DataType: dataType, From: startDate, To: endDate},
+		Mode:   usecase.VerifyMode(mode),
+	})
+	if err != nil {
+		return err
+	}
+
+	doc := toVerifyReportDocument(report)
+	enc := json.NewEncoder(c.cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	if failOnIssues && len(doc.Issues) > 0 {
+		return fmt.Errorf("verify found %d issue(s)", len(doc.Issues))
+	}
+	return nil
+}
+
+func (c *verifyCommand) getOptionDateFlag(flag string) (*time.Time, error) {
+	dateStr, err := c.cmd.Flags().GetString(flag)
+	if err != nil {
+		return nil, err
+	} else if dateStr == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", flag, err)
+	}
+	return &date, nil
+}
+
+func toVerifyReportDocument(report *usecase.VerifyReport) *verifyReportDocument {
+	doc := &verifyReportDocument{
+		Checked:    report.Checked,
+		OK:         report.OK,
+		Unverified: report.Unverified,
+		Issues:     []verifyIssueDocument{},
+	}
+	for _, issue := range report.Issues {
+		switch issue.Kind {
+		case usecase.IssueKindMissing:
+			doc.Missing++
+		case usecase.IssueKindCorrupted:
+			doc.Corrupted++
+		case usecase.IssueKindOrphaned:
+			doc.Orphaned++
+		}
+		doc.Issues = append(doc.Issues, verifyIssueDocument{
+			Kind:   string(issue.Kind),
+			Key:    issue.Key,
+			Detail: issue.Detail,
+		})
+	}
+	return doc
+}
@@ -13,6 +13,7 @@ import (
 	"stock-tool/cmd/task/cmd"
 	"stock-tool/database"
 	"stock-tool/internal/api/jquants"
+	"stock-tool/internal/infra/bronze"
 	"stock-tool/internal/infra/repository"
 	"stock-tool/internal/infra/storage"
 )
@@ -35,6 +36,12 @@ type envVars struct {
 	S3SecretKey        string `env:"S3_SECRET_KEY"`
 	S3Region           string `env:"S3_REGION" envDefault:"ap-northeast-1"`
 	S3ForcePathStyle   bool   `env:"S3_FORCE_PATH_STYLE" envDefault:"true"`
+	S3SSE              string `env:"S3_SSE"`
+	S3SSECustomerKey   string `env:"S3_SSE_C_KEY"`
+	S3CABundle         string `env:"S3_CA_BUNDLE"`
+	StorageBackend     string `env:"STORAGE_BACKEND" envDefault:"s3"`
+	StorageFSRoot      string `env:"STORAGE_FS_ROOT"`
+	AppEnv             string `env:"APP_ENV"`
 }
 
 var ev envVars
@@ -56,6 +63,30 @@ func init() {
 		fmt.Printf("failed to parse environment variables: %v\n", err)
 		os.Exit(1)
 	}
+	if err := storageConfig().Validate(); err != nil {
+		fmt.Printf("invalid storage configuration: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// storageConfig returns the object store configuration of the environment.
+func storageConfig() storage.Config {
+	return storage.Config{
+		Backend: ev.StorageBackend,
+		FSRoot:  ev.StorageFSRoot,
+		AppEnv:  ev.AppEnv,
+		S3: storage.S3Config{
+			Endpoint:       ev.S3Endpoint,
+			Bucket:         ev.S3Bucket,
+			AccessKey:      ev.S3AccessKey,
+			SecretKey:      ev.S3SecretKey,
+			Region:         ev.S3Region,
+			ForcePathStyle: ev.S3ForcePathStyle,
+			Encryption:     storage.SSEMode(ev.S3SSE),
+			SSECustomerKey: ev.S3SSECustomerKey,
+			CABundlePath:   ev.S3CABundle,
+		},
+	}
 }
 
 func main() {
@@ -95,15 +126,35 @@ func main() {
 		}
 		return repository.NewExtractTaskRepository(db), nil
 	})
-	do.Provide(injector, func(i *do.Injector) (*storage.S3Client, error) {
-		return storage.NewS3Client(storage.S3Config{
-			Endpoint:       ev.S3Endpoint,
-			Bucket:         ev.S3Bucket,
-			AccessKey:      ev.S3AccessKey,
-			SecretKey:      ev.S3SecretKey,
-			Region:         ev.S3Region,
-			ForcePathStyle: ev.S3ForcePathStyle,
-		}), nil
+	do.Provide(injector, func(i *do.Injector) (*repository.DataSourceRepository, error) {
+		rawDB := do.MustInvoke[*database.RawDB](i)
+		db, err := rawDB.CreateGormDB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gorm DB: %w", err)
+		}
+		return repository.NewDataSourceRepository(db), nil
+	})
+	do.Provide(injector, func(i *do.Injector) (*repository.DataTypeRepository, error) {
+		rawDB := do.MustInvoke[*database.RawDB](i)
+		db, err := rawDB.CreateGormDB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gorm DB: %w", err)
+		}
+		return repository.NewDataTypeRepository(db), nil
+	})
+	do.Provide(injector, func(i *do.Injector) (*repository.ProcessingExecutionRepository, error) {
+		rawDB := do.MustInvoke[*database.RawDB](i)
+		db, err := rawDB.CreateGormDB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Gorm DB: %w", err)
+		}
+		return repository.NewProcessingExecutionRepository(db), nil
+	})
+	do.Provide(injector, func(i *do.Injector) (storage.ObjectStore, error) {
+		return storage.NewObjectStore(storageConfig())
+	})
+	do.Provide(injector, func(i *do.Injector) (*bronze.Converter, error) {
+		return bronze.NewConverter(), nil
 	})
 
 	command := cmd.NewRootCmd(injector)
@@ -2,7 +2,9 @@ package jquants
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 )
 
@@ -14,10 +16,12 @@ func NewBrandFetcher(client *Client) *BrandFetcher {
 	return &BrandFetcher{client: client}
 }
 
-func (f *BrandFetcher) FetchBrands(ctx context.Context, code *string, date *time.Time) ([]byte, error) {
+// StreamBrands returns the unread body and HTTP status code of a listed-info
+// response. The body is the raw bytes sent by the API; the caller must close it.
+func (f *BrandFetcher) StreamBrands(ctx context.Context, code *string, date *time.Time) (io.ReadCloser, int, error) {
 	if !f.client.IsAuthorized() {
 		if err := f.client.Login(); err != nil {
-			return nil, fmt.Errorf("failed to login: %w", err)
+			return nil, 0, fmt.Errorf("failed to login: %w", err)
 		}
 	}
 
@@ -27,13 +31,25 @@ func (f *BrandFetcher) FetchBrands(ctx context.Context, code *string, date *time
 		jqDate = &d
 	}
 
-	resp, err := f.client.ListBrands(ListBrandRequest{
+	resp, err := f.client.ListBrandsStream(ctx, ListBrandRequest{
 		Code: code,
 		Date: jqDate,
 	})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return resp.RawBody, nil
+	return resp.Body, resp.StatusCode(), nil
+}
+
+// CountRecords returns the number of brands in a listed-info response body
+// returned by StreamBrands. The brands are counted without being decoded.
+func (f *BrandFetcher) CountRecords(rawBody []byte) (int, error) {
+	var body struct {
+		Info []json.RawMessage `json:"info"`
+	}
+	if err := json.Unmarshal(rawBody, &body); err != nil {
+		return 0, fmt.Errorf("failed to decode listed-info response: %w", err)
+	}
+	return len(body.Info), nil
 }
@@ -0,0 +1,32 @@
+package jquants
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BrandFetcher_CountRecords(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawBody  string
+		expected int
+		wantErr  bool
+	}{
+		{name: "brands", rawBody: `{"info":[{"Code":"86970"},{"Code":"72030"}]}`, expected: 2},
+		{name: "empty", rawBody: `{"info":[]}`, expected: 0},
+		{name: "no info key", rawBody: `{}`, expected: 0},
+		{name: "not json", rawBody: `<html>`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := NewBrandFetcher(nil).CountRecords([]byte(tt.rawBody))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, count)
+		})
+	}
+}
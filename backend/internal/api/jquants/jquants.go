@@ -2,6 +2,7 @@ package jquants
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -163,21 +164,23 @@ type GetDailyQuoteResponseBody struct {
 	PaginationKey *string      `json:"pagination_key"`
 }
 
+// DailyQuote is one row of daily_quotes. Prices, volumes and turnover are
+// null on days without trades, so they are nullable.
 type DailyQuote struct {
-	Date             Date            `json:"Date"`
-	Code             string          `json:"Code"`
-	Open             decimal.Decimal `json:"Open"`
-	High             decimal.Decimal `json:"High"`
-	Low              decimal.Decimal `json:"Low"`
-	Close            decimal.Decimal `json:"Close"`
-	Volume           decimal.Decimal `json:"Volume"`
-	TurnoverValue    decimal.Decimal `json:"TurnoverValue"`
-	AdjustmentFactor decimal.Decimal `json:"AdjustmentFactor"`
-	AdjustmentOpen   decimal.Decimal `json:"AdjustmentOpen"`
-	AdjustmentHigh   decimal.Decimal `json:"AdjustmentHigh"`
-	AdjustmentLow    decimal.Decimal `json:"AdjustmentLow"`
-	AdjustmentClose  decimal.Decimal `json:"AdjustmentClose"`
-	AdjustmentVolume decimal.Decimal `json:"AdjustmentVolume"`
+	Date             Date                `json:"Date"`
+	Code             string              `json:"Code"`
+	Open             decimal.NullDecimal `json:"Open"`
+	High             decimal.NullDecimal `json:"High"`
+	Low              decimal.NullDecimal `json:"Low"`
+	Close            decimal.NullDecimal `json:"Close"`
+	Volume           decimal.NullDecimal `json:"Volume"`
+	TurnoverValue    decimal.NullDecimal `json:"TurnoverValue"`
+	AdjustmentFactor decimal.Decimal     `json:"AdjustmentFactor"`
+	AdjustmentOpen   decimal.NullDecimal `json:"AdjustmentOpen"`
+	AdjustmentHigh   decimal.NullDecimal `json:"AdjustmentHigh"`
+	AdjustmentLow    decimal.NullDecimal `json:"AdjustmentLow"`
+	AdjustmentClose  decimal.NullDecimal `json:"AdjustmentClose"`
+	AdjustmentVolume decimal.NullDecimal `json:"AdjustmentVolume"`
 }
 
 func NewGetDailyQuoteRequestByCode(code string) GetDailyQuoteRequest {
@@ -213,6 +216,13 @@ func (r *GetDailyQuoteRequest) WithPaginationKey(value string) *GetDailyQuoteReq
 	return r
 }
 
+// GetStatementsRequest selects fins/statements by code or by disclosure date.
+type GetStatementsRequest struct {
+	Code          *string `json:"code"`
+	Date          *Date   `json:"date"`
+	PaginationKey *string `json:"pagination_key"`
+}
+
 type httpClient interface {
 	Do(request *http.Request) (*http.Response, error)
 }
@@ -301,60 +311,135 @@ func (c *API) RefreshToken(request RefreshTokenRequest) (*Response[RefreshTokenR
 	return nil, &errBody
 }
 
+// ListBrand requests listed/info and decodes the body as it is read, so the
+// raw bytes are never buffered. RawBody is nil.
 func (c *API) ListBrand(
 	idToken string,
 	request ListBrandRequest,
 ) (*Response[ListBrandResponseBody], error) {
-	params := url.Values{}
-	if request.Code != nil {
-		params.Add("code", *request.Code)
+	resp, err := c.ListBrandStream(context.Background(), idToken, request)
+	if err != nil {
+		return nil, err
 	}
-	if request.Date != nil {
-		params.Add("date", request.Date.Format())
+	return decodeStream[ListBrandResponseBody](resp)
+}
+
+// GetDailyQuotes requests prices/daily_quotes and decodes the body as it is
+// read, so the raw bytes are never buffered. RawBody is nil.
+func (c *API) GetDailyQuotes(
+	idToken string,
+	request GetDailyQuoteRequest,
+) (*Response[GetDailyQuoteResponseBody], error) {
+	resp, err := c.GetDailyQuotesStream(context.Background(), idToken, request)
+	if err != nil {
+		return nil, err
 	}
+	return decodeStream[GetDailyQuoteResponseBody](resp)
+}
 
-	req, err := newRequestBuilder(http.MethodGet, "listed/info").
-		withAuthorizationHeader(idToken).
-		addQueryParameters(params).
-		build()
+// ListBrandStream requests listed/info like ListBrand, but returns the
+// response body unread instead of decoding it, so a large response is never
+// held in memory. RawBody is nil. The caller must close Body.
+func (c *API) ListBrandStream(
+	ctx context.Context,
+	idToken string,
+	request ListBrandRequest,
+) (*Response[io.ReadCloser], error) {
+	req, err := newListBrandRequest(ctx, idToken, request)
+	if err != nil {
+		return nil, err
+	}
+	return c.doStream(req)
+}
+
+// GetDailyQuotesStream requests prices/daily_quotes like GetDailyQuotes, but
+// returns the response body unread. A full-market date spans every listed
+// code. See ListBrandStream.
+func (c *API) GetDailyQuotesStream(
+	ctx context.Context,
+	idToken string,
+	request GetDailyQuoteRequest,
+) (*Response[io.ReadCloser], error) {
+	req, err := newGetDailyQuotesRequest(ctx, idToken, request)
+	if err != nil {
+		return nil, err
+	}
+	return c.doStream(req)
+}
+
+// GetStatementsStream requests fins/statements and returns the response body
+// unread. See ListBrandStream.
+func (c *API) GetStatementsStream(
+	ctx context.Context,
+	idToken string,
+	request GetStatementsRequest,
+) (*Response[io.ReadCloser], error) {
+	req, err := newGetStatementsRequest(ctx, idToken, request)
 	if err != nil {
 		return nil, err
 	}
+	return c.doStream(req)
+}
 
+// doStream sends req and returns the body of a successful response unread.
+// Error responses are read and returned as *ErrorResponseBody.
+func (c *API) doStream(req *http.Request) (*Response[io.ReadCloser], error) {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode < 300 {
+		return newResponse(req, resp, resp.Body, nil), nil
+	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
 			fmt.Fprintf(os.Stderr, "failed to close response body: %v\n", err)
 		}
 	}()
 
-	rawBody, err := io.ReadAll(resp.Body)
+	errBody, err := newErrorResponseBody(resp)
 	if err != nil {
 		return nil, err
 	}
+	return nil, errBody
+}
 
-	if resp.StatusCode < 300 {
-		var body ListBrandResponseBody
-		if err := json.Unmarshal(rawBody, &body); err != nil {
-			return nil, err
+// decodeStream decodes the body of a streamed response and closes it.
+func decodeStream[T any](resp *Response[io.ReadCloser]) (*Response[T], error) {
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to close response body: %v\n", err)
 		}
-		return newResponse(req, resp, body, rawBody), nil
-	}
+	}()
 
-	var errBody ErrorResponseBody
-	if err := json.Unmarshal(rawBody, &errBody); err != nil {
+	var body T
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
 		return nil, err
 	}
-	return nil, &errBody
+	return newResponse(resp.rawRequest, resp.rawResponse, body, nil), nil
 }
 
-func (c *API) GetDailyQuotes(
+func newListBrandRequest(ctx context.Context, idToken string, request ListBrandRequest) (*http.Request, error) {
+	params := url.Values{}
+	if request.Code != nil {
+		params.Add("code", *request.Code)
+	}
+	if request.Date != nil {
+		params.Add("date", request.Date.Format())
+	}
+
+	return newRequestBuilder(http.MethodGet, "listed/info").
+		withContext(ctx).
+		withAuthorizationHeader(idToken).
+		addQueryParameters(params).
+		build()
+}
+
+func newGetDailyQuotesRequest(
+	ctx context.Context,
 	idToken string,
 	request GetDailyQuoteRequest,
-) (*Response[GetDailyQuoteResponseBody], error) {
+) (*http.Request, error) {
 	params := url.Values{}
 	if request.Code != nil {
 		params.Add("code", *request.Code)
@@ -372,45 +457,38 @@ func (c *API) GetDailyQuotes(
 		params.Add("pagination_key", *request.PaginationKey)
 	}
 
-	req, err := newRequestBuilder(http.MethodGet, "prices/daily_quotes").
+	return newRequestBuilder(http.MethodGet, "prices/daily_quotes").
+		withContext(ctx).
 		withAuthorizationHeader(idToken).
 		addQueryParameters(params).
 		build()
-	if err != nil {
-		return nil, err
-	}
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+func newGetStatementsRequest(
+	ctx context.Context,
+	idToken string,
+	request GetStatementsRequest,
+) (*http.Request, error) {
+	params := url.Values{}
+	if request.Code != nil {
+		params.Add("code", *request.Code)
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			fmt.Fprintf(os.Stderr, "failed to close response body: %v\n", err)
-		}
-	}()
-
-	rawBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	if request.Date != nil {
+		params.Add("date", request.Date.Format())
 	}
-
-	if resp.StatusCode < 300 {
-		var body GetDailyQuoteResponseBody
-		if err := json.Unmarshal(rawBody, &body); err != nil {
-			return nil, err
-		}
-		return newResponse(req, resp, body, rawBody), nil
+	if request.PaginationKey != nil {
+		params.Add("pagination_key", *request.PaginationKey)
 	}
 
-	var errBody ErrorResponseBody
-	if err := json.Unmarshal(rawBody, &errBody); err != nil {
-		return nil, err
-	}
-	return nil, &errBody
+	return newRequestBuilder(http.MethodGet, "fins/statements").
+		withContext(ctx).
+		withAuthorizationHeader(idToken).
+		addQueryParameters(params).
+		build()
 }
 
 type requestBuilder struct {
+	ctx         context.Context
 	method      string
 	path        string
 	query       url.Values
@@ -421,6 +499,7 @@ type requestBuilder struct {
 
 func newRequestBuilder(method string, path string) *requestBuilder {
 	return &requestBuilder{
+		ctx:    context.Background(),
 		method: method,
 		path:   path,
 		query:  url.Values{},
@@ -428,6 +507,16 @@ func newRequestBuilder(method string, path string) *requestBuilder {
 	}
 }
 
+func (b *requestBuilder) withContext(ctx context.Context) *requestBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	b.ctx = ctx
+
+	return b
+}
+
 func (b *requestBuilder) withAuthorizationHeader(value string) *requestBuilder {
 	if b.err != nil {
 		return b
@@ -514,7 +603,7 @@ func (b *requestBuilder) makeBody() io.Reader {
 }
 
 func (b *requestBuilder) makeRequest(u *url.URL, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(b.method, u.String(), body)
+	req, err := http.NewRequestWithContext(b.ctx, b.method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -606,6 +695,48 @@ func (c *Client) GetDailyQuotes(request GetDailyQuoteRequest) (*Response[GetDail
 	})
 }
 
+// ListBrandsStream is ListBrands returning the response body unread. See
+// API.ListBrandStream.
+func (c *Client) ListBrandsStream(ctx context.Context, request ListBrandRequest) (*Response[io.ReadCloser], error) {
+	if !c.IsAuthorized() {
+		return nil, ErrNotAuthorized
+	}
+
+	return withRefreshToken(c, func() (*Response[io.ReadCloser], error) {
+		return c.api.ListBrandStream(ctx, *c.authInfo.IDToken, request)
+	})
+}
+
+// GetDailyQuotesStream is GetDailyQuotes returning the response body unread.
+// See API.ListBrandStream.
+func (c *Client) GetDailyQuotesStream(
+	ctx context.Context,
+	request GetDailyQuoteRequest,
+) (*Response[io.ReadCloser], error) {
+	if !c.IsAuthorized() {
+		return nil, ErrNotAuthorized
+	}
+
+	return withRefreshToken(c, func() (*Response[io.ReadCloser], error) {
+		return c.api.GetDailyQuotesStream(ctx, *c.authInfo.IDToken, request)
+	})
+}
+
+// GetStatementsStream returns a fins/statements response body unread. See
+// API.ListBrandStream.
+func (c *Client) GetStatementsStream(
+	ctx context.Context,
+	request GetStatementsRequest,
+) (*Response[io.ReadCloser], error) {
+	if !c.IsAuthorized() {
+		return nil, ErrNotAuthorized
+	}
+
+	return withRefreshToken(c, func() (*Response[io.ReadCloser], error) {
+		return c.api.GetStatementsStream(ctx, *c.authInfo.IDToken, request)
+	})
+}
+
 func (c *Client) IsAuthorized() bool {
 	return c.authInfo.RefreshToken != nil && c.authInfo.IDToken != nil
 }
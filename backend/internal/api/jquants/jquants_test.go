@@ -2,6 +2,7 @@ package jquants
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -193,6 +194,143 @@ func Test_API_RefreshToken_Error(t *testing.T) {
 	assert.Equal(t, errorMessage, err.Error())
 }
 
+func Test_API_ListBrandStream_Success(t *testing.T) {
+	rawBody := `{"info":[{"Code":"86970","CompanyName":"日本取引所グループ"}]}`
+	code := "86970"
+
+	rawResp := makeResponse(200, rawBody)
+
+	httpClientMock := new(httpClientMock)
+	httpClientMock.On(
+		"Do",
+		mock.MatchedBy(requestMatcher{
+			ExpectedMethod:       http.MethodGet,
+			ExpectedURL:          fmt.Sprintf("%s/listed/info?code=%s", baseUrl, code),
+			ExpectedHeader:       map[string][]string{"Authorization": {"Bearer id-token"}},
+			ExpectedBodyContents: nil,
+		}.ToFunc()),
+	).Return(rawResp, nil)
+
+	api := &API{httpClient: httpClientMock}
+
+	resp, err := api.ListBrandStream(context.Background(), "id-token", ListBrandRequest{Code: &code})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.Nil(t, resp.RawBody)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, rawBody, string(body))
+}
+
+func Test_API_ListBrandStream_Error(t *testing.T) {
+	errorMessage := "The incoming token is invalid or expired."
+
+	rawResp := makeResponse(401, fmt.Sprintf(`{"message":"%s"}`, errorMessage))
+
+	httpClientMock := new(httpClientMock)
+	httpClientMock.On("Do", mock.Anything).Return(rawResp, nil)
+
+	api := &API{httpClient: httpClientMock}
+
+	resp, err := api.ListBrandStream(context.Background(), "id-token", ListBrandRequest{})
+
+	assert.Nil(t, resp)
+	assert.NotNil(t, err)
+	assert.Equal(t, errorMessage, err.Error())
+}
+
+type contextKey struct{}
+
+func Test_API_Stream_Requests(t *testing.T) {
+	date := Date{Year: 2025, Month: 6, Day: 2}
+	tests := []struct {
+		name        string
+		call        func(ctx context.Context, api *API) (*Response[io.ReadCloser], error)
+		expectedURL string
+	}{
+		{
+			name: "full-market daily quotes",
+			call: func(ctx context.Context, api *API) (*Response[io.ReadCloser], error) {
+				return api.GetDailyQuotesStream(ctx, "id-token", NewGetDailyQuoteRequestByDate(date))
+			},
+			expectedURL: fmt.Sprintf("%s/prices/daily_quotes?date=2025-06-02", baseUrl),
+		},
+		{
+			name: "statements by date",
+			call: func(ctx context.Context, api *API) (*Response[io.ReadCloser], error) {
+				return api.GetStatementsStream(ctx, "id-token", GetStatementsRequest{Date: &date})
+			},
+			expectedURL: fmt.Sprintf("%s/fins/statements?date=2025-06-02", baseUrl),
+		},
+		{
+			name: "brands",
+			call: func(ctx context.Context, api *API) (*Response[io.ReadCloser], error) {
+				return api.ListBrandStream(ctx, "id-token", ListBrandRequest{Date: &date})
+			},
+			expectedURL: fmt.Sprintf("%s/listed/info?date=2025-06-02", baseUrl),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), contextKey{}, tt.name)
+			matcher := requestMatcher{
+				ExpectedMethod: http.MethodGet,
+				ExpectedURL:    tt.expectedURL,
+				ExpectedHeader: map[string][]string{"Authorization": {"Bearer id-token"}},
+			}
+
+			httpClientMock := new(httpClientMock)
+			httpClientMock.On("Do", mock.MatchedBy(func(r *http.Request) bool {
+				return matcher.Matches(r) && r.Context().Value(contextKey{}) == tt.name
+			})).Return(makeResponse(200, `{}`), nil)
+
+			resp, err := tt.call(ctx, &API{httpClient: httpClientMock})
+
+			assert.Nil(t, err)
+			assert.Equal(t, 200, resp.StatusCode())
+			assert.Nil(t, resp.RawBody)
+			assert.Nil(t, resp.Body.Close())
+		})
+	}
+}
+
+func Test_API_GetDailyQuotes_Success(t *testing.T) {
+	rawBody := `{"daily_quotes":[{"Date":"2025-06-02","Code":"86970","Close":3000.5,"AdjustmentFactor":1}],` +
+		`"pagination_key":"next"}`
+
+	httpClientMock := new(httpClientMock)
+	httpClientMock.On("Do", mock.Anything).Return(makeResponse(200, rawBody), nil)
+
+	api := &API{httpClient: httpClientMock}
+
+	resp, err := api.GetDailyQuotes("id-token", NewGetDailyQuoteRequestByCode("86970"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode())
+	assert.Nil(t, resp.RawBody)
+	assert.Len(t, resp.Body.DailyQuotes, 1)
+	assert.Equal(t, "86970", resp.Body.DailyQuotes[0].Code)
+	assert.Equal(t, "3000.5", resp.Body.DailyQuotes[0].Close.Decimal.String())
+	assert.Equal(t, toStringPointer("next"), resp.Body.PaginationKey)
+}
+
+func Test_API_ListBrand_Error(t *testing.T) {
+	errorMessage := "The incoming token is invalid or expired."
+
+	httpClientMock := new(httpClientMock)
+	httpClientMock.On("Do", mock.Anything).Return(makeResponse(401, fmt.Sprintf(`{"message":"%s"}`, errorMessage)), nil)
+
+	api := &API{httpClient: httpClientMock}
+
+	resp, err := api.ListBrand("id-token", ListBrandRequest{})
+
+	assert.Nil(t, resp)
+	assert.NotNil(t, err)
+	assert.Equal(t, errorMessage, err.Error())
+}
+
 func makeResponse(statusCode int, bodyContents string) *http.Response {
 	respBody := io.NopCloser(bytes.NewReader([]byte(bodyContents)))
 
@@ -210,7 +348,7 @@ type requestMatcher struct {
 }
 
 func (m requestMatcher) Matches(request *http.Request) bool {
-	if request.Method != http.MethodPost {
+	if request.Method != m.ExpectedMethod {
 		return false
 	}
 
@@ -0,0 +1,175 @@
+// Package audit records who changed the ingestion configuration and how.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/idp"
+
+	"github.com/google/uuid"
+)
+
+// Action is the kind of change an Event records.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// EntityType is the kind of entity an Event is about.
+type EntityType string
+
+const (
+	EntityTypeDataSource EntityType = "data_source"
+	EntityTypeDataType   EntityType = "data_type"
+)
+
+// EntityTypes lists every audited entity type.
+var EntityTypes = []EntityType{EntityTypeDataSource, EntityTypeDataType}
+
+// SystemActor is recorded for changes made without an actor in the context,
+// such as those from maintenance commands.
+const SystemActor = "system"
+
+type ctxKey struct{}
+
+// WithActor returns a child context whose changes are attributed to actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, or SystemActor.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(ctxKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
+
+// Event is one recorded change. Before and After are JSON objects of entity
+// fields: a create has only After, a delete only Before, and an update holds
+// just the fields whose values changed.
+type Event struct {
+	id         uuid.UUID
+	occurredAt time.Time
+	actor      string
+	action     Action
+	entityType EntityType
+	entityID   uuid.UUID
+	before     map[string]any
+	after      map[string]any
+}
+
+// NewEvent records a change to an entity by the actor in ctx. before and
+// after are snapshots of the entity, nil for a create or delete
+// respectively; for an update, fields equal in both are dropped.
+func NewEvent(
+	ctx context.Context,
+	action Action,
+	entityType EntityType,
+	entityID uuid.UUID,
+	before map[string]any,
+	after map[string]any,
+) (*Event, error) {
+	before, err := normalize(before)
+	if err != nil {
+		return nil, fmt.Errorf("invalid before snapshot: %w", err)
+	}
+	after, err = normalize(after)
+	if err != nil {
+		return nil, fmt.Errorf("invalid after snapshot: %w", err)
+	}
+	if before != nil && after != nil {
+		before, after = diff(before, after)
+	}
+	return &Event{
+		id:         idp.NewV7(ctx),
+		occurredAt: clock.Now(ctx),
+		actor:      ActorFromContext(ctx),
+		action:     action,
+		entityType: entityType,
+		entityID:   entityID,
+		before:     before,
+		after:      after,
+	}, nil
+}
+
+func NewEventDirectly(
+	id uuid.UUID,
+	occurredAt time.Time,
+	actor string,
+	action Action,
+	entityType EntityType,
+	entityID uuid.UUID,
+	before map[string]any,
+	after map[string]any,
+) *Event {
+	return &Event{
+		id:         id,
+		occurredAt: occurredAt,
+		actor:      actor,
+		action:     action,
+		entityType: entityType,
+		entityID:   entityID,
+		before:     before,
+		after:      after,
+	}
+}
+
+func (e *Event) ID() uuid.UUID          { return e.id }
+func (e *Event) OccurredAt() time.Time  { return e.occurredAt }
+func (e *Event) Actor() string          { return e.actor }
+func (e *Event) Action() Action         { return e.action }
+func (e *Event) EntityType() EntityType { return e.entityType }
+func (e *Event) EntityID() uuid.UUID    { return e.entityID }
+func (e *Event) Before() map[string]any { return e.before }
+func (e *Event) After() map[string]any  { return e.after }
+
+// EventFilter narrows an Event listing. Zero-value fields match everything;
+// Since is inclusive and Until exclusive.
+type EventFilter struct {
+	EntityType *EntityType
+	EntityID   *uuid.UUID
+	Since      *time.Time
+	Until      *time.Time
+}
+
+// normalize round-trips a snapshot through JSON so values compare the same
+// whether they came from the database or from memory.
+func normalize(snapshot map[string]any) (map[string]any, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// diff returns the fields of before and after whose values differ.
+func diff(before, after map[string]any) (map[string]any, map[string]any) {
+	changedBefore, changedAfter := map[string]any{}, map[string]any{}
+	for k, v := range before {
+		if w, ok := after[k]; !ok || !reflect.DeepEqual(v, w) {
+			changedBefore[k] = v
+		}
+	}
+	for k, w := range after {
+		if v, ok := before[k]; !ok || !reflect.DeepEqual(v, w) {
+			changedAfter[k] = w
+		}
+	}
+	return changedBefore, changedAfter
+}
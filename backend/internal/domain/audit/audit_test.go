@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/idp"
+)
+
+type EventTestSuite struct {
+	suite.Suite
+}
+
+func TestEvent(t *testing.T) {
+	suite.Run(t, new(EventTestSuite))
+}
+
+func (s *EventTestSuite) TestNewEvent() {
+	type testCase struct {
+		name           string
+		action         Action
+		before         map[string]any
+		after          map[string]any
+		expectedBefore map[string]any
+		expectedAfter  map[string]any
+	}
+	tests := []testCase{
+		{
+			name:          "create keeps the full snapshot",
+			action:        ActionCreate,
+			after:         map[string]any{"name": "brand", "stale_timeout_minutes": 30},
+			expectedAfter: map[string]any{"name": "brand", "stale_timeout_minutes": 30.0},
+		},
+		{
+			name:           "delete keeps the full snapshot",
+			action:         ActionDelete,
+			before:         map[string]any{"name": "brand"},
+			expectedBefore: map[string]any{"name": "brand"},
+		},
+		{
+			name:   "update keeps changed fields only",
+			action: ActionUpdate,
+			before: map[string]any{
+				"enabled":  true,
+				"schedule": map[string]any{"type": "daily", "times": []any{"18:00"}},
+				"settings": map[string]any{"limit": 1.0},
+			},
+			after: map[string]any{
+				"enabled":  false,
+				"schedule": map[string]any{"type": "daily", "times": []string{"18:00", "20:00"}},
+				"settings": map[string]any{"limit": 1},
+			},
+			expectedBefore: map[string]any{
+				"enabled":  true,
+				"schedule": map[string]any{"type": "daily", "times": []any{"18:00"}},
+			},
+			expectedAfter: map[string]any{
+				"enabled":  false,
+				"schedule": map[string]any{"type": "daily", "times": []any{"18:00", "20:00"}},
+			},
+		},
+		{
+			name:           "update without changes",
+			action:         ActionUpdate,
+			before:         map[string]any{"enabled": true},
+			after:          map[string]any{"enabled": true},
+			expectedBefore: map[string]any{},
+			expectedAfter:  map[string]any{},
+		},
+	}
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	id := uuid.MustParse("01961a3d-0000-7000-8000-000000000001")
+	entityID := uuid.MustParse("01961a3d-0000-7000-8000-000000000002")
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			ctx := idp.WithFixedID(clock.WithFixedTime(context.Background(), now), id)
+			ctx = WithActor(ctx, "ci")
+
+			event, err := NewEvent(ctx, tc.action, EntityTypeDataType, entityID, tc.before, tc.after)
+			s.Require().NoError(err)
+
+			expected := NewEventDirectly(
+				id, now, "ci", tc.action, EntityTypeDataType, entityID, tc.expectedBefore, tc.expectedAfter,
+			)
+			s.True(
+				cmp.Equal(expected, event, cmp.AllowUnexported(Event{})),
+				cmp.Diff(expected, event, cmp.AllowUnexported(Event{})),
+			)
+		})
+	}
+}
+
+func (s *EventTestSuite) TestActorFromContext() {
+	s.Equal(SystemActor, ActorFromContext(context.Background()))
+	s.Equal(SystemActor, ActorFromContext(WithActor(context.Background(), "")))
+	s.Equal("ci", ActorFromContext(WithActor(context.Background(), "ci")))
+}
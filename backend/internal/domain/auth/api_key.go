@@ -0,0 +1,163 @@
+// Package auth holds the API keys that authenticate callers of the
+// management API and the roles that authorize them.
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/idp"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidRole is returned for a role that is not one of Roles.
+var ErrInvalidRole = errors.New("invalid role")
+
+// Role decides which operations an API key may call.
+type Role string
+
+const (
+	// RoleReadOnly may call operations that only read configuration.
+	RoleReadOnly Role = "read_only"
+	// RoleAdmin may call every operation.
+	RoleAdmin Role = "admin"
+)
+
+// Roles lists every valid role.
+var Roles = []Role{RoleReadOnly, RoleAdmin}
+
+// Scopes are the values OpenAPI security requirements of the API use.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+var roleScopes = map[Role][]string{
+	RoleReadOnly: {ScopeRead},
+	RoleAdmin:    {ScopeRead, ScopeWrite},
+}
+
+// ParseRole returns the role named s, or an error wrapping ErrInvalidRole.
+func ParseRole(s string) (Role, error) {
+	if r := Role(s); slices.Contains(Roles, r) {
+		return r, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidRole, s)
+}
+
+// Grants reports whether the role includes every scope in scopes.
+func (r Role) Grants(scopes []string) bool {
+	granted := roleScopes[r]
+	for _, s := range scopes {
+		if !slices.Contains(granted, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyPrefix marks plaintext keys so they are recognizable in logs and secret scanners.
+const keyPrefix = "stk_"
+
+// keyBytes is the entropy of a generated key.
+const keyBytes = 32
+
+// APIKey is an issued credential. Only the SHA-256 hash of the plaintext key
+// is kept; the plaintext is shown once when the key is issued. A revoked key
+// stays stored so it can still be listed, but no longer authenticates.
+type APIKey struct {
+	id        uuid.UUID
+	name      string
+	role      Role
+	keyHash   string
+	createdAt time.Time
+	revokedAt *time.Time
+}
+
+// NewAPIKey generates a key with a random secret and returns it together with
+// the plaintext, which the caller must hand to the key's owner.
+func NewAPIKey(ctx context.Context, name string, role Role) (*APIKey, string, error) {
+	if !slices.Contains(Roles, role) {
+		return nil, "", fmt.Errorf("%w: %q", ErrInvalidRole, role)
+	}
+	secret := make([]byte, keyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	plaintext := keyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return &APIKey{
+		id:        idp.NewV7(ctx),
+		name:      name,
+		role:      role,
+		keyHash:   HashKey(plaintext),
+		createdAt: clock.Now(ctx),
+	}, plaintext, nil
+}
+
+func NewAPIKeyDirectly(
+	id uuid.UUID,
+	name string,
+	role Role,
+	keyHash string,
+	createdAt time.Time,
+	revokedAt *time.Time,
+) *APIKey {
+	return &APIKey{
+		id:        id,
+		name:      name,
+		role:      role,
+		keyHash:   keyHash,
+		createdAt: createdAt,
+		revokedAt: revokedAt,
+	}
+}
+
+// HashKey returns the hex-encoded SHA-256 hash under which a plaintext key is
+// stored. Keys carry 256 bits of entropy, so a fast hash is sufficient.
+func HashKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(plaintext)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Revoke marks the key as revoked as of now. Revoking twice keeps the first time.
+func (k *APIKey) Revoke(ctx context.Context) {
+	if k.revokedAt != nil {
+		return
+	}
+	now := clock.Now(ctx)
+	k.revokedAt = &now
+}
+
+func (k *APIKey) ID() uuid.UUID         { return k.id }
+func (k *APIKey) Name() string          { return k.name }
+func (k *APIKey) Role() Role            { return k.role }
+func (k *APIKey) KeyHash() string       { return k.keyHash }
+func (k *APIKey) CreatedAt() time.Time  { return k.createdAt }
+func (k *APIKey) RevokedAt() *time.Time { return k.revokedAt }
+func (k *APIKey) Revoked() bool         { return k.revokedAt != nil }
+
+// Actor identifies the key in audit records as "api_key:<name>/<id>".
+func (k *APIKey) Actor() string { return "api_key:" + k.name + "/" + k.id.String() }
+
+type ctxKey struct{}
+
+// WithAPIKey returns a child context carrying the key that authenticated the request.
+func WithAPIKey(ctx context.Context, key *APIKey) context.Context {
+	return context.WithValue(ctx, ctxKey{}, key)
+}
+
+// APIKeyFromContext returns the key stored by WithAPIKey, or nil.
+func APIKeyFromContext(ctx context.Context) *APIKey {
+	key, _ := ctx.Value(ctxKey{}).(*APIKey)
+	return key
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/idp"
+)
+
+type APIKeyTestSuite struct {
+	suite.Suite
+}
+
+func TestAPIKey(t *testing.T) {
+	suite.Run(t, new(APIKeyTestSuite))
+}
+
+func (s *APIKeyTestSuite) TestNewAPIKey() {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	id := uuid.MustParse("01961a3d-0000-7000-8000-000000000001")
+	ctx := idp.WithFixedID(clock.WithFixedTime(context.Background(), now), id)
+
+	key, plaintext, err := NewAPIKey(ctx, "ci", RoleAdmin)
+	s.Require().NoError(err)
+
+	s.True(strings.HasPrefix(plaintext, "stk_"))
+	s.Equal(id, key.ID())
+	s.Equal("ci", key.Name())
+	s.Equal(RoleAdmin, key.Role())
+	s.Equal(HashKey(plaintext), key.KeyHash())
+	s.NotContains(key.KeyHash(), plaintext)
+	s.Equal(now, key.CreatedAt())
+	s.False(key.Revoked())
+
+	_, other, err := NewAPIKey(ctx, "ci", RoleAdmin)
+	s.Require().NoError(err)
+	s.NotEqual(plaintext, other)
+}
+
+func (s *APIKeyTestSuite) TestNewAPIKey_InvalidRole() {
+	_, _, err := NewAPIKey(context.Background(), "ci", Role("owner"))
+	s.ErrorIs(err, ErrInvalidRole)
+}
+
+func (s *APIKeyTestSuite) TestRevoke() {
+	first := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	key := NewAPIKeyDirectly(uuid.Nil, "ci", RoleReadOnly, "hash", first.Add(-time.Hour), nil)
+
+	key.Revoke(clock.WithFixedTime(context.Background(), first))
+	key.Revoke(clock.WithFixedTime(context.Background(), first.Add(time.Hour)))
+
+	s.True(key.Revoked())
+	s.Equal(first, *key.RevokedAt())
+}
+
+func (s *APIKeyTestSuite) TestRoleGrants() {
+	type testCase struct {
+		name     string
+		role     Role
+		scopes   []string
+		expected bool
+	}
+	tests := []testCase{
+		{name: "read-only reads", role: RoleReadOnly, scopes: []string{ScopeRead}, expected: true},
+		{name: "read-only writes", role: RoleReadOnly, scopes: []string{ScopeWrite}, expected: false},
+		{name: "admin writes", role: RoleAdmin, scopes: []string{ScopeWrite}, expected: true},
+		{name: "no scopes", role: RoleReadOnly, scopes: nil, expected: true},
+		{name: "unknown role", role: Role("owner"), scopes: []string{ScopeRead}, expected: false},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, tc.role.Grants(tc.scopes))
+		})
+	}
+}
+
+func (s *APIKeyTestSuite) TestParseRole() {
+	role, err := ParseRole("read_only")
+	s.Require().NoError(err)
+	s.Equal(RoleReadOnly, role)
+
+	_, err = ParseRole("root")
+	s.ErrorIs(err, ErrInvalidRole)
+}
+
+func (s *APIKeyTestSuite) TestContext() {
+	s.Nil(APIKeyFromContext(context.Background()))
+
+	key := NewAPIKeyDirectly(uuid.Nil, "ci", RoleAdmin, "hash", time.Time{}, nil)
+	s.Same(key, APIKeyFromContext(WithAPIKey(context.Background(), key)))
+}
@@ -0,0 +1,118 @@
+package bronze
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"stock-tool/internal/domain/extract"
+)
+
+// ManifestVersion is the version of the manifest layout written by this
+// code. Readers should reject manifests of a newer version.
+const ManifestVersion = 1
+
+// ManifestKeyPrefix is the key prefix every bronze manifest is stored under.
+// It is outside every table prefix, so a glob over a table reads only Parquet.
+const ManifestKeyPrefix = "bronze/_manifests/"
+
+// TableName returns the name of the bronze table of a landing data type.
+func TableName(source string, dataType string) string {
+	return source + "_" + dataType
+}
+
+// GeneratePartitionKey generates the object key of the Parquet file of one
+// target date of a table:
+// bronze/{source}_{data_type}/date={yyyy-mm-dd}/data.parquet
+// The date is the calendar date of date in its own location, so it is the
+// business date in the source timezone. The Hive-style directory lets
+// readers prune partitions by date.
+func GeneratePartitionKey(source string, dataType string, date time.Time) string {
+	return fmt.Sprintf("bronze/%s/date=%s/data.parquet", TableName(source, dataType), date.Format("2006-01-02"))
+}
+
+// GenerateManifestKey generates the object key of the manifest of the
+// partition generated by GeneratePartitionKey:
+// bronze/_manifests/{source}_{data_type}/date={yyyy-mm-dd}.json
+func GenerateManifestKey(source string, dataType string, date time.Time) string {
+	return fmt.Sprintf("%s%s/date=%s.json", ManifestKeyPrefix, TableName(source, dataType), date.Format("2006-01-02"))
+}
+
+// Input is a landing file a partition was converted from.
+type Input struct {
+	Key       string `json:"key"`
+	VersionID string `json:"version_id,omitempty"`
+	// SHA256 is the digest of the file as fetched, or empty if the landing
+	// record has no metadata.
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// NewInput returns the input of a recorded landing file.
+func NewInput(file *extract.ExtractedDataS3) Input {
+	input := Input{Key: file.Key(), VersionID: file.Version().ID}
+	if md := file.Metadata(); md != nil {
+		input.SHA256 = md.SHA256
+	}
+	return input
+}
+
+// Manifest records which landing files a partition was converted from. It is
+// stored as JSON under the key from GenerateManifestKey, after the partition
+// is written.
+type Manifest struct {
+	ManifestVersion int    `json:"manifest_version"`
+	Source          string `json:"source"`
+	DataType        string `json:"data_type"`
+	// Date is the target date of the partition, formatted as YYYY-MM-DD.
+	Date        string    `json:"date"`
+	OutputKey   string    `json:"output_key"`
+	Rows        int       `json:"rows"`
+	ConvertedAt time.Time `json:"converted_at"`
+	Inputs      []Input   `json:"inputs"`
+}
+
+// NewManifest returns the manifest of the partition of date converted from
+// inputs at convertedAt.
+func NewManifest(
+	source string,
+	dataType string,
+	date time.Time,
+	rows int,
+	inputs []Input,
+	convertedAt time.Time,
+) *Manifest {
+	return &Manifest{
+		ManifestVersion: ManifestVersion,
+		Source:          source,
+		DataType:        dataType,
+		Date:            date.Format("2006-01-02"),
+		OutputKey:       GeneratePartitionKey(source, dataType, date),
+		Rows:            rows,
+		ConvertedAt:     convertedAt,
+		Inputs:          inputs,
+	}
+}
+
+// Consumed reports whether the partition was converted from exactly inputs,
+// in which case converting again would write the same rows.
+func (m *Manifest) Consumed(inputs []Input) bool {
+	return slices.Equal(m.Inputs, inputs)
+}
+
+// Marshal returns the manifest as indented JSON.
+func (m *Manifest) Marshal() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
+
+// ParseManifest parses a manifest written by Manifest.Marshal.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid bronze manifest: %w", err)
+	}
+	if m.ManifestVersion < 1 || m.ManifestVersion > ManifestVersion {
+		return nil, fmt.Errorf("unsupported bronze manifest version: %d", m.ManifestVersion)
+	}
+	return &m, nil
+}
@@ -0,0 +1,78 @@
+package bronze
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/extract"
+)
+
+type BronzeTestSuite struct {
+	suite.Suite
+}
+
+func TestBronze(t *testing.T) {
+	suite.Run(t, new(BronzeTestSuite))
+}
+
+func (s *BronzeTestSuite) TestKeys() {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	// Midnight JST is the previous day in UTC; the key keeps the business date
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, jst)
+
+	s.Equal("jquants_daily_quotes", TableName("jquants", "daily_quotes"))
+	s.Equal(
+		"bronze/jquants_daily_quotes/date=2025-06-02/data.parquet",
+		GeneratePartitionKey("jquants", "daily_quotes", date),
+	)
+	s.Equal(
+		"bronze/_manifests/jquants_daily_quotes/date=2025-06-02.json",
+		GenerateManifestKey("jquants", "daily_quotes", date),
+	)
+}
+
+func (s *BronzeTestSuite) TestNewInput() {
+	md := extract.NewFileMetadata([]byte(`{"info":[]}`), extract.FormatJSON, 200)
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	file := extract.NewExtractedDataS3Directly(
+		1, "landing/jquants/listed_info/2025/06/02/all.json", "all", &md, extract.ObjectVersion{ID: "v1"}, "", nil,
+		now, now,
+	)
+	s.Equal(Input{Key: file.Key(), VersionID: "v1", SHA256: md.SHA256}, NewInput(file))
+
+	// Files recorded before tracking have no digest.
+	legacy := extract.NewExtractedDataS3Directly(
+		2, "landing/jquants/listed_info/2025/06/02/all.json", "all", nil, extract.ObjectVersion{}, "", nil, now, now,
+	)
+	s.Equal(Input{Key: legacy.Key()}, NewInput(legacy))
+}
+
+func (s *BronzeTestSuite) TestManifest() {
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	inputs := []Input{{Key: "landing/jquants/listed_info/2025/06/02/all.json", SHA256: "abc"}}
+	manifest := NewManifest("jquants", "listed_info", date, 3, inputs, date.Add(time.Hour))
+
+	data, err := manifest.Marshal()
+	s.Require().NoError(err)
+	s.JSONEq(`{
+		"manifest_version": 1,
+		"source": "jquants",
+		"data_type": "listed_info",
+		"date": "2025-06-02",
+		"output_key": "bronze/jquants_listed_info/date=2025-06-02/data.parquet",
+		"rows": 3,
+		"converted_at": "2025-06-02T01:00:00Z",
+		"inputs": [{"key": "landing/jquants/listed_info/2025/06/02/all.json", "sha256": "abc"}]
+	}`, string(data))
+
+	parsed, err := ParseManifest(data)
+	s.Require().NoError(err)
+	s.Equal(manifest, parsed)
+	s.True(parsed.Consumed(inputs))
+	s.False(parsed.Consumed([]Input{{Key: inputs[0].Key, SHA256: "def"}}))
+
+	_, err = ParseManifest([]byte(`{"manifest_version": 2}`))
+	s.EqualError(err, "unsupported bronze manifest version: 2")
+}
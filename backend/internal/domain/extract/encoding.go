@@ -0,0 +1,114 @@
+package extract
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Encoding is the compression of a landing file as stored, named as in the
+// HTTP Content-Encoding header. The zero value stores the file as fetched.
+type Encoding string
+
+const (
+	EncodingNone Encoding = ""
+	EncodingGzip Encoding = "gzip"
+	EncodingZstd Encoding = "zstd"
+)
+
+// Extension returns what the encoding appends to the format extension of a
+// landing key, e.g. ".gz" for brand.json.gz.
+func (e Encoding) Extension() string {
+	switch e {
+	case EncodingGzip:
+		return ".gz"
+	case EncodingZstd:
+		return ".zst"
+	default:
+		return ""
+	}
+}
+
+// NewWriter returns a writer that compresses what is written to it into w.
+// Closing it flushes the compressed stream but does not close w.
+func (e Encoding) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	switch e {
+	case EncodingGzip:
+		return gzip.NewWriter(w), nil
+	case EncodingZstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", e)
+	}
+}
+
+// NewReader returns a reader that decompresses r. EncodingNone returns r
+// unchanged. Closing it does not close r.
+func (e Encoding) NewReader(r io.Reader) (io.ReadCloser, error) {
+	switch e {
+	case EncodingNone:
+		return io.NopCloser(r), nil
+	case EncodingGzip:
+		return gzip.NewReader(r)
+	case EncodingZstd:
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", e)
+	}
+}
+
+// Compress returns data compressed with the encoding.
+func (e Encoding) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := e.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CompressStream returns a reader of r compressed with the encoding. r is
+// read as the result is, so neither is held in memory. Closing the result
+// before EOF stops reading r and waits until r is no longer read.
+func (e Encoding) CompressStream(r io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w, err := e.NewWriter(pw)
+		if err == nil {
+			_, err = io.Copy(w, r)
+			if closeErr := w.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		pw.CloseWithError(err)
+	}()
+	return &compressReader{PipeReader: pr, done: done}
+}
+
+// compressReader is the result of CompressStream.
+type compressReader struct {
+	*io.PipeReader
+	done chan struct{}
+}
+
+// Close stops the compression and waits for it to return.
+func (c *compressReader) Close() error {
+	err := c.PipeReader.Close()
+	<-c.done
+	return err
+}
@@ -2,7 +2,10 @@ package extract
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"stock-tool/internal/util/clock"
@@ -10,6 +13,18 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrExecutionAlreadyRunning is returned when an execution for the same
+// task and target date is already in progress.
+var ErrExecutionAlreadyRunning = errors.New("execution already running for target date")
+
+// ErrDependenciesNotMet is returned when a data type the extracted data type
+// depends on has no succeeded execution for the target date yet.
+var ErrDependenciesNotMet = errors.New("dependencies not met for target date")
+
+// ErrEmptyResponse is returned when an extraction fails because the response
+// holds no records and the data type's empty response policy rejects it.
+var ErrEmptyResponse = errors.New("response holds no records")
+
 type ExecutionStatus string
 
 const (
@@ -18,6 +33,23 @@ const (
 	ExecutionStatusFailed    ExecutionStatus = "failed"
 )
 
+// ErrorCategory classifies why an execution failed, for failures that need
+// to be told apart from errors. Uncategorized failures have no category.
+type ErrorCategory string
+
+const (
+	// ErrorCategoryEmptyResponse is an empty response rejected by the
+	// data type's empty response policy.
+	ErrorCategoryEmptyResponse ErrorCategory = "empty_response"
+	// ErrorCategoryDeferred is an empty response taken to mean the data is
+	// not published yet. The target date is left for a later run.
+	ErrorCategoryDeferred ErrorCategory = "deferred"
+	// ErrorCategoryOutOfPlan is a target date the subscription plan of the
+	// source cannot fetch. Retrying does not help until the plan changes or
+	// the date enters the plan range.
+	ErrorCategoryOutOfPlan ErrorCategory = "out_of_plan"
+)
+
 // ExtractTask defines what to extract from a source: the combination of
 // source, data type, and timing that identifies a repeatable extraction job.
 type ExtractTask struct {
@@ -97,12 +129,14 @@ func (t *ExtractTask) Executions() []*ExtractTaskExecution {
 
 // ExtractTaskExecution tracks a single run of data extraction.
 // Status transitions: running -> succeeded (via Succeed) or
-// running -> failed (via Fail). Terminal status must not change.
+// running -> failed (via Fail or FailWithCategory). Terminal status must
+// not change.
 type ExtractTaskExecution struct {
 	id             int
 	targetDateTime time.Time
 	status         ExecutionStatus
 	errorInfo      *string
+	errorCategory  *ErrorCategory
 	startedAt      *time.Time
 	finishedAt     *time.Time
 	createdAt      time.Time
@@ -117,6 +151,7 @@ func NewRunningExecution(ctx context.Context, targetDateTime time.Time) *Extract
 		targetDateTime: targetDateTime,
 		status:         ExecutionStatusRunning,
 		errorInfo:      nil,
+		errorCategory:  nil,
 		startedAt:      &now,
 		finishedAt:     nil,
 		createdAt:      now,
@@ -125,11 +160,32 @@ func NewRunningExecution(ctx context.Context, targetDateTime time.Time) *Extract
 	}
 }
 
+// NewReindexedExecution returns a succeeded execution rebuilt from what it
+// landed, for an execution whose record was lost.
+func NewReindexedExecution(
+	ctx context.Context,
+	targetDateTime time.Time,
+	startedAt time.Time,
+	finishedAt time.Time,
+) *ExtractTaskExecution {
+	now := clock.Now(ctx)
+	return &ExtractTaskExecution{
+		targetDateTime: targetDateTime,
+		status:         ExecutionStatusSucceeded,
+		startedAt:      &startedAt,
+		finishedAt:     &finishedAt,
+		createdAt:      now,
+		updatedAt:      now,
+		s3Files:        []*ExtractedDataS3{},
+	}
+}
+
 func NewExtractTaskExecutionDirectly(
 	id int,
 	targetDateTime time.Time,
 	status ExecutionStatus,
 	errorInfo *string,
+	errorCategory *ErrorCategory,
 	startedAt *time.Time,
 	finishedAt *time.Time,
 	createdAt time.Time,
@@ -141,6 +197,7 @@ func NewExtractTaskExecutionDirectly(
 		targetDateTime: targetDateTime,
 		status:         status,
 		errorInfo:      errorInfo,
+		errorCategory:  errorCategory,
 		startedAt:      startedAt,
 		finishedAt:     finishedAt,
 		createdAt:      createdAt,
@@ -164,6 +221,22 @@ func (t *ExtractTaskExecution) Fail(ctx context.Context, errorInfo string) {
 	t.updatedAt = now
 }
 
+// FailWithCategory is Fail for a failure of a known category.
+func (t *ExtractTaskExecution) FailWithCategory(ctx context.Context, category ErrorCategory, errorInfo string) {
+	t.Fail(ctx, errorInfo)
+	t.errorCategory = &category
+}
+
+// IsStale reports whether the execution is still running once timeout has
+// passed since it started, as of now. A non-positive timeout never makes an
+// execution stale.
+func (t *ExtractTaskExecution) IsStale(now time.Time, timeout time.Duration) bool {
+	if t.status != ExecutionStatusRunning || t.startedAt == nil || timeout <= 0 {
+		return false
+	}
+	return !now.Before(t.startedAt.Add(timeout))
+}
+
 func (t *ExtractTaskExecution) AddS3File(file *ExtractedDataS3) {
 	t.s3Files = append(t.s3Files, file)
 }
@@ -184,6 +257,10 @@ func (t *ExtractTaskExecution) ErrorInfo() *string {
 	return t.errorInfo
 }
 
+func (t *ExtractTaskExecution) ErrorCategory() *ErrorCategory {
+	return t.errorCategory
+}
+
 func (t *ExtractTaskExecution) StartedAt() *time.Time {
 	return t.startedAt
 }
@@ -204,34 +281,75 @@ func (t *ExtractTaskExecution) S3Files() []*ExtractedDataS3 {
 	return t.s3Files
 }
 
-// ExtractedDataS3 records the S3 object key of data produced by an extraction run.
-type ExtractedDataS3 struct {
-	id        int
-	key       string
-	createdAt time.Time
-	updatedAt time.Time
+// ObjectVersion holds the S3 version IDs involved in writing a file. Both
+// are empty when the bucket is not versioned.
+type ObjectVersion struct {
+	// ID is the version of the object the file was written as.
+	ID string
+	// SupersededID is the version of the object the write replaced under the
+	// same key, or empty when there was none.
+	SupersededID string
 }
 
-func NewExtractedDataS3(ctx context.Context, key string) *ExtractedDataS3 {
+// ExtractedDataS3 records the S3 object key of data produced by an extraction
+// run, together with the metadata needed to check the object's integrity.
+// Window is the window of the target date the file holds, as named by
+// FileWindow; the current files of a date are the newest of each window.
+// A record whose object was removed under a retention policy is kept and
+// marked purged via Purge.
+type ExtractedDataS3 struct {
+	id          int
+	key         string
+	window      string
+	metadata    *FileMetadata
+	version     ObjectVersion
+	manifestKey string
+	purgedAt    *time.Time
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func NewExtractedDataS3(
+	ctx context.Context,
+	key string,
+	window string,
+	metadata FileMetadata,
+	version ObjectVersion,
+	manifestKey string,
+) *ExtractedDataS3 {
 	now := clock.Now(ctx)
 	return &ExtractedDataS3{
-		key:       key,
-		createdAt: now,
-		updatedAt: now,
+		key:         key,
+		window:      window,
+		metadata:    &metadata,
+		version:     version,
+		manifestKey: manifestKey,
+		createdAt:   now,
+		updatedAt:   now,
 	}
 }
 
 func NewExtractedDataS3Directly(
 	id int,
 	key string,
+	window string,
+	metadata *FileMetadata,
+	version ObjectVersion,
+	manifestKey string,
+	purgedAt *time.Time,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) *ExtractedDataS3 {
 	return &ExtractedDataS3{
-		id:        id,
-		key:       key,
-		createdAt: createdAt,
-		updatedAt: updatedAt,
+		id:          id,
+		key:         key,
+		window:      window,
+		metadata:    metadata,
+		version:     version,
+		manifestKey: manifestKey,
+		purgedAt:    purgedAt,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
 	}
 }
 
@@ -243,6 +361,40 @@ func (s *ExtractedDataS3) Key() string {
 	return s.key
 }
 
+func (s *ExtractedDataS3) Window() string {
+	return s.window
+}
+
+// Metadata returns the file metadata, or nil for files recorded before
+// metadata was tracked.
+func (s *ExtractedDataS3) Metadata() *FileMetadata {
+	return s.metadata
+}
+
+func (s *ExtractedDataS3) Version() ObjectVersion {
+	return s.version
+}
+
+// ManifestKey returns the key of the manifest of the execution that wrote
+// the file, or an empty string for files recorded before manifests were
+// written.
+func (s *ExtractedDataS3) ManifestKey() string {
+	return s.manifestKey
+}
+
+// PurgedAt returns when the object of the file was removed, or nil while it
+// is kept.
+func (s *ExtractedDataS3) PurgedAt() *time.Time {
+	return s.purgedAt
+}
+
+// Purge marks the file as removed from object storage.
+func (s *ExtractedDataS3) Purge(ctx context.Context) {
+	now := clock.Now(ctx)
+	s.purgedAt = &now
+	s.updatedAt = now
+}
+
 func (s *ExtractedDataS3) CreatedAt() time.Time {
 	return s.createdAt
 }
@@ -270,3 +422,114 @@ func GenerateS3Key(source string, dataType string, executionTime time.Time, ext
 		ext,
 	)
 }
+
+// GenerateOverwriteS3Key generates the deterministic S3 object key that every
+// run of the same (source, data type, target date, window) writes to under
+// the overwrite re-run strategy:
+// landing/{source}/{data_type}/{yyyy}/{mm}/{dd}/{window}.{ext}
+// The date is the calendar date of targetDate in its own location, so it is
+// the business date in the source timezone.
+func GenerateOverwriteS3Key(source string, dataType string, targetDate time.Time, window string, ext string) string {
+	return fmt.Sprintf(
+		"landing/%s/%s/%04d/%02d/%02d/%s.%s",
+		source,
+		dataType,
+		targetDate.Year(),
+		targetDate.Month(),
+		targetDate.Day(),
+		window,
+		ext,
+	)
+}
+
+// LandingKey is what the key of a landing file tells about it, as parsed by
+// ParseLandingKey.
+type LandingKey struct {
+	Source   string
+	DataType string
+	// Date is the date directory of the key, at midnight UTC. It is the run
+	// date in UTC for an append key, and the target date in the source
+	// timezone for an overwrite key.
+	Date time.Time
+	// ExecutedAt is the run time of an append key, or nil for an overwrite key.
+	ExecutedAt *time.Time
+	// Window is the window of an overwrite key, or empty for an append key.
+	Window   string
+	Format   Format
+	Encoding Encoding
+}
+
+var appendKeyName = regexp.MustCompile(`^(\d{8}T\d{6}Z)_[0-9a-f]{8}$`)
+
+// ParseLandingKey parses a key generated by GenerateS3Key or
+// GenerateOverwriteS3Key.
+func ParseLandingKey(key string) (*LandingKey, error) {
+	invalid := fmt.Errorf("unrecognized landing key: %s", key)
+	parts := strings.Split(strings.TrimPrefix(key, "landing/"), "/")
+	if !strings.HasPrefix(key, "landing/") || len(parts) != 6 || parts[0] == "" || parts[1] == "" {
+		return nil, invalid
+	}
+	date, err := time.Parse("2006/01/02", strings.Join(parts[2:5], "/"))
+	if err != nil {
+		return nil, invalid
+	}
+
+	name := parts[5]
+	var encoding Encoding
+	for _, e := range []Encoding{EncodingGzip, EncodingZstd} {
+		if strings.HasSuffix(name, e.Extension()) {
+			encoding = e
+			name = strings.TrimSuffix(name, e.Extension())
+		}
+	}
+	name, ext, ok := cutLast(name, ".")
+	if !ok || name == "" {
+		return nil, invalid
+	}
+
+	parsed := &LandingKey{
+		Source:   parts[0],
+		DataType: parts[1],
+		Date:     date,
+		Format:   Format(ext),
+		Encoding: encoding,
+	}
+	if m := appendKeyName.FindStringSubmatch(name); m != nil {
+		executedAt, err := time.Parse("20060102T150405Z", m[1])
+		if err != nil {
+			return nil, invalid
+		}
+		parsed.ExecutedAt = &executedAt
+	} else {
+		parsed.Window = name
+	}
+	return parsed, nil
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// FileWindow names the slice of a target date that one extraction requests,
+// for use in GenerateOverwriteS3Key. Requests for the same code and date
+// range share a window; a request without them is the "all" window.
+func FileWindow(code *string, startDate *time.Time, endDate *time.Time) string {
+	var parts []string
+	if code != nil {
+		parts = append(parts, "code-"+*code)
+	}
+	if startDate != nil {
+		parts = append(parts, "from-"+startDate.Format("20060102"))
+	}
+	if endDate != nil {
+		parts = append(parts, "to-"+endDate.Format("20060102"))
+	}
+	if len(parts) == 0 {
+		return "all"
+	}
+	return strings.Join(parts, "_")
+}
@@ -1,12 +1,17 @@
 package extract
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"io"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/util/clock"
 )
 
 type ExtractTestSuite struct {
@@ -61,6 +66,38 @@ func (s *ExtractTestSuite) TestGenerateS3Key() {
 	})
 }
 
+func (s *ExtractTestSuite) TestGenerateOverwriteS3Key() {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	// Midnight JST is the previous day in UTC; the key keeps the business date
+	targetDate := time.Date(2025, 6, 2, 0, 0, 0, 0, jst)
+
+	key := GenerateOverwriteS3Key("jquants", "brand", targetDate, "all", "json")
+
+	s.Equal("landing/jquants/brand/2025/06/02/all.json", key)
+	s.Equal(key, GenerateOverwriteS3Key("jquants", "brand", targetDate, "all", "json"))
+}
+
+func (s *ExtractTestSuite) TestFileWindow() {
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)
+	code := "86970"
+
+	s.Equal("all", FileWindow(nil, nil, nil))
+	s.Equal("from-20250602", FileWindow(nil, &start, nil))
+	s.Equal("code-86970_from-20250602_to-20250606", FileWindow(&code, &start, &end))
+
+	// A manifest request names the window its files were written under
+	window, err := NewManifestRequest("daily", &code, &start, &end).Window()
+	s.Require().NoError(err)
+	s.Equal("code-86970_from-20250602_to-20250606", window)
+	window, err = NewManifestRequest("daily", nil, nil, nil).Window()
+	s.Require().NoError(err)
+	s.Equal("all", window)
+	invalid := "2025-13-01"
+	_, err = ManifestRequest{Timing: "daily", StartDate: &invalid}.Window()
+	s.EqualError(err, "invalid request date: 2025-13-01")
+}
+
 func (s *ExtractTestSuite) TestNewRunningExecution() {
 	targetDateTime := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
 
@@ -95,4 +132,269 @@ func (s *ExtractTestSuite) TestFail() {
 	s.NotNil(exec.FinishedAt())
 	s.NotNil(exec.ErrorInfo())
 	s.Equal("connection timeout", *exec.ErrorInfo())
+	s.Nil(exec.ErrorCategory())
+}
+
+func (s *ExtractTestSuite) TestFailWithCategory() {
+	ctx := context.Background()
+	exec := NewRunningExecution(ctx, time.Now())
+
+	exec.FailWithCategory(ctx, ErrorCategoryDeferred, "empty response")
+
+	s.Equal(ExecutionStatusFailed, exec.Status())
+	s.NotNil(exec.FinishedAt())
+	s.Equal("empty response", *exec.ErrorInfo())
+	s.Equal(ErrorCategoryDeferred, *exec.ErrorCategory())
+}
+
+func (s *ExtractTestSuite) TestIsStale() {
+	startedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	ctx := clock.WithFixedTime(context.Background(), startedAt)
+	running := NewRunningExecution(ctx, startedAt)
+	finished := NewRunningExecution(ctx, startedAt)
+	finished.Fail(ctx, "connection timeout")
+
+	s.False(running.IsStale(startedAt.Add(29*time.Minute), 30*time.Minute))
+	s.True(running.IsStale(startedAt.Add(30*time.Minute), 30*time.Minute))
+	s.False(running.IsStale(startedAt.Add(time.Hour), 0), "zero timeout never expires")
+	s.False(finished.IsStale(startedAt.Add(time.Hour), 30*time.Minute))
+}
+
+func (s *ExtractTestSuite) TestNewFileMetadata() {
+	md := NewFileMetadata([]byte(`{"info":[]}`), FormatJSON, 200)
+
+	s.Equal(FileMetadata{
+		SizeBytes:   11,
+		SHA256:      "08d2652660ef731cc382ba5ec3e8b2fad8cf8af29142d6afd4e15973f30f8b7c",
+		Format:      FormatJSON,
+		ContentType: "application/json",
+		HTTPStatus:  200,
+	}, md)
+	s.Equal(map[string]string{
+		"sha256":      md.SHA256,
+		"size-bytes":  "11",
+		"format":      "json",
+		"http-status": "200",
+	}, md.ObjectMetadata())
+}
+
+func (s *ExtractTestSuite) TestFileDigest() {
+	// Writing in pieces gives the metadata of the whole file.
+	d := NewFileDigest(FormatJSON, 200)
+	_, _ = d.Write([]byte(`{"info"`))
+	_, _ = d.Write([]byte(`:[]}`))
+	s.Equal(NewFileMetadata([]byte(`{"info":[]}`), FormatJSON, 200), d.Metadata())
+
+	// Before the digest is known, the size and SHA-256 are left out.
+	md := FileMetadata{Format: FormatJSON, ContentType: "application/json", HTTPStatus: 200}
+	s.Equal(map[string]string{"format": "json", "http-status": "200"}, md.ObjectMetadata())
+}
+
+func (s *ExtractTestSuite) TestEncoding() {
+	data := []byte(`{"info":[]}`)
+	for _, e := range []Encoding{EncodingGzip, EncodingZstd} {
+		s.Run(string(e), func() {
+			compressed, err := e.Compress(data)
+			s.Require().NoError(err)
+			s.NotEqual(data, compressed)
+
+			r, err := e.NewReader(bytes.NewReader(compressed))
+			s.Require().NoError(err)
+			got, err := io.ReadAll(r)
+			s.Require().NoError(err)
+			s.Equal(data, got)
+
+			// Streaming gives the same bytes back once decompressed.
+			stream := e.CompressStream(bytes.NewReader(data))
+			r, err = e.NewReader(stream)
+			s.Require().NoError(err)
+			got, err = io.ReadAll(r)
+			s.Require().NoError(err)
+			s.Equal(data, got)
+			s.NoError(stream.Close())
+
+			// Closing before EOF returns once the source is no longer read.
+			digest := NewFileDigest(FormatJSON, 200)
+			stream = e.CompressStream(io.TeeReader(rand.Reader, digest))
+			_, err = io.ReadFull(stream, make([]byte, 1024))
+			s.Require().NoError(err)
+			s.NoError(stream.Close())
+			s.Positive(digest.Metadata().SizeBytes)
+		})
+	}
+
+	r, err := EncodingNone.NewReader(bytes.NewReader(data))
+	s.Require().NoError(err)
+	got, err := io.ReadAll(r)
+	s.Require().NoError(err)
+	s.Equal(data, got)
+
+	_, err = Encoding("br").NewReader(bytes.NewReader(data))
+	s.EqualError(err, "unsupported content encoding: br")
+}
+
+func (s *ExtractTestSuite) TestCompressedMetadata() {
+	data := []byte(`{"info":[]}`)
+	compressed, err := EncodingGzip.Compress(data)
+	s.Require().NoError(err)
+	stored := NewFileMetadata(compressed, FormatJSON, 200)
+	md := NewFileMetadata(data, FormatJSON, 200).Compressed(EncodingGzip, stored)
+
+	s.Equal(int64(len(data)), md.SizeBytes)
+	s.Equal(int64(len(compressed)), md.StoredSizeBytes())
+	s.Equal(stored.SHA256, md.CompressedSHA256)
+	s.Equal(stored.SHA256, md.ObjectMetadata()[MetadataKeyCompressedSHA256])
+	s.Equal(md.SHA256, md.ObjectMetadata()[MetadataKeySHA256])
+
+	// Uncompressed files are stored as fetched.
+	raw := NewFileMetadata(data, FormatJSON, 200)
+	s.Equal(raw, raw.Compressed(EncodingNone, stored))
+	s.Equal(raw.SizeBytes, raw.StoredSizeBytes())
+}
+
+func (s *ExtractTestSuite) TestGenerateManifestKey() {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	// The date stays the calendar date of the target in its own location
+	target := time.Date(2025, 6, 1, 0, 0, 0, 0, jst)
+	s.Equal(
+		"landing/_manifests/jquants/brand/2025/06/01/execution-42.json",
+		GenerateManifestKey("jquants", "brand", target, 42),
+	)
+}
+
+func (s *ExtractTestSuite) TestManifestMarshal() {
+	target := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	fetchedAt := time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC)
+	code, start := "86970", time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	metadata := NewFileMetadata([]byte(`{"info":[]}`), FormatJSON, 200)
+	manifest := &Manifest{
+		ManifestVersion: ManifestVersion,
+		Source:          "jquants",
+		DataType:        "brand",
+		TargetDate:      "2025-06-01",
+		TargetDateTime:  target,
+		ExecutionID:     42,
+		Request:         NewManifestRequest("daily", &code, &start, nil),
+		FetchStartedAt:  fetchedAt,
+		FetchFinishedAt: fetchedAt.Add(time.Second),
+		Objects: []ManifestObject{
+			NewManifestObject("landing/jquants/brand/2025/06/01/all.json", metadata, ObjectVersion{ID: "v1"}),
+		},
+	}
+
+	data, err := manifest.Marshal()
+	s.Require().NoError(err)
+	s.JSONEq(`{
+		"manifest_version": 1,
+		"source": "jquants",
+		"data_type": "brand",
+		"target_date": "2025-06-01",
+		"target_date_time": "2025-06-01T00:00:00Z",
+		"execution_id": 42,
+		"request": {"timing": "daily", "code": "86970", "start_date": "2025-05-01"},
+		"fetch_started_at": "2025-06-01T09:30:00Z",
+		"fetch_finished_at": "2025-06-01T09:30:01Z",
+		"objects": [{
+			"key": "landing/jquants/brand/2025/06/01/all.json",
+			"version_id": "v1",
+			"format": "json",
+			"content_type": "application/json",
+			"http_status": 200,
+			"size_bytes": 11,
+			"sha256": "`+metadata.SHA256+`"
+		}]
+	}`, string(data))
+}
+
+func (s *ExtractTestSuite) TestParseLandingKey() {
+	executedAt := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+	date := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	s.Run("append key", func() {
+		parsed, err := ParseLandingKey(GenerateS3Key("jquants", "brand", executedAt, "json"))
+		s.Require().NoError(err)
+		s.Equal(&LandingKey{
+			Source: "jquants", DataType: "brand", Date: date, ExecutedAt: &executedAt, Format: FormatJSON,
+		}, parsed)
+	})
+
+	s.Run("compressed overwrite key", func() {
+		key := GenerateOverwriteS3Key("jquants", "brand", date, "code-86970", "json") + EncodingGzip.Extension()
+		parsed, err := ParseLandingKey(key)
+		s.Require().NoError(err)
+		s.Equal(&LandingKey{
+			Source: "jquants", DataType: "brand", Date: date, Window: "code-86970",
+			Format: FormatJSON, Encoding: EncodingGzip,
+		}, parsed)
+	})
+
+	for _, key := range []string{
+		"landing/_manifests/jquants/brand/2025/06/01/execution-1.json",
+		"landing/jquants/brand/2025/13/01/all.json",
+		"landing/jquants/brand/2025/06/01/all",
+		"bronze/jquants/brand/2025/06/01/all.json",
+	} {
+		_, err := ParseLandingKey(key)
+		s.EqualError(err, "unrecognized landing key: "+key)
+	}
+}
+
+func (s *ExtractTestSuite) TestObjectInfoFileMetadata() {
+	data := []byte(`{"info":[]}`)
+	compressed, err := EncodingZstd.Compress(data)
+	s.Require().NoError(err)
+	md := NewFileMetadata(data, FormatJSON, 200).Compressed(EncodingZstd, NewFileMetadata(compressed, FormatJSON, 200))
+
+	// The metadata written with an object is read back from it.
+	info := ObjectInfo{
+		SizeBytes:       md.StoredSizeBytes(),
+		ContentType:     md.ContentType,
+		ContentEncoding: md.Encoding,
+		Metadata:        md.ObjectMetadata(),
+	}
+	got, err := info.FileMetadata()
+	s.Require().NoError(err)
+	s.Equal(&md, got)
+
+	// A streamed upload has no digest to read.
+	info.Metadata = map[string]string{MetadataKeyFormat: "json"}
+	got, err = info.FileMetadata()
+	s.Require().NoError(err)
+	s.Nil(got)
+
+	info.Metadata = map[string]string{MetadataKeySHA256: md.SHA256, MetadataKeySizeBytes: "x"}
+	_, err = info.FileMetadata()
+	s.ErrorContains(err, "invalid size-bytes metadata")
+}
+
+func (s *ExtractTestSuite) TestParseManifest() {
+	manifest := &Manifest{
+		ManifestVersion: ManifestVersion,
+		Source:          "jquants",
+		DataType:        "brand",
+		TargetDate:      "2025-06-01",
+		TargetDateTime:  time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		ExecutionID:     42,
+		Request:         NewManifestRequest("daily", nil, nil, nil),
+		Objects: []ManifestObject{
+			NewManifestObject(
+				"landing/jquants/brand/2025/06/01/all.json",
+				NewFileMetadata([]byte(`{"info":[]}`), FormatJSON, 200),
+				ObjectVersion{ID: "v1"},
+			),
+		},
+	}
+	data, err := manifest.Marshal()
+	s.Require().NoError(err)
+
+	parsed, err := ParseManifest(data)
+	s.Require().NoError(err)
+	s.Equal(manifest, parsed)
+	s.Equal(NewFileMetadata([]byte(`{"info":[]}`), FormatJSON, 200), parsed.Objects[0].Metadata())
+	s.Equal(ObjectVersion{ID: "v1"}, parsed.Objects[0].Version())
+
+	_, err = ParseManifest([]byte(`{"manifest_version": 2}`))
+	s.EqualError(err, "unsupported manifest version: 2")
+	_, err = ParseManifest([]byte(`not json`))
+	s.ErrorContains(err, "invalid manifest")
 }
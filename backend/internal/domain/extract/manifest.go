@@ -0,0 +1,158 @@
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ManifestVersion is the version of the manifest layout written by this
+// code. Readers should reject manifests of a newer version.
+const ManifestVersion = 1
+
+// ManifestKeyPrefix is the key prefix every manifest is stored under.
+const ManifestKeyPrefix = "landing/_manifests/"
+
+// Manifest describes what one succeeded execution landed, so the landing zone
+// can be read without the database. It is stored as JSON under the key from
+// GenerateManifestKey.
+type Manifest struct {
+	ManifestVersion int    `json:"manifest_version"`
+	Source          string `json:"source"`
+	DataType        string `json:"data_type"`
+	// TargetDate is the calendar date of TargetDateTime in its own location,
+	// formatted as YYYY-MM-DD.
+	TargetDate      string           `json:"target_date"`
+	TargetDateTime  time.Time        `json:"target_date_time"`
+	ExecutionID     int              `json:"execution_id"`
+	Request         ManifestRequest  `json:"request"`
+	FetchStartedAt  time.Time        `json:"fetch_started_at"`
+	FetchFinishedAt time.Time        `json:"fetch_finished_at"`
+	Objects         []ManifestObject `json:"objects"`
+}
+
+// ManifestRequest holds the parameters the source API was called with.
+type ManifestRequest struct {
+	Timing string  `json:"timing"`
+	Code   *string `json:"code,omitempty"`
+	// StartDate and EndDate are formatted as YYYY-MM-DD.
+	StartDate *string `json:"start_date,omitempty"`
+	EndDate   *string `json:"end_date,omitempty"`
+}
+
+// ManifestObject is one landed object of a Manifest. The sizes and digests
+// are those of ExtractedDataS3: of the bytes as fetched, and of the bytes
+// stored when ContentEncoding is set.
+type ManifestObject struct {
+	Key                 string `json:"key"`
+	VersionID           string `json:"version_id,omitempty"`
+	Format              Format `json:"format"`
+	ContentType         string `json:"content_type"`
+	ContentEncoding     string `json:"content_encoding,omitempty"`
+	HTTPStatus          int    `json:"http_status,omitempty"`
+	SizeBytes           int64  `json:"size_bytes"`
+	SHA256              string `json:"sha256"`
+	CompressedSizeBytes int64  `json:"compressed_size_bytes,omitempty"`
+	CompressedSHA256    string `json:"compressed_sha256,omitempty"`
+}
+
+// NewManifestRequest returns the request parameters of a manifest.
+func NewManifestRequest(timing string, code *string, startDate *time.Time, endDate *time.Time) ManifestRequest {
+	req := ManifestRequest{Timing: timing, Code: code}
+	if startDate != nil {
+		s := startDate.Format("2006-01-02")
+		req.StartDate = &s
+	}
+	if endDate != nil {
+		s := endDate.Format("2006-01-02")
+		req.EndDate = &s
+	}
+	return req
+}
+
+// Window returns the window of the target date the request fetched, as
+// named by FileWindow.
+func (r ManifestRequest) Window() (string, error) {
+	var dates [2]*time.Time
+	for i, s := range []*string{r.StartDate, r.EndDate} {
+		if s == nil {
+			continue
+		}
+		d, err := time.Parse("2006-01-02", *s)
+		if err != nil {
+			return "", fmt.Errorf("invalid request date: %s", *s)
+		}
+		dates[i] = &d
+	}
+	return FileWindow(r.Code, dates[0], dates[1]), nil
+}
+
+// NewManifestObject returns the manifest entry of an object stored under key.
+func NewManifestObject(key string, metadata FileMetadata, version ObjectVersion) ManifestObject {
+	return ManifestObject{
+		Key:                 key,
+		VersionID:           version.ID,
+		Format:              metadata.Format,
+		ContentType:         metadata.ContentType,
+		ContentEncoding:     string(metadata.Encoding),
+		HTTPStatus:          metadata.HTTPStatus,
+		SizeBytes:           metadata.SizeBytes,
+		SHA256:              metadata.SHA256,
+		CompressedSizeBytes: metadata.CompressedSizeBytes,
+		CompressedSHA256:    metadata.CompressedSHA256,
+	}
+}
+
+// Metadata returns the file metadata the object was recorded with.
+func (o ManifestObject) Metadata() FileMetadata {
+	return FileMetadata{
+		SizeBytes:           o.SizeBytes,
+		SHA256:              o.SHA256,
+		Format:              o.Format,
+		ContentType:         o.ContentType,
+		HTTPStatus:          o.HTTPStatus,
+		Encoding:            Encoding(o.ContentEncoding),
+		CompressedSizeBytes: o.CompressedSizeBytes,
+		CompressedSHA256:    o.CompressedSHA256,
+	}
+}
+
+// Version returns the object version the object was written as.
+func (o ManifestObject) Version() ObjectVersion {
+	return ObjectVersion{ID: o.VersionID}
+}
+
+// Marshal returns the manifest as indented JSON.
+func (m *Manifest) Marshal() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
+
+// ParseManifest parses a manifest written by Manifest.Marshal.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if m.ManifestVersion < 1 || m.ManifestVersion > ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version: %d", m.ManifestVersion)
+	}
+	return &m, nil
+}
+
+// GenerateManifestKey generates the object key of the manifest of an
+// execution:
+// landing/_manifests/{source}/{data_type}/{yyyy}/{mm}/{dd}/execution-{id}.json
+// The date is the calendar date of targetDate in its own location, as in
+// GenerateOverwriteS3Key.
+func GenerateManifestKey(source string, dataType string, targetDate time.Time, executionID int) string {
+	return fmt.Sprintf(
+		"%s%s/%s/%04d/%02d/%02d/execution-%d.json",
+		ManifestKeyPrefix,
+		source,
+		dataType,
+		targetDate.Year(),
+		targetDate.Month(),
+		targetDate.Day(),
+		executionID,
+	)
+}
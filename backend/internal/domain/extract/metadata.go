@@ -0,0 +1,218 @@
+package extract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strconv"
+	"time"
+)
+
+// Format is the data format of a landing file, which is also its key extension.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+)
+
+var contentTypes = map[Format]string{
+	FormatJSON: "application/json",
+}
+
+// ContentType returns the MIME type of the format, or
+// application/octet-stream for an unknown format.
+func (f Format) ContentType() string {
+	if ct, ok := contentTypes[f]; ok {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+// Object metadata keys under which FileMetadata is written to S3. S3 returns
+// them with the x-amz-meta- prefix.
+const (
+	MetadataKeySHA256     = "sha256"
+	MetadataKeySizeBytes  = "size-bytes"
+	MetadataKeyFormat     = "format"
+	MetadataKeyHTTPStatus = "http-status"
+	// The size and SHA-256 of the stored bytes of a compressed file
+	MetadataKeyCompressedSHA256    = "compressed-sha256"
+	MetadataKeyCompressedSizeBytes = "compressed-size-bytes"
+)
+
+// FileMetadata describes the bytes of a landing file as they were uploaded,
+// so the stored object can later be checked against them (FR-3). SizeBytes
+// and SHA256 always describe the bytes as fetched; a compressed file also
+// records the bytes stored, so both can be verified.
+type FileMetadata struct {
+	SizeBytes int64
+	// SHA256 is the hex-encoded SHA-256 digest of the file.
+	SHA256      string
+	Format      Format
+	ContentType string
+	// HTTPStatus is the status code of the source API response the file was
+	// taken from, or 0 when the source was not HTTP.
+	HTTPStatus int
+	// Encoding is the compression of the stored object. The compressed size
+	// and SHA-256 are zero for EncodingNone.
+	Encoding            Encoding
+	CompressedSizeBytes int64
+	CompressedSHA256    string
+}
+
+// NewFileMetadata computes the metadata of data in the given format.
+func NewFileMetadata(data []byte, format Format, httpStatus int) FileMetadata {
+	d := NewFileDigest(format, httpStatus)
+	_, _ = d.Write(data)
+	return d.Metadata()
+}
+
+// Compressed returns m for a file stored with encoding, whose stored bytes
+// are described by stored. EncodingNone returns m unchanged.
+func (m FileMetadata) Compressed(encoding Encoding, stored FileMetadata) FileMetadata {
+	if encoding == EncodingNone {
+		return m
+	}
+	m.Encoding = encoding
+	m.CompressedSizeBytes = stored.SizeBytes
+	m.CompressedSHA256 = stored.SHA256
+	return m
+}
+
+// StoredSizeBytes returns the size of the object as stored.
+func (m FileMetadata) StoredSizeBytes() int64 {
+	if m.Encoding != EncodingNone {
+		return m.CompressedSizeBytes
+	}
+	return m.SizeBytes
+}
+
+// ObjectMetadata returns the user-defined object metadata to store with the
+// file. The content type and encoding are set on the object itself. The
+// sizes and SHA-256 digests are left out when SHA256 is empty, as for a file
+// streamed to storage before they are known.
+func (m FileMetadata) ObjectMetadata() map[string]string {
+	md := map[string]string{
+		MetadataKeyFormat: string(m.Format),
+	}
+	if m.SHA256 != "" {
+		md[MetadataKeySHA256] = m.SHA256
+		md[MetadataKeySizeBytes] = strconv.FormatInt(m.SizeBytes, 10)
+		if m.Encoding != EncodingNone {
+			md[MetadataKeyCompressedSHA256] = m.CompressedSHA256
+			md[MetadataKeyCompressedSizeBytes] = strconv.FormatInt(m.CompressedSizeBytes, 10)
+		}
+	}
+	if m.HTTPStatus != 0 {
+		md[MetadataKeyHTTPStatus] = strconv.Itoa(m.HTTPStatus)
+	}
+	return md
+}
+
+// FileDigest computes the FileMetadata of the bytes written to it, for a file
+// that is streamed rather than held in memory.
+type FileDigest struct {
+	hash       hash.Hash
+	size       int64
+	format     Format
+	httpStatus int
+}
+
+func NewFileDigest(format Format, httpStatus int) *FileDigest {
+	return &FileDigest{hash: sha256.New(), format: format, httpStatus: httpStatus}
+}
+
+func (d *FileDigest) Write(p []byte) (int, error) {
+	n, _ := d.hash.Write(p)
+	d.size += int64(n)
+	return n, nil
+}
+
+// Metadata returns the metadata of the bytes written so far.
+func (d *FileDigest) Metadata() FileMetadata {
+	return FileMetadata{
+		SizeBytes:   d.size,
+		SHA256:      hex.EncodeToString(d.hash.Sum(nil)),
+		Format:      d.format,
+		ContentType: d.format.ContentType(),
+		HTTPStatus:  d.httpStatus,
+	}
+}
+
+// ObjectInfo is what object storage reports about a stored object.
+type ObjectInfo struct {
+	// SizeBytes is the size of the object as stored, compressed or not.
+	SizeBytes       int64
+	ContentType     string
+	ContentEncoding Encoding
+	// Metadata is the user-defined object metadata, keyed as in ObjectMetadata.
+	Metadata map[string]string
+	// VersionID is the version of the current object, or empty if the bucket
+	// is not versioned.
+	VersionID string
+	// ETag is the entity tag of the object, quoted as S3 returns it.
+	ETag string
+}
+
+// FileMetadata returns the file metadata written as the object's metadata by
+// FileMetadata.ObjectMetadata, or nil when the object has no SHA-256, as for
+// a streamed or legacy upload.
+func (i ObjectInfo) FileMetadata() (*FileMetadata, error) {
+	md := i.Metadata
+	if md[MetadataKeySHA256] == "" {
+		return nil, nil
+	}
+	m := &FileMetadata{
+		SHA256:      md[MetadataKeySHA256],
+		Format:      Format(md[MetadataKeyFormat]),
+		ContentType: i.ContentType,
+		Encoding:    i.ContentEncoding,
+	}
+	var err error
+	if m.SizeBytes, err = strconv.ParseInt(md[MetadataKeySizeBytes], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", MetadataKeySizeBytes, err)
+	}
+	if s := md[MetadataKeyHTTPStatus]; s != "" {
+		if m.HTTPStatus, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("invalid %s metadata: %w", MetadataKeyHTTPStatus, err)
+		}
+	}
+	if m.Encoding != EncodingNone {
+		m.CompressedSHA256 = md[MetadataKeyCompressedSHA256]
+		m.CompressedSizeBytes = i.SizeBytes
+	}
+	return m, nil
+}
+
+// ObjectSummary is what object storage reports about an object when listing.
+type ObjectSummary struct {
+	Key          string
+	SizeBytes    int64
+	ETag         string
+	LastModified time.Time
+}
+
+// FileFilter selects landing files by the execution that wrote them. Empty
+// fields match everything; From is inclusive and To exclusive, both compared
+// with the execution's target date.
+type FileFilter struct {
+	Source   string
+	DataType string
+	From     *time.Time
+	To       *time.Time
+}
+
+// KeyPrefix returns the landing key prefix that holds every file the filter
+// can match, following GenerateS3Key.
+func (f FileFilter) KeyPrefix() string {
+	prefix := "landing/"
+	if f.Source == "" {
+		return prefix
+	}
+	prefix += f.Source + "/"
+	if f.DataType == "" {
+		return prefix
+	}
+	return prefix + f.DataType + "/"
+}
@@ -0,0 +1,38 @@
+package ingestion
+
+import "fmt"
+
+// Compression is the encoding applied to the landing files of a data type at
+// upload. The raw bytes are still verifiable after decompression (FR-2).
+type Compression string
+
+const (
+	// CompressionNone stores files as fetched.
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+// NewCompression returns the compression named s. An empty s means
+// CompressionNone, the behavior before compression was configurable.
+func NewCompression(s string) (Compression, error) {
+	switch Compression(s) {
+	case "", CompressionNone:
+		return CompressionNone, nil
+	case CompressionGzip:
+		return CompressionGzip, nil
+	case CompressionZstd:
+		return CompressionZstd, nil
+	default:
+		return "", fmt.Errorf("invalid compression: %s", s)
+	}
+}
+
+// ContentEncoding returns the HTTP Content-Encoding of files stored with the
+// compression, or an empty string for CompressionNone.
+func (c Compression) ContentEncoding() string {
+	if c == CompressionNone {
+		return ""
+	}
+	return string(c)
+}
@@ -16,24 +16,38 @@ import (
 // already exists in the repository.
 var ErrDataSourceNameConflict = errors.New("data source name already exists")
 
+// ErrVersionMismatch is returned when an update or delete targets a version
+// of a DataSource or DataType that is no longer current.
+var ErrVersionMismatch = errors.New("version mismatch")
+
 // DataSource represents an external data provider from which stock data
 // is ingested. Timezone must be a valid IANA location; NewDataSource
-// validates on creation and Update re-validates on mutation.
+// validates on creation and Update re-validates on mutation. Kind is fixed
+// at creation; it selects the schema settings are validated against.
+// Retention is the default retention policy of the data types of the source
+// that set no retention rule of their own.
+// Version starts at 1 and is incremented by the repository on every
+// persisted update; it backs optimistic concurrency control.
 type DataSource struct {
 	id        uuid.UUID
+	kind      SourceKind
 	name      string
 	enabled   bool
 	timezone  *time.Location
+	retention RetentionPolicy
 	settings  map[string]any
+	version   int
 	createdAt time.Time
 	updatedAt time.Time
 }
 
 func NewDataSource(
 	ctx context.Context,
+	kind SourceKind,
 	name string,
 	enabled bool,
 	timezone string,
+	retention RetentionPolicy,
 	settings map[string]any,
 ) (*DataSource, error) {
 	loc, err := time.LoadLocation(timezone)
@@ -43,10 +57,13 @@ func NewDataSource(
 	now := clock.Now(ctx)
 	return &DataSource{
 		id:        idp.NewV7(ctx),
+		kind:      kind,
 		name:      name,
 		enabled:   enabled,
 		timezone:  loc,
+		retention: retention,
 		settings:  settings,
+		version:   1,
 		createdAt: now,
 		updatedAt: now,
 	}, nil
@@ -54,19 +71,25 @@ func NewDataSource(
 
 func NewDataSourceDirectly(
 	id uuid.UUID,
+	kind SourceKind,
 	name string,
 	enabled bool,
 	timezone *time.Location,
+	retention RetentionPolicy,
 	settings map[string]any,
+	version int,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) *DataSource {
 	return &DataSource{
 		id:        id,
+		kind:      kind,
 		name:      name,
 		enabled:   enabled,
 		timezone:  timezone,
+		retention: retention,
 		settings:  settings,
+		version:   version,
 		createdAt: createdAt,
 		updatedAt: updatedAt,
 	}
@@ -77,6 +100,7 @@ func (s *DataSource) Update(
 	name string,
 	enabled bool,
 	timezone string,
+	retention RetentionPolicy,
 	settings map[string]any,
 ) error {
 	loc, err := time.LoadLocation(timezone)
@@ -86,16 +110,26 @@ func (s *DataSource) Update(
 	s.name = name
 	s.enabled = enabled
 	s.timezone = loc
+	s.retention = retention
 	s.settings = settings
 	s.updatedAt = clock.Now(ctx)
 	return nil
 }
 
-func (s *DataSource) ID() uuid.UUID            { return s.id }
-func (s *DataSource) Name() string             { return s.name }
-func (s *DataSource) Enabled() bool            { return s.enabled }
-func (s *DataSource) Timezone() *time.Location { return s.timezone }
-func (s *DataSource) TimezoneString() string   { return s.timezone.String() }
-func (s *DataSource) Settings() map[string]any { return s.settings }
-func (s *DataSource) CreatedAt() time.Time     { return s.createdAt }
-func (s *DataSource) UpdatedAt() time.Time     { return s.updatedAt }
+func (s *DataSource) ID() uuid.UUID              { return s.id }
+func (s *DataSource) Kind() SourceKind           { return s.kind }
+func (s *DataSource) Name() string               { return s.name }
+func (s *DataSource) Enabled() bool              { return s.enabled }
+func (s *DataSource) Timezone() *time.Location   { return s.timezone }
+func (s *DataSource) TimezoneString() string     { return s.timezone.String() }
+func (s *DataSource) Retention() RetentionPolicy { return s.retention }
+func (s *DataSource) Settings() map[string]any   { return s.settings }
+func (s *DataSource) Version() int               { return s.version }
+func (s *DataSource) CreatedAt() time.Time       { return s.createdAt }
+func (s *DataSource) UpdatedAt() time.Time       { return s.updatedAt }
+
+// DataSourceFilter narrows a DataSource listing. Zero-value fields match everything.
+type DataSourceFilter struct {
+	Enabled    *bool
+	NamePrefix string
+}
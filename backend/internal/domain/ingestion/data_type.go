@@ -17,7 +17,9 @@ var ErrDataTypeNameConflict = errors.New("data type name already exists")
 
 // DataType represents a category of data belonging to a DataSource.
 // It holds ingestion configuration: update schedule, backfill policy,
-// and stale timeout.
+// stale timeout, re-run strategy, empty response policy, compression and
+// retention.
+// Version behaves as on DataSource.
 type DataType struct {
 	id                  uuid.UUID
 	dataSourceID        uuid.UUID
@@ -26,7 +28,12 @@ type DataType struct {
 	schedule            Schedule
 	backfillEnabled     bool
 	staleTimeoutMinutes int
+	rerunStrategy       RerunStrategy
+	emptyResponsePolicy EmptyResponsePolicy
+	compression         Compression
+	retention           RetentionPolicy
 	settings            map[string]any
+	version             int
 	createdAt           time.Time
 	updatedAt           time.Time
 }
@@ -39,6 +46,10 @@ func NewDataType(
 	schedule Schedule,
 	backfillEnabled bool,
 	staleTimeoutMinutes int,
+	rerunStrategy RerunStrategy,
+	emptyResponsePolicy EmptyResponsePolicy,
+	compression Compression,
+	retention RetentionPolicy,
 	settings map[string]any,
 ) *DataType {
 	now := clock.Now(ctx)
@@ -50,7 +61,12 @@ func NewDataType(
 		schedule:            schedule,
 		backfillEnabled:     backfillEnabled,
 		staleTimeoutMinutes: staleTimeoutMinutes,
+		rerunStrategy:       rerunStrategy,
+		emptyResponsePolicy: emptyResponsePolicy,
+		compression:         compression,
+		retention:           retention,
 		settings:            settings,
+		version:             1,
 		createdAt:           now,
 		updatedAt:           now,
 	}
@@ -64,7 +80,12 @@ func NewDataTypeDirectly(
 	schedule Schedule,
 	backfillEnabled bool,
 	staleTimeoutMinutes int,
+	rerunStrategy RerunStrategy,
+	emptyResponsePolicy EmptyResponsePolicy,
+	compression Compression,
+	retention RetentionPolicy,
 	settings map[string]any,
+	version int,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) *DataType {
@@ -76,22 +97,32 @@ func NewDataTypeDirectly(
 		schedule:            schedule,
 		backfillEnabled:     backfillEnabled,
 		staleTimeoutMinutes: staleTimeoutMinutes,
+		rerunStrategy:       rerunStrategy,
+		emptyResponsePolicy: emptyResponsePolicy,
+		compression:         compression,
+		retention:           retention,
 		settings:            settings,
+		version:             version,
 		createdAt:           createdAt,
 		updatedAt:           updatedAt,
 	}
 }
 
-func (t *DataType) ID() uuid.UUID            { return t.id }
-func (t *DataType) DataSourceID() uuid.UUID  { return t.dataSourceID }
-func (t *DataType) Name() string             { return t.name }
-func (t *DataType) Enabled() bool            { return t.enabled }
-func (t *DataType) Schedule() Schedule       { return t.schedule }
-func (t *DataType) BackfillEnabled() bool    { return t.backfillEnabled }
-func (t *DataType) StaleTimeoutMinutes() int { return t.staleTimeoutMinutes }
-func (t *DataType) Settings() map[string]any { return t.settings }
-func (t *DataType) CreatedAt() time.Time     { return t.createdAt }
-func (t *DataType) UpdatedAt() time.Time     { return t.updatedAt }
+func (t *DataType) ID() uuid.UUID                            { return t.id }
+func (t *DataType) DataSourceID() uuid.UUID                  { return t.dataSourceID }
+func (t *DataType) Name() string                             { return t.name }
+func (t *DataType) Enabled() bool                            { return t.enabled }
+func (t *DataType) Schedule() Schedule                       { return t.schedule }
+func (t *DataType) BackfillEnabled() bool                    { return t.backfillEnabled }
+func (t *DataType) StaleTimeoutMinutes() int                 { return t.staleTimeoutMinutes }
+func (t *DataType) RerunStrategy() RerunStrategy             { return t.rerunStrategy }
+func (t *DataType) EmptyResponsePolicy() EmptyResponsePolicy { return t.emptyResponsePolicy }
+func (t *DataType) Compression() Compression                 { return t.compression }
+func (t *DataType) Retention() RetentionPolicy               { return t.retention }
+func (t *DataType) Settings() map[string]any                 { return t.settings }
+func (t *DataType) Version() int                             { return t.version }
+func (t *DataType) CreatedAt() time.Time                     { return t.createdAt }
+func (t *DataType) UpdatedAt() time.Time                     { return t.updatedAt }
 
 func (t *DataType) Update(
 	ctx context.Context,
@@ -100,6 +131,10 @@ func (t *DataType) Update(
 	schedule Schedule,
 	backfillEnabled bool,
 	staleTimeoutMinutes int,
+	rerunStrategy RerunStrategy,
+	emptyResponsePolicy EmptyResponsePolicy,
+	compression Compression,
+	retention RetentionPolicy,
 	settings map[string]any,
 ) {
 	t.name = name
@@ -107,6 +142,10 @@ func (t *DataType) Update(
 	t.schedule = schedule
 	t.backfillEnabled = backfillEnabled
 	t.staleTimeoutMinutes = staleTimeoutMinutes
+	t.rerunStrategy = rerunStrategy
+	t.emptyResponsePolicy = emptyResponsePolicy
+	t.compression = compression
+	t.retention = retention
 	t.settings = settings
 	t.updatedAt = clock.Now(ctx)
 }
@@ -114,3 +153,10 @@ func (t *DataType) Update(
 func (t *DataType) StaleTimeout() time.Duration {
 	return time.Duration(t.staleTimeoutMinutes) * time.Minute
 }
+
+// DataTypeFilter narrows a DataType listing. Zero-value fields match everything.
+type DataTypeFilter struct {
+	DataSourceID *uuid.UUID
+	Enabled      *bool
+	NamePrefix   string
+}
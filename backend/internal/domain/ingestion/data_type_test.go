@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -24,7 +25,12 @@ func (s *DataTypeTestSuite) mustDailySchedule(times ...TimeOfDay) Schedule {
 }
 
 func (s *DataTypeTestSuite) TestStaleTimeout() {
-	dt := NewDataType(context.Background(), uuid.Nil, "test", true, s.mustDailySchedule("09:00"), false, 30, nil)
+	dt := NewDataType(
+		context.Background(),
+		uuid.Nil, "test", true,
+		s.mustDailySchedule("09:00"),
+		false, 30, RerunStrategyAppend, EmptyResponsePolicySuccess, CompressionNone, RetentionPolicy{}, nil,
+	)
 
 	s.Equal(30*time.Minute, dt.StaleTimeout())
 }
@@ -34,15 +40,19 @@ func (s *DataTypeTestSuite) TestUpdate() {
 		context.Background(),
 		uuid.Nil, "original", true,
 		s.mustDailySchedule("18:00"),
-		true, 30, map[string]any{"k": "v"},
+		true, 30, RerunStrategyAppend, EmptyResponsePolicySuccess, CompressionNone, RetentionPolicy{},
+		map[string]any{"k": "v"},
 	)
 
+	retention, err := NewRetentionPolicy(lo.ToPtr(3), nil)
+	s.Require().NoError(err)
 	ctx := context.Background()
 	dt.Update(
 		ctx,
 		"renamed", false,
 		s.mustDailySchedule("09:00", "15:00"),
-		false, 60, map[string]any{},
+		false, 60, RerunStrategyOverwrite, EmptyResponsePolicyRetryLater, CompressionZstd, retention,
+		map[string]any{},
 	)
 
 	s.Equal("renamed", dt.Name())
@@ -51,6 +61,151 @@ func (s *DataTypeTestSuite) TestUpdate() {
 	s.Equal([]TimeOfDay{"09:00", "15:00"}, dt.Schedule().Times())
 	s.False(dt.BackfillEnabled())
 	s.Equal(60, dt.StaleTimeoutMinutes())
+	s.Equal(RerunStrategyOverwrite, dt.RerunStrategy())
+	s.Equal(EmptyResponsePolicyRetryLater, dt.EmptyResponsePolicy())
+	s.Equal(CompressionZstd, dt.Compression())
+	s.Equal(retention, dt.Retention())
 	s.Empty(dt.Settings())
 	s.True(dt.UpdatedAt().After(dt.CreatedAt()))
 }
+
+func (s *DataTypeTestSuite) TestNewRerunStrategy() {
+	type testCase struct {
+		name     string
+		input    string
+		expected RerunStrategy
+		wantErr  bool
+	}
+	tests := []testCase{
+		{name: "empty defaults to append", input: "", expected: RerunStrategyAppend},
+		{name: "append", input: "append", expected: RerunStrategyAppend},
+		{name: "overwrite", input: "overwrite", expected: RerunStrategyOverwrite},
+		{name: "unknown", input: "replace", wantErr: true},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			got, err := NewRerunStrategy(tc.input)
+			if tc.wantErr {
+				s.EqualError(err, "invalid rerun strategy: "+tc.input)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tc.expected, got)
+		})
+	}
+}
+
+func (s *DataTypeTestSuite) TestNewEmptyResponsePolicy() {
+	type testCase struct {
+		name     string
+		input    string
+		expected EmptyResponsePolicy
+		wantErr  bool
+	}
+	tests := []testCase{
+		{name: "empty defaults to success", input: "", expected: EmptyResponsePolicySuccess},
+		{name: "success", input: "success", expected: EmptyResponsePolicySuccess},
+		{name: "fail", input: "fail", expected: EmptyResponsePolicyFail},
+		{name: "retry_later", input: "retry_later", expected: EmptyResponsePolicyRetryLater},
+		{name: "unknown", input: "skip", wantErr: true},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			got, err := NewEmptyResponsePolicy(tc.input)
+			if tc.wantErr {
+				s.EqualError(err, "invalid empty response policy: "+tc.input)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tc.expected, got)
+		})
+	}
+}
+
+func (s *DataTypeTestSuite) TestNewCompression() {
+	type testCase struct {
+		name             string
+		input            string
+		expected         Compression
+		expectedEncoding string
+		wantErr          bool
+	}
+	tests := []testCase{
+		{name: "empty defaults to none", input: "", expected: CompressionNone},
+		{name: "none", input: "none", expected: CompressionNone},
+		{name: "gzip", input: "gzip", expected: CompressionGzip, expectedEncoding: "gzip"},
+		{name: "zstd", input: "zstd", expected: CompressionZstd, expectedEncoding: "zstd"},
+		{name: "unknown", input: "brotli", wantErr: true},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			got, err := NewCompression(tc.input)
+			if tc.wantErr {
+				s.EqualError(err, "invalid compression: "+tc.input)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tc.expected, got)
+			s.Equal(tc.expectedEncoding, got.ContentEncoding())
+		})
+	}
+}
+
+func (s *DataTypeTestSuite) TestNewRetentionPolicy() {
+	type testCase struct {
+		name                 string
+		keepLatest           *int
+		supersededMaxAgeDays *int
+		expectedErr          string
+	}
+	tests := []testCase{
+		{name: "no rules", keepLatest: nil, supersededMaxAgeDays: nil},
+		{name: "both rules", keepLatest: lo.ToPtr(1), supersededMaxAgeDays: lo.ToPtr(0)},
+		{
+			name:        "keep latest below one",
+			keepLatest:  lo.ToPtr(0),
+			expectedErr: "invalid retention keepLatest: 0, must be at least 1",
+		},
+		{
+			name:                 "negative age",
+			supersededMaxAgeDays: lo.ToPtr(-1),
+			expectedErr:          "invalid retention supersededMaxAgeDays: -1, must not be negative",
+		},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			got, err := NewRetentionPolicy(tc.keepLatest, tc.supersededMaxAgeDays)
+			if tc.expectedErr != "" {
+				s.EqualError(err, tc.expectedErr)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tc.keepLatest, got.KeepLatest())
+			s.Equal(tc.supersededMaxAgeDays, got.SupersededMaxAgeDays())
+		})
+	}
+}
+
+func (s *DataTypeTestSuite) TestRetentionPolicyKeeps() {
+	day := 24 * time.Hour
+	keepLatest, err := NewRetentionPolicy(lo.ToPtr(2), nil)
+	s.Require().NoError(err)
+	maxAge, err := NewRetentionPolicy(nil, lo.ToPtr(7))
+	s.Require().NoError(err)
+	both, err := NewRetentionPolicy(lo.ToPtr(2), lo.ToPtr(7))
+	s.Require().NoError(err)
+
+	// Without rules every file is kept.
+	s.True(RetentionPolicy{}.Keeps(100, 365*day))
+
+	s.True(keepLatest.Keeps(2, 365*day))
+	s.False(keepLatest.Keeps(3, 0))
+
+	s.True(maxAge.Keeps(100, 7*day-time.Second))
+	s.False(maxAge.Keeps(1, 7*day))
+
+	// A file is kept while any rule keeps it.
+	s.True(both.Keeps(3, day))
+	s.True(both.Keeps(1, 30*day))
+	s.False(both.Keeps(3, 30*day))
+}
@@ -0,0 +1,95 @@
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrDependencyCycle is returned when dependencies between data types would
+// form a cycle.
+var ErrDependencyCycle = errors.New("data type dependencies form a cycle")
+
+// Dependency is a data type that must be extracted before the data type
+// holding it (D9). A target date of the holder is ready once the data type
+// depended on has a succeeded execution for that date or for one of the
+// maxLagDays days before it.
+type Dependency struct {
+	dependsOnID uuid.UUID
+	maxLagDays  int
+}
+
+// NewDependency returns a dependency on the data type dependsOnID. Returns an
+// error if maxLagDays is negative.
+func NewDependency(dependsOnID uuid.UUID, maxLagDays int) (Dependency, error) {
+	if maxLagDays < 0 {
+		return Dependency{}, fmt.Errorf("maxLagDays must not be negative: %d", maxLagDays)
+	}
+	return Dependency{dependsOnID: dependsOnID, maxLagDays: maxLagDays}, nil
+}
+
+func (d Dependency) DependsOnID() uuid.UUID { return d.dependsOnID }
+func (d Dependency) MaxLagDays() int        { return d.maxLagDays }
+
+// Window returns the range of target dates [from, to) whose succeeded
+// execution satisfies the dependency for the calendar date of target in its
+// location.
+func (d Dependency) Window(target time.Time) (from, to time.Time) {
+	day := time.Date(target.Year(), target.Month(), target.Day(), 0, 0, 0, 0, target.Location())
+	return day.AddDate(0, 0, -d.maxLagDays), day.AddDate(0, 0, 1)
+}
+
+// FindDependencyCycle returns a cycle in graph, which maps each data type ID
+// to the IDs it depends on, or nil if there is none. The cycle starts and
+// ends with the same ID. Data types are visited in ID order, so the same
+// graph always yields the same cycle.
+func FindDependencyCycle(graph map[uuid.UUID][]uuid.UUID) []uuid.UUID {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[uuid.UUID]int, len(graph))
+	var path []uuid.UUID
+
+	var visit func(id uuid.UUID) []uuid.UUID
+	visit = func(id uuid.UUID) []uuid.UUID {
+		switch state[id] {
+		case visiting:
+			start := slices.Index(path, id)
+			return append(slices.Clone(path[start:]), id)
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		path = append(path, id)
+		for _, next := range sortedIDs(graph[id]) {
+			if cycle := visit(next); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(graph))
+	for id := range graph {
+		ids = append(ids, id)
+	}
+	for _, id := range sortedIDs(ids) {
+		if cycle := visit(id); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+func sortedIDs(ids []uuid.UUID) []uuid.UUID {
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+	return sorted
+}
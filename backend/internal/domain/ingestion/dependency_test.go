@@ -0,0 +1,89 @@
+package ingestion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type DependencyTestSuite struct {
+	suite.Suite
+}
+
+func TestDependency(t *testing.T) {
+	suite.Run(t, new(DependencyTestSuite))
+}
+
+func (s *DependencyTestSuite) TestNewDependency() {
+	id := uuid.MustParse("01961f1a-89c4-7641-b052-4dca477a4501")
+
+	dep, err := NewDependency(id, 3)
+	s.Require().NoError(err)
+	s.Equal(id, dep.DependsOnID())
+	s.Equal(3, dep.MaxLagDays())
+
+	_, err = NewDependency(id, -1)
+	s.EqualError(err, "maxLagDays must not be negative: -1")
+}
+
+func (s *DependencyTestSuite) TestWindow() {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	target := time.Date(2026, 10, 16, 15, 30, 0, 0, jst)
+
+	type testCase struct {
+		name         string
+		maxLagDays   int
+		expectedFrom time.Time
+	}
+	tests := []testCase{
+		{name: "same date only", maxLagDays: 0, expectedFrom: time.Date(2026, 10, 16, 0, 0, 0, 0, jst)},
+		{name: "earlier dates allowed", maxLagDays: 7, expectedFrom: time.Date(2026, 10, 9, 0, 0, 0, 0, jst)},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			dep, err := NewDependency(uuid.Nil, tc.maxLagDays)
+			s.Require().NoError(err)
+
+			from, to := dep.Window(target)
+
+			s.Equal(tc.expectedFrom, from)
+			s.Equal(time.Date(2026, 10, 17, 0, 0, 0, 0, jst), to)
+		})
+	}
+}
+
+func (s *DependencyTestSuite) TestFindDependencyCycle() {
+	a := uuid.MustParse("01961f1a-89c4-7641-b052-4dca477a4501")
+	b := uuid.MustParse("01961f1a-89c4-7641-b052-4dca477a4502")
+	c := uuid.MustParse("01961f1a-89c4-7641-b052-4dca477a4503")
+
+	type testCase struct {
+		name     string
+		graph    map[uuid.UUID][]uuid.UUID
+		expected []uuid.UUID
+	}
+	tests := []testCase{
+		{name: "empty", graph: map[uuid.UUID][]uuid.UUID{}},
+		{
+			name:  "diamond is not a cycle",
+			graph: map[uuid.UUID][]uuid.UUID{a: {b, c}, b: {c}, c: nil},
+		},
+		{
+			name:     "self dependency",
+			graph:    map[uuid.UUID][]uuid.UUID{a: {a}},
+			expected: []uuid.UUID{a, a},
+		},
+		{
+			name:     "indirect cycle",
+			graph:    map[uuid.UUID][]uuid.UUID{a: {b}, b: {c}, c: {b}},
+			expected: []uuid.UUID{b, c, b},
+		},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, FindDependencyCycle(tc.graph))
+		})
+	}
+}
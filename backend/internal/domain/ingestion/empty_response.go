@@ -0,0 +1,34 @@
+package ingestion
+
+import "fmt"
+
+// EmptyResponsePolicy decides the outcome of an extraction whose response
+// holds zero records (D8).
+type EmptyResponsePolicy string
+
+const (
+	// EmptyResponsePolicySuccess lands the empty response and succeeds.
+	EmptyResponsePolicySuccess EmptyResponsePolicy = "success"
+	// EmptyResponsePolicyFail fails the execution without landing a file.
+	EmptyResponsePolicyFail EmptyResponsePolicy = "fail"
+	// EmptyResponsePolicyRetryLater fails the execution without landing a
+	// file and marks it as deferred, for data that is published after the
+	// scheduled run. The target date stays a gap, so the next run or
+	// backfill extracts it again.
+	EmptyResponsePolicyRetryLater EmptyResponsePolicy = "retry_later"
+)
+
+// NewEmptyResponsePolicy returns the policy named s. An empty s means
+// EmptyResponsePolicySuccess, the behavior before the policy was configurable.
+func NewEmptyResponsePolicy(s string) (EmptyResponsePolicy, error) {
+	switch EmptyResponsePolicy(s) {
+	case "", EmptyResponsePolicySuccess:
+		return EmptyResponsePolicySuccess, nil
+	case EmptyResponsePolicyFail:
+		return EmptyResponsePolicyFail, nil
+	case EmptyResponsePolicyRetryLater:
+		return EmptyResponsePolicyRetryLater, nil
+	default:
+		return "", fmt.Errorf("invalid empty response policy: %s", s)
+	}
+}
@@ -0,0 +1,28 @@
+package ingestion
+
+import "fmt"
+
+// RerunStrategy decides what happens to the landing file of a target date
+// when the same (source, data type, target date) is extracted again (FR-10).
+type RerunStrategy string
+
+const (
+	// RerunStrategyAppend writes a new file per run and keeps the earlier ones.
+	RerunStrategyAppend RerunStrategy = "append"
+	// RerunStrategyOverwrite writes every run of a target date to the same
+	// key, replacing the previous object.
+	RerunStrategyOverwrite RerunStrategy = "overwrite"
+)
+
+// NewRerunStrategy returns the strategy named s. An empty s means
+// RerunStrategyAppend, the behavior before the strategy was configurable.
+func NewRerunStrategy(s string) (RerunStrategy, error) {
+	switch RerunStrategy(s) {
+	case "", RerunStrategyAppend:
+		return RerunStrategyAppend, nil
+	case RerunStrategyOverwrite:
+		return RerunStrategyOverwrite, nil
+	default:
+		return "", fmt.Errorf("invalid rerun strategy: %s", s)
+	}
+}
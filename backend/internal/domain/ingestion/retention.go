@@ -0,0 +1,77 @@
+package ingestion
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetentionPolicy decides how long the superseded landing files of a data
+// type, or by default of every data type of a data source, are kept before
+// `task gc` purges them. A file is superseded when a later file of a
+// succeeded execution holds the data of its target date. It is kept while
+// any rule that is set keeps it, so the zero value, with no rules, keeps
+// every file. The current file of a target date is never purged.
+type RetentionPolicy struct {
+	keepLatest           *int
+	supersededMaxAgeDays *int
+}
+
+// NewRetentionPolicy creates a RetentionPolicy. keepLatest keeps the newest
+// files of each target date, including the current one; supersededMaxAgeDays
+// keeps superseded files for that many days after they were written. A nil
+// rule is not set.
+func NewRetentionPolicy(keepLatest *int, supersededMaxAgeDays *int) (RetentionPolicy, error) {
+	if keepLatest != nil && *keepLatest < 1 {
+		return RetentionPolicy{}, fmt.Errorf("invalid retention keepLatest: %d, must be at least 1", *keepLatest)
+	}
+	if supersededMaxAgeDays != nil && *supersededMaxAgeDays < 0 {
+		return RetentionPolicy{}, fmt.Errorf(
+			"invalid retention supersededMaxAgeDays: %d, must not be negative", *supersededMaxAgeDays,
+		)
+	}
+	return RetentionPolicy{keepLatest: keepLatest, supersededMaxAgeDays: supersededMaxAgeDays}, nil
+}
+
+func (p RetentionPolicy) KeepLatest() *int           { return p.keepLatest }
+func (p RetentionPolicy) SupersededMaxAgeDays() *int { return p.supersededMaxAgeDays }
+
+// IsZero reports whether the policy sets no rule and so keeps every file.
+func (p RetentionPolicy) IsZero() bool {
+	return p.keepLatest == nil && p.supersededMaxAgeDays == nil
+}
+
+// Or returns p, or fallback when p sets no rule. A data type that sets no
+// retention rule of its own follows the policy of its data source.
+func (p RetentionPolicy) Or(fallback RetentionPolicy) RetentionPolicy {
+	if p.IsZero() {
+		return fallback
+	}
+	return p
+}
+
+// Keeps reports whether the policy keeps a superseded file that is the
+// rank-th newest file of its target date, counting from 1, and was written
+// age ago.
+func (p RetentionPolicy) Keeps(rank int, age time.Duration) bool {
+	if p.IsZero() {
+		return true
+	}
+	if p.keepLatest != nil && rank <= *p.keepLatest {
+		return true
+	}
+	if p.supersededMaxAgeDays != nil && age < time.Duration(*p.supersededMaxAgeDays)*24*time.Hour {
+		return true
+	}
+	return false
+}
+
+// RetentionTarget is the retention policy in effect for a data type,
+// together with the names its landing files are recorded under and the
+// timezone its target dates are evaluated in.
+type RetentionTarget struct {
+	// Source is the name of the data source.
+	Source   string
+	DataType string
+	Timezone *time.Location
+	Policy   RetentionPolicy
+}
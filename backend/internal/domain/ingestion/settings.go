@@ -0,0 +1,122 @@
+package ingestion
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SourceKind identifies the provider behind a DataSource. It selects the
+// SettingsSchema used to validate the settings of the source and of its
+// data types, and cannot change after creation.
+type SourceKind string
+
+// SourceKindGeneric is the kind of sources that have no registered schema.
+// Their settings are stored as given without validation.
+const SourceKindGeneric SourceKind = "generic"
+
+// ErrUnknownSourceKind is returned when no schema is registered for a kind.
+var ErrUnknownSourceKind = errors.New("unknown data source kind")
+
+// SettingsSchema validates the settings of one source kind. Both methods
+// return a *SettingsError listing every invalid field, or nil.
+type SettingsSchema interface {
+	ValidateSourceSettings(settings map[string]any) error
+	ValidateDataTypeSettings(settings map[string]any) error
+}
+
+// SettingsSchemas maps each source kind to the schema its settings must satisfy.
+type SettingsSchemas map[SourceKind]SettingsSchema
+
+// Lookup returns the schema registered for kind. SourceKindGeneric is always
+// known and accepts any settings.
+func (s SettingsSchemas) Lookup(kind SourceKind) (SettingsSchema, error) {
+	if schema, ok := s[kind]; ok {
+		return schema, nil
+	}
+	if kind == SourceKindGeneric {
+		return genericSchema{}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownSourceKind, kind)
+}
+
+type genericSchema struct{}
+
+func (genericSchema) ValidateSourceSettings(map[string]any) error   { return nil }
+func (genericSchema) ValidateDataTypeSettings(map[string]any) error { return nil }
+
+// FieldError describes one invalid settings field. Path is dot-separated
+// from the settings root, e.g. "retry_policy.max_retries".
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+// SettingsError reports every invalid field found in a settings map.
+type SettingsError struct {
+	Fields []FieldError
+}
+
+func (e *SettingsError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, fmt.Sprintf("settings.%s: %s", f.Path, f.Message))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// NewSettingsError returns a *SettingsError for fields, or nil when fields is empty.
+func NewSettingsError(fields []FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return &SettingsError{Fields: fields}
+}
+
+// DecodeSettings decodes settings into the struct pointed to by dst using its
+// json tags. Unknown keys and values of the wrong type are reported as a
+// *SettingsError. A nil map decodes as an empty object.
+func DecodeSettings(settings map[string]any, dst any) error {
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		raw = []byte("{}")
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return NewSettingsError([]FieldError{{
+				Path:    typeErr.Field,
+				Message: fmt.Sprintf("must be of type %s", jsonTypeName(typeErr.Type.Kind().String())),
+			}})
+		}
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return NewSettingsError([]FieldError{{Path: strings.Trim(field, `"`), Message: "is not a known setting"}})
+		}
+		return NewSettingsError([]FieldError{{Message: err.Error()}})
+	}
+	return nil
+}
+
+func jsonTypeName(goKind string) string {
+	switch goKind {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "integer"
+	case "float32", "float64":
+		return "number"
+	case "bool":
+		return "boolean"
+	case "slice", "array":
+		return "array"
+	case "struct", "map", "ptr":
+		return "object"
+	default:
+		return goKind
+	}
+}
@@ -0,0 +1,55 @@
+package ingestion
+
+import (
+	"errors"
+	"testing"
+)
+
+type rejectAllSchema struct{}
+
+func (rejectAllSchema) ValidateSourceSettings(map[string]any) error {
+	return NewSettingsError([]FieldError{{Path: "x", Message: "rejected"}})
+}
+
+func (rejectAllSchema) ValidateDataTypeSettings(map[string]any) error {
+	return NewSettingsError([]FieldError{{Path: "y", Message: "rejected"}})
+}
+
+func TestSettingsSchemas_Lookup(t *testing.T) {
+	schemas := SettingsSchemas{"strict": rejectAllSchema{}}
+
+	generic, err := schemas.Lookup(SourceKindGeneric)
+	if err != nil {
+		t.Fatalf("generic lookup: %v", err)
+	}
+	if err := generic.ValidateSourceSettings(map[string]any{"anything": 1}); err != nil {
+		t.Errorf("generic schema rejected settings: %v", err)
+	}
+
+	strict, err := schemas.Lookup("strict")
+	if err != nil {
+		t.Fatalf("strict lookup: %v", err)
+	}
+	if err := strict.ValidateSourceSettings(nil); err == nil {
+		t.Error("expected registered schema to be used")
+	}
+
+	if _, err := schemas.Lookup("unknown"); !errors.Is(err, ErrUnknownSourceKind) {
+		t.Errorf("expected ErrUnknownSourceKind, got %v", err)
+	}
+}
+
+func TestSettingsError_Error(t *testing.T) {
+	err := NewSettingsError([]FieldError{
+		{Path: "plan", Message: "is required"},
+		{Path: "retry_policy.max_retries", Message: "must be between 0 and 10"},
+	})
+
+	expected := "settings.plan: is required; settings.retry_policy.max_retries: must be between 0 and 10"
+	if err.Error() != expected {
+		t.Errorf("Error() = %q, want %q", err.Error(), expected)
+	}
+	if NewSettingsError(nil) != nil {
+		t.Error("expected nil error for no fields")
+	}
+}
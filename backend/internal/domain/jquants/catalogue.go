@@ -0,0 +1,97 @@
+package jquants
+
+// Timezone is the timezone J-Quants publishes in; update times in the
+// Catalogue are in it.
+const Timezone = "Asia/Tokyo"
+
+// DataSourceName is the name of the data source the catalogue is seeded into.
+const DataSourceName = "jquants"
+
+// catalogueStaleTimeoutMinutes is the stale execution timeout of catalogue
+// entries. The spec defers it to the source-level default, which is unset on a
+// fresh source, so one hour covers the slowest documented responses.
+const catalogueStaleTimeoutMinutes = 60
+
+// CatalogueEntry is a documented J-Quants data type with its default
+// configuration.
+type CatalogueEntry struct {
+	Name            string
+	Endpoint        string
+	UpdateFrequency UpdateFrequency
+	// UpdateTimes are the JST times of day at which J-Quants publishes new
+	// data, as HH:MM. Times past midnight, such as 27:00, wrap to the next
+	// day. Weekly and irregular types are polled daily at their documented
+	// time, since gap detection decides whether anything is due.
+	UpdateTimes         []string
+	BackfillEnabled     bool
+	StaleTimeoutMinutes int
+}
+
+// Settings returns the data type settings of the entry.
+func (e CatalogueEntry) Settings() map[string]any {
+	return map[string]any{
+		"endpoint":         e.Endpoint,
+		"update_frequency": string(e.UpdateFrequency),
+	}
+}
+
+// Catalogue lists every data type documented in
+// doc/spec/data-ingestion/data-sources/jquants.md. Irregular types have no
+// business-day calendar of expected dates, so they are not backfilled.
+var Catalogue = []CatalogueEntry{
+	daily("listed_info", "/listed/info", "17:30"),
+	daily("daily_quotes", "/prices/daily_quotes", "16:30"),
+	daily("financial_statements", "/fins/fs_details", "18:00", "00:30"),
+	daily("statements", "/fins/statements", "18:00", "00:30"),
+	daily("index_option", "/option/index_option", "03:00"),
+	daily("prices_am", "/prices/prices_am", "12:00"),
+	daily("trades_spec", "/markets/short_selling", "16:30", "17:30"),
+	daily("margin_trading", "/markets/daily_margin_interest", "16:30"),
+	daily("breakdown", "/markets/breakdown", "18:00"),
+	daily("dividend", "/fins/dividend",
+		"12:00", "13:00", "14:00", "15:00", "16:00", "17:00", "18:00", "19:00"),
+	daily("indices", "/indices/topix", "16:30"),
+	daily("futures", "/derivatives/futures", "03:00"),
+	daily("options", "/derivatives/options", "03:00"),
+	{
+		Name:                "weekly_margin_trading",
+		Endpoint:            "/markets/weekly_margin_interest",
+		UpdateFrequency:     UpdateFrequencyWeekly,
+		UpdateTimes:         []string{"16:30"},
+		BackfillEnabled:     true,
+		StaleTimeoutMinutes: catalogueStaleTimeoutMinutes,
+	},
+	{
+		Name:                "trading_by_investor_type",
+		Endpoint:            "/markets/trades_spec",
+		UpdateFrequency:     UpdateFrequencyWeekly,
+		UpdateTimes:         []string{"18:00"},
+		BackfillEnabled:     true,
+		StaleTimeoutMinutes: catalogueStaleTimeoutMinutes,
+	},
+	{
+		Name:                "earnings_calendar",
+		Endpoint:            "/fins/announcement",
+		UpdateFrequency:     UpdateFrequencyIrregular,
+		UpdateTimes:         []string{"19:00"},
+		StaleTimeoutMinutes: catalogueStaleTimeoutMinutes,
+	},
+	{
+		Name:                "trading_calendar",
+		Endpoint:            "/markets/trading_calendar",
+		UpdateFrequency:     UpdateFrequencyIrregular,
+		UpdateTimes:         []string{"18:00"},
+		StaleTimeoutMinutes: catalogueStaleTimeoutMinutes,
+	},
+}
+
+func daily(name, endpoint string, times ...string) CatalogueEntry {
+	return CatalogueEntry{
+		Name:                name,
+		Endpoint:            endpoint,
+		UpdateFrequency:     UpdateFrequencyDaily,
+		UpdateTimes:         times,
+		BackfillEnabled:     true,
+		StaleTimeoutMinutes: catalogueStaleTimeoutMinutes,
+	}
+}
@@ -0,0 +1,35 @@
+package jquants
+
+import (
+	"testing"
+
+	"stock-tool/internal/domain/ingestion"
+)
+
+func TestCatalogue(t *testing.T) {
+	if len(Catalogue) != 17 {
+		t.Errorf("expected 17 documented data types, got %d", len(Catalogue))
+	}
+	seen := map[string]bool{}
+	for _, e := range Catalogue {
+		if seen[e.Name] {
+			t.Errorf("%s: listed more than once", e.Name)
+		}
+		seen[e.Name] = true
+
+		if err := (SettingsSchema{}).ValidateDataTypeSettings(e.Settings()); err != nil {
+			t.Errorf("%s: invalid settings: %v", e.Name, err)
+		}
+		if len(e.UpdateTimes) == 0 {
+			t.Errorf("%s: no update times", e.Name)
+		}
+		for _, s := range e.UpdateTimes {
+			if _, err := ingestion.NewTimeOfDay(s); err != nil {
+				t.Errorf("%s: %v", e.Name, err)
+			}
+		}
+		if e.StaleTimeoutMinutes <= 0 {
+			t.Errorf("%s: stale timeout must be positive", e.Name)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package jquants
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"stock-tool/internal/domain/ingestion"
+)
+
+// ErrTargetDateOutOfPlanRange is returned for target dates the plan of the
+// source cannot fetch. Retrying does not help until the plan changes or, for
+// dates inside the free plan delay, until enough time has passed.
+var ErrTargetDateOutOfPlanRange = errors.New("target date is outside the plan range")
+
+// freePlanDelayDays is how far behind today the free plan serves data (12 weeks).
+const freePlanDelayDays = 12 * 7
+
+// premiumEarliestDate is the start of the data J-Quants offers at all,
+// documented as "~2008-".
+var premiumEarliestDate = time.Date(2008, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// historyYears is the historical limit of each plan other than premium.
+var historyYears = map[Plan]int{
+	PlanFree:     2,
+	PlanLight:    5,
+	PlanStandard: 10,
+}
+
+// PlanFromSettings returns the plan of a J-Quants data source from its settings.
+func PlanFromSettings(settings map[string]any) (Plan, error) {
+	s, err := ParseSourceSettings(settings)
+	if err != nil {
+		return "", err
+	}
+	return s.Plan, nil
+}
+
+// SourcePlan returns the plan of src. ok is false when src is not a J-Quants
+// source, whose target dates no plan bounds.
+func SourcePlan(src *ingestion.DataSource) (plan Plan, ok bool, err error) {
+	if src.Kind() != Kind {
+		return "", false, nil
+	}
+	plan, err = PlanFromSettings(src.Settings())
+	if err != nil {
+		return "", false, fmt.Errorf("invalid settings of data source %s: %w", src.Name(), err)
+	}
+	return plan, true, nil
+}
+
+// CheckSourceTargetDate checks target against the plan of src as of now,
+// comparing calendar dates in the timezone of src. It returns an error
+// wrapping ErrTargetDateOutOfPlanRange when the plan cannot fetch target;
+// sources of other kinds accept every date.
+func CheckSourceTargetDate(src *ingestion.DataSource, target, now time.Time) error {
+	plan, ok, err := SourcePlan(src)
+	if err != nil || !ok {
+		return err
+	}
+	loc := src.Timezone()
+	return plan.CheckTargetDate(target.In(loc), now.In(loc))
+}
+
+// EarliestDate returns the first target date the plan can fetch as of now.
+// The date is midnight in the location of now.
+func (p Plan) EarliestDate(now time.Time) time.Time {
+	years, ok := historyYears[p]
+	if !ok {
+		return dateIn(premiumEarliestDate, now.Location())
+	}
+	return startOfDay(now).AddDate(-years, 0, 0)
+}
+
+// LatestDate returns the last target date the plan serves without delay as of
+// now: today for paid plans and 12 weeks ago for the free plan. The date is
+// midnight in the location of now.
+func (p Plan) LatestDate(now time.Time) time.Time {
+	today := startOfDay(now)
+	if p == PlanFree {
+		return today.AddDate(0, 0, -freePlanDelayDays)
+	}
+	return today
+}
+
+// Contains reports whether the plan can fetch target as of now. Only the
+// calendar date of target is compared.
+func (p Plan) Contains(target, now time.Time) bool {
+	d := dateIn(target, now.Location())
+	return !d.Before(p.EarliestDate(now)) && !d.After(p.LatestDate(now))
+}
+
+// CheckTargetDate returns an error wrapping ErrTargetDateOutOfPlanRange when
+// the plan cannot fetch target as of now.
+func (p Plan) CheckTargetDate(target, now time.Time) error {
+	if p.Contains(target, now) {
+		return nil
+	}
+	return fmt.Errorf(
+		"%w: %s is not between %s and %s on the %s plan",
+		ErrTargetDateOutOfPlanRange,
+		target.Format(time.DateOnly),
+		p.EarliestDate(now).Format(time.DateOnly),
+		p.LatestDate(now).Format(time.DateOnly),
+		p,
+	)
+}
+
+// FilterTargetDates returns the dates the plan can fetch as of now, in order.
+// Gap detection uses it to drop dates outside the plan from the expected set.
+func (p Plan) FilterTargetDates(dates []time.Time, now time.Time) []time.Time {
+	filtered := make([]time.Time, 0, len(dates))
+	for _, d := range dates {
+		if p.Contains(d, now) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// dateIn interprets the calendar date of t as midnight in loc.
+func dateIn(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return dateIn(t, t.Location())
+}
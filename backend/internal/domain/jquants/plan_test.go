@@ -0,0 +1,146 @@
+package jquants
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+
+	"stock-tool/internal/domain/ingestion"
+)
+
+func TestPlan_DateRange(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2026-10-18 06:00 in Tokyo, still 2026-10-17 in UTC.
+	now := time.Date(2026, 10, 17, 21, 0, 0, 0, time.UTC).In(tokyo)
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, tokyo) }
+
+	tests := []struct {
+		plan             Plan
+		expectedEarliest time.Time
+		expectedLatest   time.Time
+	}{
+		{plan: PlanFree, expectedEarliest: date(2024, 10, 18), expectedLatest: date(2026, 7, 26)},
+		{plan: PlanLight, expectedEarliest: date(2021, 10, 18), expectedLatest: date(2026, 10, 18)},
+		{plan: PlanStandard, expectedEarliest: date(2016, 10, 18), expectedLatest: date(2026, 10, 18)},
+		{plan: PlanPremium, expectedEarliest: date(2008, 1, 1), expectedLatest: date(2026, 10, 18)},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.plan), func(t *testing.T) {
+			if got := tt.plan.EarliestDate(now); !got.Equal(tt.expectedEarliest) {
+				t.Errorf("EarliestDate() = %v, want %v", got, tt.expectedEarliest)
+			}
+			if got := tt.plan.LatestDate(now); !got.Equal(tt.expectedLatest) {
+				t.Errorf("LatestDate() = %v, want %v", got, tt.expectedLatest)
+			}
+			if !tt.plan.Contains(tt.expectedEarliest, now) || !tt.plan.Contains(tt.expectedLatest, now) {
+				t.Error("Contains() = false for a range boundary")
+			}
+			if tt.plan.Contains(tt.expectedEarliest.AddDate(0, 0, -1), now) {
+				t.Error("Contains() = true before the earliest date")
+			}
+			if tt.plan.Contains(tt.expectedLatest.AddDate(0, 0, 1), now) {
+				t.Error("Contains() = true after the latest date")
+			}
+		})
+	}
+}
+
+func TestPlan_CheckTargetDate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	if err := PlanFree.CheckTargetDate(time.Date(2026, 7, 26, 0, 0, 0, 0, time.UTC), now); err != nil {
+		t.Errorf("CheckTargetDate() unexpected error: %v", err)
+	}
+
+	err := PlanFree.CheckTargetDate(time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC), now)
+	if !errors.Is(err, ErrTargetDateOutOfPlanRange) {
+		t.Fatalf("CheckTargetDate() error = %v, want ErrTargetDateOutOfPlanRange", err)
+	}
+	expected := "target date is outside the plan range: 2026-07-27 is not between 2024-10-18 and 2026-07-26 on the free plan"
+	if err.Error() != expected {
+		t.Errorf("CheckTargetDate() error = %q, want %q", err.Error(), expected)
+	}
+}
+
+func TestPlan_FilterTargetDates(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	dates := []time.Time{
+		time.Date(2024, 10, 17, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 10, 18, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 7, 26, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+	}
+
+	got := PlanFree.FilterTargetDates(dates, now)
+	if diff := cmp.Diff(dates[1:3], got); diff != "" {
+		t.Errorf("FilterTargetDates() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPlanFromSettings(t *testing.T) {
+	plan, err := PlanFromSettings(map[string]any{"plan": "standard"})
+	if err != nil || plan != PlanStandard {
+		t.Errorf("PlanFromSettings() = %q, %v, want standard", plan, err)
+	}
+	if _, err := PlanFromSettings(map[string]any{}); err == nil {
+		t.Error("PlanFromSettings() expected error for missing plan")
+	}
+}
+
+func TestCheckSourceTargetDate(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := func(kind ingestion.SourceKind, settings map[string]any) *ingestion.DataSource {
+		return ingestion.NewDataSourceDirectly(
+			uuid.Nil, kind, "src", true, tokyo, ingestion.RetentionPolicy{}, settings, 1, time.Time{}, time.Time{},
+		)
+	}
+	// 2026-10-18 06:00 in Tokyo; the free plan serves up to 2026-07-26 there.
+	now := time.Date(2026, 10, 17, 21, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		src     *ingestion.DataSource
+		target  time.Time
+		wantErr error
+	}{
+		{
+			name:   "latest date",
+			src:    source(Kind, map[string]any{"plan": "free"}),
+			target: time.Date(2026, 7, 26, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name: "target compared in the source timezone",
+			src:  source(Kind, map[string]any{"plan": "free"}),
+			// 2026-07-27 in Tokyo
+			target:  time.Date(2026, 7, 26, 15, 0, 0, 0, time.UTC),
+			wantErr: ErrTargetDateOutOfPlanRange,
+		},
+		{
+			name:   "other kinds accept every date",
+			src:    source(ingestion.SourceKindGeneric, map[string]any{}),
+			target: time.Date(2000, 1, 1, 0, 0, 0, 0, tokyo),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSourceTargetDate(tt.src, tt.target, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckSourceTargetDate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	err = CheckSourceTargetDate(source(Kind, map[string]any{}), now, now)
+	if err == nil || errors.Is(err, ErrTargetDateOutOfPlanRange) {
+		t.Errorf("CheckSourceTargetDate() error = %v, want an invalid settings error", err)
+	}
+}
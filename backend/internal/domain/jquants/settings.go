@@ -0,0 +1,172 @@
+// Package jquants holds the J-Quants-specific rules for data sources of kind
+// Kind: the settings schema and the subscription plan model.
+package jquants
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"stock-tool/internal/domain/ingestion"
+)
+
+// Kind is the source kind of J-Quants data sources.
+const Kind ingestion.SourceKind = "jquants"
+
+// Plan is the J-Quants subscription plan of a data source.
+type Plan string
+
+const (
+	PlanFree     Plan = "free"
+	PlanLight    Plan = "light"
+	PlanStandard Plan = "standard"
+	PlanPremium  Plan = "premium"
+)
+
+var plans = []Plan{PlanFree, PlanLight, PlanStandard, PlanPremium}
+
+func (p Plan) valid() bool { return slices.Contains(plans, p) }
+
+// UpdateFrequency is how often J-Quants publishes a data type.
+type UpdateFrequency string
+
+const (
+	UpdateFrequencyDaily     UpdateFrequency = "daily"
+	UpdateFrequencyWeekly    UpdateFrequency = "weekly"
+	UpdateFrequencyIrregular UpdateFrequency = "irregular"
+)
+
+var updateFrequencies = []UpdateFrequency{UpdateFrequencyDaily, UpdateFrequencyWeekly, UpdateFrequencyIrregular}
+
+// maxRetries caps RetryPolicy.MaxRetries so a misconfiguration cannot stall a run.
+const maxRetries = 10
+
+// SourceSettings is the settings schema of a J-Quants data source.
+type SourceSettings struct {
+	Plan Plan `json:"plan"`
+	// MaxConcurrentExecutions limits parallel executions against the API; nil means unlimited.
+	MaxConcurrentExecutions *int `json:"max_concurrent_executions,omitempty"`
+	// DefaultStaleTimeoutMinutes applies to data types that do not set their own.
+	DefaultStaleTimeoutMinutes *int `json:"default_stale_timeout_minutes,omitempty"`
+}
+
+// DataTypeSettings is the settings schema of a data type under a J-Quants source.
+type DataTypeSettings struct {
+	Endpoint        string          `json:"endpoint,omitempty"`
+	UpdateFrequency UpdateFrequency `json:"update_frequency,omitempty"`
+	// ProcessingRangeDays is the number of target dates fetched per execution.
+	ProcessingRangeDays *int         `json:"processing_range_days,omitempty"`
+	RetryPolicy         *RetryPolicy `json:"retry_policy,omitempty"`
+}
+
+// RetryPolicy controls retries of failed API requests with exponential backoff.
+type RetryPolicy struct {
+	MaxRetries            int `json:"max_retries"`
+	InitialBackoffSeconds int `json:"initial_backoff_seconds"`
+	MaxBackoffSeconds     int `json:"max_backoff_seconds"`
+}
+
+// DefaultRetryPolicy is the documented J-Quants default: 3 retries with
+// exponential backoff.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 3, InitialBackoffSeconds: 1, MaxBackoffSeconds: 30}
+
+// ParseSourceSettings decodes and validates the settings of a J-Quants data source.
+func ParseSourceSettings(settings map[string]any) (SourceSettings, error) {
+	var s SourceSettings
+	if err := ingestion.DecodeSettings(settings, &s); err != nil {
+		return SourceSettings{}, err
+	}
+	var errs []ingestion.FieldError
+	switch {
+	case s.Plan == "":
+		errs = append(errs, ingestion.FieldError{Path: "plan", Message: "is required"})
+	case !s.Plan.valid():
+		errs = append(errs, ingestion.FieldError{Path: "plan", Message: oneOf(plans)})
+	}
+	if v := s.MaxConcurrentExecutions; v != nil && *v < 1 {
+		errs = append(errs, ingestion.FieldError{Path: "max_concurrent_executions", Message: "must be at least 1"})
+	}
+	if v := s.DefaultStaleTimeoutMinutes; v != nil && *v < 0 {
+		errs = append(errs, ingestion.FieldError{
+			Path:    "default_stale_timeout_minutes",
+			Message: "must not be negative",
+		})
+	}
+	if err := ingestion.NewSettingsError(errs); err != nil {
+		return SourceSettings{}, err
+	}
+	return s, nil
+}
+
+// ParseDataTypeSettings decodes and validates the settings of a J-Quants data type.
+func ParseDataTypeSettings(settings map[string]any) (DataTypeSettings, error) {
+	var s DataTypeSettings
+	if err := ingestion.DecodeSettings(settings, &s); err != nil {
+		return DataTypeSettings{}, err
+	}
+	var errs []ingestion.FieldError
+	if s.Endpoint != "" && !strings.HasPrefix(s.Endpoint, "/") {
+		errs = append(errs, ingestion.FieldError{Path: "endpoint", Message: `must start with "/"`})
+	}
+	if s.UpdateFrequency != "" && !slices.Contains(updateFrequencies, s.UpdateFrequency) {
+		errs = append(errs, ingestion.FieldError{Path: "update_frequency", Message: oneOf(updateFrequencies)})
+	}
+	if v := s.ProcessingRangeDays; v != nil && *v < 1 {
+		errs = append(errs, ingestion.FieldError{Path: "processing_range_days", Message: "must be at least 1"})
+	}
+	if p := s.RetryPolicy; p != nil {
+		if p.MaxRetries < 0 || p.MaxRetries > maxRetries {
+			errs = append(errs, ingestion.FieldError{
+				Path:    "retry_policy.max_retries",
+				Message: fmt.Sprintf("must be between 0 and %d", maxRetries),
+			})
+		}
+		if p.InitialBackoffSeconds < 1 {
+			errs = append(errs, ingestion.FieldError{
+				Path:    "retry_policy.initial_backoff_seconds",
+				Message: "must be at least 1",
+			})
+		}
+		if p.MaxBackoffSeconds < p.InitialBackoffSeconds {
+			errs = append(errs, ingestion.FieldError{
+				Path:    "retry_policy.max_backoff_seconds",
+				Message: "must not be less than initial_backoff_seconds",
+			})
+		}
+	}
+	if err := ingestion.NewSettingsError(errs); err != nil {
+		return DataTypeSettings{}, err
+	}
+	return s, nil
+}
+
+// Retry returns the configured retry policy, or DefaultRetryPolicy when unset.
+func (s DataTypeSettings) Retry() RetryPolicy {
+	if s.RetryPolicy == nil {
+		return DefaultRetryPolicy
+	}
+	return *s.RetryPolicy
+}
+
+// SettingsSchema validates J-Quants settings; register it under Kind.
+type SettingsSchema struct{}
+
+var _ ingestion.SettingsSchema = SettingsSchema{}
+
+func (SettingsSchema) ValidateSourceSettings(settings map[string]any) error {
+	_, err := ParseSourceSettings(settings)
+	return err
+}
+
+func (SettingsSchema) ValidateDataTypeSettings(settings map[string]any) error {
+	_, err := ParseDataTypeSettings(settings)
+	return err
+}
+
+func oneOf[T ~string](values []T) string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = string(v)
+	}
+	return "must be one of " + strings.Join(strs, ", ")
+}
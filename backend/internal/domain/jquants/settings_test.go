@@ -0,0 +1,140 @@
+package jquants
+
+import (
+	"testing"
+
+	"stock-tool/internal/domain/ingestion"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/samber/lo"
+)
+
+func TestParseSourceSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    map[string]any
+		expected    SourceSettings
+		expectedErr []ingestion.FieldError
+	}{
+		{
+			name:     "valid",
+			settings: map[string]any{"plan": "light", "max_concurrent_executions": 2.0},
+			expected: SourceSettings{Plan: PlanLight, MaxConcurrentExecutions: lo.ToPtr(2)},
+		},
+		{
+			name:        "missing plan",
+			settings:    nil,
+			expectedErr: []ingestion.FieldError{{Path: "plan", Message: "is required"}},
+		},
+		{
+			name:     "misspelled plan and invalid concurrency",
+			settings: map[string]any{"plan": "freee", "max_concurrent_executions": 0.0},
+			expectedErr: []ingestion.FieldError{
+				{Path: "plan", Message: "must be one of free, light, standard, premium"},
+				{Path: "max_concurrent_executions", Message: "must be at least 1"},
+			},
+		},
+		{
+			name:        "wrong type",
+			settings:    map[string]any{"plan": 1.0},
+			expectedErr: []ingestion.FieldError{{Path: "plan", Message: "must be of type string"}},
+		},
+		{
+			name:        "unknown key",
+			settings:    map[string]any{"plan": "free", "token": "x"},
+			expectedErr: []ingestion.FieldError{{Path: "token", Message: "is not a known setting"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSourceSettings(tt.settings)
+			assertFieldErrors(t, tt.expectedErr, err)
+			if !cmp.Equal(tt.expected, got) {
+				t.Error(cmp.Diff(tt.expected, got))
+			}
+		})
+	}
+}
+
+func TestParseDataTypeSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    map[string]any
+		expected    DataTypeSettings
+		expectedErr []ingestion.FieldError
+	}{
+		{
+			name:     "empty",
+			settings: map[string]any{},
+			expected: DataTypeSettings{},
+		},
+		{
+			name: "valid",
+			settings: map[string]any{
+				"endpoint":         "/prices/daily_quotes",
+				"update_frequency": "daily",
+				"retry_policy":     map[string]any{"max_retries": 5.0, "initial_backoff_seconds": 2.0, "max_backoff_seconds": 60.0},
+			},
+			expected: DataTypeSettings{
+				Endpoint:        "/prices/daily_quotes",
+				UpdateFrequency: UpdateFrequencyDaily,
+				RetryPolicy:     &RetryPolicy{MaxRetries: 5, InitialBackoffSeconds: 2, MaxBackoffSeconds: 60},
+			},
+		},
+		{
+			name: "invalid values",
+			settings: map[string]any{
+				"update_frequency":      "hourly",
+				"processing_range_days": 0.0,
+				"retry_policy":          map[string]any{"max_retries": 11.0, "initial_backoff_seconds": 5.0, "max_backoff_seconds": 1.0},
+			},
+			expectedErr: []ingestion.FieldError{
+				{Path: "update_frequency", Message: "must be one of daily, weekly, irregular"},
+				{Path: "processing_range_days", Message: "must be at least 1"},
+				{Path: "retry_policy.max_retries", Message: "must be between 0 and 10"},
+				{Path: "retry_policy.max_backoff_seconds", Message: "must not be less than initial_backoff_seconds"},
+			},
+		},
+		{
+			name:        "nested wrong type",
+			settings:    map[string]any{"retry_policy": map[string]any{"max_retries": "3"}},
+			expectedErr: []ingestion.FieldError{{Path: "retry_policy.max_retries", Message: "must be of type integer"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDataTypeSettings(tt.settings)
+			assertFieldErrors(t, tt.expectedErr, err)
+			if !cmp.Equal(tt.expected, got) {
+				t.Error(cmp.Diff(tt.expected, got))
+			}
+		})
+	}
+}
+
+func TestDataTypeSettings_Retry(t *testing.T) {
+	if got := (DataTypeSettings{}).Retry(); got != DefaultRetryPolicy {
+		t.Errorf("Retry() = %+v, want default %+v", got, DefaultRetryPolicy)
+	}
+	custom := RetryPolicy{MaxRetries: 1, InitialBackoffSeconds: 1, MaxBackoffSeconds: 1}
+	if got := (DataTypeSettings{RetryPolicy: &custom}).Retry(); got != custom {
+		t.Errorf("Retry() = %+v, want %+v", got, custom)
+	}
+}
+
+func assertFieldErrors(t *testing.T, expected []ingestion.FieldError, err error) {
+	t.Helper()
+	if expected == nil {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	settingsErr, ok := err.(*ingestion.SettingsError)
+	if !ok {
+		t.Fatalf("expected *ingestion.SettingsError, got %T: %v", err, err)
+	}
+	if !cmp.Equal(expected, settingsErr.Fields) {
+		t.Error(cmp.Diff(expected, settingsErr.Fields))
+	}
+}
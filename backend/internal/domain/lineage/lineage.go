@@ -0,0 +1,210 @@
+package lineage
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"stock-tool/internal/util/clock"
+)
+
+// Zone is a layer of the lakehouse data moves through.
+type Zone string
+
+const (
+	ZoneLanding Zone = "landing"
+	ZoneBronze  Zone = "bronze"
+	ZoneSilver  Zone = "silver"
+	ZoneGold    Zone = "gold"
+)
+
+type ExecutionStatus string
+
+const (
+	ExecutionStatusRunning   ExecutionStatus = "running"
+	ExecutionStatusSucceeded ExecutionStatus = "succeeded"
+	ExecutionStatusFailed    ExecutionStatus = "failed"
+)
+
+// ProcessingExecution records a single batch run that moved data from one
+// zone to the next: what it read and what it wrote. Lineage is traced by
+// matching the input key patterns of an execution to the output references
+// of the executions of the zone before.
+// Status transitions: running -> succeeded (via Succeed) or
+// running -> failed (via Fail). Terminal status must not change.
+type ProcessingExecution struct {
+	id         int
+	sourceZone Zone
+	targetZone Zone
+	// sourceIdentifier names the input data set, e.g. "jquants/daily_quotes".
+	sourceIdentifier string
+	// targetIdentifier names the output data set, e.g. "jquants_daily_quotes".
+	targetIdentifier string
+	inputKeyPatterns []string
+	outputRefs       []string
+	recordsRead      int
+	recordsWritten   int
+	status           ExecutionStatus
+	errorInfo        *string
+	startedAt        *time.Time
+	finishedAt       *time.Time
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+func NewRunningProcessingExecution(
+	ctx context.Context,
+	sourceZone Zone,
+	targetZone Zone,
+	sourceIdentifier string,
+	targetIdentifier string,
+	inputKeyPatterns []string,
+) *ProcessingExecution {
+	now := clock.Now(ctx)
+	return &ProcessingExecution{
+		sourceZone:       sourceZone,
+		targetZone:       targetZone,
+		sourceIdentifier: sourceIdentifier,
+		targetIdentifier: targetIdentifier,
+		inputKeyPatterns: inputKeyPatterns,
+		outputRefs:       []string{},
+		status:           ExecutionStatusRunning,
+		startedAt:        &now,
+		createdAt:        now,
+		updatedAt:        now,
+	}
+}
+
+func NewProcessingExecutionDirectly(
+	id int,
+	sourceZone Zone,
+	targetZone Zone,
+	sourceIdentifier string,
+	targetIdentifier string,
+	inputKeyPatterns []string,
+	outputRefs []string,
+	recordsRead int,
+	recordsWritten int,
+	status ExecutionStatus,
+	errorInfo *string,
+	startedAt *time.Time,
+	finishedAt *time.Time,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *ProcessingExecution {
+	return &ProcessingExecution{
+		id:               id,
+		sourceZone:       sourceZone,
+		targetZone:       targetZone,
+		sourceIdentifier: sourceIdentifier,
+		targetIdentifier: targetIdentifier,
+		inputKeyPatterns: inputKeyPatterns,
+		outputRefs:       outputRefs,
+		recordsRead:      recordsRead,
+		recordsWritten:   recordsWritten,
+		status:           status,
+		errorInfo:        errorInfo,
+		startedAt:        startedAt,
+		finishedAt:       finishedAt,
+		createdAt:        createdAt,
+		updatedAt:        updatedAt,
+	}
+}
+
+// Succeed marks the execution succeeded with the references of what it wrote
+// and the number of records it read and wrote.
+func (e *ProcessingExecution) Succeed(ctx context.Context, outputRefs []string, recordsRead int, recordsWritten int) {
+	now := clock.Now(ctx)
+	e.status = ExecutionStatusSucceeded
+	e.outputRefs = outputRefs
+	e.recordsRead = recordsRead
+	e.recordsWritten = recordsWritten
+	e.finishedAt = &now
+	e.updatedAt = now
+}
+
+func (e *ProcessingExecution) Fail(ctx context.Context, errorInfo string) {
+	now := clock.Now(ctx)
+	e.status = ExecutionStatusFailed
+	e.errorInfo = &errorInfo
+	e.finishedAt = &now
+	e.updatedAt = now
+}
+
+func (e *ProcessingExecution) ID() int {
+	return e.id
+}
+
+func (e *ProcessingExecution) SourceZone() Zone {
+	return e.sourceZone
+}
+
+func (e *ProcessingExecution) TargetZone() Zone {
+	return e.targetZone
+}
+
+func (e *ProcessingExecution) SourceIdentifier() string {
+	return e.sourceIdentifier
+}
+
+func (e *ProcessingExecution) TargetIdentifier() string {
+	return e.targetIdentifier
+}
+
+// InputKeyPatterns returns the keys of the data the execution read, where
+// '*' in a pattern matches any sequence of characters, including '/'.
+func (e *ProcessingExecution) InputKeyPatterns() []string {
+	return e.inputKeyPatterns
+}
+
+// OutputRefs returns the keys (or snapshot references) of the data the
+// execution wrote; empty unless it succeeded.
+func (e *ProcessingExecution) OutputRefs() []string {
+	return e.outputRefs
+}
+
+func (e *ProcessingExecution) RecordsRead() int {
+	return e.recordsRead
+}
+
+func (e *ProcessingExecution) RecordsWritten() int {
+	return e.recordsWritten
+}
+
+func (e *ProcessingExecution) Status() ExecutionStatus {
+	return e.status
+}
+
+func (e *ProcessingExecution) ErrorInfo() *string {
+	return e.errorInfo
+}
+
+func (e *ProcessingExecution) StartedAt() *time.Time {
+	return e.startedAt
+}
+
+func (e *ProcessingExecution) FinishedAt() *time.Time {
+	return e.finishedAt
+}
+
+func (e *ProcessingExecution) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+func (e *ProcessingExecution) UpdatedAt() time.Time {
+	return e.updatedAt
+}
+
+// MatchKeyPattern reports whether key matches an input key pattern, in which
+// '*' matches any sequence of characters, including '/'.
+func MatchKeyPattern(pattern string, key string) bool {
+	if !strings.Contains(pattern, "*") {
+		return pattern == key
+	}
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(key)
+}
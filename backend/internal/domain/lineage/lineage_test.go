@@ -0,0 +1,100 @@
+package lineage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/util/clock"
+)
+
+type LineageTestSuite struct {
+	suite.Suite
+}
+
+func TestLineage(t *testing.T) {
+	suite.Run(t, new(LineageTestSuite))
+}
+
+func (s *LineageTestSuite) TestProcessingExecution() {
+	startedAt := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(time.Second)
+	inputs := []string{"landing/jquants/daily_quotes/2025/06/02/all.json"}
+
+	s.Run("succeed", func() {
+		e := NewRunningProcessingExecution(clock.WithFixedTime(context.Background(), startedAt),
+			ZoneLanding, ZoneBronze, "jquants/daily_quotes", "jquants_daily_quotes", inputs)
+		s.Equal(ExecutionStatusRunning, e.Status())
+		s.Equal(&startedAt, e.StartedAt())
+		s.Nil(e.FinishedAt())
+		s.Empty(e.OutputRefs())
+
+		outputs := []string{"bronze/jquants_daily_quotes/date=2025-06-02/data.parquet"}
+		e.Succeed(clock.WithFixedTime(context.Background(), finishedAt), outputs, 3, 3)
+		s.Equal(ExecutionStatusSucceeded, e.Status())
+		s.Equal(outputs, e.OutputRefs())
+		s.Equal(3, e.RecordsRead())
+		s.Equal(3, e.RecordsWritten())
+		s.Equal(&finishedAt, e.FinishedAt())
+		s.Equal(finishedAt, e.UpdatedAt())
+		s.Equal(startedAt, e.CreatedAt())
+	})
+
+	s.Run("fail", func() {
+		e := NewRunningProcessingExecution(clock.WithFixedTime(context.Background(), startedAt),
+			ZoneLanding, ZoneBronze, "jquants/daily_quotes", "jquants_daily_quotes", inputs)
+		e.Fail(clock.WithFixedTime(context.Background(), finishedAt), "failed to convert")
+		s.Equal(ExecutionStatusFailed, e.Status())
+		s.Equal("failed to convert", *e.ErrorInfo())
+		s.Equal(&finishedAt, e.FinishedAt())
+		s.Empty(e.OutputRefs())
+	})
+}
+
+func (s *LineageTestSuite) TestMatchKeyPattern() {
+	type TestCase struct {
+		name    string
+		pattern string
+		key     string
+		want    bool
+	}
+	tests := []TestCase{
+		{
+			name:    "exact key",
+			pattern: "bronze/jquants_brand/date=2025-06-02/data.parquet",
+			key:     "bronze/jquants_brand/date=2025-06-02/data.parquet",
+			want:    true,
+		},
+		{
+			name:    "different key",
+			pattern: "bronze/jquants_brand/date=2025-06-02/data.parquet",
+			key:     "bronze/jquants_brand/date=2025-06-03/data.parquet",
+			want:    false,
+		},
+		{
+			name:    "star spans directories",
+			pattern: "bronze/jquants_brand/*.parquet",
+			key:     "bronze/jquants_brand/date=2025-06-02/data.parquet",
+			want:    true,
+		},
+		{
+			name:    "other characters are literal",
+			pattern: "bronze/jquants_brand/date=2025-06-0?/*",
+			key:     "bronze/jquants_brand/date=2025-06-02/data.parquet",
+			want:    false,
+		},
+		{
+			name:    "anchored at both ends",
+			pattern: "jquants_brand/*",
+			key:     "bronze/jquants_brand/date=2025-06-02/data.parquet",
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.want, MatchKeyPattern(tt.pattern, tt.key))
+		})
+	}
+}
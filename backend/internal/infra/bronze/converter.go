@@ -0,0 +1,237 @@
+package bronze
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/decimal128"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/apache/arrow/go/v10/parquet"
+	"github.com/apache/arrow/go/v10/parquet/compress"
+	"github.com/apache/arrow/go/v10/parquet/pqarrow"
+	"github.com/shopspring/decimal"
+
+	"stock-tool/internal/api/jquants"
+)
+
+const (
+	// decimalPrecision and decimalScale are those of every decimal column.
+	// J-Quants sends at most a few decimal places; a value with more than
+	// decimalScale is rejected rather than rounded.
+	decimalPrecision = 38
+	decimalScale     = 10
+)
+
+var decimalType = &arrow.Decimal128Type{Precision: decimalPrecision, Scale: decimalScale}
+
+// listedInfoSchema and dailyQuotesSchema keep the field names of the API, so
+// a bronze table reads like the response it came from.
+var (
+	listedInfoSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "Date", Type: arrow.FixedWidthTypes.Date32},
+		{Name: "Code", Type: arrow.BinaryTypes.String},
+		{Name: "CompanyName", Type: arrow.BinaryTypes.String},
+		{Name: "CompanyNameEnglish", Type: arrow.BinaryTypes.String},
+		{Name: "Sector17Code", Type: arrow.BinaryTypes.String},
+		{Name: "Sector17CodeName", Type: arrow.BinaryTypes.String},
+		{Name: "Sector33Code", Type: arrow.BinaryTypes.String},
+		{Name: "Sector33CodeName", Type: arrow.BinaryTypes.String},
+		{Name: "ScaleCategory", Type: arrow.BinaryTypes.String},
+		{Name: "MarketCode", Type: arrow.BinaryTypes.String},
+		{Name: "MarketCodeName", Type: arrow.BinaryTypes.String},
+	}, nil)
+
+	dailyQuotesSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "Date", Type: arrow.FixedWidthTypes.Date32},
+		{Name: "Code", Type: arrow.BinaryTypes.String},
+		{Name: "Open", Type: decimalType, Nullable: true},
+		{Name: "High", Type: decimalType, Nullable: true},
+		{Name: "Low", Type: decimalType, Nullable: true},
+		{Name: "Close", Type: decimalType, Nullable: true},
+		{Name: "Volume", Type: decimalType, Nullable: true},
+		{Name: "TurnoverValue", Type: decimalType, Nullable: true},
+		{Name: "AdjustmentFactor", Type: decimalType},
+		{Name: "AdjustmentOpen", Type: decimalType, Nullable: true},
+		{Name: "AdjustmentHigh", Type: decimalType, Nullable: true},
+		{Name: "AdjustmentLow", Type: decimalType, Nullable: true},
+		{Name: "AdjustmentClose", Type: decimalType, Nullable: true},
+		{Name: "AdjustmentVolume", Type: decimalType, Nullable: true},
+	}, nil)
+)
+
+// Converter converts landing files to Parquet, one row per record of the
+// response, with no other transformation.
+type Converter struct {
+	mem memory.Allocator
+}
+
+func NewConverter() *Converter {
+	return &Converter{mem: memory.DefaultAllocator}
+}
+
+// Convert decodes the landing files of source and dataType read from rs,
+// already decompressed and oldest first, and writes their records to w as one
+// zstd-compressed Parquet file. A record with the same date and code as a
+// record of an earlier file replaces it, so where the windows of the files
+// overlap the newest fetch wins. Returns the number of rows written.
+func (c *Converter) Convert(source string, dataType string, rs []io.Reader, w io.Writer) (int, error) {
+	switch source {
+	case "jquants":
+		switch dataType {
+		// brand is the name the extract task lands listed_info under
+		case "brand", "listed_info":
+			return c.convertListedInfo(rs, w)
+		case "daily_quotes":
+			return c.convertDailyQuotes(rs, w)
+		default:
+			return 0, fmt.Errorf("unsupported data type: %s.%s", source, dataType)
+		}
+	default:
+		return 0, fmt.Errorf("unsupported source: %s", source)
+	}
+}
+
+func (c *Converter) convertListedInfo(rs []io.Reader, w io.Writer) (int, error) {
+	var brands records[jquants.BrandInfo]
+	for _, r := range rs {
+		var body jquants.ListBrandResponseBody
+		if err := json.NewDecoder(r).Decode(&body); err != nil {
+			return 0, fmt.Errorf("failed to decode listed-info response: %w", err)
+		}
+		for _, info := range body.Brands {
+			brands.put(recordKey{date: info.Date, code: info.Code}, info)
+		}
+	}
+
+	b := array.NewRecordBuilder(c.mem, listedInfoSchema)
+	defer b.Release()
+	for _, info := range brands.rows {
+		b.Field(0).(*array.Date32Builder).Append(date32(info.Date))
+		for i, s := range []string{
+			info.Code,
+			info.CompanyName,
+			info.CompanyNameEnglish,
+			info.Sector17Code,
+			info.Sector17CodeName,
+			info.Sector33Code,
+			info.Sector33CodeName,
+			info.ScaleCategory,
+			info.MarketCode,
+			info.MarketCodeName,
+		} {
+			b.Field(i + 1).(*array.StringBuilder).Append(s)
+		}
+	}
+	return len(brands.rows), c.write(b, w)
+}
+
+func (c *Converter) convertDailyQuotes(rs []io.Reader, w io.Writer) (int, error) {
+	var quotes records[jquants.DailyQuote]
+	for _, r := range rs {
+		var body jquants.GetDailyQuoteResponseBody
+		if err := json.NewDecoder(r).Decode(&body); err != nil {
+			return 0, fmt.Errorf("failed to decode daily-quotes response: %w", err)
+		}
+		for _, q := range body.DailyQuotes {
+			quotes.put(recordKey{date: q.Date, code: q.Code}, q)
+		}
+	}
+
+	b := array.NewRecordBuilder(c.mem, dailyQuotesSchema)
+	defer b.Release()
+	for _, q := range quotes.rows {
+		b.Field(0).(*array.Date32Builder).Append(date32(q.Date))
+		b.Field(1).(*array.StringBuilder).Append(q.Code)
+		for i, d := range []decimal.NullDecimal{
+			q.Open,
+			q.High,
+			q.Low,
+			q.Close,
+			q.Volume,
+			q.TurnoverValue,
+			{Decimal: q.AdjustmentFactor, Valid: true},
+			q.AdjustmentOpen,
+			q.AdjustmentHigh,
+			q.AdjustmentLow,
+			q.AdjustmentClose,
+			q.AdjustmentVolume,
+		} {
+			field := dailyQuotesSchema.Field(i + 2)
+			if err := appendDecimal(b.Field(i+2).(*array.Decimal128Builder), d); err != nil {
+				return 0, fmt.Errorf("invalid %s of %s on %s: %w", field.Name, q.Code, q.Date.Format(), err)
+			}
+		}
+	}
+	return len(quotes.rows), c.write(b, w)
+}
+
+// recordKey identifies a record of a response: one code on one date.
+type recordKey struct {
+	date jquants.Date
+	code string
+}
+
+// records collects the records of several responses in the order they were
+// first seen, keeping the last value put under each key.
+type records[T any] struct {
+	rows  []T
+	index map[recordKey]int
+}
+
+func (r *records[T]) put(key recordKey, row T) {
+	if i, ok := r.index[key]; ok {
+		r.rows[i] = row
+		return
+	}
+	if r.index == nil {
+		r.index = map[recordKey]int{}
+	}
+	r.index[key] = len(r.rows)
+	r.rows = append(r.rows, row)
+}
+
+// write writes the rows built in b to w as a Parquet file.
+func (c *Converter) write(b *array.RecordBuilder, w io.Writer) error {
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	props := parquet.NewWriterProperties(
+		parquet.WithCompression(compress.Codecs.Zstd),
+		parquet.WithAllocator(c.mem),
+	)
+	fw, err := pqarrow.NewFileWriter(rec.Schema(), w, props, pqarrow.NewArrowWriterProperties(
+		pqarrow.WithAllocator(c.mem),
+	))
+	if err != nil {
+		return fmt.Errorf("failed to create Parquet writer: %w", err)
+	}
+	if err := fw.Write(rec); err != nil {
+		return fmt.Errorf("failed to write Parquet rows: %w", err)
+	}
+	if err := fw.Close(); err != nil {
+		return fmt.Errorf("failed to finish Parquet file: %w", err)
+	}
+	return nil
+}
+
+func date32(d jquants.Date) arrow.Date32 {
+	return arrow.Date32FromTime(time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC))
+}
+
+// appendDecimal appends d at decimalScale, or null if it is not valid.
+func appendDecimal(b *array.Decimal128Builder, d decimal.NullDecimal) error {
+	if !d.Valid {
+		b.AppendNull()
+		return nil
+	}
+	scaled := d.Decimal.Shift(decimalScale)
+	if !scaled.Equal(scaled.Truncate(0)) {
+		return fmt.Errorf("%s has more than %d decimal places", d.Decimal, decimalScale)
+	}
+	b.Append(decimal128.FromBigInt(scaled.BigInt()))
+	return nil
+}
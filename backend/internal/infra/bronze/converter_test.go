@@ -0,0 +1,135 @@
+package bronze
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/decimal128"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+	"github.com/apache/arrow/go/v10/parquet/pqarrow"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConverterTestSuite struct {
+	suite.Suite
+	converter *Converter
+}
+
+func TestConverter(t *testing.T) {
+	suite.Run(t, new(ConverterTestSuite))
+}
+
+func (s *ConverterTestSuite) SetupTest() {
+	s.converter = NewConverter()
+}
+
+// convert converts bodies and reads the Parquet file back.
+func (s *ConverterTestSuite) convert(dataType string, bodies ...string) (int, arrow.Table) {
+	rs := make([]io.Reader, 0, len(bodies))
+	for _, body := range bodies {
+		rs = append(rs, strings.NewReader(body))
+	}
+	var buf bytes.Buffer
+	rows, err := s.converter.Convert("jquants", dataType, rs, &buf)
+	s.Require().NoError(err)
+
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf.Bytes()), nil,
+		pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	s.Require().NoError(err)
+	s.T().Cleanup(table.Release)
+	return rows, table
+}
+
+func (s *ConverterTestSuite) column(table arrow.Table, name string) arrow.Array {
+	indices := table.Schema().FieldIndices(name)
+	s.Require().Len(indices, 1, name)
+	chunks := table.Column(indices[0]).Data().Chunks()
+	s.Require().Len(chunks, 1, name)
+	return chunks[0]
+}
+
+func decimalString(n decimal128.Num) string {
+	return decimal.NewFromBigInt(n.BigInt(), -decimalScale).String()
+}
+
+func (s *ConverterTestSuite) TestListedInfo() {
+	rows, table := s.convert("listed_info", `{"info":[
+		{"Date":"2025-06-02","Code":"86970","CompanyName":"日本取引所グループ","CompanyNameEnglish":"Japan Exchange Group,Inc.",
+		 "Sector17Code":"16","Sector17CodeName":"金融（除く銀行）","Sector33Code":"7200","Sector33CodeName":"その他金融業",
+		 "ScaleCategory":"TOPIX Large70","MarketCode":"0111","MarketCodeName":"プライム"}
+	]}`)
+
+	s.Equal(1, rows)
+	s.Equal(int64(1), table.NumRows())
+	s.Equal(11, int(table.NumCols()))
+	s.Equal("2025-06-02", s.column(table, "Date").(*array.Date32).Value(0).FormattedString())
+	s.Equal("86970", s.column(table, "Code").(*array.String).Value(0))
+	s.Equal("日本取引所グループ", s.column(table, "CompanyName").(*array.String).Value(0))
+	s.Equal("プライム", s.column(table, "MarketCodeName").(*array.String).Value(0))
+}
+
+func (s *ConverterTestSuite) TestDailyQuotes() {
+	rows, table := s.convert("daily_quotes", `{"daily_quotes":[
+		{"Date":"2025-06-02","Code":"86970","Open":2047.5,"High":2069.0,"Low":2035.5,"Close":2045.0,
+		 "Volume":2202500.0,"TurnoverValue":4507051850.0,"AdjustmentFactor":1.0,"AdjustmentOpen":2047.5,
+		 "AdjustmentHigh":2069.0,"AdjustmentLow":2035.5,"AdjustmentClose":2045.0,"AdjustmentVolume":2202500.0},
+		{"Date":"2025-06-02","Code":"13010","Open":null,"High":null,"Low":null,"Close":null,
+		 "Volume":null,"TurnoverValue":null,"AdjustmentFactor":0.5,"AdjustmentOpen":null,
+		 "AdjustmentHigh":null,"AdjustmentLow":null,"AdjustmentClose":null,"AdjustmentVolume":null}
+	]}`)
+
+	s.Equal(2, rows)
+	s.Equal(int64(2), table.NumRows())
+	open := s.column(table, "Open").(*array.Decimal128)
+	s.Equal(&arrow.Decimal128Type{Precision: 38, Scale: 10}, open.DataType())
+	s.Equal("2047.5", decimalString(open.Value(0)))
+	// Days without trades stay null rather than zero.
+	s.True(open.IsNull(1))
+	s.Equal("4507051850", decimalString(s.column(table, "TurnoverValue").(*array.Decimal128).Value(0)))
+	factor := s.column(table, "AdjustmentFactor").(*array.Decimal128)
+	s.Equal("0.5", decimalString(factor.Value(1)))
+}
+
+func (s *ConverterTestSuite) TestMerge() {
+	// The whole date, then one code fetched again later
+	rows, table := s.convert("listed_info", `{"info":[
+		{"Date":"2025-06-02","Code":"86970","CompanyName":"日本取引所グループ","MarketCodeName":"スタンダード"},
+		{"Date":"2025-06-02","Code":"13010","CompanyName":"極洋","MarketCodeName":"プライム"}
+	]}`, `{"info":[
+		{"Date":"2025-06-02","Code":"86970","CompanyName":"日本取引所グループ","MarketCodeName":"プライム"},
+		{"Date":"2025-06-03","Code":"86970","CompanyName":"日本取引所グループ","MarketCodeName":"プライム"}
+	]}`)
+
+	// The later record replaces the earlier one in place
+	s.Equal(3, rows)
+	codes := s.column(table, "Code").(*array.String)
+	dates := s.column(table, "Date").(*array.Date32)
+	markets := s.column(table, "MarketCodeName").(*array.String)
+	s.Equal("86970", codes.Value(0))
+	s.Equal("プライム", markets.Value(0))
+	s.Equal("13010", codes.Value(1))
+	s.Equal("86970", codes.Value(2))
+	s.Equal("2025-06-03", dates.Value(2).FormattedString())
+}
+
+func (s *ConverterTestSuite) TestInvalid() {
+	var buf bytes.Buffer
+	_, err := s.converter.Convert("jquants", "daily_quotes", []io.Reader{strings.NewReader(`{"daily_quotes":[
+		{"Date":"2025-06-02","Code":"86970","Open":0.12345678901,"AdjustmentFactor":1}
+	]}`)}, &buf)
+	s.EqualError(err, "invalid Open of 86970 on 2025-06-02: 0.12345678901 has more than 10 decimal places")
+
+	_, err = s.converter.Convert("jquants", "daily_quotes", []io.Reader{strings.NewReader(`not json`)}, &buf)
+	s.ErrorContains(err, "failed to decode daily-quotes response")
+
+	_, err = s.converter.Convert("jquants", "statements", []io.Reader{strings.NewReader(`{}`)}, &buf)
+	s.EqualError(err, "unsupported data type: jquants.statements")
+	_, err = s.converter.Convert("edinet", "listed_info", []io.Reader{strings.NewReader(`{}`)}, &buf)
+	s.EqualError(err, "unsupported source: edinet")
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"stock-tool/internal/domain/auth"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+type APIKey struct {
+	ID        uuid.UUID `gorm:"type:uuid"`
+	Name      string
+	Role      string
+	KeyHash   string
+	CreatedAt time.Time `gorm:"autoCreateTime:false"`
+	RevokedAt *time.Time
+}
+
+func (m *APIKey) toEntity() *auth.APIKey {
+	return auth.NewAPIKeyDirectly(m.ID, m.Name, auth.Role(m.Role), m.KeyHash, m.CreatedAt, m.RevokedAt)
+}
+
+func toAPIKeyDBModel(e *auth.APIKey) *APIKey {
+	return &APIKey{
+		ID:        e.ID(),
+		Name:      e.Name(),
+		Role:      string(e.Role()),
+		KeyHash:   e.KeyHash(),
+		CreatedAt: e.CreatedAt(),
+		RevokedAt: e.RevokedAt(),
+	}
+}
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *auth.APIKey) error {
+	return r.db.WithContext(ctx).Create(toAPIKeyDBModel(key)).Error
+}
+
+func (r *APIKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*auth.APIKey, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r *APIKeyRepository) FindByKeyHash(ctx context.Context, keyHash string) (*auth.APIKey, error) {
+	return r.findOne(ctx, "key_hash = ?", keyHash)
+}
+
+func (r *APIKeyRepository) findOne(ctx context.Context, query string, arg any) (*auth.APIKey, error) {
+	var dbKey APIKey
+	err := r.db.WithContext(ctx).First(&dbKey, query, arg).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dbKey.toEntity(), nil
+}
+
+// List returns every key, revoked ones included, oldest first.
+func (r *APIKeyRepository) List(ctx context.Context) ([]*auth.APIKey, error) {
+	var dbKeys []APIKey
+	if err := r.db.WithContext(ctx).Order("created_at, id").Find(&dbKeys).Error; err != nil {
+		return nil, err
+	}
+	return lo.Map(dbKeys, func(k APIKey, _ int) *auth.APIKey { return k.toEntity() }), nil
+}
+
+// Revoke persists the revocation time of key.
+func (r *APIKeyRepository) Revoke(ctx context.Context, key *auth.APIKey) error {
+	return r.db.WithContext(ctx).
+		Model(&APIKey{}).
+		Where("id = ?", key.ID()).
+		Update("revoked_at", key.RevokedAt()).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"stock-tool/internal/domain/auth"
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/testutil"
+)
+
+var apiKeyCmpOpts = cmp.Options{
+	cmp.AllowUnexported(auth.APIKey{}),
+	cmp.Comparer(func(a, b time.Time) bool {
+		return a.Round(time.Microsecond).Equal(b.Round(time.Microsecond))
+	}),
+}
+
+type APIKeyRepositoryTestSuite struct {
+	testutil.DBTest
+	repo *APIKeyRepository
+	db   *gorm.DB
+}
+
+func TestAPIKeyRepository(t *testing.T) {
+	suite.Run(t, new(APIKeyRepositoryTestSuite))
+}
+
+func (s *APIKeyRepositoryTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+
+	s.db = db
+	s.repo = NewAPIKeyRepository(db)
+}
+
+func (s *APIKeyRepositoryTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+func (s *APIKeyRepositoryTestSuite) TestCreateAndFind() {
+	ctx := context.Background()
+	key, plaintext, err := auth.NewAPIKey(ctx, "ci", auth.RoleAdmin)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Create(ctx, key))
+
+	byHash, err := s.repo.FindByKeyHash(ctx, auth.HashKey(plaintext))
+	s.Require().NoError(err)
+	s.True(cmp.Equal(key, byHash, apiKeyCmpOpts...), cmp.Diff(key, byHash, apiKeyCmpOpts...))
+
+	byID, err := s.repo.FindByID(ctx, key.ID())
+	s.Require().NoError(err)
+	s.True(cmp.Equal(key, byID, apiKeyCmpOpts...), cmp.Diff(key, byID, apiKeyCmpOpts...))
+
+	missing, err := s.repo.FindByKeyHash(ctx, auth.HashKey("stk_unknown"))
+	s.NoError(err)
+	s.Nil(missing)
+}
+
+func (s *APIKeyRepositoryTestSuite) TestRevoke() {
+	ctx := context.Background()
+	key, _, err := auth.NewAPIKey(ctx, "ci", auth.RoleReadOnly)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Create(ctx, key))
+
+	key.Revoke(clock.WithFixedTime(ctx, time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)))
+	s.Require().NoError(s.repo.Revoke(ctx, key))
+
+	keys, err := s.repo.List(ctx)
+	s.Require().NoError(err)
+	s.True(cmp.Equal([]*auth.APIKey{key}, keys, apiKeyCmpOpts...), cmp.Diff([]*auth.APIKey{key}, keys, apiKeyCmpOpts...))
+}
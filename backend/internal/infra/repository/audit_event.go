@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"stock-tool/internal/domain/audit"
+	"stock-tool/internal/util/pagination"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type AuditEvent struct {
+	ID         uuid.UUID `gorm:"type:uuid"`
+	OccurredAt time.Time
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   uuid.UUID `gorm:"type:uuid"`
+	Before     *datatypes.JSONType[map[string]any]
+	After      *datatypes.JSONType[map[string]any]
+}
+
+func (m *AuditEvent) toEntity() *audit.Event {
+	return audit.NewEventDirectly(
+		m.ID,
+		m.OccurredAt,
+		m.Actor,
+		audit.Action(m.Action),
+		audit.EntityType(m.EntityType),
+		m.EntityID,
+		jsonObjectOrNil(m.Before),
+		jsonObjectOrNil(m.After),
+	)
+}
+
+func toAuditEventDBModel(e *audit.Event) *AuditEvent {
+	return &AuditEvent{
+		ID:         e.ID(),
+		OccurredAt: e.OccurredAt(),
+		Actor:      e.Actor(),
+		Action:     string(e.Action()),
+		EntityType: string(e.EntityType()),
+		EntityID:   e.EntityID(),
+		Before:     newJSONObjectOrNil(e.Before()),
+		After:      newJSONObjectOrNil(e.After()),
+	}
+}
+
+func jsonObjectOrNil(v *datatypes.JSONType[map[string]any]) map[string]any {
+	if v == nil {
+		return nil
+	}
+	return v.Data()
+}
+
+func newJSONObjectOrNil(m map[string]any) *datatypes.JSONType[map[string]any] {
+	if m == nil {
+		return nil
+	}
+	return lo.ToPtr(datatypes.NewJSONType(m))
+}
+
+// recordAuditEvent writes an audit event for a change made through tx, so
+// the event commits or rolls back together with the change.
+func recordAuditEvent(
+	ctx context.Context,
+	tx *gorm.DB,
+	action audit.Action,
+	entityType audit.EntityType,
+	entityID uuid.UUID,
+	before map[string]any,
+	after map[string]any,
+) error {
+	event, err := audit.NewEvent(ctx, action, entityType, entityID, before, after)
+	if err != nil {
+		return err
+	}
+	return tx.Create(toAuditEventDBModel(event)).Error
+}
+
+type AuditEventRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditEventRepository(db *gorm.DB) *AuditEventRepository {
+	return &AuditEventRepository{db: db}
+}
+
+func (r *AuditEventRepository) List(
+	ctx context.Context,
+	filter audit.EventFilter,
+	page pagination.Params,
+) ([]*audit.Event, error) {
+	query := r.db.WithContext(ctx)
+	if filter.EntityType != nil {
+		query = query.Where("entity_type = ?", string(*filter.EntityType))
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Since != nil {
+		query = query.Where("occurred_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("occurred_at < ?", *filter.Until)
+	}
+
+	var dbEvents []AuditEvent
+	if err := paginate(query, page).Find(&dbEvents).Error; err != nil {
+		return nil, err
+	}
+	return lo.Map(dbEvents, func(e AuditEvent, _ int) *audit.Event { return e.toEntity() }), nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"stock-tool/internal/domain/audit"
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/pagination"
+	"stock-tool/internal/util/testutil"
+)
+
+type AuditEventRepositoryTestSuite struct {
+	testutil.DBTest
+	db     *gorm.DB
+	repo   *AuditEventRepository
+	dsRepo *DataSourceRepository
+	dtRepo *DataTypeRepository
+}
+
+func TestAuditEventRepository(t *testing.T) {
+	suite.Run(t, new(AuditEventRepositoryTestSuite))
+}
+
+func (s *AuditEventRepositoryTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+
+	s.db = db
+	s.repo = NewAuditEventRepository(db)
+	s.dsRepo = NewDataSourceRepository(db)
+	s.dtRepo = NewDataTypeRepository(db)
+}
+
+func (s *AuditEventRepositoryTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+func (s *AuditEventRepositoryTestSuite) list(filter audit.EventFilter) []*audit.Event {
+	events, err := s.repo.List(context.Background(), filter, pagination.Params{
+		Limit: pagination.MaxLimit,
+		Sort:  pagination.Sort{Field: pagination.SortFieldID},
+	})
+	s.Require().NoError(err)
+	return events
+}
+
+func (s *AuditEventRepositoryTestSuite) TestRecordsChanges() {
+	ctx := audit.WithActor(context.Background(), "api_key:ci")
+
+	src, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "src", true, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+	)
+	s.Require().NoError(err)
+	src, err = s.dsRepo.Create(ctx, src)
+	s.Require().NoError(err)
+
+	schedule, err := ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})
+	s.Require().NoError(err)
+	dt, err := s.dtRepo.Create(ctx, ingestion.NewDataType(
+		ctx, src.ID(), "brand", true, schedule, false, 30,
+		ingestion.RerunStrategyAppend, ingestion.EmptyResponsePolicySuccess, ingestion.CompressionNone, ingestion.RetentionPolicy{}, nil,
+	))
+	s.Require().NoError(err)
+
+	dt.Update(ctx, "brand", false, schedule, false, 30,
+		ingestion.RerunStrategyAppend, ingestion.EmptyResponsePolicySuccess, ingestion.CompressionNone,
+		ingestion.RetentionPolicy{}, nil)
+	s.Require().NoError(s.dtRepo.Update(ctx, dt))
+
+	s.Require().NoError(s.dsRepo.Delete(ctx, src.ID(), nil))
+
+	type row struct {
+		action     audit.Action
+		entityType audit.EntityType
+		entityID   uuid.UUID
+		before     map[string]any
+		after      map[string]any
+	}
+	got := lo.Map(s.list(audit.EventFilter{}), func(e *audit.Event, _ int) row {
+		s.Equal("api_key:ci", e.Actor())
+		return row{e.Action(), e.EntityType(), e.EntityID(), e.Before(), e.After()}
+	})
+	s.Require().Len(got, 5)
+	s.Equal(audit.ActionCreate, got[0].action)
+	s.Equal(src.ID(), got[0].entityID)
+	s.Nil(got[0].before)
+	s.Equal("src", got[0].after["name"])
+	s.Equal(audit.ActionCreate, got[1].action)
+	s.Equal(dt.ID(), got[1].entityID)
+	s.Equal(row{
+		action:     audit.ActionUpdate,
+		entityType: audit.EntityTypeDataType,
+		entityID:   dt.ID(),
+		before:     map[string]any{"enabled": true},
+		after:      map[string]any{"enabled": false},
+	}, got[2])
+	s.Equal(audit.ActionDelete, got[3].action)
+	s.Equal(dt.ID(), got[3].entityID, "cascaded data type delete is recorded")
+	s.Equal(false, got[3].before["enabled"])
+	s.Nil(got[3].after)
+	s.Equal(audit.ActionDelete, got[4].action)
+	s.Equal(src.ID(), got[4].entityID)
+}
+
+func (s *AuditEventRepositoryTestSuite) TestRollsBackWithChange() {
+	ctx := context.Background()
+	src, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "src", true, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+	)
+	s.Require().NoError(err)
+	_, err = s.dsRepo.Create(ctx, src)
+	s.Require().NoError(err)
+
+	stale := ingestion.NewDataSourceDirectly(
+		src.ID(), src.Kind(), "renamed", true, src.Timezone(), src.Retention(), map[string]any{}, 2,
+		src.CreatedAt(), src.UpdatedAt(),
+	)
+	s.ErrorIs(s.dsRepo.Update(ctx, stale), ingestion.ErrVersionMismatch)
+
+	duplicate, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "src", true, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+	)
+	s.Require().NoError(err)
+	_, err = s.dsRepo.Create(ctx, duplicate)
+	s.ErrorIs(err, ingestion.ErrDataSourceNameConflict)
+
+	events := s.list(audit.EventFilter{})
+	s.Require().Len(events, 1)
+	s.Equal(audit.ActionCreate, events[0].Action())
+	s.Equal(audit.SystemActor, events[0].Actor())
+}
+
+func (s *AuditEventRepositoryTestSuite) TestList_Filters() {
+	t0 := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	var ids []uuid.UUID
+	for i, name := range []string{"a", "b", "c"} {
+		ctx := clock.WithFixedTime(context.Background(), t0.Add(time.Duration(i)*time.Hour))
+		src, err := ingestion.NewDataSource(
+			ctx, ingestion.SourceKindGeneric, name, true, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+		)
+		s.Require().NoError(err)
+		_, err = s.dsRepo.Create(ctx, src)
+		s.Require().NoError(err)
+		ids = append(ids, src.ID())
+	}
+
+	byEntity := s.list(audit.EventFilter{EntityID: &ids[1]})
+	s.Require().Len(byEntity, 1)
+	s.Equal(ids[1], byEntity[0].EntityID())
+
+	since, until := t0.Add(time.Hour), t0.Add(2*time.Hour)
+	byTime := s.list(audit.EventFilter{Since: &since, Until: &until})
+	s.Require().Len(byTime, 1)
+	s.Equal(ids[1], byTime[0].EntityID())
+
+	dataTypes := s.list(audit.EventFilter{EntityType: lo.ToPtr(audit.EntityTypeDataType)})
+	s.Empty(dataTypes)
+}
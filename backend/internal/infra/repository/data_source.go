@@ -6,32 +6,44 @@ import (
 	"fmt"
 	"time"
 
+	"stock-tool/internal/domain/audit"
 	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/util/pagination"
 
 	"github.com/google/uuid"
 	"github.com/samber/lo"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DataSource struct {
-	ID        uuid.UUID `gorm:"type:uuid"`
-	Name      string
-	Enabled   bool
-	Timezone  string
-	Settings  datatypes.JSONType[map[string]any]
-	CreatedAt time.Time `gorm:"autoCreateTime:false"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime:false"`
+	ID       uuid.UUID `gorm:"type:uuid"`
+	Kind     string    `gorm:"default:generic"`
+	Name     string
+	Enabled  bool
+	Timezone string
+	// RetentionKeepLatest and RetentionSupersededMaxAgeDays are NULL for
+	// retention rules that are not set.
+	RetentionKeepLatest           *int
+	RetentionSupersededMaxAgeDays *int
+	Settings                      datatypes.JSONType[map[string]any]
+	Version                       int       `gorm:"default:1"`
+	CreatedAt                     time.Time `gorm:"autoCreateTime:false"`
+	UpdatedAt                     time.Time `gorm:"autoUpdateTime:false"`
 }
 
 func (m *DataSource) toEntity() *ingestion.DataSource {
 	loc, _ := time.LoadLocation(m.Timezone)
 	return ingestion.NewDataSourceDirectly(
 		m.ID,
+		ingestion.SourceKind(m.Kind),
 		m.Name,
 		m.Enabled,
 		loc,
+		toRetentionPolicy(m.RetentionKeepLatest, m.RetentionSupersededMaxAgeDays),
 		m.Settings.Data(),
+		m.Version,
 		m.CreatedAt,
 		m.UpdatedAt,
 	)
@@ -39,13 +51,30 @@ func (m *DataSource) toEntity() *ingestion.DataSource {
 
 func toDataSourceDBModel(e *ingestion.DataSource) *DataSource {
 	return &DataSource{
-		ID:        e.ID(),
-		Name:      e.Name(),
-		Enabled:   e.Enabled(),
-		Timezone:  e.TimezoneString(),
-		Settings:  datatypes.NewJSONType(e.Settings()),
-		CreatedAt: e.CreatedAt(),
-		UpdatedAt: e.UpdatedAt(),
+		ID:                            e.ID(),
+		Kind:                          string(e.Kind()),
+		Name:                          e.Name(),
+		Enabled:                       e.Enabled(),
+		Timezone:                      e.TimezoneString(),
+		RetentionKeepLatest:           e.Retention().KeepLatest(),
+		RetentionSupersededMaxAgeDays: e.Retention().SupersededMaxAgeDays(),
+		Settings:                      datatypes.NewJSONType(e.Settings()),
+		Version:                       e.Version(),
+		CreatedAt:                     e.CreatedAt(),
+		UpdatedAt:                     e.UpdatedAt(),
+	}
+}
+
+// auditSnapshot returns the fields of the row recorded in audit events.
+func (m *DataSource) auditSnapshot() map[string]any {
+	return map[string]any{
+		"kind":                              m.Kind,
+		"name":                              m.Name,
+		"enabled":                           m.Enabled,
+		"timezone":                          m.Timezone,
+		"retention_keep_latest":             m.RetentionKeepLatest,
+		"retention_superseded_max_age_days": m.RetentionSupersededMaxAgeDays,
+		"settings":                          m.Settings.Data(),
 	}
 }
 
@@ -59,7 +88,15 @@ func NewDataSourceRepository(db *gorm.DB) *DataSourceRepository {
 
 func (r *DataSourceRepository) Create(ctx context.Context, src *ingestion.DataSource) (*ingestion.DataSource, error) {
 	dbModel := toDataSourceDBModel(src)
-	if err := r.db.WithContext(ctx).Create(dbModel).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbModel).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(
+			ctx, tx, audit.ActionCreate, audit.EntityTypeDataSource, dbModel.ID, nil, dbModel.auditSnapshot(),
+		)
+	})
+	if err != nil {
 		if isUniqueViolation(err) {
 			return nil, fmt.Errorf("name %q: %w", src.Name(), ingestion.ErrDataSourceNameConflict)
 		}
@@ -80,36 +117,123 @@ func (r *DataSourceRepository) FindByID(ctx context.Context, id uuid.UUID) (*ing
 	return dbSource.toEntity(), nil
 }
 
-func (r *DataSourceRepository) List(ctx context.Context) ([]*ingestion.DataSource, error) {
+// FindByName returns the DataSource with the given name, or (nil, nil) if
+// not found.
+func (r *DataSourceRepository) FindByName(ctx context.Context, name string) (*ingestion.DataSource, error) {
+	var dbSource DataSource
+	err := r.db.WithContext(ctx).First(&dbSource, "name = ?", name).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dbSource.toEntity(), nil
+}
+
+func (r *DataSourceRepository) List(
+	ctx context.Context,
+	filter ingestion.DataSourceFilter,
+	page pagination.Params,
+) ([]*ingestion.DataSource, error) {
+	query := r.db.WithContext(ctx)
+	if filter.Enabled != nil {
+		query = query.Where("enabled = ?", *filter.Enabled)
+	}
+	query = whereNamePrefix(query, filter.NamePrefix)
+
 	var dbSources []DataSource
-	if err := r.db.WithContext(ctx).Find(&dbSources).Error; err != nil {
+	if err := paginate(query, page).Find(&dbSources).Error; err != nil {
 		return nil, err
 	}
 	return lo.Map(dbSources, func(s DataSource, _ int) *ingestion.DataSource { return s.toEntity() }), nil
 }
 
+// Update persists src only if the stored version still equals src.Version(),
+// incrementing the version in the same statement. An audit event with the
+// changed fields is written in the same transaction.
 func (r *DataSourceRepository) Update(ctx context.Context, src *ingestion.DataSource) error {
-	err := r.db.WithContext(ctx).
-		Model(&DataSource{}).
-		Where("id = ?", src.ID()).
-		Updates(map[string]any{
-			"name":       src.Name(),
-			"enabled":    src.Enabled(),
-			"timezone":   src.TimezoneString(),
-			"settings":   datatypes.NewJSONType(src.Settings()),
-			"updated_at": src.UpdatedAt(),
-		}).Error
-	if err != nil {
-		if isUniqueViolation(err) {
-			return fmt.Errorf("name %q: %w", src.Name(), ingestion.ErrDataSourceNameConflict)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		mismatch := fmt.Errorf(
+			"data source %s at version %d: %w", src.ID(), src.Version(), ingestion.ErrVersionMismatch,
+		)
+		var before DataSource
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", src.ID()).Take(&before).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mismatch
 		}
-		return err
-	}
-	return nil
+		if err != nil {
+			return err
+		}
+
+		after := toDataSourceDBModel(src)
+		result := tx.Model(&DataSource{}).
+			Where("id = ? AND version = ?", src.ID(), src.Version()).
+			Updates(map[string]any{
+				"name":                              after.Name,
+				"enabled":                           after.Enabled,
+				"timezone":                          after.Timezone,
+				"retention_keep_latest":             after.RetentionKeepLatest,
+				"retention_superseded_max_age_days": after.RetentionSupersededMaxAgeDays,
+				"settings":                          after.Settings,
+				"version":                           gorm.Expr("version + 1"),
+				"updated_at":                        after.UpdatedAt,
+			})
+		if err := result.Error; err != nil {
+			if isUniqueViolation(err) {
+				return fmt.Errorf("name %q: %w", src.Name(), ingestion.ErrDataSourceNameConflict)
+			}
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return mismatch
+		}
+		return recordAuditEvent(
+			ctx, tx, audit.ActionUpdate, audit.EntityTypeDataSource, src.ID(),
+			before.auditSnapshot(), after.auditSnapshot(),
+		)
+	})
 }
 
-// Delete deletes the data source with the given ID.
-// Associated data_types rows are automatically deleted via ON DELETE CASCADE on the foreign key constraint.
-func (r *DataSourceRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&DataSource{}).Error
+// Delete deletes the data source with the given ID. When version is non-nil,
+// the row is deleted only at that version; otherwise ErrVersionMismatch is returned.
+// Associated data_types rows are automatically deleted via ON DELETE CASCADE on the foreign key constraint;
+// audit events are written for the data source and each of them in the same transaction.
+func (r *DataSourceRepository) Delete(ctx context.Context, id uuid.UUID, version *int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before DataSource
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id)
+		if version != nil {
+			query = query.Where("version = ?", *version)
+		}
+		err := query.Take(&before).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if version != nil {
+				return fmt.Errorf("data source %s at version %d: %w", id, *version, ingestion.ErrVersionMismatch)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var dataTypes []DataType
+		if err := tx.Where("data_source_id = ?", id).Order("id").Find(&dataTypes).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id = ?", id).Delete(&DataSource{}).Error; err != nil {
+			return err
+		}
+		for _, dt := range dataTypes {
+			err := recordAuditEvent(
+				ctx, tx, audit.ActionDelete, audit.EntityTypeDataType, dt.ID, dt.auditSnapshot(), nil,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return recordAuditEvent(
+			ctx, tx, audit.ActionDelete, audit.EntityTypeDataSource, id, before.auditSnapshot(), nil,
+		)
+	})
 }
@@ -15,11 +15,12 @@ import (
 
 	"stock-tool/internal/domain/ingestion"
 	"stock-tool/internal/util/idp"
+	"stock-tool/internal/util/pagination"
 	"stock-tool/internal/util/testutil"
 )
 
 var dataSrcCmpOpts = cmp.Options{
-	cmp.AllowUnexported(ingestion.DataSource{}),
+	cmp.AllowUnexported(ingestion.DataSource{}, ingestion.RetentionPolicy{}),
 	cmp.Comparer(func(a, b *time.Location) bool {
 		if a == nil && b == nil {
 			return true
@@ -114,7 +115,9 @@ func (s *DataSourceRepositoryTestSuite) TestFindByID() {
 			name: "found",
 			setup: func() (uuid.UUID, *ingestion.DataSource) {
 				s.seedDataSource()
-				src, err := ingestion.NewDataSource(ctx, "another-source", false, "UTC", map[string]any{})
+				src, err := ingestion.NewDataSource(
+					ctx, ingestion.SourceKindGeneric, "another-source", false, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+				)
 				s.Require().NoError(err)
 				_, err = s.repo.Create(ctx, src)
 				s.Require().NoError(err)
@@ -145,11 +148,34 @@ func (s *DataSourceRepositoryTestSuite) TestFindByID() {
 	}
 }
 
+func (s *DataSourceRepositoryTestSuite) TestFindByName() {
+	ctx := context.Background()
+	s.seedDataSource()
+	src, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "another-source", false, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+	)
+	s.Require().NoError(err)
+	_, err = s.repo.Create(ctx, src)
+	s.Require().NoError(err)
+
+	result, err := s.repo.FindByName(ctx, "another-source")
+	s.Require().NoError(err)
+	s.Require().NotNil(result)
+	s.True(cmp.Equal(*src, *result, dataSrcCmpOpts...), cmp.Diff(*src, *result, dataSrcCmpOpts...))
+
+	result, err = s.repo.FindByName(ctx, "unknown")
+	s.NoError(err)
+	s.Nil(result)
+}
+
 func (s *DataSourceRepositoryTestSuite) TestCreate() {
 	fixedID := uuid.MustParse("01961f1a-89c4-7641-b052-4dca477a457a")
 	ctx := idp.WithFixedID(context.Background(), fixedID)
 
-	src, err := ingestion.NewDataSource(ctx, "test-source", true, "UTC", map[string]any{"key": "val"})
+	src, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "test-source", true, "UTC", ingestion.RetentionPolicy{},
+		map[string]any{"key": "val"},
+	)
 	s.Require().NoError(err)
 	created, err := s.repo.Create(ctx, src)
 
@@ -157,10 +183,13 @@ func (s *DataSourceRepositoryTestSuite) TestCreate() {
 	loc, _ := time.LoadLocation("UTC")
 	expected := ingestion.NewDataSourceDirectly(
 		fixedID,
+		ingestion.SourceKindGeneric,
 		"test-source",
 		true,
 		loc,
+		ingestion.RetentionPolicy{},
 		map[string]any{"key": "val"},
+		1,
 		created.CreatedAt(),
 		created.UpdatedAt(),
 	)
@@ -172,12 +201,15 @@ func (s *DataSourceRepositoryTestSuite) TestList() {
 	s.seedDataSource()
 
 	// Create a second source
-	src, err := ingestion.NewDataSource(ctx, "another-source", false, "US/Eastern", map[string]any{})
+	src, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "another-source", false, "US/Eastern", ingestion.RetentionPolicy{},
+		map[string]any{},
+	)
 	s.Require().NoError(err)
 	_, err = s.repo.Create(ctx, src)
 	s.Require().NoError(err)
 
-	list, err := s.repo.List(ctx)
+	list, err := s.repo.List(ctx, ingestion.DataSourceFilter{}, pagination.Params{Limit: 10})
 
 	s.Require().NoError(err)
 	s.Require().Len(list, 2)
@@ -186,12 +218,71 @@ func (s *DataSourceRepositoryTestSuite) TestList() {
 	s.Equal([]string{"another-source", "j-quants"}, names)
 }
 
+func (s *DataSourceRepositoryTestSuite) TestList_FilterAndPage() {
+	ctx := context.Background()
+	s.seedDataSource()
+	for _, name := range []string{"another-source", "j-quants-premium"} {
+		src, err := ingestion.NewDataSource(
+			ctx, ingestion.SourceKindGeneric, name, false, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+		)
+		s.Require().NoError(err)
+		_, err = s.repo.Create(ctx, src)
+		s.Require().NoError(err)
+	}
+
+	byName := pagination.Sort{Field: pagination.SortFieldName}
+	tests := []struct {
+		name     string
+		filter   ingestion.DataSourceFilter
+		page     pagination.Params
+		expected []string
+	}{
+		{
+			name:     "filter by enabled",
+			filter:   ingestion.DataSourceFilter{Enabled: lo.ToPtr(false)},
+			page:     pagination.Params{Limit: 10, Sort: byName},
+			expected: []string{"another-source", "j-quants-premium"},
+		},
+		{
+			name:     "filter by name prefix",
+			filter:   ingestion.DataSourceFilter{NamePrefix: "j-quants"},
+			page:     pagination.Params{Limit: 10, Sort: byName},
+			expected: []string{"j-quants", "j-quants-premium"},
+		},
+		{
+			name:     "descending id order with limit",
+			page:     pagination.Params{Limit: 2, Sort: pagination.Sort{Field: pagination.SortFieldID, Desc: true}},
+			expected: []string{"j-quants-premium", "another-source"},
+		},
+		{
+			name: "after name cursor",
+			page: pagination.Params{
+				Limit: 10,
+				Sort:  byName,
+				After: &pagination.Cursor{Sort: byName, ID: uuid.Max, Name: "another-source"},
+			},
+			expected: []string{"j-quants", "j-quants-premium"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			list, err := s.repo.List(ctx, tt.filter, tt.page)
+
+			s.Require().NoError(err)
+			names := lo.Map(list, func(ds *ingestion.DataSource, _ int) string { return ds.Name() })
+			s.Equal(tt.expected, names)
+		})
+	}
+}
+
 func (s *DataSourceRepositoryTestSuite) TestUpdate() {
 	ctx := context.Background()
 	seededID := s.seedDataSource()
 
 	// distractor: should remain unchanged after the update
-	anotherSrc, err := ingestion.NewDataSource(ctx, "another-source", false, "UTC", map[string]any{})
+	anotherSrc, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "another-source", false, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+	)
 	s.Require().NoError(err)
 	anotherCreated, err := s.repo.Create(ctx, anotherSrc)
 	s.Require().NoError(err)
@@ -199,7 +290,11 @@ func (s *DataSourceRepositoryTestSuite) TestUpdate() {
 	found, err := s.repo.FindByID(ctx, seededID)
 	s.Require().NoError(err)
 
-	s.Require().NoError(found.Update(ctx, "j-quants-updated", false, "US/Eastern", map[string]any{"api_version": "v3"}))
+	retention, err := ingestion.NewRetentionPolicy(lo.ToPtr(3), lo.ToPtr(30))
+	s.Require().NoError(err)
+	s.Require().NoError(found.Update(
+		ctx, "j-quants-updated", false, "US/Eastern", retention, map[string]any{"api_version": "v3"},
+	))
 	err = s.repo.Update(ctx, found)
 	s.Require().NoError(err)
 
@@ -208,10 +303,13 @@ func (s *DataSourceRepositoryTestSuite) TestUpdate() {
 	loc, _ := time.LoadLocation("US/Eastern")
 	expected := ingestion.NewDataSourceDirectly(
 		found.ID(),
+		ingestion.SourceKindGeneric,
 		"j-quants-updated",
 		false,
 		loc,
+		retention,
 		map[string]any{"api_version": "v3"},
+		found.Version()+1,
 		found.CreatedAt(),
 		result.UpdatedAt(),
 	)
@@ -224,12 +322,60 @@ func (s *DataSourceRepositoryTestSuite) TestUpdate() {
 	s.Equal("another-source", anotherResult.Name())
 }
 
+func (s *DataSourceRepositoryTestSuite) TestUpdate_StaleVersion() {
+	ctx := context.Background()
+	seededID := s.seedDataSource()
+
+	first, err := s.repo.FindByID(ctx, seededID)
+	s.Require().NoError(err)
+	second, err := s.repo.FindByID(ctx, seededID)
+	s.Require().NoError(err)
+
+	s.Require().NoError(first.Update(ctx, "first-writer", true, "UTC", ingestion.RetentionPolicy{}, map[string]any{}))
+	s.Require().NoError(s.repo.Update(ctx, first))
+
+	s.Require().NoError(second.Update(ctx, "second-writer", true, "UTC", ingestion.RetentionPolicy{}, map[string]any{}))
+	err = s.repo.Update(ctx, second)
+	s.ErrorIs(err, ingestion.ErrVersionMismatch)
+
+	result, err := s.repo.FindByID(ctx, seededID)
+	s.Require().NoError(err)
+	s.Equal("first-writer", result.Name())
+	s.Equal(first.Version()+1, result.Version())
+
+	// A deleted data source has no version to match
+	s.Require().NoError(s.repo.Delete(ctx, seededID, nil))
+	s.ErrorIs(s.repo.Update(ctx, result), ingestion.ErrVersionMismatch)
+}
+
+func (s *DataSourceRepositoryTestSuite) TestDelete_StaleVersion() {
+	ctx := context.Background()
+	seededID := s.seedDataSource()
+
+	found, err := s.repo.FindByID(ctx, seededID)
+	s.Require().NoError(err)
+
+	err = s.repo.Delete(ctx, seededID, lo.ToPtr(found.Version()+1))
+	s.ErrorIs(err, ingestion.ErrVersionMismatch)
+
+	result, err := s.repo.FindByID(ctx, seededID)
+	s.Require().NoError(err)
+	s.NotNil(result)
+
+	s.Require().NoError(s.repo.Delete(ctx, seededID, lo.ToPtr(found.Version())))
+	result, err = s.repo.FindByID(ctx, seededID)
+	s.NoError(err)
+	s.Nil(result)
+}
+
 func (s *DataSourceRepositoryTestSuite) TestDelete() {
 	ctx := context.Background()
 	seededID := s.seedDataSource()
 
 	// distractor: should survive the delete
-	anotherSrc, err := ingestion.NewDataSource(ctx, "another-source", false, "UTC", map[string]any{})
+	anotherSrc, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "another-source", false, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+	)
 	s.Require().NoError(err)
 	anotherCreated, err := s.repo.Create(ctx, anotherSrc)
 	s.Require().NoError(err)
@@ -237,7 +383,7 @@ func (s *DataSourceRepositoryTestSuite) TestDelete() {
 	found, err := s.repo.FindByID(ctx, seededID)
 	s.Require().NoError(err)
 
-	err = s.repo.Delete(ctx, found.ID())
+	err = s.repo.Delete(ctx, found.ID(), nil)
 	s.Require().NoError(err)
 
 	result, err := s.repo.FindByID(ctx, found.ID())
@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"time"
 
+	"stock-tool/database"
+	"stock-tool/internal/domain/audit"
 	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/util/pagination"
 
 	"github.com/google/uuid"
 	"github.com/samber/lo"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DataType struct {
@@ -22,9 +26,17 @@ type DataType struct {
 	Schedule            datatypes.JSONType[scheduleJSON]
 	BackfillEnabled     bool
 	StaleTimeoutMinutes int
-	Settings            datatypes.JSONType[map[string]any]
-	CreatedAt           time.Time `gorm:"autoCreateTime:false"`
-	UpdatedAt           time.Time `gorm:"autoUpdateTime:false"`
+	RerunStrategy       string `gorm:"default:append"`
+	EmptyResponsePolicy string `gorm:"default:success"`
+	Compression         string `gorm:"default:none"`
+	// RetentionKeepLatest and RetentionSupersededMaxAgeDays are NULL for
+	// retention rules that are not set.
+	RetentionKeepLatest           *int
+	RetentionSupersededMaxAgeDays *int
+	Settings                      datatypes.JSONType[map[string]any]
+	Version                       int       `gorm:"default:1"`
+	CreatedAt                     time.Time `gorm:"autoCreateTime:false"`
+	UpdatedAt                     time.Time `gorm:"autoUpdateTime:false"`
 }
 
 func (m *DataType) toEntity() *ingestion.DataType {
@@ -36,12 +48,43 @@ func (m *DataType) toEntity() *ingestion.DataType {
 		m.Schedule.Data().toEntity(),
 		m.BackfillEnabled,
 		m.StaleTimeoutMinutes,
+		ingestion.RerunStrategy(m.RerunStrategy),
+		ingestion.EmptyResponsePolicy(m.EmptyResponsePolicy),
+		ingestion.Compression(m.Compression),
+		toRetentionPolicy(m.RetentionKeepLatest, m.RetentionSupersededMaxAgeDays),
 		m.Settings.Data(),
+		m.Version,
 		m.CreatedAt,
 		m.UpdatedAt,
 	)
 }
 
+// auditSnapshot returns the fields of the row recorded in audit events.
+func (m *DataType) auditSnapshot() map[string]any {
+	return map[string]any{
+		"data_source_id":                    m.DataSourceID,
+		"name":                              m.Name,
+		"enabled":                           m.Enabled,
+		"schedule":                          m.Schedule.Data(),
+		"backfill_enabled":                  m.BackfillEnabled,
+		"stale_timeout_minutes":             m.StaleTimeoutMinutes,
+		"rerun_strategy":                    m.RerunStrategy,
+		"empty_response_policy":             m.EmptyResponsePolicy,
+		"compression":                       m.Compression,
+		"retention_keep_latest":             m.RetentionKeepLatest,
+		"retention_superseded_max_age_days": m.RetentionSupersededMaxAgeDays,
+		"settings":                          m.Settings.Data(),
+	}
+}
+
+func toRetentionPolicy(keepLatest *int, supersededMaxAgeDays *int) ingestion.RetentionPolicy {
+	policy, err := ingestion.NewRetentionPolicy(keepLatest, supersededMaxAgeDays)
+	if err != nil {
+		panic("repository: corrupt retention policy in database: " + err.Error())
+	}
+	return policy
+}
+
 type scheduleJSON struct {
 	Type  string   `json:"type"`
 	Times []string `json:"times,omitempty"`
@@ -65,16 +108,22 @@ func toScheduleJSON(s ingestion.Schedule) scheduleJSON {
 
 func toDataTypeDBModel(e *ingestion.DataType) *DataType {
 	return &DataType{
-		ID:                  e.ID(),
-		DataSourceID:        e.DataSourceID(),
-		Name:                e.Name(),
-		Enabled:             e.Enabled(),
-		Schedule:            datatypes.NewJSONType(toScheduleJSON(e.Schedule())),
-		BackfillEnabled:     e.BackfillEnabled(),
-		StaleTimeoutMinutes: e.StaleTimeoutMinutes(),
-		Settings:            datatypes.NewJSONType(e.Settings()),
-		CreatedAt:           e.CreatedAt(),
-		UpdatedAt:           e.UpdatedAt(),
+		ID:                            e.ID(),
+		DataSourceID:                  e.DataSourceID(),
+		Name:                          e.Name(),
+		Enabled:                       e.Enabled(),
+		Schedule:                      datatypes.NewJSONType(toScheduleJSON(e.Schedule())),
+		BackfillEnabled:               e.BackfillEnabled(),
+		StaleTimeoutMinutes:           e.StaleTimeoutMinutes(),
+		RerunStrategy:                 string(e.RerunStrategy()),
+		EmptyResponsePolicy:           string(e.EmptyResponsePolicy()),
+		Compression:                   string(e.Compression()),
+		RetentionKeepLatest:           e.Retention().KeepLatest(),
+		RetentionSupersededMaxAgeDays: e.Retention().SupersededMaxAgeDays(),
+		Settings:                      datatypes.NewJSONType(e.Settings()),
+		Version:                       e.Version(),
+		CreatedAt:                     e.CreatedAt(),
+		UpdatedAt:                     e.UpdatedAt(),
 	}
 }
 
@@ -88,7 +137,15 @@ func NewDataTypeRepository(db *gorm.DB) *DataTypeRepository {
 
 func (r *DataTypeRepository) Create(ctx context.Context, dt *ingestion.DataType) (*ingestion.DataType, error) {
 	dbModel := toDataTypeDBModel(dt)
-	if err := r.db.WithContext(ctx).Create(dbModel).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbModel).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(
+			ctx, tx, audit.ActionCreate, audit.EntityTypeDataType, dbModel.ID, nil, dbModel.auditSnapshot(),
+		)
+	})
+	if err != nil {
 		if isUniqueViolation(err) {
 			return nil, fmt.Errorf("name %q: %w", dt.Name(), ingestion.ErrDataTypeNameConflict)
 		}
@@ -109,40 +166,171 @@ func (r *DataTypeRepository) FindByID(ctx context.Context, id uuid.UUID) (*inges
 	return dbDataType.toEntity(), nil
 }
 
-func (r *DataTypeRepository) ListBySourceID(
+// FindByName returns the DataType with the given name under the data source
+// with the given ID, or (nil, nil) if not found.
+func (r *DataTypeRepository) FindByName(
 	ctx context.Context,
 	dataSourceID uuid.UUID,
+	name string,
+) (*ingestion.DataType, error) {
+	var dbDataType DataType
+	err := r.db.WithContext(ctx).First(&dbDataType, "data_source_id = ? AND name = ?", dataSourceID, name).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dbDataType.toEntity(), nil
+}
+
+func (r *DataTypeRepository) List(
+	ctx context.Context,
+	filter ingestion.DataTypeFilter,
+	page pagination.Params,
 ) ([]*ingestion.DataType, error) {
+	query := r.db.WithContext(ctx)
+	if filter.DataSourceID != nil {
+		query = query.Where("data_source_id = ?", *filter.DataSourceID)
+	}
+	if filter.Enabled != nil {
+		query = query.Where("enabled = ?", *filter.Enabled)
+	}
+	query = whereNamePrefix(query, filter.NamePrefix)
+
 	var dbTypes []DataType
-	err := r.db.WithContext(ctx).Where("data_source_id = ?", dataSourceID).Find(&dbTypes).Error
-	if err != nil {
+	if err := paginate(query, page).Find(&dbTypes).Error; err != nil {
 		return nil, err
 	}
 	return lo.Map(dbTypes, func(dt DataType, _ int) *ingestion.DataType { return dt.toEntity() }), nil
 }
 
-func (r *DataTypeRepository) Update(ctx context.Context, dt *ingestion.DataType) error {
+// retentionRow is the retention rules of a data type and of its data
+// source, with the names its landing files are recorded under.
+type retentionRow struct {
+	SourceName                          string
+	SourceTimezone                      string
+	SourceRetentionKeepLatest           *int
+	SourceRetentionSupersededMaxAgeDays *int
+	Name                                string
+	RetentionKeepLatest                 *int
+	RetentionSupersededMaxAgeDays       *int
+}
+
+// ListRetentionPolicies returns the retention policy in effect for every data
+// type that it or its data source sets a retention rule for, named by its
+// data source, ordered by data source name and data type name. A data type
+// that sets no rule of its own follows the policy of its data source.
+func (r *DataTypeRepository) ListRetentionPolicies(ctx context.Context) ([]ingestion.RetentionTarget, error) {
+	var rows []retentionRow
 	err := r.db.WithContext(ctx).
 		Model(&DataType{}).
-		Where("id = ?", dt.ID()).
-		Updates(map[string]any{
-			"name":                  dt.Name(),
-			"enabled":               dt.Enabled(),
-			"schedule":              datatypes.NewJSONType(toScheduleJSON(dt.Schedule())),
-			"backfill_enabled":      dt.BackfillEnabled(),
-			"stale_timeout_minutes": dt.StaleTimeoutMinutes(),
-			"settings":              datatypes.NewJSONType(dt.Settings()),
-			"updated_at":            dt.UpdatedAt(),
-		}).Error
+		Select("s.name AS source_name, s.timezone AS source_timezone, " +
+			"s.retention_keep_latest AS source_retention_keep_latest, " +
+			"s.retention_superseded_max_age_days AS source_retention_superseded_max_age_days, " +
+			"data_types.name, data_types.retention_keep_latest, data_types.retention_superseded_max_age_days").
+		Joins(fmt.Sprintf("JOIN %s.data_sources s ON s.id = data_types.data_source_id", database.SchemaName)).
+		Where("data_types.retention_keep_latest IS NOT NULL OR " +
+			"data_types.retention_superseded_max_age_days IS NOT NULL OR " +
+			"s.retention_keep_latest IS NOT NULL OR " +
+			"s.retention_superseded_max_age_days IS NOT NULL").
+		Order("s.name").
+		Order("data_types.name").
+		Scan(&rows).Error
 	if err != nil {
-		if isUniqueViolation(err) {
-			return fmt.Errorf("name %q: %w", dt.Name(), ingestion.ErrDataTypeNameConflict)
+		return nil, err
+	}
+	targets := make([]ingestion.RetentionTarget, 0, len(rows))
+	for _, row := range rows {
+		loc, err := time.LoadLocation(row.SourceTimezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone of data source %s: %w", row.SourceName, err)
 		}
-		return err
+		own := toRetentionPolicy(row.RetentionKeepLatest, row.RetentionSupersededMaxAgeDays)
+		source := toRetentionPolicy(row.SourceRetentionKeepLatest, row.SourceRetentionSupersededMaxAgeDays)
+		targets = append(targets, ingestion.RetentionTarget{
+			Source:   row.SourceName,
+			DataType: row.Name,
+			Timezone: loc,
+			Policy:   own.Or(source),
+		})
 	}
-	return nil
+	return targets, nil
+}
+
+// Update persists dt only if the stored version still equals dt.Version(),
+// incrementing the version in the same statement. An audit event with the
+// changed fields is written in the same transaction.
+func (r *DataTypeRepository) Update(ctx context.Context, dt *ingestion.DataType) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		mismatch := fmt.Errorf("data type %s at version %d: %w", dt.ID(), dt.Version(), ingestion.ErrVersionMismatch)
+		var before DataType
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", dt.ID()).Take(&before).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return mismatch
+		}
+		if err != nil {
+			return err
+		}
+
+		after := toDataTypeDBModel(dt)
+		result := tx.Model(&DataType{}).
+			Where("id = ? AND version = ?", dt.ID(), dt.Version()).
+			Updates(map[string]any{
+				"name":                              after.Name,
+				"enabled":                           after.Enabled,
+				"schedule":                          after.Schedule,
+				"backfill_enabled":                  after.BackfillEnabled,
+				"stale_timeout_minutes":             after.StaleTimeoutMinutes,
+				"rerun_strategy":                    after.RerunStrategy,
+				"empty_response_policy":             after.EmptyResponsePolicy,
+				"compression":                       after.Compression,
+				"retention_keep_latest":             after.RetentionKeepLatest,
+				"retention_superseded_max_age_days": after.RetentionSupersededMaxAgeDays,
+				"settings":                          after.Settings,
+				"version":                           gorm.Expr("version + 1"),
+				"updated_at":                        after.UpdatedAt,
+			})
+		if err := result.Error; err != nil {
+			if isUniqueViolation(err) {
+				return fmt.Errorf("name %q: %w", dt.Name(), ingestion.ErrDataTypeNameConflict)
+			}
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return mismatch
+		}
+		return recordAuditEvent(
+			ctx, tx, audit.ActionUpdate, audit.EntityTypeDataType, dt.ID(),
+			before.auditSnapshot(), after.auditSnapshot(),
+		)
+	})
 }
 
-func (r *DataTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&DataType{}).Error
+// Delete removes the data type with the given ID. When version is non-nil,
+// the row is deleted only at that version; otherwise ErrVersionMismatch is returned.
+// An audit event is written in the same transaction.
+func (r *DataTypeRepository) Delete(ctx context.Context, id uuid.UUID, version *int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before DataType
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id)
+		if version != nil {
+			query = query.Where("version = ?", *version)
+		}
+		err := query.Take(&before).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if version != nil {
+				return fmt.Errorf("data type %s at version %d: %w", id, *version, ingestion.ErrVersionMismatch)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("id = ?", id).Delete(&DataType{}).Error; err != nil {
+			return err
+		}
+		return recordAuditEvent(ctx, tx, audit.ActionDelete, audit.EntityTypeDataType, id, before.auditSnapshot(), nil)
+	})
 }
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"stock-tool/database"
+	"stock-tool/internal/domain/audit"
+	"stock-tool/internal/domain/ingestion"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+type DataTypeDependency struct {
+	DataTypeID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	DependsOnID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	MaxLagDays  int
+}
+
+func (m *DataTypeDependency) toEntity() (ingestion.Dependency, error) {
+	dep, err := ingestion.NewDependency(m.DependsOnID, m.MaxLagDays)
+	if err != nil {
+		return ingestion.Dependency{}, fmt.Errorf(
+			"corrupt dependency of data type %s on %s: %w", m.DataTypeID, m.DependsOnID, err,
+		)
+	}
+	return dep, nil
+}
+
+// dependenciesAuditSnapshot returns the dependencies of a data type as the
+// fields recorded in its audit events.
+func dependenciesAuditSnapshot(deps []DataTypeDependency) map[string]any {
+	return map[string]any{
+		"depends_on": lo.Map(deps, func(d DataTypeDependency, _ int) map[string]any {
+			return map[string]any{"data_type_id": d.DependsOnID, "max_lag_days": d.MaxLagDays}
+		}),
+	}
+}
+
+// ListDependencies returns the dependencies of the data type with the given
+// ID, ordered by the ID of the data type depended on.
+func (r *DataTypeRepository) ListDependencies(ctx context.Context, id uuid.UUID) ([]ingestion.Dependency, error) {
+	var dbDeps []DataTypeDependency
+	err := r.db.WithContext(ctx).
+		Where("data_type_id = ?", id).
+		Order("depends_on_id").
+		Find(&dbDeps).Error
+	if err != nil {
+		return nil, err
+	}
+	deps := make([]ingestion.Dependency, 0, len(dbDeps))
+	for _, d := range dbDeps {
+		dep, err := d.toEntity()
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// ReplaceDependencies replaces the dependencies of the data type with the
+// given ID with deps. Returns an error wrapping ingestion.ErrDependencyCycle
+// if deps would make the dependencies form a cycle. The table is locked
+// against other writers until the transaction ends, so concurrent
+// replacements cannot form a cycle between them. An audit event with the
+// previous and new dependencies is written in the same transaction.
+func (r *DataTypeRepository) ReplaceDependencies(
+	ctx context.Context,
+	id uuid.UUID,
+	deps []ingestion.Dependency,
+) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		lock := fmt.Sprintf("LOCK TABLE %s.data_type_dependencies IN SHARE ROW EXCLUSIVE MODE", database.SchemaName)
+		if err := tx.Exec(lock).Error; err != nil {
+			return err
+		}
+
+		var all []DataTypeDependency
+		if err := tx.Order("data_type_id, depends_on_id").Find(&all).Error; err != nil {
+			return err
+		}
+		var before []DataTypeDependency
+		graph := make(map[uuid.UUID][]uuid.UUID)
+		for _, d := range all {
+			if d.DataTypeID == id {
+				before = append(before, d)
+				continue
+			}
+			graph[d.DataTypeID] = append(graph[d.DataTypeID], d.DependsOnID)
+		}
+		after := lo.Map(deps, func(d ingestion.Dependency, _ int) DataTypeDependency {
+			return DataTypeDependency{DataTypeID: id, DependsOnID: d.DependsOnID(), MaxLagDays: d.MaxLagDays()}
+		})
+		slices.SortFunc(after, func(a, b DataTypeDependency) int {
+			return strings.Compare(a.DependsOnID.String(), b.DependsOnID.String())
+		})
+		for _, d := range after {
+			graph[id] = append(graph[id], d.DependsOnID)
+		}
+		if cycle := ingestion.FindDependencyCycle(graph); cycle != nil {
+			path := lo.Map(cycle, func(id uuid.UUID, _ int) string { return id.String() })
+			return fmt.Errorf("%s: %w", strings.Join(path, " -> "), ingestion.ErrDependencyCycle)
+		}
+
+		if err := tx.Where("data_type_id = ?", id).Delete(&DataTypeDependency{}).Error; err != nil {
+			return err
+		}
+		if len(after) > 0 {
+			if err := tx.Create(&after).Error; err != nil {
+				return err
+			}
+		}
+		return recordAuditEvent(
+			ctx, tx, audit.ActionUpdate, audit.EntityTypeDataType, id,
+			dependenciesAuditSnapshot(before), dependenciesAuditSnapshot(after),
+		)
+	})
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"stock-tool/internal/domain/audit"
+	"stock-tool/internal/domain/ingestion"
+)
+
+func (s *DataTypeRepositoryTestSuite) mustDependency(dependsOnID uuid.UUID, maxLagDays int) ingestion.Dependency {
+	dep, err := ingestion.NewDependency(dependsOnID, maxLagDays)
+	s.Require().NoError(err)
+	return dep
+}
+
+func (s *DataTypeRepositoryTestSuite) TestReplaceDependencies() {
+	ctx := audit.WithActor(context.Background(), "api_key:ci")
+	types, err := s.listBySource(ctx, s.seedDataSource())
+	s.Require().NoError(err)
+	s.Require().Len(types, 2)
+	quotes, listed := types[0].ID(), types[1].ID()
+
+	err = s.repo.ReplaceDependencies(ctx, quotes, []ingestion.Dependency{s.mustDependency(listed, 7)})
+	s.Require().NoError(err)
+
+	deps, err := s.repo.ListDependencies(ctx, quotes)
+	s.Require().NoError(err)
+	s.Equal([]ingestion.Dependency{s.mustDependency(listed, 7)}, deps)
+	deps, err = s.repo.ListDependencies(ctx, listed)
+	s.Require().NoError(err)
+	s.Empty(deps, "dependencies are not symmetric")
+
+	var event AuditEvent
+	s.Require().NoError(s.db.Order("occurred_at DESC").First(&event).Error)
+	s.Equal(quotes, event.EntityID)
+	s.Equal(map[string]any{"depends_on": []any{}}, event.Before.Data())
+	s.Equal(map[string]any{
+		"depends_on": []any{map[string]any{"data_type_id": listed.String(), "max_lag_days": float64(7)}},
+	}, event.After.Data())
+
+	s.Run("cycle", func() {
+		err := s.repo.ReplaceDependencies(ctx, listed, []ingestion.Dependency{s.mustDependency(quotes, 0)})
+		s.ErrorIs(err, ingestion.ErrDependencyCycle)
+
+		deps, err := s.repo.ListDependencies(ctx, listed)
+		s.Require().NoError(err)
+		s.Empty(deps, "a rejected replacement changes nothing")
+	})
+
+	s.Run("clear", func() {
+		s.Require().NoError(s.repo.ReplaceDependencies(ctx, quotes, nil))
+
+		deps, err := s.repo.ListDependencies(ctx, quotes)
+		s.Require().NoError(err)
+		s.Empty(deps)
+	})
+}
+
+func (s *DataTypeRepositoryTestSuite) TestDelete_CascadesDependencies() {
+	ctx := context.Background()
+	types, err := s.listBySource(ctx, s.seedDataSource())
+	s.Require().NoError(err)
+	quotes, listed := types[0].ID(), types[1].ID()
+	s.Require().NoError(s.repo.ReplaceDependencies(ctx, quotes, []ingestion.Dependency{s.mustDependency(listed, 0)}))
+
+	s.Require().NoError(s.repo.Delete(ctx, listed, nil))
+
+	deps, err := s.repo.ListDependencies(ctx, quotes)
+	s.Require().NoError(err)
+	s.Empty(deps)
+}
+
+func (s *DataTypeRepositoryTestSuite) TestListDependencies_CorruptRow() {
+	ctx := context.Background()
+	types, err := s.listBySource(ctx, s.seedDataSource())
+	s.Require().NoError(err)
+	quotes, listed := types[0].ID(), types[1].ID()
+	// Bypass the constraint a migration could have missed
+	s.Require().NoError(s.db.Exec(
+		"ALTER TABLE stock.data_type_dependencies DROP CONSTRAINT data_type_dependencies_max_lag_days_check",
+	).Error)
+	s.Require().NoError(s.db.Create(&DataTypeDependency{DataTypeID: quotes, DependsOnID: listed, MaxLagDays: -1}).Error)
+
+	_, err = s.repo.ListDependencies(ctx, quotes)
+	s.ErrorContains(err, "corrupt dependency of data type "+quotes.String())
+}
@@ -7,17 +7,19 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"stock-tool/internal/domain/ingestion"
 	"stock-tool/internal/util/idp"
+	"stock-tool/internal/util/pagination"
 	"stock-tool/internal/util/testutil"
 )
 
 var dataTypeCmpOpts = cmp.Options{
-	cmp.AllowUnexported(ingestion.DataType{}, ingestion.Schedule{}),
+	cmp.AllowUnexported(ingestion.DataType{}, ingestion.Schedule{}, ingestion.RetentionPolicy{}),
 }
 
 type DataTypeRepositoryTestSuite struct {
@@ -49,6 +51,21 @@ func (s *DataTypeRepositoryTestSuite) mustSchedule(sched ingestion.Schedule, err
 	return sched
 }
 
+func (s *DataTypeRepositoryTestSuite) mustRetention(
+	policy ingestion.RetentionPolicy,
+	err error,
+) ingestion.RetentionPolicy {
+	s.Require().NoError(err)
+	return policy
+}
+
+func (s *DataTypeRepositoryTestSuite) listBySource(
+	ctx context.Context,
+	srcID uuid.UUID,
+) ([]*ingestion.DataType, error) {
+	return s.repo.List(ctx, ingestion.DataTypeFilter{DataSourceID: &srcID}, pagination.Params{Limit: 10})
+}
+
 func (s *DataTypeRepositoryTestSuite) seedDataSource() uuid.UUID {
 	now := time.Now()
 	source := &DataSource{
@@ -107,6 +124,10 @@ func (s *DataTypeRepositoryTestSuite) TestCreate() {
 		s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"12:00"})),
 		false,
 		15,
+		ingestion.RerunStrategyOverwrite,
+		ingestion.EmptyResponsePolicyRetryLater,
+		ingestion.CompressionGzip,
+		s.mustRetention(ingestion.NewRetentionPolicy(lo.ToPtr(3), nil)),
 		map[string]any{"x": "y"},
 	)
 	created, err := s.repo.Create(ctx, dt)
@@ -120,7 +141,12 @@ func (s *DataTypeRepositoryTestSuite) TestCreate() {
 		s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"12:00"})),
 		false,
 		15,
+		ingestion.RerunStrategyOverwrite,
+		ingestion.EmptyResponsePolicyRetryLater,
+		ingestion.CompressionGzip,
+		s.mustRetention(ingestion.NewRetentionPolicy(lo.ToPtr(3), nil)),
 		map[string]any{"x": "y"},
+		1,
 		created.CreatedAt(),
 		created.UpdatedAt(),
 	)
@@ -138,7 +164,7 @@ func (s *DataTypeRepositoryTestSuite) TestFindByID() {
 			name: "found",
 			setup: func() (uuid.UUID, *ingestion.DataType) {
 				srcID := s.seedDataSource()
-				types, err := s.repo.ListBySourceID(ctx, srcID)
+				types, err := s.listBySource(ctx, srcID)
 				s.Require().NoError(err)
 				s.Require().NotEmpty(types)
 				return types[0].ID(), types[0]
@@ -168,45 +194,163 @@ func (s *DataTypeRepositoryTestSuite) TestFindByID() {
 	}
 }
 
-func (s *DataTypeRepositoryTestSuite) TestListBySourceID() {
+func (s *DataTypeRepositoryTestSuite) TestFindByName() {
 	ctx := context.Background()
 	srcID := s.seedDataSource()
-
+	// A data type of the same name under another source must not be found
 	now := time.Now()
-	anotherSource := &DataSource{
+	other := &DataSource{
 		ID:        uuid.Must(uuid.NewV7()),
-		Name:      "another-source",
+		Name:      "other-source",
 		Enabled:   true,
 		Timezone:  "UTC",
 		Settings:  datatypes.NewJSONType(map[string]any{}),
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
-	s.Require().NoError(s.db.Create(anotherSource).Error)
+	s.Require().NoError(s.db.Create(other).Error)
 	s.Require().NoError(s.db.Create(&DataType{
 		ID:                  uuid.Must(uuid.NewV7()),
-		DataSourceID:        anotherSource.ID,
-		Name:                "other-type",
+		DataSourceID:        other.ID,
+		Name:                "daily-quotes",
 		Enabled:             true,
-		Schedule:            datatypes.NewJSONType(scheduleJSON{Type: "daily", Times: []string{"00:00"}}),
-		BackfillEnabled:     false,
-		StaleTimeoutMinutes: 60,
+		Schedule:            datatypes.NewJSONType(scheduleJSON{Type: "daily", Times: []string{"18:00"}}),
+		StaleTimeoutMinutes: 30,
 		Settings:            datatypes.NewJSONType(map[string]any{}),
 		CreatedAt:           now,
 		UpdatedAt:           now,
 	}).Error)
+	types, err := s.listBySource(ctx, srcID)
+	s.Require().NoError(err)
+	expected, ok := lo.Find(types, func(dt *ingestion.DataType) bool { return dt.Name() == "daily-quotes" })
+	s.Require().True(ok)
+
+	result, err := s.repo.FindByName(ctx, srcID, "daily-quotes")
+	s.Require().NoError(err)
+	s.Require().NotNil(result)
+	s.True(cmp.Equal(*expected, *result, dataTypeCmpOpts...), cmp.Diff(*expected, *result, dataTypeCmpOpts...))
+
+	result, err = s.repo.FindByName(ctx, srcID, "unknown")
+	s.NoError(err)
+	s.Nil(result)
+}
+
+func (s *DataTypeRepositoryTestSuite) TestList() {
+	ctx := context.Background()
+	srcID := s.seedDataSource()
+	now := time.Now()
+	newDataType := func(sourceID uuid.UUID, name string) *DataType {
+		return &DataType{
+			ID:                  uuid.Must(uuid.NewV7()),
+			DataSourceID:        sourceID,
+			Name:                name,
+			Enabled:             true,
+			Schedule:            datatypes.NewJSONType(scheduleJSON{Type: "daily", Times: []string{"00:00"}}),
+			StaleTimeoutMinutes: 60,
+			Settings:            datatypes.NewJSONType(map[string]any{}),
+			CreatedAt:           now,
+			UpdatedAt:           now,
+		}
+	}
 
-	types, err := s.repo.ListBySourceID(ctx, srcID)
+	// "_" in the name must not act as a LIKE wildcard for the "daily_" prefix
+	dailyBars := newDataType(srcID, "daily_bars")
+	s.Require().NoError(s.db.Create(dailyBars).Error)
 
+	// distractor source
+	anotherSource := &DataSource{
+		ID:        uuid.Must(uuid.NewV7()),
+		Name:      "another-source",
+		Enabled:   true,
+		Timezone:  "UTC",
+		Settings:  datatypes.NewJSONType(map[string]any{}),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.Require().NoError(s.db.Create(anotherSource).Error)
+	otherType := newDataType(anotherSource.ID, "other-type")
+	s.Require().NoError(s.db.Create(otherType).Error)
+	anotherType := newDataType(anotherSource.ID, "another-type")
+	s.Require().NoError(s.db.Create(anotherType).Error)
+
+	firstPage, err := s.repo.List(ctx, ingestion.DataTypeFilter{DataSourceID: &srcID}, pagination.Params{Limit: 1})
 	s.Require().NoError(err)
-	s.Len(types, 2)
+	s.Require().Len(firstPage, 1)
+
+	byName := pagination.Sort{Field: pagination.SortFieldName}
+	byNameDesc := pagination.Sort{Field: pagination.SortFieldName, Desc: true}
+	tests := []struct {
+		name     string
+		filter   ingestion.DataTypeFilter
+		page     pagination.Params
+		expected []string
+	}{
+		{
+			name:     "filter by source in id order",
+			filter:   ingestion.DataTypeFilter{DataSourceID: &srcID},
+			page:     pagination.Params{Limit: 10},
+			expected: []string{"daily-quotes", "listed-info", "daily_bars"},
+		},
+		{
+			name:     "filter by enabled",
+			filter:   ingestion.DataTypeFilter{Enabled: lo.ToPtr(false)},
+			page:     pagination.Params{Limit: 10},
+			expected: []string{"listed-info"},
+		},
+		{
+			name:     "filter by name prefix",
+			filter:   ingestion.DataTypeFilter{NamePrefix: "daily_"},
+			page:     pagination.Params{Limit: 10},
+			expected: []string{"daily_bars"},
+		},
+		{
+			name:     "sort by name",
+			filter:   ingestion.DataTypeFilter{DataSourceID: &anotherSource.ID},
+			page:     pagination.Params{Limit: 10, Sort: byName},
+			expected: []string{"another-type", "other-type"},
+		},
+		{
+			name:     "sort by name descending",
+			filter:   ingestion.DataTypeFilter{DataSourceID: &anotherSource.ID},
+			page:     pagination.Params{Limit: 10, Sort: byNameDesc},
+			expected: []string{"other-type", "another-type"},
+		},
+		{
+			name:   "after id cursor",
+			filter: ingestion.DataTypeFilter{DataSourceID: &srcID},
+			page: pagination.Params{
+				Limit: 1,
+				After: &pagination.Cursor{ID: firstPage[0].ID()},
+			},
+			expected: []string{"listed-info"},
+		},
+		{
+			name:   "after name cursor",
+			filter: ingestion.DataTypeFilter{DataSourceID: &anotherSource.ID},
+			page: pagination.Params{
+				Limit: 10,
+				Sort:  byName,
+				After: &pagination.Cursor{Sort: byName, ID: anotherType.ID, Name: anotherType.Name},
+			},
+			expected: []string{"other-type"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			types, err := s.repo.List(ctx, tt.filter, tt.page)
+
+			s.Require().NoError(err)
+			names := lo.Map(types, func(dt *ingestion.DataType, _ int) string { return dt.Name() })
+			s.Equal(tt.expected, names)
+		})
+	}
 }
 
 func (s *DataTypeRepositoryTestSuite) TestUpdate() {
 	ctx := context.Background()
 	srcID := s.seedDataSource()
 
-	types, err := s.repo.ListBySourceID(ctx, srcID)
+	types, err := s.listBySource(ctx, srcID)
 	s.Require().NoError(err)
 	s.Require().NotEmpty(types)
 	origDT := types[0]
@@ -218,6 +362,10 @@ func (s *DataTypeRepositoryTestSuite) TestUpdate() {
 		s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"06:00", "14:00"})),
 		false,
 		90,
+		ingestion.RerunStrategyOverwrite,
+		ingestion.EmptyResponsePolicyFail,
+		ingestion.CompressionZstd,
+		s.mustRetention(ingestion.NewRetentionPolicy(nil, lo.ToPtr(30))),
 		map[string]any{"endpoint": "/quotes/v2"},
 	)
 	err = s.repo.Update(ctx, origDT)
@@ -233,7 +381,12 @@ func (s *DataTypeRepositoryTestSuite) TestUpdate() {
 		s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"06:00", "14:00"})),
 		false,
 		90,
+		ingestion.RerunStrategyOverwrite,
+		ingestion.EmptyResponsePolicyFail,
+		ingestion.CompressionZstd,
+		s.mustRetention(ingestion.NewRetentionPolicy(nil, lo.ToPtr(30))),
 		map[string]any{"endpoint": "/quotes/v2"},
+		origDT.Version()+1,
 		origDT.CreatedAt(),
 		result.UpdatedAt(),
 	)
@@ -246,16 +399,106 @@ func (s *DataTypeRepositoryTestSuite) TestUpdate() {
 	s.Equal(types[1].Name(), distractor.Name())
 }
 
+func (s *DataTypeRepositoryTestSuite) TestListRetentionPolicies() {
+	ctx := context.Background()
+	srcID := s.seedDataSource()
+	s.Require().NoError(s.db.Model(&DataType{}).
+		Where("data_source_id = ? AND name = ?", srcID, "listed-info").
+		Updates(map[string]any{"retention_keep_latest": 2, "retention_superseded_max_age_days": 7}).Error)
+	// edinet keeps one file by default; announcements sets its own rule instead
+	now := time.Now()
+	edinet := &DataSource{
+		ID:                  uuid.Must(uuid.NewV7()),
+		Name:                "edinet",
+		Enabled:             true,
+		Timezone:            "UTC",
+		RetentionKeepLatest: lo.ToPtr(1),
+		Settings:            datatypes.NewJSONType(map[string]any{}),
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+	s.Require().NoError(s.db.Create(edinet).Error)
+	for _, dt := range []*DataType{
+		{Name: "filings"},
+		{Name: "announcements", RetentionSupersededMaxAgeDays: lo.ToPtr(30)},
+	} {
+		dt.ID = uuid.Must(uuid.NewV7())
+		dt.DataSourceID = edinet.ID
+		dt.Enabled = true
+		dt.Schedule = datatypes.NewJSONType(scheduleJSON{Type: "daily", Times: []string{"18:00"}})
+		dt.StaleTimeoutMinutes = 30
+		dt.Settings = datatypes.NewJSONType(map[string]any{})
+		dt.CreatedAt = now
+		dt.UpdatedAt = now
+		s.Require().NoError(s.db.Create(dt).Error)
+	}
+
+	// daily-quotes sets no rule, nor does j-quants, and is left out.
+	targets, err := s.repo.ListRetentionPolicies(ctx)
+	s.Require().NoError(err)
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	s.Require().NoError(err)
+	expected := []ingestion.RetentionTarget{
+		{
+			Source:   "edinet",
+			DataType: "announcements",
+			Timezone: time.UTC,
+			Policy:   s.mustRetention(ingestion.NewRetentionPolicy(nil, lo.ToPtr(30))),
+		},
+		{
+			Source:   "edinet",
+			DataType: "filings",
+			Timezone: time.UTC,
+			Policy:   s.mustRetention(ingestion.NewRetentionPolicy(lo.ToPtr(1), nil)),
+		},
+		{
+			Source:   "j-quants",
+			DataType: "listed-info",
+			Timezone: jst,
+			Policy:   s.mustRetention(ingestion.NewRetentionPolicy(lo.ToPtr(2), lo.ToPtr(7))),
+		},
+	}
+	opts := append(cmp.Options{
+		cmp.Comparer(func(a, b *time.Location) bool { return a.String() == b.String() }),
+	}, dataTypeCmpOpts...)
+	s.True(cmp.Equal(expected, targets, opts...), cmp.Diff(expected, targets, opts...))
+}
+
+func (s *DataTypeRepositoryTestSuite) TestUpdate_StaleVersion() {
+	ctx := context.Background()
+	srcID := s.seedDataSource()
+
+	types, err := s.listBySource(ctx, srcID)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(types)
+	stale := types[0]
+
+	fresh, err := s.repo.FindByID(ctx, stale.ID())
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Update(ctx, fresh))
+
+	err = s.repo.Update(ctx, stale)
+	s.ErrorIs(err, ingestion.ErrVersionMismatch)
+
+	err = s.repo.Delete(ctx, stale.ID(), lo.ToPtr(stale.Version()))
+	s.ErrorIs(err, ingestion.ErrVersionMismatch)
+
+	result, err := s.repo.FindByID(ctx, stale.ID())
+	s.Require().NoError(err)
+	s.Require().NotNil(result)
+	s.Equal(stale.Version()+1, result.Version())
+}
+
 func (s *DataTypeRepositoryTestSuite) TestDelete() {
 	ctx := context.Background()
 	srcID := s.seedDataSource()
 
-	types, err := s.repo.ListBySourceID(ctx, srcID)
+	types, err := s.listBySource(ctx, srcID)
 	s.Require().NoError(err)
 	s.Require().NotEmpty(types)
 	dtID := types[0].ID()
 
-	err = s.repo.Delete(ctx, dtID)
+	err = s.repo.Delete(ctx, dtID, nil)
 	s.Require().NoError(err)
 
 	result, err := s.repo.FindByID(ctx, dtID)
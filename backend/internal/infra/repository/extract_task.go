@@ -62,6 +62,7 @@ type ExtractTaskExecution struct {
 	TargetDateTime   time.Time
 	Status           string
 	ErrorInfo        *string
+	ErrorCategory    *string
 	StartedAt        *time.Time
 	FinishedAt       *time.Time
 	CreatedAt        time.Time          `gorm:"autoCreateTime:false"`
@@ -79,6 +80,7 @@ func (t *ExtractTaskExecution) ToEntity() *extract.ExtractTaskExecution {
 		t.TargetDateTime,
 		extract.ExecutionStatus(t.Status),
 		t.ErrorInfo,
+		(*extract.ErrorCategory)(t.ErrorCategory),
 		t.StartedAt,
 		t.FinishedAt,
 		t.CreatedAt,
@@ -93,6 +95,7 @@ func toExtractTaskExecution(e *extract.ExtractTaskExecution) *ExtractTaskExecuti
 		TargetDateTime: e.TargetDateTime(),
 		Status:         string(e.Status()),
 		ErrorInfo:      e.ErrorInfo(),
+		ErrorCategory:  (*string)(e.ErrorCategory()),
 		StartedAt:      e.StartedAt(),
 		FinishedAt:     e.FinishedAt(),
 		CreatedAt:      e.CreatedAt(),
@@ -110,6 +113,19 @@ type ExtractedDataS3 struct {
 	ID                     int
 	ExtractTaskExecutionID int
 	Key                    string
+	Window                 string `gorm:"column:file_window"`
+	SizeBytes              *int64
+	Sha256                 *string `gorm:"column:sha256"`
+	Format                 *string
+	ContentType            *string
+	HTTPStatus             *int
+	ContentEncoding        *string
+	CompressedSizeBytes    *int64
+	CompressedSha256       *string `gorm:"column:compressed_sha256"`
+	VersionID              *string
+	SupersededVersionID    *string
+	ManifestKey            *string
+	PurgedAt               *time.Time
 	CreatedAt              time.Time `gorm:"autoCreateTime:false"`
 	UpdatedAt              time.Time `gorm:"autoUpdateTime:false"`
 }
@@ -119,21 +135,60 @@ func (s *ExtractedDataS3) TableName() string {
 }
 
 func (s *ExtractedDataS3) ToEntity() *extract.ExtractedDataS3 {
+	var metadata *extract.FileMetadata
+	if s.Sha256 != nil {
+		metadata = &extract.FileMetadata{
+			SizeBytes:           lo.FromPtr(s.SizeBytes),
+			SHA256:              *s.Sha256,
+			Format:              extract.Format(lo.FromPtr(s.Format)),
+			ContentType:         lo.FromPtr(s.ContentType),
+			HTTPStatus:          lo.FromPtr(s.HTTPStatus),
+			Encoding:            extract.Encoding(lo.FromPtr(s.ContentEncoding)),
+			CompressedSizeBytes: lo.FromPtr(s.CompressedSizeBytes),
+			CompressedSHA256:    lo.FromPtr(s.CompressedSha256),
+		}
+	}
 	return extract.NewExtractedDataS3Directly(
 		s.ID,
 		s.Key,
+		s.Window,
+		metadata,
+		extract.ObjectVersion{
+			ID:           lo.FromPtr(s.VersionID),
+			SupersededID: lo.FromPtr(s.SupersededVersionID),
+		},
+		lo.FromPtr(s.ManifestKey),
+		s.PurgedAt,
 		s.CreatedAt,
 		s.UpdatedAt,
 	)
 }
 
 func toExtractedDataS3(e *extract.ExtractedDataS3) *ExtractedDataS3 {
-	return &ExtractedDataS3{
-		ID:        e.ID(),
-		Key:       e.Key(),
-		CreatedAt: e.CreatedAt(),
-		UpdatedAt: e.UpdatedAt(),
+	dbS3 := &ExtractedDataS3{
+		ID:                  e.ID(),
+		Key:                 e.Key(),
+		Window:              e.Window(),
+		VersionID:           lo.EmptyableToPtr(e.Version().ID),
+		SupersededVersionID: lo.EmptyableToPtr(e.Version().SupersededID),
+		ManifestKey:         lo.EmptyableToPtr(e.ManifestKey()),
+		PurgedAt:            e.PurgedAt(),
+		CreatedAt:           e.CreatedAt(),
+		UpdatedAt:           e.UpdatedAt(),
 	}
+	if md := e.Metadata(); md != nil {
+		dbS3.SizeBytes = &md.SizeBytes
+		dbS3.Sha256 = &md.SHA256
+		dbS3.Format = lo.ToPtr(string(md.Format))
+		dbS3.ContentType = &md.ContentType
+		dbS3.HTTPStatus = lo.EmptyableToPtr(md.HTTPStatus)
+		if md.Encoding != extract.EncodingNone {
+			dbS3.ContentEncoding = lo.ToPtr(string(md.Encoding))
+			dbS3.CompressedSizeBytes = &md.CompressedSizeBytes
+			dbS3.CompressedSha256 = &md.CompressedSHA256
+		}
+	}
+	return dbS3
 }
 
 type ExtractTaskRepository struct {
@@ -176,6 +231,34 @@ func (r *ExtractTaskRepository) CreateExecution(
 	dbExec := toExtractTaskExecution(exec)
 	dbExec.ExtractTaskID = taskID
 	if err := r.db.WithContext(ctx).Create(dbExec).Error; err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf(
+				"target date %s: %w",
+				exec.TargetDateTime().Format(time.RFC3339),
+				extract.ErrExecutionAlreadyRunning,
+			)
+		}
+		return nil, err
+	}
+	return dbExec.ToEntity(), nil
+}
+
+// FindRunningExecution returns the running execution of the task with the
+// given ID for targetDateTime, or (nil, nil) if there is none.
+func (r *ExtractTaskRepository) FindRunningExecution(
+	ctx context.Context,
+	taskID int,
+	targetDateTime time.Time,
+) (*extract.ExtractTaskExecution, error) {
+	var dbExec ExtractTaskExecution
+	err := r.db.WithContext(ctx).
+		Where("extract_task_id = ? AND target_date_time = ?", taskID, targetDateTime).
+		Where("status = ?", string(extract.ExecutionStatusRunning)).
+		First(&dbExec).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
 		return nil, err
 	}
 	return dbExec.ToEntity(), nil
@@ -187,10 +270,11 @@ func (r *ExtractTaskRepository) UpdateExecution(ctx context.Context, exec *extra
 		Model(&ExtractTaskExecution{}).
 		Where("id = ?", dbExec.ID).
 		Updates(map[string]any{
-			"status":      dbExec.Status,
-			"error_info":  dbExec.ErrorInfo,
-			"finished_at": dbExec.FinishedAt,
-			"updated_at":  dbExec.UpdatedAt,
+			"status":         dbExec.Status,
+			"error_info":     dbExec.ErrorInfo,
+			"error_category": dbExec.ErrorCategory,
+			"finished_at":    dbExec.FinishedAt,
+			"updated_at":     dbExec.UpdatedAt,
 		}).Error
 }
 
@@ -207,6 +291,206 @@ func (r *ExtractTaskRepository) CreateExtractedDataS3(
 	return dbS3.ToEntity(), nil
 }
 
+// UpdateExtractedDataS3 persists the purge state of an S3 file record.
+func (r *ExtractTaskRepository) UpdateExtractedDataS3(ctx context.Context, s3File *extract.ExtractedDataS3) error {
+	dbS3 := toExtractedDataS3(s3File)
+	return r.db.WithContext(ctx).
+		Model(&ExtractedDataS3{}).
+		Where("id = ?", dbS3.ID).
+		Updates(map[string]any{
+			"purged_at":  dbS3.PurgedAt,
+			"updated_at": dbS3.UpdatedAt,
+		}).Error
+}
+
+// ListExecutions returns the executions of every task for source and dataType,
+// newest first, with their S3 files. When beforeID is set, only executions
+// with a lower ID are returned.
+func (r *ExtractTaskRepository) ListExecutions(
+	ctx context.Context,
+	source string,
+	dataType string,
+	beforeID *int,
+	limit int,
+) ([]*extract.ExtractTaskExecution, error) {
+	join := fmt.Sprintf(
+		"JOIN %s.extract_tasks t ON t.id = extract_task_executions.extract_task_id",
+		database.SchemaName,
+	)
+	query := r.db.WithContext(ctx).
+		Preload("ExtractedDataS3s", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Joins(join).
+		Where("t.source = ? AND t.data_type = ?", source, dataType)
+	if beforeID != nil {
+		query = query.Where("extract_task_executions.id < ?", *beforeID)
+	}
+
+	var dbExecs []*ExtractTaskExecution
+	if err := query.Order("extract_task_executions.id DESC").Limit(limit).Find(&dbExecs).Error; err != nil {
+		return nil, err
+	}
+	return lo.Map(dbExecs, func(e *ExtractTaskExecution, _ int) *extract.ExtractTaskExecution {
+		return e.ToEntity()
+	}), nil
+}
+
+// ListExtractedDataS3s returns the S3 files written by executions matching
+// filter, ordered by ID. Purged files are left out.
+func (r *ExtractTaskRepository) ListExtractedDataS3s(
+	ctx context.Context,
+	filter extract.FileFilter,
+) ([]*extract.ExtractedDataS3, error) {
+	query := r.db.WithContext(ctx).
+		Joins(fmt.Sprintf(
+			"JOIN %s.extract_task_executions e ON e.id = extracted_data_s3s.extract_task_execution_id",
+			database.SchemaName,
+		)).
+		Joins(fmt.Sprintf("JOIN %s.extract_tasks t ON t.id = e.extract_task_id", database.SchemaName)).
+		Where("extracted_data_s3s.purged_at IS NULL")
+	if filter.Source != "" {
+		query = query.Where("t.source = ?", filter.Source)
+	}
+	if filter.DataType != "" {
+		query = query.Where("t.data_type = ?", filter.DataType)
+	}
+	if filter.From != nil {
+		query = query.Where("e.target_date_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("e.target_date_time < ?", *filter.To)
+	}
+
+	var dbS3s []*ExtractedDataS3
+	if err := query.Order("extracted_data_s3s.id").Find(&dbS3s).Error; err != nil {
+		return nil, err
+	}
+	return lo.Map(dbS3s, func(f *ExtractedDataS3, _ int) *extract.ExtractedDataS3 { return f.ToEntity() }), nil
+}
+
+// ListExtractedDataS3Keys returns every recorded S3 key that starts with
+// prefix, leaving out keys whose records are all purged.
+func (r *ExtractTaskRepository) ListExtractedDataS3Keys(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := r.db.WithContext(ctx).
+		Model(&ExtractedDataS3{}).
+		Where(`key LIKE ? ESCAPE '\'`, likeEscaper.Replace(prefix)+"%").
+		Where("purged_at IS NULL").
+		Distinct("key").
+		Order("key").
+		Pluck("key", &keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ListExtractedDataS3sByKeyPrefix returns every record whose S3 key starts
+// with prefix, purged or not, ordered by ID.
+func (r *ExtractTaskRepository) ListExtractedDataS3sByKeyPrefix(
+	ctx context.Context,
+	prefix string,
+) ([]*extract.ExtractedDataS3, error) {
+	var dbS3s []*ExtractedDataS3
+	err := r.db.WithContext(ctx).
+		Where(`key LIKE ? ESCAPE '\'`, likeEscaper.Replace(prefix)+"%").
+		Order("id").
+		Find(&dbS3s).Error
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(dbS3s, func(f *ExtractedDataS3, _ int) *extract.ExtractedDataS3 { return f.ToEntity() }), nil
+}
+
+// FindCurrentFiles returns the files holding the current data of source and
+// dataType for the calendar date of targetDate in its location: for each
+// window of the date, the latest file of the latest succeeded execution that
+// fetched it. Files are ordered by ID, oldest first. It works for both re-run
+// strategies, since an overwrite run records the shared key again.
+func (r *ExtractTaskRepository) FindCurrentFiles(
+	ctx context.Context,
+	source string,
+	dataType string,
+	targetDate time.Time,
+) ([]*extract.ExtractedDataS3, error) {
+	day := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 0, 0, 0, 0, targetDate.Location())
+	current := r.db.WithContext(ctx).
+		Model(&ExtractedDataS3{}).
+		Select("DISTINCT ON (extracted_data_s3s.file_window) extracted_data_s3s.*").
+		Joins(fmt.Sprintf(
+			"JOIN %s.extract_task_executions e ON e.id = extracted_data_s3s.extract_task_execution_id",
+			database.SchemaName,
+		)).
+		Joins(fmt.Sprintf("JOIN %s.extract_tasks t ON t.id = e.extract_task_id", database.SchemaName)).
+		Where("t.source = ? AND t.data_type = ?", source, dataType).
+		Where("e.status = ?", string(extract.ExecutionStatusSucceeded)).
+		Where("e.target_date_time >= ? AND e.target_date_time < ?", day, day.AddDate(0, 0, 1)).
+		Where("extracted_data_s3s.purged_at IS NULL").
+		Order("extracted_data_s3s.file_window").
+		Order("e.id DESC").
+		Order("extracted_data_s3s.id DESC")
+	var dbS3s []*ExtractedDataS3
+	if err := r.db.WithContext(ctx).Table("(?) AS current_files", current).Order("id").Find(&dbS3s).Error; err != nil {
+		return nil, err
+	}
+	return lo.Map(dbS3s, func(f *ExtractedDataS3, _ int) *extract.ExtractedDataS3 { return f.ToEntity() }), nil
+}
+
+// HasSucceededExecution reports whether source and dataType have a succeeded
+// execution whose target date time is in [from, to).
+func (r *ExtractTaskRepository) HasSucceededExecution(
+	ctx context.Context,
+	source string,
+	dataType string,
+	from time.Time,
+	to time.Time,
+) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&ExtractTaskExecution{}).
+		Joins(fmt.Sprintf(
+			"JOIN %s.extract_tasks t ON t.id = extract_task_executions.extract_task_id",
+			database.SchemaName,
+		)).
+		Where("t.source = ? AND t.data_type = ?", source, dataType).
+		Where("extract_task_executions.status = ?", string(extract.ExecutionStatusSucceeded)).
+		Where("extract_task_executions.target_date_time >= ?", from).
+		Where("extract_task_executions.target_date_time < ?", to).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ListSucceededTargetDates returns the distinct target date times of the
+// succeeded executions of source and dataType in [from, to), oldest first.
+func (r *ExtractTaskRepository) ListSucceededTargetDates(
+	ctx context.Context,
+	source string,
+	dataType string,
+	from time.Time,
+	to time.Time,
+) ([]time.Time, error) {
+	var dates []time.Time
+	err := r.db.WithContext(ctx).
+		Model(&ExtractTaskExecution{}).
+		Joins(fmt.Sprintf(
+			"JOIN %s.extract_tasks t ON t.id = extract_task_executions.extract_task_id",
+			database.SchemaName,
+		)).
+		Where("t.source = ? AND t.data_type = ?", source, dataType).
+		Where("extract_task_executions.status = ?", string(extract.ExecutionStatusSucceeded)).
+		Where("extract_task_executions.target_date_time >= ?", from).
+		Where("extract_task_executions.target_date_time < ?", to).
+		Distinct("extract_task_executions.target_date_time").
+		Order("extract_task_executions.target_date_time").
+		Pluck("extract_task_executions.target_date_time", &dates).Error
+	if err != nil {
+		return nil, err
+	}
+	return dates, nil
+}
+
 func (r *ExtractTaskRepository) Transaction(ctx context.Context, f func(tx *ExtractTaskRepository) error) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return f(&ExtractTaskRepository{db: tx})
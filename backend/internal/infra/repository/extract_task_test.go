@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 
@@ -61,7 +62,8 @@ func (s *ExtractTaskRepositoryTestSuite) TestCreate() {
 	ctx := context.Background()
 	targetDateTime := time.Now().UTC().Add(-24 * time.Hour).Truncate(time.Microsecond)
 
-	s3File := extract.NewExtractedDataS3(ctx, "path/to/key.csv")
+	metadata := extract.NewFileMetadata([]byte("a,b\n"), "csv", 0)
+	s3File := extract.NewExtractedDataS3(ctx, "path/to/key.csv", "all", metadata, extract.ObjectVersion{}, "")
 	exec := extract.NewRunningExecution(ctx, targetDateTime)
 	exec.AddS3File(s3File)
 	task := extract.NewExtractTask(ctx, "j-quants", "daily-quotes", "daily")
@@ -93,6 +95,10 @@ func (s *ExtractTaskRepositoryTestSuite) TestCreate() {
 							ID:                     1,
 							ExtractTaskExecutionID: 1,
 							Key:                    "path/to/key.csv",
+							SizeBytes:              &metadata.SizeBytes,
+							Sha256:                 &metadata.SHA256,
+							Format:                 lo.ToPtr("csv"),
+							ContentType:            lo.ToPtr("application/octet-stream"),
 						},
 					},
 				},
@@ -154,6 +160,30 @@ func (s *ExtractTaskRepositoryTestSuite) TestCreateExecution() {
 	s.Equal(targetDateTime, created.TargetDateTime())
 }
 
+func (s *ExtractTaskRepositoryTestSuite) TestCreateExecution_DuplicateRunning() {
+	ctx := context.Background()
+
+	task := extract.NewExtractTask(ctx, "jquants", "brand", "daily")
+	s.Require().NoError(s.repo.Create(ctx, task))
+
+	found, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+	s.Require().NoError(err)
+
+	targetDateTime := time.Now().UTC().Truncate(time.Microsecond)
+	first, err := s.repo.CreateExecution(ctx, found.ID(), extract.NewRunningExecution(ctx, targetDateTime))
+	s.Require().NoError(err)
+
+	_, err = s.repo.CreateExecution(ctx, found.ID(), extract.NewRunningExecution(ctx, targetDateTime))
+	s.ErrorIs(err, extract.ErrExecutionAlreadyRunning)
+
+	// Once the first execution finishes, the same target date may run again
+	first.Succeed(ctx)
+	s.Require().NoError(s.repo.UpdateExecution(ctx, first))
+
+	_, err = s.repo.CreateExecution(ctx, found.ID(), extract.NewRunningExecution(ctx, targetDateTime))
+	s.NoError(err)
+}
+
 func (s *ExtractTaskRepositoryTestSuite) TestUpdateExecution() {
 	ctx := context.Background()
 
@@ -178,6 +208,7 @@ func (s *ExtractTaskRepositoryTestSuite) TestUpdateExecution() {
 		created.TargetDateTime(),
 		created.Status(),
 		created.ErrorInfo(),
+		created.ErrorCategory(),
 		created.StartedAt(),
 		created.FinishedAt(),
 		created.CreatedAt(),
@@ -216,11 +247,298 @@ func (s *ExtractTaskRepositoryTestSuite) TestCreateExtractedDataS3() {
 	created, err := s.repo.CreateExecution(ctx, found.ID(), exec)
 	s.Require().NoError(err)
 
-	s3File := extract.NewExtractedDataS3(ctx, "landing/jquants/brand/2025/06/01/data.json")
+	metadata := extract.NewFileMetadata([]byte(`{"info":[]}`), extract.FormatJSON, 200)
+	manifestKey := "landing/_manifests/jquants/brand/2025/06/01/execution-1.json"
+	s3File := extract.NewExtractedDataS3(ctx, "landing/jquants/brand/2025/06/01/data.json", "all", metadata, extract.ObjectVersion{}, manifestKey)
 	s3Created, err := s.repo.CreateExtractedDataS3(ctx, created.ID(), s3File)
 
 	s.NoError(err)
 	s.NotNil(s3Created)
 	s.Greater(s3Created.ID(), 0)
 	s.Equal("landing/jquants/brand/2025/06/01/data.json", s3Created.Key())
+	s.Equal(&metadata, s3Created.Metadata())
+	s.Equal(manifestKey, s3Created.ManifestKey())
+
+	// The compressed size and digest are recorded with the encoding.
+	compressed := metadata.Compressed(extract.EncodingZstd, extract.NewFileMetadata([]byte("zstd"), extract.FormatJSON, 200))
+	s3File = extract.NewExtractedDataS3(ctx, "landing/jquants/brand/2025/06/01/data.json.zst", "all", compressed, extract.ObjectVersion{}, "")
+	s3Created, err = s.repo.CreateExtractedDataS3(ctx, created.ID(), s3File)
+	s.Require().NoError(err)
+	s.Equal(&compressed, s3Created.Metadata())
+	var row ExtractedDataS3
+	s.Require().NoError(s.db.First(&row, s3Created.ID()).Error)
+	s.Equal(&compressed, row.ToEntity().Metadata())
+	s.Nil(row.ManifestKey)
+}
+
+func (s *ExtractTaskRepositoryTestSuite) TestListExecutions() {
+	ctx := context.Background()
+
+	daily := extract.NewExtractTask(ctx, "jquants", "brand", "daily")
+	s.Require().NoError(s.repo.Create(ctx, daily))
+	weekly := extract.NewExtractTask(ctx, "jquants", "brand", "weekly")
+	s.Require().NoError(s.repo.Create(ctx, weekly))
+	// distractor: another data type
+	other := extract.NewExtractTask(ctx, "jquants", "daily_quotes", "daily")
+	s.Require().NoError(s.repo.Create(ctx, other))
+
+	var ids []int
+	target := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for i, taskKey := range []string{"daily", "weekly", "daily"} {
+		task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", taskKey)
+		s.Require().NoError(err)
+		exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, target.AddDate(0, 0, i)))
+		s.Require().NoError(err)
+		ids = append(ids, exec.ID())
+	}
+	metadata := extract.NewFileMetadata([]byte(`{}`), extract.FormatJSON, 200)
+	_, err := s.repo.CreateExtractedDataS3(ctx, ids[0], extract.NewExtractedDataS3(ctx, "landing/a.json", "all", metadata, extract.ObjectVersion{}, ""))
+	s.Require().NoError(err)
+	found, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "daily_quotes", "daily")
+	s.Require().NoError(err)
+	_, err = s.repo.CreateExecution(ctx, found.ID(), extract.NewRunningExecution(ctx, target))
+	s.Require().NoError(err)
+
+	page1, err := s.repo.ListExecutions(ctx, "jquants", "brand", nil, 2)
+	s.Require().NoError(err)
+	s.Equal([]int{ids[2], ids[1]}, lo.Map(page1, func(e *extract.ExtractTaskExecution, _ int) int { return e.ID() }))
+
+	page2, err := s.repo.ListExecutions(ctx, "jquants", "brand", &ids[1], 2)
+	s.Require().NoError(err)
+	s.Require().Len(page2, 1)
+	s.Equal(ids[0], page2[0].ID())
+	s.Require().Len(page2[0].S3Files(), 1)
+	s.Equal(&metadata, page2[0].S3Files()[0].Metadata())
+}
+
+func (s *ExtractTaskRepositoryTestSuite) TestListExtractedDataS3s() {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	metadata := extract.NewFileMetadata([]byte(`{}`), extract.FormatJSON, 200)
+	record := func(dataType string, target time.Time, key string) {
+		task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", dataType, "daily")
+		s.Require().NoError(err)
+		if task == nil {
+			s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", dataType, "daily")))
+			task, err = s.repo.FindBySourceAndDataType(ctx, "jquants", dataType, "daily")
+			s.Require().NoError(err)
+		}
+		exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, target))
+		s.Require().NoError(err)
+		_, err = s.repo.CreateExtractedDataS3(ctx, exec.ID(), extract.NewExtractedDataS3(ctx, key, "all", metadata, extract.ObjectVersion{}, ""))
+		s.Require().NoError(err)
+	}
+	record("brand", day, "landing/jquants/brand/1.json")
+	record("brand", day.AddDate(0, 0, 1), "landing/jquants/brand/2.json")
+	record("brand", day.AddDate(0, 0, 2), "landing/jquants/brand/3.json")
+	// distractor: another data type whose name shares the prefix
+	record("brand_x", day, "landing/jquants/brand_x/1.json")
+
+	from, to := day.AddDate(0, 0, 1), day.AddDate(0, 0, 2)
+	files, err := s.repo.ListExtractedDataS3s(ctx, extract.FileFilter{
+		Source: "jquants", DataType: "brand", From: &from, To: &to,
+	})
+	s.Require().NoError(err)
+	s.Equal(
+		[]string{"landing/jquants/brand/2.json"},
+		lo.Map(files, func(f *extract.ExtractedDataS3, _ int) string { return f.Key() }),
+	)
+	s.Equal(&metadata, files[0].Metadata())
+
+	keys, err := s.repo.ListExtractedDataS3Keys(ctx, "landing/jquants/brand/")
+	s.Require().NoError(err)
+	s.Equal([]string{
+		"landing/jquants/brand/1.json",
+		"landing/jquants/brand/2.json",
+		"landing/jquants/brand/3.json",
+	}, keys)
+}
+
+func (s *ExtractTaskRepositoryTestSuite) TestUpdateExtractedDataS3() {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	metadata := extract.NewFileMetadata([]byte(`{}`), extract.FormatJSON, 200)
+
+	s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", "brand", "daily")))
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+	s.Require().NoError(err)
+	exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, day))
+	s.Require().NoError(err)
+	exec.Succeed(ctx)
+	s.Require().NoError(s.repo.UpdateExecution(ctx, exec))
+	old, err := s.repo.CreateExtractedDataS3(
+		ctx, exec.ID(), extract.NewExtractedDataS3(ctx, "landing/jquants/brand/old.json", "all", metadata, extract.ObjectVersion{}, ""),
+	)
+	s.Require().NoError(err)
+	purged, err := s.repo.CreateExtractedDataS3(
+		ctx, exec.ID(), extract.NewExtractedDataS3(ctx, "landing/jquants/brand/new.json", "all", metadata, extract.ObjectVersion{}, ""),
+	)
+	s.Require().NoError(err)
+
+	purged.Purge(ctx)
+	s.Require().NoError(s.repo.UpdateExtractedDataS3(ctx, purged))
+
+	// Purged files are no longer listed, current or verified
+	found, err := s.repo.FindCurrentFiles(ctx, "jquants", "brand", day)
+	s.Require().NoError(err)
+	s.Require().Len(found, 1)
+	s.Equal(old.ID(), found[0].ID())
+	files, err := s.repo.ListExtractedDataS3s(ctx, extract.FileFilter{Source: "jquants", DataType: "brand"})
+	s.Require().NoError(err)
+	s.Equal(
+		[]string{"landing/jquants/brand/old.json"},
+		lo.Map(files, func(f *extract.ExtractedDataS3, _ int) string { return f.Key() }),
+	)
+	keys, err := s.repo.ListExtractedDataS3Keys(ctx, "landing/jquants/brand/")
+	s.Require().NoError(err)
+	s.Equal([]string{"landing/jquants/brand/old.json"}, keys)
+
+	// but stay in the execution history and the records by key
+	all, err := s.repo.ListExtractedDataS3sByKeyPrefix(ctx, "landing/jquants/brand/")
+	s.Require().NoError(err)
+	s.Equal(
+		[]string{"landing/jquants/brand/old.json", "landing/jquants/brand/new.json"},
+		lo.Map(all, func(f *extract.ExtractedDataS3, _ int) string { return f.Key() }),
+	)
+	executions, err := s.repo.ListExecutions(ctx, "jquants", "brand", nil, 10)
+	s.Require().NoError(err)
+	s.Require().Len(executions, 1)
+	s.Require().Len(executions[0].S3Files(), 2)
+	s.Nil(executions[0].S3Files()[0].PurgedAt())
+	s.NotNil(executions[0].S3Files()[1].PurgedAt())
+}
+
+func (s *ExtractTaskRepositoryTestSuite) TestFindCurrentFiles() {
+	ctx := context.Background()
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, jst)
+	metadata := extract.NewFileMetadata([]byte(`{}`), extract.FormatJSON, 200)
+
+	s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", "brand", "daily")))
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+	s.Require().NoError(err)
+	run := func(target time.Time, key string, window string, succeed bool) {
+		exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, target))
+		s.Require().NoError(err)
+		_, err = s.repo.CreateExtractedDataS3(
+			ctx, exec.ID(), extract.NewExtractedDataS3(ctx, key, window, metadata, extract.ObjectVersion{}, ""),
+		)
+		s.Require().NoError(err)
+		if succeed {
+			exec.Succeed(ctx)
+		} else {
+			exec.Fail(ctx, "failed")
+		}
+		s.Require().NoError(s.repo.UpdateExecution(ctx, exec))
+	}
+	run(day, "landing/jquants/brand/first.json", "all", true)
+	run(day, "landing/jquants/brand/code.json", "code-86970", true)
+	run(day.Add(9*time.Hour), "landing/jquants/brand/second.json", "all", true)
+	run(day, "landing/jquants/brand/failed.json", "code-86970", false)
+	run(day.AddDate(0, 0, 1), "landing/jquants/brand/next-day.json", "all", true)
+
+	// The latest file of each window, oldest first
+	current, err := s.repo.FindCurrentFiles(ctx, "jquants", "brand", day.Add(12*time.Hour))
+	s.Require().NoError(err)
+	s.Equal(
+		[]string{"landing/jquants/brand/code.json", "landing/jquants/brand/second.json"},
+		lo.Map(current, func(f *extract.ExtractedDataS3, _ int) string { return f.Key() }),
+	)
+	s.Equal(
+		[]string{"code-86970", "all"},
+		lo.Map(current, func(f *extract.ExtractedDataS3, _ int) string { return f.Window() }),
+	)
+
+	none, err := s.repo.FindCurrentFiles(ctx, "jquants", "brand", day.AddDate(0, 0, -1))
+	s.NoError(err)
+	s.Empty(none)
+}
+
+func (s *ExtractTaskRepositoryTestSuite) TestHasSucceededExecution() {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", "trading_calendar", "daily")))
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "trading_calendar", "daily")
+	s.Require().NoError(err)
+	succeeded, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, day))
+	s.Require().NoError(err)
+	succeeded.Succeed(ctx)
+	s.Require().NoError(s.repo.UpdateExecution(ctx, succeeded))
+	// distractor: a failed execution of the next day
+	failed, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, day.AddDate(0, 0, 1)))
+	s.Require().NoError(err)
+	failed.Fail(ctx, "failed")
+	s.Require().NoError(s.repo.UpdateExecution(ctx, failed))
+
+	tests := []struct {
+		name     string
+		dataType string
+		from     time.Time
+		expected bool
+	}{
+		{name: "succeeded in range", dataType: "trading_calendar", from: day, expected: true},
+		{name: "only failed in range", dataType: "trading_calendar", from: day.AddDate(0, 0, 1), expected: false},
+		{name: "other data type", dataType: "brand", from: day, expected: false},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got, err := s.repo.HasSucceededExecution(ctx, "jquants", tt.dataType, tt.from, tt.from.AddDate(0, 0, 1))
+			s.Require().NoError(err)
+			s.Equal(tt.expected, got)
+		})
+	}
+}
+
+func (s *ExtractTaskRepositoryTestSuite) TestListSucceededTargetDates() {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", "daily_quotes", "daily")))
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "daily_quotes", "daily")
+	s.Require().NoError(err)
+	for _, target := range []time.Time{day.AddDate(0, 0, 2), day, day, day.AddDate(0, 0, 5)} {
+		exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, target))
+		s.Require().NoError(err)
+		exec.Succeed(ctx)
+		s.Require().NoError(s.repo.UpdateExecution(ctx, exec))
+	}
+	// distractor: a failed execution inside the range
+	failed, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, day.AddDate(0, 0, 1)))
+	s.Require().NoError(err)
+	failed.Fail(ctx, "failed")
+	s.Require().NoError(s.repo.UpdateExecution(ctx, failed))
+
+	got, err := s.repo.ListSucceededTargetDates(ctx, "jquants", "daily_quotes", day, day.AddDate(0, 0, 5))
+	s.Require().NoError(err)
+	s.Equal([]time.Time{day, day.AddDate(0, 0, 2)}, lo.Map(got, func(d time.Time, _ int) time.Time { return d.UTC() }))
+
+	none, err := s.repo.ListSucceededTargetDates(ctx, "jquants", "brand", day, day.AddDate(0, 0, 5))
+	s.NoError(err)
+	s.Empty(none)
+}
+
+func (s *ExtractTaskRepositoryTestSuite) TestFindRunningExecution() {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", "brand", "daily")))
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+	s.Require().NoError(err)
+	// distractor: a finished execution of the same day
+	finished, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, day))
+	s.Require().NoError(err)
+	finished.Succeed(ctx)
+	s.Require().NoError(s.repo.UpdateExecution(ctx, finished))
+	running, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, day))
+	s.Require().NoError(err)
+
+	found, err := s.repo.FindRunningExecution(ctx, task.ID(), day)
+	s.Require().NoError(err)
+	s.Require().NotNil(found)
+	s.Equal(running.ID(), found.ID())
+
+	none, err := s.repo.FindRunningExecution(ctx, task.ID(), day.AddDate(0, 0, 1))
+	s.NoError(err)
+	s.Nil(none)
 }
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"strings"
+
+	"stock-tool/internal/util/pagination"
+
+	"gorm.io/gorm"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// whereNamePrefix restricts the query to rows whose name starts with prefix.
+func whereNamePrefix(db *gorm.DB, prefix string) *gorm.DB {
+	if prefix == "" {
+		return db
+	}
+	return db.Where(`name LIKE ? ESCAPE '\'`, likeEscaper.Replace(prefix)+"%")
+}
+
+// paginate applies keyset ordering, the cursor condition and the limit of p.
+func paginate(db *gorm.DB, p pagination.Params) *gorm.DB {
+	dir, cmpOp := "ASC", ">"
+	if p.Sort.Desc {
+		dir, cmpOp = "DESC", "<"
+	}
+
+	switch p.Sort.Field {
+	case pagination.SortFieldName:
+		if p.After != nil {
+			db = db.Where("(name, id) "+cmpOp+" (?, ?)", p.After.Name, p.After.ID)
+		}
+		db = db.Order("name " + dir).Order("id " + dir)
+	default:
+		if p.After != nil {
+			db = db.Where("id "+cmpOp+" ?", p.After.ID)
+		}
+		db = db.Order("id " + dir)
+	}
+	return db.Limit(p.Limit)
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"stock-tool/internal/domain/lineage"
+
+	"github.com/samber/lo"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type ProcessingExecution struct {
+	ID               int
+	SourceZone       string
+	TargetZone       string
+	SourceIdentifier string
+	TargetIdentifier string
+	InputKeyPatterns datatypes.JSONType[[]string]
+	OutputRefs       datatypes.JSONType[[]string]
+	RecordsRead      int
+	RecordsWritten   int
+	Status           string
+	ErrorInfo        *string
+	StartedAt        *time.Time
+	FinishedAt       *time.Time
+	CreatedAt        time.Time `gorm:"autoCreateTime:false"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime:false"`
+}
+
+func (e *ProcessingExecution) ToEntity() *lineage.ProcessingExecution {
+	return lineage.NewProcessingExecutionDirectly(
+		e.ID,
+		lineage.Zone(e.SourceZone),
+		lineage.Zone(e.TargetZone),
+		e.SourceIdentifier,
+		e.TargetIdentifier,
+		e.InputKeyPatterns.Data(),
+		e.OutputRefs.Data(),
+		e.RecordsRead,
+		e.RecordsWritten,
+		lineage.ExecutionStatus(e.Status),
+		e.ErrorInfo,
+		e.StartedAt,
+		e.FinishedAt,
+		e.CreatedAt,
+		e.UpdatedAt,
+	)
+}
+
+func toProcessingExecution(e *lineage.ProcessingExecution) *ProcessingExecution {
+	return &ProcessingExecution{
+		ID:               e.ID(),
+		SourceZone:       string(e.SourceZone()),
+		TargetZone:       string(e.TargetZone()),
+		SourceIdentifier: e.SourceIdentifier(),
+		TargetIdentifier: e.TargetIdentifier(),
+		InputKeyPatterns: datatypes.NewJSONType(nonNilStrings(e.InputKeyPatterns())),
+		OutputRefs:       datatypes.NewJSONType(nonNilStrings(e.OutputRefs())),
+		RecordsRead:      e.RecordsRead(),
+		RecordsWritten:   e.RecordsWritten(),
+		Status:           string(e.Status()),
+		ErrorInfo:        e.ErrorInfo(),
+		StartedAt:        e.StartedAt(),
+		FinishedAt:       e.FinishedAt(),
+		CreatedAt:        e.CreatedAt(),
+		UpdatedAt:        e.UpdatedAt(),
+	}
+}
+
+// nonNilStrings returns s, or an empty slice if s is nil, which would be
+// stored as JSON null.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+type ProcessingExecutionRepository struct {
+	db *gorm.DB
+}
+
+func NewProcessingExecutionRepository(db *gorm.DB) *ProcessingExecutionRepository {
+	return &ProcessingExecutionRepository{db: db}
+}
+
+func (r *ProcessingExecutionRepository) Create(
+	ctx context.Context,
+	exec *lineage.ProcessingExecution,
+) (*lineage.ProcessingExecution, error) {
+	dbExec := toProcessingExecution(exec)
+	if err := r.db.WithContext(ctx).Create(dbExec).Error; err != nil {
+		return nil, err
+	}
+	return dbExec.ToEntity(), nil
+}
+
+// Update persists the status, outputs and record counts of an execution.
+func (r *ProcessingExecutionRepository) Update(ctx context.Context, exec *lineage.ProcessingExecution) error {
+	dbExec := toProcessingExecution(exec)
+	return r.db.WithContext(ctx).
+		Model(&ProcessingExecution{}).
+		Where("id = ?", dbExec.ID).
+		Updates(map[string]any{
+			"output_refs":     dbExec.OutputRefs,
+			"records_read":    dbExec.RecordsRead,
+			"records_written": dbExec.RecordsWritten,
+			"status":          dbExec.Status,
+			"error_info":      dbExec.ErrorInfo,
+			"finished_at":     dbExec.FinishedAt,
+			"updated_at":      dbExec.UpdatedAt,
+		}).Error
+}
+
+// ListProducers returns the succeeded executions with an output reference
+// matching pattern, newest first. In pattern, '*' matches any sequence of
+// characters, as in lineage.MatchKeyPattern.
+func (r *ProcessingExecutionRepository) ListProducers(
+	ctx context.Context,
+	pattern string,
+) ([]*lineage.ProcessingExecution, error) {
+	query := r.db.WithContext(ctx).
+		Where("status = ?", string(lineage.ExecutionStatusSucceeded))
+	if strings.Contains(pattern, "*") {
+		like := strings.ReplaceAll(likeEscaper.Replace(pattern), "*", "%")
+		query = query.Where(
+			`EXISTS (SELECT 1 FROM jsonb_array_elements_text(output_refs) AS ref WHERE ref LIKE ? ESCAPE '\')`,
+			like,
+		)
+	} else {
+		// Containment can use the GIN index on output_refs
+		ref, err := json.Marshal([]string{pattern})
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("output_refs @> ?::jsonb", string(ref))
+	}
+
+	var dbExecs []*ProcessingExecution
+	if err := query.Order("id DESC").Find(&dbExecs).Error; err != nil {
+		return nil, err
+	}
+	return lo.Map(dbExecs, func(e *ProcessingExecution, _ int) *lineage.ProcessingExecution {
+		return e.ToEntity()
+	}), nil
+}
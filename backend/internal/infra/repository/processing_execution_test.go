@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/lineage"
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/testutil"
+)
+
+type ProcessingExecutionRepositoryTestSuite struct {
+	testutil.DBTest
+	repo *ProcessingExecutionRepository
+}
+
+func TestProcessingExecutionRepository(t *testing.T) {
+	suite.Run(t, new(ProcessingExecutionRepositoryTestSuite))
+}
+
+func (s *ProcessingExecutionRepositoryTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+	s.repo = NewProcessingExecutionRepository(db)
+}
+
+func (s *ProcessingExecutionRepositoryTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+// record creates an execution reading inputs and, when outputs is not nil,
+// marks it succeeded with them.
+func (s *ProcessingExecutionRepositoryTestSuite) record(inputs []string, outputs []string) *lineage.ProcessingExecution {
+	ctx := context.Background()
+	exec, err := s.repo.Create(ctx, lineage.NewRunningProcessingExecution(
+		ctx, lineage.ZoneLanding, lineage.ZoneBronze, "jquants/brand", "jquants_brand", inputs,
+	))
+	s.Require().NoError(err)
+	if outputs != nil {
+		exec.Succeed(ctx, outputs, 2, 2)
+		s.Require().NoError(s.repo.Update(ctx, exec))
+	}
+	return exec
+}
+
+func (s *ProcessingExecutionRepositoryTestSuite) TestCreateAndUpdate() {
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	ctx := clock.WithFixedTime(context.Background(), now)
+	inputs := []string{"landing/jquants/brand/2025/06/02/all.json"}
+
+	created, err := s.repo.Create(ctx, lineage.NewRunningProcessingExecution(
+		ctx, lineage.ZoneLanding, lineage.ZoneBronze, "jquants/brand", "jquants_brand", inputs,
+	))
+	s.Require().NoError(err)
+	s.NotZero(created.ID())
+	s.Equal(lineage.ExecutionStatusRunning, created.Status())
+	s.Equal(inputs, created.InputKeyPatterns())
+	s.Equal([]string{}, created.OutputRefs())
+
+	outputs := []string{"bronze/jquants_brand/date=2025-06-02/data.parquet"}
+	created.Succeed(ctx, outputs, 3, 3)
+	s.Require().NoError(s.repo.Update(ctx, created))
+
+	found, err := s.repo.ListProducers(ctx, outputs[0])
+	s.Require().NoError(err)
+	s.Require().Len(found, 1)
+	s.Equal(created.ID(), found[0].ID())
+	s.Equal(lineage.ZoneLanding, found[0].SourceZone())
+	s.Equal(lineage.ZoneBronze, found[0].TargetZone())
+	s.Equal("jquants/brand", found[0].SourceIdentifier())
+	s.Equal("jquants_brand", found[0].TargetIdentifier())
+	s.Equal(inputs, found[0].InputKeyPatterns())
+	s.Equal(outputs, found[0].OutputRefs())
+	s.Equal(3, found[0].RecordsRead())
+	s.Equal(3, found[0].RecordsWritten())
+	s.Equal(lineage.ExecutionStatusSucceeded, found[0].Status())
+	s.True(now.Equal(lo.FromPtr(found[0].FinishedAt())))
+}
+
+func (s *ProcessingExecutionRepositoryTestSuite) TestListProducers() {
+	ctx := context.Background()
+	day1 := "bronze/jquants_brand/date=2025-06-02/data.parquet"
+	day2 := "bronze/jquants_brand/date=2025-06-03/data.parquet"
+	first := s.record([]string{"landing/a.json"}, []string{day1})
+	second := s.record([]string{"landing/b.json"}, []string{day2})
+	rerun := s.record([]string{"landing/c.json"}, []string{day1})
+	// Running and failed executions produced nothing
+	s.record([]string{"landing/d.json"}, nil)
+	failed := s.record([]string{"landing/e.json"}, nil)
+	failed.Fail(ctx, "failed")
+	s.Require().NoError(s.repo.Update(ctx, failed))
+
+	ids := func(execs []*lineage.ProcessingExecution) []int {
+		return lo.Map(execs, func(e *lineage.ProcessingExecution, _ int) int { return e.ID() })
+	}
+
+	s.Run("exact reference", func() {
+		found, err := s.repo.ListProducers(ctx, day1)
+		s.Require().NoError(err)
+		s.Equal([]int{rerun.ID(), first.ID()}, ids(found))
+	})
+
+	s.Run("pattern", func() {
+		found, err := s.repo.ListProducers(ctx, "bronze/jquants_brand/*")
+		s.Require().NoError(err)
+		s.Equal([]int{rerun.ID(), second.ID(), first.ID()}, ids(found))
+	})
+
+	s.Run("LIKE wildcards are literal", func() {
+		found, err := s.repo.ListProducers(ctx, "bronze/jquants%/*")
+		s.Require().NoError(err)
+		s.Empty(found)
+	})
+
+	s.Run("no producer", func() {
+		found, err := s.repo.ListProducers(ctx, "bronze/jquants_brand/date=2025-06-04/data.parquet")
+		s.Require().NoError(err)
+		s.Empty(found)
+	})
+}
@@ -0,0 +1,406 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"stock-tool/internal/domain/extract"
+)
+
+const (
+	// fsMetaDir holds a JSON sidecar per object with its content type and
+	// user-defined metadata, mirroring the object tree.
+	fsMetaDir = ".meta"
+	// fsTmpDir holds files being written. It is on the same filesystem as the
+	// objects, so renaming a finished file into place is atomic.
+	fsTmpDir = ".tmp"
+)
+
+// FSClient stores objects as files under a root directory, for development
+// and tests without an S3 endpoint. A key maps to the path of the same name
+// under the root, so the landing layout is the same as in S3. Buckets are not
+// versioned.
+type FSClient struct {
+	root string
+}
+
+// fsObjectMeta is the sidecar stored for every object.
+type fsObjectMeta struct {
+	ContentType     string           `json:"content_type"`
+	ContentEncoding extract.Encoding `json:"content_encoding,omitempty"`
+	// ETag is the quoted hex MD5 of the content, as S3 reports it for an
+	// object uploaded in a single part.
+	ETag     string            `json:"etag"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+func NewFSClient(root string) *FSClient {
+	return &FSClient{root: root}
+}
+
+// PutObject writes data under key. See PutObjectStream.
+func (c *FSClient) PutObject(
+	ctx context.Context,
+	key string,
+	data []byte,
+	contentType string,
+	metadata map[string]string,
+) (string, error) {
+	return c.PutObjectStream(ctx, key, bytes.NewReader(data), contentType, extract.EncodingNone, metadata)
+}
+
+// PutObjectStream copies body into a temporary file, then renames it into
+// place, so readers never see a partial object. The copy stops once ctx is
+// done and nothing is renamed into place. The old sidecar is removed
+// before the rename and the new one renamed into place after it, so a sidecar
+// never describes other content than its object; an object whose sidecar is
+// missing is reported without metadata. The returned version ID is always
+// empty.
+func (c *FSClient) PutObjectStream(
+	ctx context.Context,
+	key string,
+	body io.Reader,
+	contentType string,
+	contentEncoding extract.Encoding,
+	metadata map[string]string,
+) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	objectPath, metaPath, err := c.paths(key)
+	if err != nil {
+		return "", err
+	}
+	h := md5.New()
+	tmp, err := c.writeTemp(ctxReader{ctx: ctx, Reader: io.TeeReader(body, h)}, filepath.Base(objectPath))
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp)
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	meta, err := json.Marshal(fsObjectMeta{
+		ContentType:     contentType,
+		ContentEncoding: contentEncoding,
+		ETag:            strconv.Quote(hex.EncodeToString(h.Sum(nil))),
+		Metadata:        metadata,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	metaTmp, err := c.writeTemp(bytes.NewReader(meta), filepath.Base(metaPath))
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(metaTmp)
+
+	if err := os.Remove(metaPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	if err := c.rename(tmp, objectPath); err != nil {
+		return "", err
+	}
+	if err := c.rename(metaTmp, metaPath); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// HeadObject returns the size and metadata of the object under key, or
+// (nil, nil) if it does not exist.
+func (c *FSClient) HeadObject(ctx context.Context, key string) (*extract.ObjectInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	objectPath, metaPath, err := c.paths(key)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(objectPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stat.IsDir() {
+		return nil, nil
+	}
+
+	meta, err := c.readMeta(key, metaPath)
+	if err != nil {
+		return nil, err
+	}
+	return &extract.ObjectInfo{
+		SizeBytes:       stat.Size(),
+		ContentType:     meta.ContentType,
+		ContentEncoding: meta.ContentEncoding,
+		Metadata:        meta.Metadata,
+		ETag:            meta.ETag,
+	}, nil
+}
+
+// GetObject opens the content of the object under key for reading, or
+// returns (nil, nil) if it does not exist. An object stored with a content
+// encoding is decompressed as it is read, and reads fail once ctx is done.
+// The caller must close it.
+func (c *FSClient) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	objectPath, metaPath, err := c.paths(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(objectPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	meta, err := c.readMeta(key, metaPath)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return decode(&ctxReadCloser{ctxReader: ctxReader{ctx: ctx, Reader: f}, Closer: f}, meta.ContentEncoding)
+}
+
+// ListObjects iterates over every object whose key starts with prefix. The
+// matching keys are collected up front to sort them the way S3 does; the
+// size and entity tag of each are read as the iteration reaches it.
+func (c *FSClient) ListObjects(ctx context.Context, prefix string) iter.Seq2[extract.ObjectSummary, error] {
+	return func(yield func(extract.ObjectSummary, error) bool) {
+		keys, err := c.listKeys(ctx, prefix)
+		if err != nil {
+			yield(extract.ObjectSummary{}, err)
+			return
+		}
+		for _, key := range keys {
+			if err := ctx.Err(); err != nil {
+				yield(extract.ObjectSummary{}, err)
+				return
+			}
+			objectPath, metaPath, err := c.paths(key)
+			if err != nil {
+				yield(extract.ObjectSummary{}, err)
+				return
+			}
+			stat, err := os.Stat(objectPath)
+			if errors.Is(err, fs.ErrNotExist) {
+				// Deleted since the walk
+				continue
+			}
+			if err != nil {
+				yield(extract.ObjectSummary{}, err)
+				return
+			}
+			meta, err := c.readMeta(key, metaPath)
+			if err != nil {
+				yield(extract.ObjectSummary{}, err)
+				return
+			}
+			summary := extract.ObjectSummary{
+				Key:          key,
+				SizeBytes:    stat.Size(),
+				ETag:         meta.ETag,
+				LastModified: stat.ModTime(),
+			}
+			if !yield(summary, nil) {
+				return
+			}
+		}
+	}
+}
+
+// listKeys returns the keys of every object whose key starts with prefix, in
+// the lexicographic order S3 lists them in.
+func (c *FSClient) listKeys(ctx context.Context, prefix string) ([]string, error) {
+	// Only the directory holding the prefix can contain matching keys.
+	dir := c.root
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		if !filepath.IsLocal(filepath.FromSlash(prefix[:i])) {
+			return nil, fmt.Errorf("invalid key prefix: %s", prefix)
+		}
+		dir = filepath.Join(c.root, filepath.FromSlash(prefix[:i]))
+	}
+
+	var keys []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(c.root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if d.IsDir() {
+			if key == fsMetaDir || key == fsTmpDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(keys)
+	return keys, nil
+}
+
+// DeleteObject removes the object under key. Deleting a missing object is
+// not an error, as in S3.
+func (c *FSClient) DeleteObject(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	objectPath, metaPath, err := c.paths(key)
+	if err != nil {
+		return err
+	}
+	for _, p := range []string{objectPath, metaPath} {
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteObjectVersion deletes the object under key. The filesystem keeps no
+// versions, so a non-empty versionID is an error.
+func (c *FSClient) DeleteObjectVersion(ctx context.Context, key, versionID string) error {
+	if versionID != "" {
+		return fmt.Errorf("object versions are not supported by filesystem storage: %s", key)
+	}
+	return c.DeleteObject(ctx, key)
+}
+
+// CheckWritable verifies that the root is writable by writing a probe object
+// and deleting it.
+func (c *FSClient) CheckWritable(ctx context.Context) error {
+	key, err := newProbeKey()
+	if err != nil {
+		return err
+	}
+	if _, err := c.PutObject(ctx, key, []byte{}, "application/octet-stream", nil); err != nil {
+		return fmt.Errorf("storage root %s is not writable: %w", c.root, err)
+	}
+	if err := c.DeleteObject(ctx, key); err != nil {
+		return fmt.Errorf("failed to delete probe object %s: %w", key, err)
+	}
+	return nil
+}
+
+// paths returns the object and sidecar paths of key, rejecting keys that
+// would resolve outside the root or into the reserved directories.
+func (c *FSClient) paths(key string) (objectPath, metaPath string, err error) {
+	rel := filepath.FromSlash(key)
+	first, _, _ := strings.Cut(key, "/")
+	if !filepath.IsLocal(rel) || strings.HasSuffix(key, "/") || first == fsMetaDir || first == fsTmpDir {
+		return "", "", fmt.Errorf("invalid object key: %s", key)
+	}
+	return filepath.Join(c.root, rel), filepath.Join(c.root, fsMetaDir, rel+".json"), nil
+}
+
+// readMeta returns the sidecar of key, or an empty one if it is missing.
+func (c *FSClient) readMeta(key, metaPath string) (fsObjectMeta, error) {
+	var meta fsObjectMeta
+	raw, err := os.ReadFile(metaPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return meta, nil
+	}
+	if err != nil {
+		return meta, err
+	}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return meta, fmt.Errorf("failed to decode metadata of %s: %w", key, err)
+	}
+	return meta, nil
+}
+
+// writeTemp copies r into a new file under the temporary directory and
+// returns its path.
+func (c *FSClient) writeTemp(r io.Reader, name string) (string, error) {
+	tmpDir := filepath.Join(c.root, fsTmpDir)
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(tmpDir, name+".*")
+	if err != nil {
+		return "", err
+	}
+	if err := writeAndSync(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+func writeAndSync(f *os.File, r io.Reader) error {
+	if err := f.Chmod(0o644); err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// ctxReader fails reads once ctx is done, as reading an S3 request or
+// response body does when its context is canceled.
+type ctxReader struct {
+	ctx context.Context
+	io.Reader
+}
+
+func (r ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.Reader.Read(p)
+}
+
+type ctxReadCloser struct {
+	ctxReader
+	io.Closer
+}
+
+// rename moves a finished temporary file to target, creating its directory.
+func (c *FSClient) rename(tmp, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(tmp, target)
+}
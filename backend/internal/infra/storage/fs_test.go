@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/extract"
+)
+
+type FSClientTestSuite struct {
+	suite.Suite
+	root   string
+	client *FSClient
+}
+
+func TestFSClient(t *testing.T) {
+	suite.Run(t, new(FSClientTestSuite))
+}
+
+func (s *FSClientTestSuite) SetupTest() {
+	s.root = s.T().TempDir()
+	s.client = NewFSClient(s.root)
+}
+
+func (s *FSClientTestSuite) TestPutObject() {
+	ctx := context.Background()
+	key := "landing/jquants/brand/2025/06/01/20250601T120000Z_abc12345.json"
+	data := []byte(`{"info":[{"Code":"86970","CompanyName":"日本取引所グループ"}]}`)
+	metadata := map[string]string{"sha256": "abc", "format": "json"}
+
+	versionID, err := s.client.PutObject(ctx, key, data, "application/json", metadata)
+	s.Require().NoError(err)
+	s.Empty(versionID)
+
+	// The key is the path under the root, with the raw bytes unchanged.
+	onDisk, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(key)))
+	s.Require().NoError(err)
+	s.Equal(data, onDisk)
+	tmp, err := os.ReadDir(filepath.Join(s.root, fsTmpDir))
+	s.Require().NoError(err)
+	s.Empty(tmp)
+
+	// Overwriting replaces content and metadata.
+	_, err = s.client.PutObject(ctx, key, []byte(`{}`), "text/plain", nil)
+	s.Require().NoError(err)
+	info, err := s.client.HeadObject(ctx, key)
+	s.Require().NoError(err)
+	s.Require().NotNil(info)
+	s.Equal([]byte(`{}`), s.readObject(ctx, key))
+	s.Equal(int64(2), info.SizeBytes)
+	s.Equal("text/plain", info.ContentType)
+	s.Nil(info.Metadata)
+}
+
+func (s *FSClientTestSuite) TestPutObjectStream() {
+	ctx := context.Background()
+	key := "stream/data.json"
+	data := bytes.Repeat([]byte(`{"Code":"86970"}`), 1<<16)
+
+	body := iotest.HalfReader(bytes.NewReader(data))
+	_, err := s.client.PutObjectStream(ctx, key, body, "application/json", extract.EncodingNone, nil)
+	s.Require().NoError(err)
+	s.True(bytes.Equal(data, s.readObject(ctx, key)), "raw bytes are unchanged")
+	info, err := s.client.HeadObject(ctx, key)
+	s.Require().NoError(err)
+	s.Require().NotNil(info)
+	s.Equal(int64(len(data)), info.SizeBytes)
+	s.Equal(fmt.Sprintf("%q", fmt.Sprintf("%x", md5.Sum(data))), info.ETag)
+
+	// A failed read leaves the existing object and no temporary file.
+	failing := io.MultiReader(bytes.NewReader(data), iotest.ErrReader(errors.New("connection reset")))
+	_, err = s.client.PutObjectStream(ctx, key, failing, "text/plain", extract.EncodingNone, nil)
+	s.EqualError(err, "connection reset")
+	s.True(bytes.Equal(data, s.readObject(ctx, key)))
+	tmp, err := os.ReadDir(filepath.Join(s.root, fsTmpDir))
+	s.Require().NoError(err)
+	s.Empty(tmp)
+
+	// A failed rename of the object leaves no sidecar behind for it.
+	_, err = s.client.PutObject(ctx, "stream", data, "application/json", nil)
+	s.Error(err)
+	_, err = os.Stat(filepath.Join(s.root, fsMetaDir, "stream.json"))
+	s.ErrorIs(err, fs.ErrNotExist)
+}
+
+func (s *FSClientTestSuite) TestCompressedObject() {
+	ctx := context.Background()
+	data := []byte(`{"info":[{"Code":"86970","CompanyName":"日本取引所グループ"}]}`)
+	for _, encoding := range []extract.Encoding{extract.EncodingGzip, extract.EncodingZstd} {
+		s.Run(string(encoding), func() {
+			key := "compressed/data.json" + encoding.Extension()
+			compressed, err := encoding.Compress(data)
+			s.Require().NoError(err)
+			_, err = s.client.PutObjectStream(ctx, key, bytes.NewReader(compressed), "application/json", encoding, nil)
+			s.Require().NoError(err)
+
+			// The stored bytes are compressed; reads return the raw bytes.
+			onDisk, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(key)))
+			s.Require().NoError(err)
+			s.Equal(compressed, onDisk)
+			s.Equal(data, s.readObject(ctx, key))
+			info, err := s.client.HeadObject(ctx, key)
+			s.Require().NoError(err)
+			s.Require().NotNil(info)
+			s.Equal(encoding, info.ContentEncoding)
+			s.Equal(int64(len(compressed)), info.SizeBytes)
+		})
+	}
+}
+
+func (s *FSClientTestSuite) TestHeadGetListAndDelete() {
+	ctx := context.Background()
+	data := []byte(`{"info":[]}`)
+	etag := `"e7245a4e40c9c59a3acbf07808af99be"`
+	metadata := map[string]string{"sha256": "abc"}
+	for _, key := range []string{"verify/a/2.json", "verify/a/1.json", "verify/a-b/1.json", "verify/b/1.json"} {
+		_, err := s.client.PutObject(ctx, key, data, "application/json", metadata)
+		s.Require().NoError(err)
+	}
+
+	info, err := s.client.HeadObject(ctx, "verify/a/1.json")
+	s.Require().NoError(err)
+	s.Require().NotNil(info)
+	s.Equal(int64(11), info.SizeBytes)
+	s.Equal("application/json", info.ContentType)
+	s.Equal(metadata, info.Metadata)
+	s.Equal(etag, info.ETag)
+	s.Empty(info.VersionID)
+
+	body := s.readObject(ctx, "verify/a/1.json")
+	s.Equal(data, body)
+
+	objects := collectObjects(s.T(), s.client.ListObjects(ctx, "verify/a/"))
+	s.Equal([]string{"verify/a/1.json", "verify/a/2.json"}, objectKeys(objects))
+	s.Equal(int64(11), objects[0].SizeBytes)
+	s.Equal(etag, objects[0].ETag)
+	s.False(objects[0].LastModified.IsZero())
+	objects = collectObjects(s.T(), s.client.ListObjects(ctx, "verify/a"))
+	s.Equal([]string{"verify/a-b/1.json", "verify/a/1.json", "verify/a/2.json"}, objectKeys(objects))
+	s.Len(collectObjects(s.T(), s.client.ListObjects(ctx, "")), 4)
+	s.Empty(collectObjects(s.T(), s.client.ListObjects(ctx, "missing/")))
+
+	// Stopping early ends the iteration.
+	var first []string
+	for obj, err := range s.client.ListObjects(ctx, "verify/") {
+		s.Require().NoError(err)
+		first = append(first, obj.Key)
+		break
+	}
+	s.Equal([]string{"verify/a-b/1.json"}, first)
+
+	s.Require().NoError(s.client.DeleteObject(ctx, "verify/a/1.json"))
+	s.Require().NoError(s.client.DeleteObject(ctx, "verify/a/1.json"))
+	info, err = s.client.HeadObject(ctx, "verify/a/1.json")
+	s.NoError(err)
+	s.Nil(info)
+	rc, err := s.client.GetObject(ctx, "verify/a/1.json")
+	s.NoError(err)
+	s.Nil(rc)
+	objects = collectObjects(s.T(), s.client.ListObjects(ctx, "verify/a/"))
+	s.Equal([]string{"verify/a/2.json"}, objectKeys(objects))
+
+	s.EqualError(
+		s.client.DeleteObjectVersion(ctx, "verify/a/2.json", "v1"),
+		"object versions are not supported by filesystem storage: verify/a/2.json",
+	)
+	s.Require().NoError(s.client.DeleteObjectVersion(ctx, "verify/a/2.json", ""))
+	s.Empty(collectObjects(s.T(), s.client.ListObjects(ctx, "verify/a/")))
+}
+
+func (s *FSClientTestSuite) TestInvalidKey() {
+	ctx := context.Background()
+	for _, key := range []string{"", "../escape.json", "/abs.json", "a/../../b.json", ".meta/x.json", ".tmp/x", "dir/"} {
+		s.Run(key, func() {
+			_, err := s.client.PutObject(ctx, key, []byte("x"), "text/plain", nil)
+			s.EqualError(err, "invalid object key: "+key)
+		})
+	}
+}
+
+func (s *FSClientTestSuite) TestCheckWritable() {
+	ctx := context.Background()
+	s.Require().NoError(s.client.CheckWritable(ctx))
+	// The probe is deleted.
+	s.Empty(collectObjects(s.T(), s.client.ListObjects(ctx, probeKeyPrefix)))
+
+	// A root that is a file cannot be written to.
+	file := filepath.Join(s.root, "file")
+	s.Require().NoError(os.WriteFile(file, nil, 0o644))
+	s.ErrorContains(NewFSClient(file).CheckWritable(ctx), "is not writable")
+}
+
+func (s *FSClientTestSuite) TestCanceledContext() {
+	key := "landing/jquants/brand/2025/06/01/20250601T120000Z_abc12345.json"
+	_, err := s.client.PutObject(context.Background(), key, []byte(`{"info":[]}`), "application/json", nil)
+	s.Require().NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	rc, err := s.client.GetObject(ctx, key)
+	s.Require().NoError(err)
+	defer rc.Close()
+	cancel()
+
+	_, err = io.ReadAll(rc)
+	s.ErrorIs(err, context.Canceled, "reading an open object")
+	_, err = s.client.PutObject(ctx, "landing/other.json", []byte("{}"), "application/json", nil)
+	s.ErrorIs(err, context.Canceled)
+	_, err = s.client.HeadObject(ctx, key)
+	s.ErrorIs(err, context.Canceled)
+	_, err = s.client.GetObject(ctx, key)
+	s.ErrorIs(err, context.Canceled)
+	for _, err := range s.client.ListObjects(ctx, "landing/") {
+		s.ErrorIs(err, context.Canceled)
+	}
+	s.ErrorIs(s.client.DeleteObject(ctx, key), context.Canceled)
+
+	// Nothing was written or deleted.
+	objects := collectObjects(s.T(), s.client.ListObjects(context.Background(), "landing/"))
+	s.Equal([]string{key}, objectKeys(objects))
+}
+
+func (s *FSClientTestSuite) readObject(ctx context.Context, key string) []byte {
+	rc, err := s.client.GetObject(ctx, key)
+	s.Require().NoError(err)
+	s.Require().NotNil(rc)
+	defer rc.Close()
+	body, err := io.ReadAll(rc)
+	s.Require().NoError(err)
+	return body
+}
+
+func collectObjects(t *testing.T, seq iter.Seq2[extract.ObjectSummary, error]) []extract.ObjectSummary {
+	t.Helper()
+	var objects []extract.ObjectSummary
+	for obj, err := range seq {
+		require.NoError(t, err)
+		objects = append(objects, obj)
+	}
+	return objects
+}
+
+func objectKeys(objects []extract.ObjectSummary) []string {
+	keys := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		keys = append(keys, obj.Key)
+	}
+	return keys
+}
@@ -3,10 +3,33 @@ package storage
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"os"
+
+	"stock-tool/internal/domain/extract"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/samber/lo"
+)
+
+const (
+	// DefaultMultipartPartSize is the part size of multipart uploads when
+	// S3Config leaves it unset.
+	DefaultMultipartPartSize int64 = 16 << 20
+	// maxMultipartParts is the most parts S3 accepts in one upload.
+	maxMultipartParts = 10000
 )
 
 type S3Config struct {
@@ -16,43 +39,446 @@ type S3Config struct {
 	SecretKey      string
 	Region         string
 	ForcePathStyle bool
+	// MultipartPartSize is the part size of streamed uploads in bytes. S3
+	// requires at least 5 MiB for every part but the last. A stream longer
+	// than one part is uploaded in parts. Defaults to DefaultMultipartPartSize.
+	MultipartPartSize int64
+	// Encryption is the server-side encryption of written objects.
+	Encryption SSEMode
+	// SSECustomerKey is the base64-encoded 256-bit key of SSEModeCustomer.
+	// Every object is written and read with it, so a bucket must not mix
+	// objects encrypted with different keys or without one.
+	SSECustomerKey string
+	// CABundlePath is a PEM file of CA certificates trusted in addition to the
+	// system pool, for an endpoint with a private CA. Optional.
+	CABundlePath string
+}
+
+// SSEMode selects the server-side encryption of written objects.
+type SSEMode string
+
+const (
+	// SSEModeNone leaves encryption to the bucket's default.
+	SSEModeNone SSEMode = ""
+	// SSEModeS3 encrypts with keys managed by the storage (SSE-S3).
+	SSEModeS3 SSEMode = "sse-s3"
+	// SSEModeCustomer encrypts with S3Config.SSECustomerKey (SSE-C).
+	SSEModeCustomer SSEMode = "sse-c"
+)
+
+// LakehouseBucketName returns the bucket of the lakehouse in the given
+// environment, such as "dev" or "prod".
+func LakehouseBucketName(env string) string {
+	return fmt.Sprintf("locatw-%s-stocktool-lakehouse", env)
 }
 
 type S3Client struct {
-	client *s3.Client
-	bucket string
+	client   *s3.Client
+	bucket   string
+	partSize int64
+	sse      sseOptions
 }
 
-func NewS3Client(cfg S3Config) *S3Client {
-	client := s3.New(s3.Options{
+// NewS3Client returns a client of the bucket in cfg. It fails if the
+// encryption settings are invalid or the CA bundle cannot be read; it does not
+// contact the endpoint, which CheckWritable does.
+func NewS3Client(cfg S3Config) (*S3Client, error) {
+	sse, err := newSSEOptions(cfg.Encryption, cfg.SSECustomerKey)
+	if err != nil {
+		return nil, err
+	}
+	opts := s3.Options{
 		BaseEndpoint: &cfg.Endpoint,
 		Region:       cfg.Region,
 		Credentials:  credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, ""),
 		UsePathStyle: cfg.ForcePathStyle,
-	})
+	}
+	if cfg.CABundlePath != "" {
+		httpClient, err := newHTTPClientWithCABundle(cfg.CABundlePath)
+		if err != nil {
+			return nil, err
+		}
+		opts.HTTPClient = httpClient
+	}
 
-	return &S3Client{
-		client: client,
-		bucket: cfg.Bucket,
+	c := NewS3ClientFromClient(s3.New(opts), cfg.Bucket, cfg.MultipartPartSize)
+	c.sse = sse
+	return c, nil
+}
+
+// newHTTPClientWithCABundle returns an HTTP client that trusts the
+// certificates in the PEM file at path as well as the system pool.
+func newHTTPClientWithCABundle(path string) (*awshttp.BuildableClient, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", path)
+	}
+	return awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+		if tr.TLSClientConfig == nil {
+			tr.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}), nil
+}
+
+// sseOptions are the encryption parameters sent with requests. For SSE-C the
+// key goes with every request that reads or writes object content.
+type sseOptions struct {
+	mode SSEMode
+	// key and keyMD5 are base64-encoded, as the SSE-C headers take them.
+	key    string
+	keyMD5 string
+}
+
+func newSSEOptions(mode SSEMode, customerKey string) (sseOptions, error) {
+	switch mode {
+	case SSEModeNone, SSEModeS3:
+		if customerKey != "" {
+			return sseOptions{}, fmt.Errorf("an SSE-C key is set but the encryption mode is %q", mode)
+		}
+		return sseOptions{mode: mode}, nil
+	case SSEModeCustomer:
+		key, err := base64.StdEncoding.DecodeString(customerKey)
+		if err != nil {
+			return sseOptions{}, fmt.Errorf("invalid SSE-C key: %w", err)
+		}
+		if len(key) != 32 {
+			return sseOptions{}, fmt.Errorf("invalid SSE-C key: want 32 bytes, got %d", len(key))
+		}
+		sum := md5.Sum(key)
+		return sseOptions{
+			mode:   mode,
+			key:    customerKey,
+			keyMD5: base64.StdEncoding.EncodeToString(sum[:]),
+		}, nil
+	default:
+		return sseOptions{}, fmt.Errorf("unknown encryption mode: %s", mode)
 	}
 }
 
-func NewS3ClientFromClient(client *s3.Client, bucket string) *S3Client {
+// serverSideEncryption returns the x-amz-server-side-encryption of writes.
+func (o sseOptions) serverSideEncryption() types.ServerSideEncryption {
+	if o.mode == SSEModeS3 {
+		return types.ServerSideEncryptionAes256
+	}
+	return ""
+}
+
+// customerAlgorithm, customerKey and customerKeyMD5 return the SSE-C
+// headers, or nil if SSE-C is not used.
+func (o sseOptions) customerAlgorithm() *string {
+	if o.mode != SSEModeCustomer {
+		return nil
+	}
+	return aws.String(string(types.ServerSideEncryptionAes256))
+}
+
+func (o sseOptions) customerKey() *string {
+	return lo.EmptyableToPtr(o.key)
+}
+
+func (o sseOptions) customerKeyMD5() *string {
+	return lo.EmptyableToPtr(o.keyMD5)
+}
+
+func NewS3ClientFromClient(client *s3.Client, bucket string, partSize int64) *S3Client {
+	if partSize == 0 {
+		partSize = DefaultMultipartPartSize
+	}
 	return &S3Client{
-		client: client,
-		bucket: bucket,
+		client:   client,
+		bucket:   bucket,
+		partSize: partSize,
+	}
+}
+
+// PutObject uploads data under key. metadata is stored as user-defined
+// object metadata and returned by S3 with the x-amz-meta- prefix. Returns the
+// version ID of the written object, which is empty if the bucket is not
+// versioned.
+func (c *S3Client) PutObject(
+	ctx context.Context,
+	key string,
+	data []byte,
+	contentType string,
+	metadata map[string]string,
+) (string, error) {
+	return c.putObject(ctx, key, data, contentType, extract.EncodingNone, metadata)
+}
+
+func (c *S3Client) putObject(
+	ctx context.Context,
+	key string,
+	data []byte,
+	contentType string,
+	contentEncoding extract.Encoding,
+	metadata map[string]string,
+) (string, error) {
+	out, err := c.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:          aws.String(c.bucket),
+		Key:             aws.String(key),
+		Body:            bytes.NewReader(data),
+		ContentType:     aws.String(contentType),
+		ContentEncoding: lo.EmptyableToPtr(string(contentEncoding)),
+		Metadata:        metadata,
+
+		ServerSideEncryption: c.sse.serverSideEncryption(),
+		SSECustomerAlgorithm: c.sse.customerAlgorithm(),
+		SSECustomerKey:       c.sse.customerKey(),
+		SSECustomerKeyMD5:    c.sse.customerKeyMD5(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.VersionId), nil
+}
+
+// PutObjectStream uploads body under key, reading it to EOF. A body that
+// fits in one part is sent as a single PutObject; a longer one as a multipart
+// upload, holding one part in memory at a time. A failed multipart upload is
+// aborted. Returns the version ID as PutObject does.
+func (c *S3Client) PutObjectStream(
+	ctx context.Context,
+	key string,
+	body io.Reader,
+	contentType string,
+	contentEncoding extract.Encoding,
+	metadata map[string]string,
+) (string, error) {
+	buf := make([]byte, c.partSize)
+	n, err := io.ReadFull(body, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return c.putObject(ctx, key, buf[:n], contentType, contentEncoding, metadata)
 	}
+	if err != nil {
+		return "", err
+	}
+
+	created, err := c.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:          aws.String(c.bucket),
+		Key:             aws.String(key),
+		ContentType:     aws.String(contentType),
+		ContentEncoding: lo.EmptyableToPtr(string(contentEncoding)),
+		Metadata:        metadata,
+		// Parts carry the CRC32 checksum the SDK computes by default.
+		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+
+		ServerSideEncryption: c.sse.serverSideEncryption(),
+		SSECustomerAlgorithm: c.sse.customerAlgorithm(),
+		SSECustomerKey:       c.sse.customerKey(),
+		SSECustomerKeyMD5:    c.sse.customerKeyMD5(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+	versionID, err := c.uploadParts(ctx, key, created.UploadId, body, buf)
+	if err != nil {
+		// The upload must be aborted even if ctx is done, or its parts stay stored.
+		_, abortErr := c.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(c.bucket),
+			Key:      aws.String(key),
+			UploadId: created.UploadId,
+		})
+		if abortErr != nil {
+			return "", fmt.Errorf("%w (failed to abort multipart upload: %w)", err, abortErr)
+		}
+		return "", err
+	}
+	return versionID, nil
+}
+
+// uploadParts uploads buf, which holds a full first part, and then the rest
+// of body one part at a time, and completes the upload.
+func (c *S3Client) uploadParts(
+	ctx context.Context,
+	key string,
+	uploadID *string,
+	body io.Reader,
+	buf []byte,
+) (string, error) {
+	var parts []types.CompletedPart
+	n := len(buf)
+	for number := int32(1); n > 0; number++ {
+		if number > maxMultipartParts {
+			return "", fmt.Errorf("body exceeds %d parts of %d bytes", maxMultipartParts, c.partSize)
+		}
+		out, err := c.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:            aws.String(c.bucket),
+			Key:               aws.String(key),
+			UploadId:          uploadID,
+			PartNumber:        aws.Int32(number),
+			Body:              bytes.NewReader(buf[:n]),
+			ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+
+			SSECustomerAlgorithm: c.sse.customerAlgorithm(),
+			SSECustomerKey:       c.sse.customerKey(),
+			SSECustomerKeyMD5:    c.sse.customerKeyMD5(),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to upload part %d: %w", number, err)
+		}
+		parts = append(parts, types.CompletedPart{
+			ETag:          out.ETag,
+			ChecksumCRC32: out.ChecksumCRC32,
+			PartNumber:    aws.Int32(number),
+		})
+
+		n, err = io.ReadFull(body, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return "", err
+		}
+	}
+
+	out, err := c.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(c.bucket),
+		Key:             aws.String(key),
+		UploadId:        uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+
+		SSECustomerAlgorithm: c.sse.customerAlgorithm(),
+		SSECustomerKey:       c.sse.customerKey(),
+		SSECustomerKeyMD5:    c.sse.customerKeyMD5(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return aws.ToString(out.VersionId), nil
 }
 
-func (c *S3Client) PutObject(ctx context.Context, key string, data []byte) error {
-	_, err := c.client.PutObject(ctx, &s3.PutObjectInput{
+// HeadObject returns the size and metadata of the object under key, or
+// (nil, nil) if it does not exist.
+func (c *S3Client) HeadObject(ctx context.Context, key string) (*extract.ObjectInfo, error) {
+	out, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+
+		SSECustomerAlgorithm: c.sse.customerAlgorithm(),
+		SSECustomerKey:       c.sse.customerKey(),
+		SSECustomerKeyMD5:    c.sse.customerKeyMD5(),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &extract.ObjectInfo{
+		SizeBytes:       aws.ToInt64(out.ContentLength),
+		ContentType:     aws.ToString(out.ContentType),
+		ContentEncoding: extract.Encoding(aws.ToString(out.ContentEncoding)),
+		Metadata:        out.Metadata,
+		VersionID:       aws.ToString(out.VersionId),
+		ETag:            aws.ToString(out.ETag),
+	}, nil
+}
+
+// GetObject opens the content of the object under key for reading, or
+// returns (nil, nil) if it does not exist. The body is streamed from S3 and
+// decompressed by its Content-Encoding as it is read; the caller must close
+// it.
+func (c *S3Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+
+		SSECustomerAlgorithm: c.sse.customerAlgorithm(),
+		SSECustomerKey:       c.sse.customerKey(),
+		SSECustomerKeyMD5:    c.sse.customerKeyMD5(),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return decode(out.Body, extract.Encoding(aws.ToString(out.ContentEncoding)))
+}
+
+// ListObjects iterates over every object whose key starts with prefix. Each
+// page of up to 1,000 objects is requested when the previous one has been
+// consumed.
+func (c *S3Client) ListObjects(ctx context.Context, prefix string) iter.Seq2[extract.ObjectSummary, error] {
+	return func(yield func(extract.ObjectSummary, error) bool) {
+		paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
+			Bucket: aws.String(c.bucket),
+			Prefix: aws.String(prefix),
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				yield(extract.ObjectSummary{}, err)
+				return
+			}
+			for _, obj := range page.Contents {
+				summary := extract.ObjectSummary{
+					Key:          aws.ToString(obj.Key),
+					SizeBytes:    aws.ToInt64(obj.Size),
+					ETag:         aws.ToString(obj.ETag),
+					LastModified: aws.ToTime(obj.LastModified),
+				}
+				if !yield(summary, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DeleteObject removes the object under key. In a versioned bucket this adds
+// a delete marker and keeps the earlier versions.
+func (c *S3Client) DeleteObject(ctx context.Context, key string) error {
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(c.bucket),
 		Key:    aws.String(key),
-		Body:   bytes.NewReader(data),
 	})
 	return err
 }
 
+// DeleteObjectVersion permanently removes one version of the object under
+// key, without adding a delete marker. An empty versionID deletes the object
+// as DeleteObject does.
+func (c *S3Client) DeleteObjectVersion(ctx context.Context, key, versionID string) error {
+	if versionID == "" {
+		return c.DeleteObject(ctx, key)
+	}
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket:    aws.String(c.bucket),
+		Key:       aws.String(key),
+		VersionId: aws.String(versionID),
+	})
+	return err
+}
+
+// CheckWritable verifies that the bucket is reachable and accepts writes with
+// the configured encryption, by writing a probe object and permanently
+// deleting it.
+func (c *S3Client) CheckWritable(ctx context.Context) error {
+	if _, err := c.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(c.bucket)}); err != nil {
+		return fmt.Errorf("bucket %s is not reachable: %w", c.bucket, err)
+	}
+	key, err := newProbeKey()
+	if err != nil {
+		return err
+	}
+	versionID, err := c.PutObject(ctx, key, []byte{}, "application/octet-stream", nil)
+	if err != nil {
+		return fmt.Errorf("bucket %s is not writable: %w", c.bucket, err)
+	}
+	if err := c.DeleteObjectVersion(ctx, key, versionID); err != nil {
+		return fmt.Errorf("failed to delete probe object %s: %w", key, err)
+	}
+	return nil
+}
+
 func (c *S3Client) CreateBucket(ctx context.Context) error {
 	_, err := c.client.CreateBucket(ctx, &s3.CreateBucketInput{
 		Bucket: aws.String(c.bucket),
@@ -1,15 +1,27 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/stretchr/testify/suite"
 
+	"stock-tool/internal/domain/extract"
 	"stock-tool/internal/util/testutil"
 )
 
@@ -24,7 +36,8 @@ func TestS3Client(t *testing.T) {
 
 func (s *S3ClientTestSuite) SetupSuite() {
 	s.S3Test.SetupSuite()
-	s.client = NewS3Client(S3Config{
+	var err error
+	s.client, err = NewS3Client(S3Config{
 		Endpoint:       s.Endpoint,
 		Bucket:         testutil.TestS3Bucket,
 		AccessKey:      testutil.TestS3AccessKey,
@@ -32,6 +45,7 @@ func (s *S3ClientTestSuite) SetupSuite() {
 		Region:         testutil.TestS3Region,
 		ForcePathStyle: true,
 	})
+	s.Require().NoError(err)
 }
 
 func (s *S3ClientTestSuite) TestPutObject() {
@@ -56,16 +70,145 @@ func (s *S3ClientTestSuite) TestPutObject() {
 		s.Run(tt.name, func() {
 			ctx := context.Background()
 
-			err := s.client.PutObject(ctx, tt.key, tt.data)
+			metadata := map[string]string{"sha256": "abc", "format": "json"}
+			_, err := s.client.PutObject(ctx, tt.key, tt.data, "application/json", metadata)
 			s.Require().NoError(err)
 
-			body := s.getObject(ctx, tt.key)
+			body, head := s.getObject(ctx, tt.key)
 			s.Equal(tt.data, body)
+			s.Equal("application/json", aws.ToString(head.ContentType))
+			s.Equal(metadata, head.Metadata)
 		})
 	}
 }
 
-func (s *S3ClientTestSuite) getObject(ctx context.Context, key string) []byte {
+func (s *S3ClientTestSuite) TestPutObjectStream() {
+	ctx := context.Background()
+	client := NewS3ClientFromClient(s.client.client, testutil.TestS3Bucket, 5<<20)
+	metadata := map[string]string{"format": "json"}
+	// Two full parts and a short last one
+	large := bytes.Repeat([]byte(`{"Code":"86970"}`), (11<<20)/16)
+
+	for name, data := range map[string][]byte{"single part": []byte(`{"info":[]}`), "multipart": large} {
+		s.Run(name, func() {
+			key := "stream/" + strings.ReplaceAll(name, " ", "-") + ".json"
+			r := iotest.HalfReader(bytes.NewReader(data))
+			_, err := client.PutObjectStream(ctx, key, r, "application/json", extract.EncodingNone, metadata)
+			s.Require().NoError(err)
+
+			body, head := s.getObject(ctx, key)
+			s.Equal(len(data), len(body))
+			s.True(bytes.Equal(data, body), "raw bytes are unchanged")
+			s.Equal("application/json", aws.ToString(head.ContentType))
+			s.Equal(metadata, head.Metadata)
+		})
+	}
+
+	// A failed read aborts the upload and stores nothing.
+	failing := io.MultiReader(bytes.NewReader(large), iotest.ErrReader(errors.New("connection reset")))
+	_, err := client.PutObjectStream(ctx, "stream/failed.json", failing, "application/json", extract.EncodingNone, nil)
+	s.EqualError(err, "connection reset")
+	info, err := client.HeadObject(ctx, "stream/failed.json")
+	s.Require().NoError(err)
+	s.Nil(info)
+	uploads, err := client.client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(testutil.TestS3Bucket),
+		Prefix: aws.String("stream/"),
+	})
+	s.Require().NoError(err)
+	s.Empty(uploads.Uploads)
+}
+
+func (s *S3ClientTestSuite) TestCompressedObject() {
+	ctx := context.Background()
+	data := []byte(`{"info":[{"Code":"86970","CompanyName":"日本取引所グループ"}]}`)
+	for _, encoding := range []extract.Encoding{extract.EncodingGzip, extract.EncodingZstd} {
+		s.Run(string(encoding), func() {
+			key := "compressed/data.json" + encoding.Extension()
+			compressed, err := encoding.Compress(data)
+			s.Require().NoError(err)
+			_, err = s.client.PutObjectStream(ctx, key, bytes.NewReader(compressed), "application/json", encoding, nil)
+			s.Require().NoError(err)
+
+			// The stored bytes are compressed; reads return the raw bytes.
+			stored, head := s.getObject(ctx, key)
+			s.Equal(compressed, stored)
+			s.Equal(string(encoding), aws.ToString(head.ContentEncoding))
+			rc, err := s.client.GetObject(ctx, key)
+			s.Require().NoError(err)
+			s.Require().NotNil(rc)
+			body, err := io.ReadAll(rc)
+			s.Require().NoError(err)
+			s.Require().NoError(rc.Close())
+			s.Equal(data, body)
+			info, err := s.client.HeadObject(ctx, key)
+			s.Require().NoError(err)
+			s.Require().NotNil(info)
+			s.Equal(encoding, info.ContentEncoding)
+			s.Equal(int64(len(compressed)), info.SizeBytes)
+		})
+	}
+}
+
+func (s *S3ClientTestSuite) TestHeadGetListAndDelete() {
+	ctx := context.Background()
+	data := []byte(`{"info":[]}`)
+	etag := `"e7245a4e40c9c59a3acbf07808af99be"`
+	metadata := map[string]string{"sha256": "abc"}
+	for _, key := range []string{"verify/a/2.json", "verify/a/1.json", "verify/a-b/1.json", "verify/b/1.json"} {
+		_, err := s.client.PutObject(ctx, key, data, "application/json", metadata)
+		s.Require().NoError(err)
+	}
+
+	info, err := s.client.HeadObject(ctx, "verify/a/1.json")
+	s.Require().NoError(err)
+	s.Require().NotNil(info)
+	s.Equal(int64(11), info.SizeBytes)
+	s.Equal("application/json", info.ContentType)
+	s.Equal(metadata, info.Metadata)
+	s.Equal(etag, info.ETag)
+
+	rc, err := s.client.GetObject(ctx, "verify/a/1.json")
+	s.Require().NoError(err)
+	s.Require().NotNil(rc)
+	body, err := io.ReadAll(rc)
+	s.Require().NoError(err)
+	s.Require().NoError(rc.Close())
+	s.Equal(data, body)
+
+	objects := collectObjects(s.T(), s.client.ListObjects(ctx, "verify/a/"))
+	s.Equal([]string{"verify/a/1.json", "verify/a/2.json"}, objectKeys(objects))
+	s.Equal(int64(11), objects[0].SizeBytes)
+	s.Equal(etag, objects[0].ETag)
+	s.False(objects[0].LastModified.IsZero())
+	objects = collectObjects(s.T(), s.client.ListObjects(ctx, "verify/a"))
+	s.Equal([]string{"verify/a-b/1.json", "verify/a/1.json", "verify/a/2.json"}, objectKeys(objects))
+
+	s.Require().NoError(s.client.DeleteObject(ctx, "verify/a/1.json"))
+	s.Require().NoError(s.client.DeleteObject(ctx, "verify/a/1.json"))
+	info, err = s.client.HeadObject(ctx, "verify/a/1.json")
+	s.NoError(err)
+	s.Nil(info)
+	rc, err = s.client.GetObject(ctx, "verify/a/1.json")
+	s.NoError(err)
+	s.Nil(rc)
+	objects = collectObjects(s.T(), s.client.ListObjects(ctx, "verify/a/"))
+	s.Equal([]string{"verify/a/2.json"}, objectKeys(objects))
+
+	s.Require().NoError(s.client.DeleteObjectVersion(ctx, "verify/a/2.json", ""))
+	s.Empty(collectObjects(s.T(), s.client.ListObjects(ctx, "verify/a/")))
+}
+
+func (s *S3ClientTestSuite) TestCheckWritable() {
+	ctx := context.Background()
+	s.Require().NoError(s.client.CheckWritable(ctx))
+	s.Empty(collectObjects(s.T(), s.client.ListObjects(ctx, probeKeyPrefix)))
+
+	missing := NewS3ClientFromClient(s.client.client, "missing-bucket", 0)
+	s.ErrorContains(missing.CheckWritable(ctx), "bucket missing-bucket is not reachable")
+}
+
+func (s *S3ClientTestSuite) getObject(ctx context.Context, key string) ([]byte, *s3.GetObjectOutput) {
 	rawClient := s3.New(s3.Options{
 		BaseEndpoint: aws.String(s.Endpoint),
 		Region:       testutil.TestS3Region,
@@ -84,5 +227,115 @@ func (s *S3ClientTestSuite) getObject(ctx context.Context, key string) []byte {
 
 	body, err := io.ReadAll(result.Body)
 	s.Require().NoError(err)
-	return body
+	return body, result
+}
+
+// S3ConfigTestSuite checks the requests NewS3Client sends for S3Config
+// options, against a stub endpoint.
+type S3ConfigTestSuite struct {
+	suite.Suite
+	// headers are the headers of the last request the stub received.
+	headers http.Header
+}
+
+func TestS3Config(t *testing.T) {
+	suite.Run(t, new(S3ConfigTestSuite))
+}
+
+func (s *S3ConfigTestSuite) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.headers = r.Header.Clone()
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func (s *S3ConfigTestSuite) newClient(endpoint string, cfg S3Config) (*S3Client, error) {
+	cfg.Endpoint = endpoint
+	cfg.Bucket = LakehouseBucketName("dev")
+	cfg.AccessKey, cfg.SecretKey, cfg.Region = "key", "secret", "ap-northeast-1"
+	cfg.ForcePathStyle = true
+	return NewS3Client(cfg)
+}
+
+func (s *S3ConfigTestSuite) TestEncryption() {
+	server := httptest.NewServer(s.handler())
+	defer server.Close()
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{0x42}, 32)
+	keyMD5 := md5.Sum(key)
+
+	s.Run("SSE-S3", func() {
+		client, err := s.newClient(server.URL, S3Config{Encryption: SSEModeS3})
+		s.Require().NoError(err)
+		_, err = client.PutObject(ctx, "a.json", []byte(`{}`), "application/json", nil)
+		s.Require().NoError(err)
+		s.Equal("AES256", s.headers.Get("X-Amz-Server-Side-Encryption"))
+		s.Empty(s.headers.Get("X-Amz-Server-Side-Encryption-Customer-Key"))
+	})
+
+	s.Run("SSE-C", func() {
+		client, err := s.newClient(server.URL, S3Config{
+			Encryption:     SSEModeCustomer,
+			SSECustomerKey: base64.StdEncoding.EncodeToString(key),
+		})
+		s.Require().NoError(err)
+		_, err = client.PutObject(ctx, "a.json", []byte(`{}`), "application/json", nil)
+		s.Require().NoError(err)
+		s.Empty(s.headers.Get("X-Amz-Server-Side-Encryption"))
+		s.Equal("AES256", s.headers.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm"))
+		s.Equal(base64.StdEncoding.EncodeToString(key), s.headers.Get("X-Amz-Server-Side-Encryption-Customer-Key"))
+		s.Equal(
+			base64.StdEncoding.EncodeToString(keyMD5[:]),
+			s.headers.Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5"),
+		)
+
+		// Reads send the key too.
+		info, err := client.HeadObject(ctx, "a.json")
+		s.Require().NoError(err)
+		s.Nil(info)
+		s.Equal(base64.StdEncoding.EncodeToString(key), s.headers.Get("X-Amz-Server-Side-Encryption-Customer-Key"))
+	})
+
+	s.Run("invalid", func() {
+		_, err := s.newClient(server.URL, S3Config{Encryption: SSEModeCustomer, SSECustomerKey: "c2hvcnQ="})
+		s.EqualError(err, "invalid SSE-C key: want 32 bytes, got 5")
+		_, err = s.newClient(server.URL, S3Config{SSECustomerKey: base64.StdEncoding.EncodeToString(key)})
+		s.EqualError(err, `an SSE-C key is set but the encryption mode is ""`)
+		_, err = s.newClient(server.URL, S3Config{Encryption: "kms"})
+		s.EqualError(err, "unknown encryption mode: kms")
+	})
+}
+
+func (s *S3ConfigTestSuite) TestCABundle() {
+	server := httptest.NewTLSServer(s.handler())
+	defer server.Close()
+	ctx := context.Background()
+
+	// The stub's certificate is trusted only through the bundle.
+	client, err := s.newClient(server.URL, S3Config{})
+	s.Require().NoError(err)
+	_, err = client.HeadObject(ctx, "a.json")
+	s.ErrorContains(err, "certificate")
+
+	bundle := filepath.Join(s.T().TempDir(), "ca.pem")
+	s.Require().NoError(os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	}), 0o644))
+	client, err = s.newClient(server.URL, S3Config{CABundlePath: bundle})
+	s.Require().NoError(err)
+	info, err := client.HeadObject(ctx, "a.json")
+	s.Require().NoError(err)
+	s.Nil(info)
+
+	empty := filepath.Join(s.T().TempDir(), "empty.pem")
+	s.Require().NoError(os.WriteFile(empty, nil, 0o644))
+	_, err = s.newClient(server.URL, S3Config{CABundlePath: empty})
+	s.EqualError(err, "no certificates found in CA bundle "+empty)
+}
+
+func (s *S3ConfigTestSuite) TestLakehouseBucketName() {
+	s.Equal("locatw-prod-stocktool-lakehouse", LakehouseBucketName("prod"))
 }
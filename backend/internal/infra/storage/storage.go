@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"stock-tool/internal/domain/extract"
+)
+
+// ObjectStore is object storage addressed by slash-separated keys. S3Client
+// and FSClient implement it with the same semantics, so the landing zone can
+// live in either.
+type ObjectStore interface {
+	// PutObject stores data under key with the given content type and
+	// user-defined object metadata. Returns the version ID of the written
+	// object, or an empty string if the store is not versioned.
+	PutObject(
+		ctx context.Context,
+		key string,
+		data []byte,
+		contentType string,
+		metadata map[string]string,
+	) (string, error)
+
+	// PutObjectStream stores everything read from body under key, without
+	// holding the whole content in memory. contentEncoding is recorded as the
+	// Content-Encoding of an already compressed body, or empty. Otherwise it
+	// behaves as PutObject.
+	PutObjectStream(
+		ctx context.Context,
+		key string,
+		body io.Reader,
+		contentType string,
+		contentEncoding extract.Encoding,
+		metadata map[string]string,
+	) (string, error)
+
+	// GetObject opens the content of the object under key for reading, or
+	// returns (nil, nil) if it does not exist. An object stored with a
+	// Content-Encoding is decompressed as it is read. The caller must close it.
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// HeadObject returns what storage reports about the object under key,
+	// or (nil, nil) if it does not exist.
+	HeadObject(ctx context.Context, key string) (*extract.ObjectInfo, error)
+
+	// ListObjects iterates over every object whose key starts with prefix,
+	// in lexicographic key order. Objects are fetched a page at a time as the
+	// iteration proceeds; an error ends the iteration.
+	ListObjects(ctx context.Context, prefix string) iter.Seq2[extract.ObjectSummary, error]
+
+	// DeleteObject removes the object under key. Deleting a missing object is
+	// not an error.
+	DeleteObject(ctx context.Context, key string) error
+
+	// DeleteObjectVersion permanently removes one version of the object under
+	// key. An empty versionID deletes the object as DeleteObject does.
+	DeleteObjectVersion(ctx context.Context, key, versionID string) error
+
+	// CheckWritable verifies that the store is reachable and accepts writes,
+	// leaving nothing behind. It is run before extraction starts.
+	CheckWritable(ctx context.Context) error
+}
+
+// Backends of Config.
+const (
+	BackendS3 = "s3"
+	BackendFS = "fs"
+)
+
+// Config selects the backend of the object store and configures it.
+type Config struct {
+	// Backend is BackendS3 or BackendFS.
+	Backend string
+	// FSRoot is the root directory of BackendFS.
+	FSRoot string
+	// AppEnv is the environment whose lakehouse bucket BackendS3 uses when
+	// S3.Bucket is empty.
+	AppEnv string
+	S3     S3Config
+}
+
+// Validate checks the backend and the settings it needs, so a command can
+// fail on startup rather than on its first object.
+func (c Config) Validate() error {
+	switch c.Backend {
+	case BackendS3:
+		if c.S3.Bucket == "" && c.AppEnv == "" {
+			return errors.New("S3_BUCKET or APP_ENV is required when STORAGE_BACKEND is s3")
+		}
+		return nil
+	case BackendFS:
+		if c.FSRoot == "" {
+			return errors.New("STORAGE_FS_ROOT is required when STORAGE_BACKEND is fs")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown STORAGE_BACKEND: %s", c.Backend)
+	}
+}
+
+// NewObjectStore returns the ObjectStore of the configured backend.
+func NewObjectStore(cfg Config) (ObjectStore, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Backend == BackendFS {
+		return NewFSClient(cfg.FSRoot), nil
+	}
+	s3Config := cfg.S3
+	if s3Config.Bucket == "" {
+		s3Config.Bucket = LakehouseBucketName(cfg.AppEnv)
+	}
+	client, err := NewS3Client(s3Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	return client, nil
+}
+
+// probeKeyPrefix is the key prefix of the objects CheckWritable writes. It is
+// outside landing/, so a probe that could not be deleted is never taken for
+// a landing file.
+const probeKeyPrefix = "_probes/"
+
+// newProbeKey returns a key under probeKeyPrefix that no other check uses.
+func newProbeKey() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate probe key: %w", err)
+	}
+	return probeKeyPrefix + hex.EncodeToString(b), nil
+}
+
+var (
+	_ ObjectStore = (*S3Client)(nil)
+	_ ObjectStore = (*FSClient)(nil)
+)
+
+// decodedBody is an object body read through a decompressor.
+type decodedBody struct {
+	io.ReadCloser
+	body io.Closer
+}
+
+// decode returns body decompressed according to encoding. Closing the result
+// closes body.
+func decode(body io.ReadCloser, encoding extract.Encoding) (io.ReadCloser, error) {
+	if encoding == extract.EncodingNone {
+		return body, nil
+	}
+	r, err := encoding.NewReader(body)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	return &decodedBody{ReadCloser: r, body: body}, nil
+}
+
+func (d *decodedBody) Close() error {
+	return errors.Join(d.ReadCloser.Close(), d.body.Close())
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StorageTestSuite struct {
+	suite.Suite
+}
+
+func TestStorage(t *testing.T) {
+	suite.Run(t, new(StorageTestSuite))
+}
+
+func (s *StorageTestSuite) TestConfigValidate() {
+	type testCase struct {
+		name    string
+		config  Config
+		wantErr string
+	}
+	tests := []testCase{
+		{name: "s3 bucket", config: Config{Backend: BackendS3, S3: S3Config{Bucket: "stocktool"}}},
+		{name: "s3 bucket of the environment", config: Config{Backend: BackendS3, AppEnv: "dev"}},
+		{
+			name:    "s3 without a bucket",
+			config:  Config{Backend: BackendS3},
+			wantErr: "S3_BUCKET or APP_ENV is required when STORAGE_BACKEND is s3",
+		},
+		{name: "fs", config: Config{Backend: BackendFS, FSRoot: "/data"}},
+		{
+			name:    "fs without a root",
+			config:  Config{Backend: BackendFS},
+			wantErr: "STORAGE_FS_ROOT is required when STORAGE_BACKEND is fs",
+		},
+		{name: "unknown", config: Config{Backend: "gcs"}, wantErr: "unknown STORAGE_BACKEND: gcs"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := tt.config.Validate()
+			if tt.wantErr != "" {
+				s.EqualError(err, tt.wantErr)
+				return
+			}
+			s.NoError(err)
+		})
+	}
+}
+
+func (s *StorageTestSuite) TestNewObjectStore() {
+	root := s.T().TempDir()
+	store, err := NewObjectStore(Config{Backend: BackendFS, FSRoot: root})
+	s.Require().NoError(err)
+	s.Equal(NewFSClient(root), store)
+
+	store, err = NewObjectStore(Config{Backend: BackendS3, AppEnv: "dev"})
+	s.Require().NoError(err)
+	s.Require().IsType(&S3Client{}, store)
+	s.Equal("locatw-dev-stocktool-lakehouse", store.(*S3Client).bucket)
+
+	_, err = NewObjectStore(Config{Backend: BackendFS})
+	s.EqualError(err, "STORAGE_FS_ROOT is required when STORAGE_BACKEND is fs")
+}
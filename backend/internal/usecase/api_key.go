@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"stock-tool/internal/domain/auth"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// APIKeyRepository provides persistence for APIKey entities.
+type APIKeyRepository interface {
+	// Create persists a new APIKey.
+	Create(ctx context.Context, key *auth.APIKey) error
+
+	// FindByID returns the APIKey with the given ID, or (nil, nil) if not found.
+	FindByID(ctx context.Context, id uuid.UUID) (*auth.APIKey, error)
+
+	// FindByKeyHash returns the APIKey stored under keyHash, or (nil, nil) if not found.
+	FindByKeyHash(ctx context.Context, keyHash string) (*auth.APIKey, error)
+
+	// List returns every APIKey, revoked ones included.
+	List(ctx context.Context) ([]*auth.APIKey, error)
+
+	// Revoke persists the revocation time of key.
+	Revoke(ctx context.Context, key *auth.APIKey) error
+}
+
+type IssueAPIKeyRequest struct {
+	Name string
+	Role string
+}
+
+type APIKeyResponse struct {
+	ID        uuid.UUID
+	Name      string
+	Role      string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+type IssueAPIKeyResponse struct {
+	APIKeyResponse
+	// Key is the plaintext key. It is not stored and cannot be retrieved again.
+	Key string
+}
+
+type APIKeyUseCase struct {
+	repo APIKeyRepository
+}
+
+func NewAPIKeyUseCase(repo APIKeyRepository) *APIKeyUseCase {
+	return &APIKeyUseCase{repo: repo}
+}
+
+// Issue creates a key and returns its plaintext. Returns a ValidationError
+// on an empty name or an unknown role.
+func (uc *APIKeyUseCase) Issue(ctx context.Context, req *IssueAPIKeyRequest) (*IssueAPIKeyResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, &ValidationError{Message: "name is required"}
+	}
+	role, err := auth.ParseRole(req.Role)
+	if err != nil {
+		return nil, &ValidationError{Message: err.Error()}
+	}
+	key, plaintext, err := auth.NewAPIKey(ctx, req.Name, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+	if err := uc.repo.Create(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to save API key: %w", err)
+	}
+	return &IssueAPIKeyResponse{APIKeyResponse: *toAPIKeyResponse(key), Key: plaintext}, nil
+}
+
+// Revoke revokes the key with the given ID. Returns (nil, nil) when the key
+// is not found; revoking a revoked key leaves it unchanged.
+func (uc *APIKeyUseCase) Revoke(ctx context.Context, id uuid.UUID) (*APIKeyResponse, error) {
+	key, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API key: %w", err)
+	}
+	if key == nil {
+		return nil, nil
+	}
+	if key.Revoked() {
+		return toAPIKeyResponse(key), nil
+	}
+	key.Revoke(ctx)
+	if err := uc.repo.Revoke(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	return toAPIKeyResponse(key), nil
+}
+
+func (uc *APIKeyUseCase) List(ctx context.Context) ([]*APIKeyResponse, error) {
+	keys, err := uc.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return lo.Map(keys, func(k *auth.APIKey, _ int) *APIKeyResponse { return toAPIKeyResponse(k) }), nil
+}
+
+// ErrUnauthenticated is returned by Authenticate for an unknown or revoked key.
+var ErrUnauthenticated = errors.New("invalid or revoked API key")
+
+// Authenticate returns the active key matching plaintext, or an error
+// wrapping ErrUnauthenticated.
+func (uc *APIKeyUseCase) Authenticate(ctx context.Context, plaintext string) (*auth.APIKey, error) {
+	key, err := uc.repo.FindByKeyHash(ctx, auth.HashKey(plaintext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find API key: %w", err)
+	}
+	if key == nil || key.Revoked() {
+		return nil, ErrUnauthenticated
+	}
+	return key, nil
+}
+
+func toAPIKeyResponse(k *auth.APIKey) *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:        k.ID(),
+		Name:      k.Name(),
+		Role:      string(k.Role()),
+		CreatedAt: k.CreatedAt(),
+		RevokedAt: k.RevokedAt(),
+	}
+}
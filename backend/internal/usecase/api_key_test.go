@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/testutil"
+)
+
+type APIKeyUseCaseTestSuite struct {
+	testutil.DBTest
+	uc *APIKeyUseCase
+}
+
+func TestAPIKeyUseCase(t *testing.T) {
+	suite.Run(t, new(APIKeyUseCaseTestSuite))
+}
+
+func (s *APIKeyUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+
+	s.uc = NewAPIKeyUseCase(repository.NewAPIKeyRepository(db))
+}
+
+func (s *APIKeyUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+func (s *APIKeyUseCaseTestSuite) TestIssue_Validation() {
+	type testCase struct {
+		name string
+		req  *IssueAPIKeyRequest
+	}
+	tests := []testCase{
+		{name: "empty name", req: &IssueAPIKeyRequest{Name: " ", Role: "admin"}},
+		{name: "unknown role", req: &IssueAPIKeyRequest{Name: "ci", Role: "owner"}},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			resp, err := s.uc.Issue(context.Background(), tc.req)
+			s.Nil(resp)
+			var ve *ValidationError
+			s.ErrorAs(err, &ve)
+		})
+	}
+}
+
+func (s *APIKeyUseCaseTestSuite) TestIssueAuthenticateRevoke() {
+	ctx := context.Background()
+	issued, err := s.uc.Issue(ctx, &IssueAPIKeyRequest{Name: "ci", Role: "read_only"})
+	s.Require().NoError(err)
+	s.Equal("ci", issued.Name)
+	s.Equal("read_only", issued.Role)
+	s.NotEmpty(issued.Key)
+
+	key, err := s.uc.Authenticate(ctx, issued.Key)
+	s.Require().NoError(err)
+	s.Equal(issued.ID, key.ID())
+
+	_, err = s.uc.Authenticate(ctx, issued.Key+"x")
+	s.ErrorIs(err, ErrUnauthenticated)
+
+	revokedAt := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	revoked, err := s.uc.Revoke(clock.WithFixedTime(ctx, revokedAt), issued.ID)
+	s.Require().NoError(err)
+	s.True(revokedAt.Equal(*revoked.RevokedAt))
+
+	_, err = s.uc.Authenticate(ctx, issued.Key)
+	s.ErrorIs(err, ErrUnauthenticated)
+
+	keys, err := s.uc.List(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(keys, 1)
+	s.NotNil(keys[0].RevokedAt)
+}
+
+func (s *APIKeyUseCaseTestSuite) TestRevoke_NotFound() {
+	resp, err := s.uc.Revoke(context.Background(), uuid.Must(uuid.NewV7()))
+	s.NoError(err)
+	s.Nil(resp)
+}
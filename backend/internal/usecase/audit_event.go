@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"stock-tool/internal/domain/audit"
+	"stock-tool/internal/util/pagination"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// AuditEventRepository provides read access to recorded audit events.
+// Events are written by the repositories of the audited entities.
+type AuditEventRepository interface {
+	// List returns the events matching filter, ordered and limited by page.
+	// Returns an empty slice when none are found.
+	List(ctx context.Context, filter audit.EventFilter, page pagination.Params) ([]*audit.Event, error)
+}
+
+// auditEventSort lists events newest first; event IDs are UUIDv7 and so
+// follow the order in which events were recorded.
+const auditEventSort = "-id"
+
+type ListAuditEventsRequest struct {
+	// EntityType, when non-empty, must be one of audit.EntityTypes.
+	EntityType string
+	EntityID   *uuid.UUID
+	// Since is inclusive and Until exclusive.
+	Since *time.Time
+	Until *time.Time
+	// Page.Sort is ignored; events are always listed newest first.
+	Page PageRequest
+}
+
+type AuditEventResponse struct {
+	ID         uuid.UUID
+	OccurredAt time.Time
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   uuid.UUID
+	Before     map[string]any
+	After      map[string]any
+}
+
+type AuditEventListResponse struct {
+	Items []*AuditEventResponse
+	// NextCursor is nil on the last page.
+	NextCursor *string
+}
+
+type AuditEventUseCase struct {
+	repo AuditEventRepository
+}
+
+func NewAuditEventUseCase(repo AuditEventRepository) *AuditEventUseCase {
+	return &AuditEventUseCase{repo: repo}
+}
+
+// List returns one page of audit events, newest first. Returns a
+// ValidationError on an unknown entity type, an empty time range or invalid
+// pagination parameters.
+func (uc *AuditEventUseCase) List(ctx context.Context, req *ListAuditEventsRequest) (*AuditEventListResponse, error) {
+	pageReq := req.Page
+	pageReq.Sort = auditEventSort
+	page, err := buildPageParams(pageReq)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := audit.EventFilter{EntityID: req.EntityID, Since: req.Since, Until: req.Until}
+	if req.EntityType != "" {
+		entityType := audit.EntityType(req.EntityType)
+		if !slices.Contains(audit.EntityTypes, entityType) {
+			return nil, &ValidationError{Message: fmt.Sprintf("unknown entity type: %s", req.EntityType)}
+		}
+		filter.EntityType = &entityType
+	}
+	if req.Since != nil && req.Until != nil && !req.Since.Before(*req.Until) {
+		return nil, &ValidationError{Message: "since must be before until"}
+	}
+
+	events, err := uc.repo.List(ctx, filter, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	events, next := trimPage(events, page, func(e *audit.Event) (uuid.UUID, string) {
+		return e.ID(), ""
+	})
+	return &AuditEventListResponse{
+		Items:      lo.Map(events, func(e *audit.Event, _ int) *AuditEventResponse { return newAuditEventResponse(e) }),
+		NextCursor: next,
+	}, nil
+}
+
+func newAuditEventResponse(e *audit.Event) *AuditEventResponse {
+	return &AuditEventResponse{
+		ID:         e.ID(),
+		OccurredAt: e.OccurredAt(),
+		Actor:      e.Actor(),
+		Action:     string(e.Action()),
+		EntityType: string(e.EntityType()),
+		EntityID:   e.EntityID(),
+		Before:     e.Before(),
+		After:      e.After(),
+	}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/audit"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/util/testutil"
+)
+
+type AuditEventUseCaseTestSuite struct {
+	testutil.DBTest
+	dsUC *DataSourceUseCase
+	uc   *AuditEventUseCase
+}
+
+func TestAuditEventUseCase(t *testing.T) {
+	suite.Run(t, new(AuditEventUseCaseTestSuite))
+}
+
+func (s *AuditEventUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+
+	s.dsUC = NewDataSourceUseCase(repository.NewDataSourceRepository(db), testSettingsSchemas)
+	s.uc = NewAuditEventUseCase(repository.NewAuditEventRepository(db))
+}
+
+func (s *AuditEventUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+func (s *AuditEventUseCaseTestSuite) TestList() {
+	ctx := audit.WithActor(context.Background(), "api_key:ci")
+	var names []string
+	for _, name := range []string{"first", "second", "third"} {
+		src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+			Name:     name,
+			Enabled:  true,
+			Timezone: "UTC",
+			Settings: map[string]any{},
+		})
+		s.Require().NoError(err)
+		names = append(names, src.Name)
+	}
+
+	page1, err := s.uc.List(ctx, &ListAuditEventsRequest{EntityType: "data_source", Page: PageRequest{Limit: 2}})
+	s.Require().NoError(err)
+	s.Require().Len(page1.Items, 2)
+	s.Require().NotNil(page1.NextCursor)
+	s.Equal("third", page1.Items[0].After["name"], "newest first")
+	s.Equal("api_key:ci", page1.Items[0].Actor)
+	s.Equal("create", page1.Items[0].Action)
+
+	page2, err := s.uc.List(ctx, &ListAuditEventsRequest{Page: PageRequest{Limit: 2, Cursor: *page1.NextCursor}})
+	s.Require().NoError(err)
+	s.Require().Len(page2.Items, 1)
+	s.Equal(names[0], page2.Items[0].After["name"])
+	s.Nil(page2.NextCursor)
+}
+
+func (s *AuditEventUseCaseTestSuite) TestList_Validation() {
+	now := time.Now()
+	type testCase struct {
+		name        string
+		req         *ListAuditEventsRequest
+		expectedMsg string
+	}
+	tests := []testCase{
+		{
+			name:        "unknown entity type",
+			req:         &ListAuditEventsRequest{EntityType: "extract_task"},
+			expectedMsg: "unknown entity type: extract_task",
+		},
+		{
+			name:        "empty time range",
+			req:         &ListAuditEventsRequest{Since: &now, Until: &now},
+			expectedMsg: "since must be before until",
+		},
+		{
+			name:        "limit too large",
+			req:         &ListAuditEventsRequest{Page: PageRequest{Limit: 101}},
+			expectedMsg: "limit must be between 1 and 100",
+		},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			resp, err := s.uc.List(context.Background(), tc.req)
+			s.Nil(resp)
+			var ve *ValidationError
+			s.Require().ErrorAs(err, &ve)
+			s.Equal(tc.expectedMsg, ve.Message)
+		})
+	}
+}
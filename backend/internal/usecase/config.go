@@ -0,0 +1,706 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/util/pagination"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// ConfigSpec is the complete desired ingestion configuration. Data sources
+// are identified by name, and data types by name within their data source.
+type ConfigSpec struct {
+	DataSources []DataSourceSpec
+}
+
+type DataSourceSpec struct {
+	Name string
+	// Kind is empty for ingestion.SourceKindGeneric.
+	Kind     string
+	Enabled  bool
+	Timezone string
+	// Retention is the default retention policy of the data types that set
+	// no retention rule of their own.
+	Retention RetentionInput
+	Settings  map[string]any
+	DataTypes []DataTypeSpec
+}
+
+type DataTypeSpec struct {
+	Name                string
+	Enabled             bool
+	Schedule            ScheduleInput
+	BackfillEnabled     bool
+	StaleTimeoutMinutes int
+	// RerunStrategy is empty for ingestion.RerunStrategyAppend.
+	RerunStrategy string
+	// EmptyResponsePolicy is empty for ingestion.EmptyResponsePolicySuccess.
+	EmptyResponsePolicy string
+	// Compression is empty for ingestion.CompressionNone.
+	Compression string
+	Retention   RetentionInput
+	Settings    map[string]any
+	// Dependencies are the data types that must be extracted first (D9).
+	Dependencies []DependencySpec
+}
+
+type DependencySpec struct {
+	// DataType names the data type depended on as "<source>/<type>".
+	DataType   string
+	MaxLagDays int
+}
+
+// AddMissing adds src if the spec has no data source of its name, and
+// otherwise adds just the data types of src that the data source lacks.
+// Entries already in the spec keep their configuration.
+func (s *ConfigSpec) AddMissing(src DataSourceSpec) {
+	i := slices.IndexFunc(s.DataSources, func(d DataSourceSpec) bool { return d.Name == src.Name })
+	if i < 0 {
+		s.DataSources = append(s.DataSources, src)
+		return
+	}
+	existing := &s.DataSources[i]
+	for _, dt := range src.DataTypes {
+		if !slices.ContainsFunc(existing.DataTypes, func(d DataTypeSpec) bool { return d.Name == dt.Name }) {
+			existing.DataTypes = append(existing.DataTypes, dt)
+		}
+	}
+}
+
+type ConfigChangeAction string
+
+const (
+	ConfigChangeCreate ConfigChangeAction = "create"
+	ConfigChangeUpdate ConfigChangeAction = "update"
+	ConfigChangeDelete ConfigChangeAction = "delete"
+)
+
+// ConfigChange is one step of a ConfigPlan.
+type ConfigChange struct {
+	Action ConfigChangeAction
+	// EntityType is "data_source", "data_type" or "data_type_dependencies".
+	EntityType string
+	// Name is "<source>" for a data source and "<source>/<type>" for a data type.
+	Name string
+	// Fields lists the fields an update changes, in spec order.
+	Fields []string
+
+	id           uuid.UUID
+	version      int
+	sourceName   string
+	source       *DataSourceSpec
+	dataType     *DataTypeSpec
+	dependencies []DependencySpec
+}
+
+// ConfigPlan is the ordered list of changes that turns the stored
+// configuration into a ConfigSpec. Applying it runs each change through the
+// data source and data type use cases. Dependencies are replaced last, once
+// every data type they name exists.
+type ConfigPlan struct {
+	Changes []ConfigChange
+}
+
+// Count returns the number of changes with the given action.
+func (p *ConfigPlan) Count(action ConfigChangeAction) int {
+	return lo.CountBy(p.Changes, func(c ConfigChange) bool { return c.Action == action })
+}
+
+const (
+	configEntityDataSource   = "data_source"
+	configEntityDataType     = "data_type"
+	configEntityDependencies = "data_type_dependencies"
+)
+
+// ConfigUseCase exports the ingestion configuration and reconciles it with
+// a ConfigSpec. It holds no transaction of its own: callers that need an
+// all-or-nothing apply build it on use cases sharing one transaction.
+type ConfigUseCase struct {
+	dataSources *DataSourceUseCase
+	dataTypes   *DataTypeUseCase
+}
+
+func NewConfigUseCase(dataSources *DataSourceUseCase, dataTypes *DataTypeUseCase) *ConfigUseCase {
+	return &ConfigUseCase{dataSources: dataSources, dataTypes: dataTypes}
+}
+
+// Export returns the stored configuration, with data sources and data types
+// ordered by name.
+func (uc *ConfigUseCase) Export(ctx context.Context) (*ConfigSpec, error) {
+	current, err := uc.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	spec := &ConfigSpec{DataSources: make([]DataSourceSpec, 0, len(current))}
+	for _, src := range current {
+		spec.DataSources = append(spec.DataSources, DataSourceSpec{
+			Name:      src.source.Name,
+			Kind:      src.source.Kind,
+			Enabled:   src.source.Enabled,
+			Timezone:  src.source.Timezone,
+			Retention: patchRetention(src.source.Retention, nil),
+			Settings:  src.source.Settings,
+			DataTypes: lo.Map(src.dataTypes, func(dt *DataTypeResponse, _ int) DataTypeSpec {
+				return toDataTypeSpec(dt, src.dependencies[dt.Name])
+			}),
+		})
+	}
+	return spec, nil
+}
+
+// Plan compares spec with the stored configuration. Returns a
+// ValidationError when spec names a data source or data type twice, a
+// dependency names a data type missing from spec or is listed twice, or
+// spec changes the kind of an existing data source. Settings, schedules and
+// dependency cycles are validated when the plan is applied.
+func (uc *ConfigUseCase) Plan(ctx context.Context, spec *ConfigSpec) (*ConfigPlan, error) {
+	if err := validateConfigSpec(spec); err != nil {
+		return nil, err
+	}
+	current, err := uc.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	currentByName := lo.KeyBy(current, func(s *storedDataSource) string { return s.source.Name })
+
+	plan := &ConfigPlan{}
+	for i := range spec.DataSources {
+		want := &spec.DataSources[i]
+		have, ok := currentByName[want.Name]
+		if !ok {
+			plan.Changes = append(plan.Changes, ConfigChange{
+				Action:     ConfigChangeCreate,
+				EntityType: configEntityDataSource,
+				Name:       want.Name,
+				source:     want,
+			})
+			for j := range want.DataTypes {
+				plan.Changes = append(plan.Changes, newDataTypeCreate(want.Name, &want.DataTypes[j]))
+			}
+			continue
+		}
+
+		kind := lo.Ternary(want.Kind == "", string(ingestion.SourceKindGeneric), want.Kind)
+		if kind != have.source.Kind {
+			return nil, &ValidationError{Message: fmt.Sprintf(
+				"data source %s: kind cannot change from %s to %s; delete and recreate it instead",
+				want.Name, have.source.Kind, kind,
+			)}
+		}
+		if fields := dataSourceChanges(have.source, want); len(fields) > 0 {
+			plan.Changes = append(plan.Changes, ConfigChange{
+				Action:     ConfigChangeUpdate,
+				EntityType: configEntityDataSource,
+				Name:       want.Name,
+				Fields:     fields,
+				id:         have.source.ID,
+				version:    have.source.Version,
+				source:     want,
+			})
+		}
+		plan.Changes = append(plan.Changes, planDataTypes(want, have)...)
+	}
+
+	wanted := lo.SliceToMap(spec.DataSources, func(s DataSourceSpec) (string, bool) { return s.Name, true })
+	for _, have := range current {
+		if wanted[have.source.Name] {
+			continue
+		}
+		for _, dt := range have.dataTypes {
+			plan.Changes = append(plan.Changes, newDataTypeDelete(have.source.Name, dt))
+		}
+		plan.Changes = append(plan.Changes, ConfigChange{
+			Action:     ConfigChangeDelete,
+			EntityType: configEntityDataSource,
+			Name:       have.source.Name,
+			id:         have.source.ID,
+			version:    have.source.Version,
+		})
+	}
+
+	// Dependencies last: the data types they name are created above, and a
+	// data type recreated under the same name gets its dependencies back
+	for _, want := range spec.DataSources {
+		for _, wantType := range want.DataTypes {
+			deps := sortedDependencies(wantType.Dependencies)
+			var stored []DependencySpec
+			if have, ok := currentByName[want.Name]; ok {
+				stored = have.dependencies[wantType.Name]
+			}
+			if !slices.Equal(deps, stored) {
+				plan.Changes = append(plan.Changes, ConfigChange{
+					Action:       ConfigChangeUpdate,
+					EntityType:   configEntityDependencies,
+					Name:         want.Name + "/" + wantType.Name,
+					sourceName:   want.Name,
+					dependencies: deps,
+				})
+			}
+		}
+	}
+	return plan, nil
+}
+
+// Apply performs the changes of plan in order. Every change expects the
+// version seen when the plan was made, so a concurrent modification fails
+// with a PreconditionFailedError instead of being overwritten. Validation
+// errors are prefixed with the name of the offending entity.
+func (uc *ConfigUseCase) Apply(ctx context.Context, plan *ConfigPlan) error {
+	// Data types created under an existing source need its ID; sources
+	// created by the plan add theirs as they are created.
+	sourceIDs := map[string]uuid.UUID{}
+	for _, c := range plan.Changes {
+		if c.EntityType == configEntityDataSource && c.Action == ConfigChangeUpdate {
+			sourceIDs[c.Name] = c.id
+		}
+	}
+	// Dependencies name data types that may be created by the plan, so
+	// their IDs are looked up once the first dependency change is reached.
+	var typeIDs map[string]uuid.UUID
+	for _, c := range plan.Changes {
+		var err error
+		if c.EntityType == configEntityDependencies {
+			if typeIDs == nil {
+				typeIDs, err = uc.dataTypeIDs(ctx)
+				if err != nil {
+					return err
+				}
+			}
+			err = uc.applyDependencies(ctx, c, typeIDs)
+		} else {
+			err = uc.applyChange(ctx, c, sourceIDs)
+		}
+		if err != nil {
+			entity := strings.ReplaceAll(c.EntityType, "_", " ")
+			return prefixError(fmt.Sprintf("%s %s %s", c.Action, entity, c.Name), err)
+		}
+	}
+	return nil
+}
+
+// applyDependencies replaces the dependencies of the data type c names.
+// typeIDs maps "<source>/<type>" to the ID of every stored data type.
+func (uc *ConfigUseCase) applyDependencies(ctx context.Context, c ConfigChange, typeIDs map[string]uuid.UUID) error {
+	id, ok := typeIDs[c.Name]
+	if !ok {
+		return &PreconditionFailedError{Message: "data type no longer exists"}
+	}
+	inputs := make([]DependencyInput, 0, len(c.dependencies))
+	for _, d := range c.dependencies {
+		dependsOn, ok := typeIDs[d.DataType]
+		if !ok {
+			return &PreconditionFailedError{Message: fmt.Sprintf("data type %s no longer exists", d.DataType)}
+		}
+		inputs = append(inputs, DependencyInput{DataTypeID: dependsOn, MaxLagDays: d.MaxLagDays})
+	}
+	replaced, err := uc.dataTypes.ReplaceDependencies(ctx, &ReplaceDependenciesRequest{
+		DataTypeID:   id,
+		Dependencies: inputs,
+	})
+	if err == nil && replaced == nil {
+		return &PreconditionFailedError{Message: "data type no longer exists"}
+	}
+	return err
+}
+
+// dataTypeIDs maps "<source>/<type>" to the ID of every stored data type.
+func (uc *ConfigUseCase) dataTypeIDs(ctx context.Context) (map[string]uuid.UUID, error) {
+	stored, err := uc.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := map[string]uuid.UUID{}
+	for _, s := range stored {
+		for _, dt := range s.dataTypes {
+			ids[s.source.Name+"/"+dt.Name] = dt.ID
+		}
+	}
+	return ids, nil
+}
+
+func (uc *ConfigUseCase) applyChange(ctx context.Context, c ConfigChange, sourceIDs map[string]uuid.UUID) error {
+	switch {
+	case c.EntityType == configEntityDataSource && c.Action == ConfigChangeCreate:
+		created, err := uc.dataSources.Create(ctx, &CreateDataSourceRequest{
+			Kind:      c.source.Kind,
+			Name:      c.source.Name,
+			Enabled:   c.source.Enabled,
+			Timezone:  c.source.Timezone,
+			Retention: c.source.Retention,
+			Settings:  lo.CoalesceMapOrEmpty(c.source.Settings),
+		})
+		if err != nil {
+			return err
+		}
+		sourceIDs[c.Name] = created.ID
+		return nil
+	case c.EntityType == configEntityDataSource && c.Action == ConfigChangeUpdate:
+		updated, err := uc.dataSources.Update(ctx, &UpdateDataSourceRequest{
+			ID:        c.id,
+			Name:      c.source.Name,
+			Enabled:   c.source.Enabled,
+			Timezone:  c.source.Timezone,
+			Retention: c.source.Retention,
+			Settings:  lo.CoalesceMapOrEmpty(c.source.Settings),
+			IfMatch:   IfMatchVersion(c.version),
+		})
+		if err == nil && updated == nil {
+			return &PreconditionFailedError{Message: "data source no longer exists"}
+		}
+		return err
+	case c.EntityType == configEntityDataSource && c.Action == ConfigChangeDelete:
+		deleted, err := uc.dataSources.Delete(ctx, c.id, IfMatchVersion(c.version))
+		if err == nil && !deleted {
+			return &PreconditionFailedError{Message: "data source no longer exists"}
+		}
+		return err
+	case c.EntityType == configEntityDataType && c.Action == ConfigChangeCreate:
+		_, err := uc.dataTypes.Create(ctx, &CreateDataTypeRequest{
+			DataSourceID:        sourceIDs[c.sourceName],
+			Name:                c.dataType.Name,
+			Enabled:             c.dataType.Enabled,
+			Schedule:            c.dataType.Schedule,
+			BackfillEnabled:     c.dataType.BackfillEnabled,
+			StaleTimeoutMinutes: c.dataType.StaleTimeoutMinutes,
+			RerunStrategy:       c.dataType.RerunStrategy,
+			EmptyResponsePolicy: c.dataType.EmptyResponsePolicy,
+			Compression:         c.dataType.Compression,
+			Retention:           c.dataType.Retention,
+			Settings:            lo.CoalesceMapOrEmpty(c.dataType.Settings),
+		})
+		return err
+	case c.EntityType == configEntityDataType && c.Action == ConfigChangeUpdate:
+		updated, err := uc.dataTypes.Update(ctx, &UpdateDataTypeRequest{
+			ID:                  c.id,
+			Name:                c.dataType.Name,
+			Enabled:             c.dataType.Enabled,
+			Schedule:            c.dataType.Schedule,
+			BackfillEnabled:     c.dataType.BackfillEnabled,
+			StaleTimeoutMinutes: c.dataType.StaleTimeoutMinutes,
+			RerunStrategy:       c.dataType.RerunStrategy,
+			EmptyResponsePolicy: c.dataType.EmptyResponsePolicy,
+			Compression:         c.dataType.Compression,
+			Retention:           c.dataType.Retention,
+			Settings:            lo.CoalesceMapOrEmpty(c.dataType.Settings),
+			IfMatch:             IfMatchVersion(c.version),
+		})
+		if err == nil && updated == nil {
+			return &PreconditionFailedError{Message: "data type no longer exists"}
+		}
+		return err
+	case c.EntityType == configEntityDataType && c.Action == ConfigChangeDelete:
+		deleted, err := uc.dataTypes.Delete(ctx, c.id, IfMatchVersion(c.version))
+		if err == nil && !deleted {
+			return &PreconditionFailedError{Message: "data type no longer exists"}
+		}
+		return err
+	default:
+		return fmt.Errorf("unsupported change %s of %s", c.Action, c.EntityType)
+	}
+}
+
+type storedDataSource struct {
+	source    *DataSourceResponse
+	dataTypes []*DataTypeResponse
+	// dependencies maps the name of each data type with dependencies to
+	// them, sorted by the data type depended on.
+	dependencies map[string][]DependencySpec
+}
+
+// load returns every data source with its data types and their
+// dependencies, ordered by name.
+func (uc *ConfigUseCase) load(ctx context.Context) ([]*storedDataSource, error) {
+	var stored []*storedDataSource
+	page := PageRequest{Limit: pagination.MaxLimit, Sort: string(pagination.SortFieldName)}
+	for {
+		list, err := uc.dataSources.List(ctx, &ListDataSourcesRequest{Page: page})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list data sources: %w", err)
+		}
+		for _, src := range list.Items {
+			stored = append(stored, &storedDataSource{source: src})
+		}
+		if list.NextCursor == nil {
+			break
+		}
+		page.Cursor = *list.NextCursor
+	}
+
+	for _, s := range stored {
+		page := PageRequest{Limit: pagination.MaxLimit, Sort: string(pagination.SortFieldName)}
+		for {
+			list, err := uc.dataTypes.List(ctx, &ListDataTypesRequest{DataSourceID: &s.source.ID, Page: page})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list data types of %s: %w", s.source.Name, err)
+			}
+			s.dataTypes = append(s.dataTypes, list.Items...)
+			if list.NextCursor == nil {
+				break
+			}
+			page.Cursor = *list.NextCursor
+		}
+	}
+
+	names := map[uuid.UUID]string{}
+	for _, s := range stored {
+		for _, dt := range s.dataTypes {
+			names[dt.ID] = s.source.Name + "/" + dt.Name
+		}
+	}
+	for _, s := range stored {
+		s.dependencies = map[string][]DependencySpec{}
+		for _, dt := range s.dataTypes {
+			deps, err := uc.dataTypes.ListDependencies(ctx, dt.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list dependencies of %s/%s: %w", s.source.Name, dt.Name, err)
+			}
+			if len(deps) == 0 {
+				continue
+			}
+			specs := lo.Map(deps, func(d *DependencyResponse, _ int) DependencySpec {
+				return DependencySpec{DataType: names[d.DataTypeID], MaxLagDays: d.MaxLagDays}
+			})
+			s.dependencies[dt.Name] = sortedDependencies(specs)
+		}
+	}
+	return stored, nil
+}
+
+// sortedDependencies returns deps sorted by the data type depended on, or
+// nil when there are none.
+func sortedDependencies(deps []DependencySpec) []DependencySpec {
+	if len(deps) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(deps)
+	slices.SortFunc(sorted, func(a, b DependencySpec) int { return strings.Compare(a.DataType, b.DataType) })
+	return sorted
+}
+
+func validateConfigSpec(spec *ConfigSpec) error {
+	var errs []string
+	allTypeNames := map[string]bool{}
+	for _, src := range spec.DataSources {
+		for _, dt := range src.DataTypes {
+			allTypeNames[src.Name+"/"+dt.Name] = true
+		}
+	}
+	sourceNames := map[string]bool{}
+	for _, src := range spec.DataSources {
+		if src.Name == "" {
+			errs = append(errs, "data source without a name")
+		} else if sourceNames[src.Name] {
+			errs = append(errs, fmt.Sprintf("data source %s is defined more than once", src.Name))
+		}
+		sourceNames[src.Name] = true
+
+		typeNames := map[string]bool{}
+		for _, dt := range src.DataTypes {
+			if dt.Name == "" {
+				errs = append(errs, fmt.Sprintf("data type without a name in data source %s", src.Name))
+			} else if typeNames[dt.Name] {
+				errs = append(errs, fmt.Sprintf("data type %s/%s is defined more than once", src.Name, dt.Name))
+			}
+			typeNames[dt.Name] = true
+
+			dependsOn := map[string]bool{}
+			for _, dep := range dt.Dependencies {
+				switch {
+				case !allTypeNames[dep.DataType]:
+					errs = append(errs, fmt.Sprintf(
+						"data type %s/%s depends on %s, which is not defined", src.Name, dt.Name, dep.DataType,
+					))
+				case dependsOn[dep.DataType]:
+					errs = append(errs, fmt.Sprintf(
+						"data type %s/%s lists dependency %s more than once", src.Name, dt.Name, dep.DataType,
+					))
+				}
+				dependsOn[dep.DataType] = true
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Message: strings.Join(errs, "; ")}
+	}
+	return nil
+}
+
+func planDataTypes(want *DataSourceSpec, have *storedDataSource) []ConfigChange {
+	var changes []ConfigChange
+	currentByName := lo.KeyBy(have.dataTypes, func(dt *DataTypeResponse) string { return dt.Name })
+	for i := range want.DataTypes {
+		wantType := &want.DataTypes[i]
+		haveType, ok := currentByName[wantType.Name]
+		if !ok {
+			changes = append(changes, newDataTypeCreate(want.Name, wantType))
+			continue
+		}
+		if fields := dataTypeChanges(haveType, wantType); len(fields) > 0 {
+			changes = append(changes, ConfigChange{
+				Action:     ConfigChangeUpdate,
+				EntityType: configEntityDataType,
+				Name:       want.Name + "/" + wantType.Name,
+				Fields:     fields,
+				id:         haveType.ID,
+				version:    haveType.Version,
+				sourceName: want.Name,
+				dataType:   wantType,
+			})
+		}
+	}
+
+	wanted := lo.SliceToMap(want.DataTypes, func(dt DataTypeSpec) (string, bool) { return dt.Name, true })
+	for _, dt := range have.dataTypes {
+		if !wanted[dt.Name] {
+			changes = append(changes, newDataTypeDelete(want.Name, dt))
+		}
+	}
+	return changes
+}
+
+func newDataTypeCreate(sourceName string, dt *DataTypeSpec) ConfigChange {
+	return ConfigChange{
+		Action:     ConfigChangeCreate,
+		EntityType: configEntityDataType,
+		Name:       sourceName + "/" + dt.Name,
+		sourceName: sourceName,
+		dataType:   dt,
+	}
+}
+
+func newDataTypeDelete(sourceName string, dt *DataTypeResponse) ConfigChange {
+	return ConfigChange{
+		Action:     ConfigChangeDelete,
+		EntityType: configEntityDataType,
+		Name:       sourceName + "/" + dt.Name,
+		id:         dt.ID,
+		version:    dt.Version,
+		sourceName: sourceName,
+	}
+}
+
+func dataSourceChanges(have *DataSourceResponse, want *DataSourceSpec) []string {
+	var fields []string
+	if have.Enabled != want.Enabled {
+		fields = append(fields, "enabled")
+	}
+	if have.Timezone != want.Timezone {
+		fields = append(fields, "timezone")
+	}
+	if !retentionEqual(have.Retention, want.Retention) {
+		fields = append(fields, "retention")
+	}
+	if !jsonEqual(have.Settings, want.Settings) {
+		fields = append(fields, "settings")
+	}
+	return fields
+}
+
+func dataTypeChanges(have *DataTypeResponse, want *DataTypeSpec) []string {
+	var fields []string
+	if have.Enabled != want.Enabled {
+		fields = append(fields, "enabled")
+	}
+	if !scheduleEqual(have.Schedule, want.Schedule) {
+		fields = append(fields, "schedule")
+	}
+	if have.BackfillEnabled != want.BackfillEnabled {
+		fields = append(fields, "backfillEnabled")
+	}
+	if have.StaleTimeoutMinutes != want.StaleTimeoutMinutes {
+		fields = append(fields, "staleTimeoutMinutes")
+	}
+	// An invalid strategy is never equal, so the update reports it when applied
+	if strategy, err := ingestion.NewRerunStrategy(want.RerunStrategy); err != nil || strategy != have.RerunStrategy {
+		fields = append(fields, "rerunStrategy")
+	}
+	if policy, err := ingestion.NewEmptyResponsePolicy(want.EmptyResponsePolicy); err != nil ||
+		policy != have.EmptyResponsePolicy {
+		fields = append(fields, "emptyResponsePolicy")
+	}
+	if compression, err := ingestion.NewCompression(want.Compression); err != nil || compression != have.Compression {
+		fields = append(fields, "compression")
+	}
+	if !retentionEqual(have.Retention, want.Retention) {
+		fields = append(fields, "retention")
+	}
+	if !jsonEqual(have.Settings, want.Settings) {
+		fields = append(fields, "settings")
+	}
+	return fields
+}
+
+func toDataTypeSpec(dt *DataTypeResponse, deps []DependencySpec) DataTypeSpec {
+	return DataTypeSpec{
+		Name:                dt.Name,
+		Enabled:             dt.Enabled,
+		Schedule:            patchSchedule(dt.Schedule, nil),
+		BackfillEnabled:     dt.BackfillEnabled,
+		StaleTimeoutMinutes: dt.StaleTimeoutMinutes,
+		RerunStrategy:       string(dt.RerunStrategy),
+		EmptyResponsePolicy: string(dt.EmptyResponsePolicy),
+		Compression:         string(dt.Compression),
+		Retention:           patchRetention(dt.Retention, nil),
+		Settings:            dt.Settings,
+		Dependencies:        deps,
+	}
+}
+
+// scheduleEqual compares a stored schedule with an input the way
+// buildSchedule would normalize it. An invalid input is never equal, so the
+// update reports the validation error when applied.
+func scheduleEqual(have ingestion.Schedule, want ScheduleInput) bool {
+	normalized, err := buildSchedule(want)
+	if err != nil {
+		return false
+	}
+	return have.Type() == normalized.Type() && slices.Equal(have.Times(), normalized.Times())
+}
+
+// retentionEqual compares a stored retention policy with an input. An invalid
+// input is never equal, as in scheduleEqual.
+func retentionEqual(have ingestion.RetentionPolicy, want RetentionInput) bool {
+	normalized, err := buildRetention(want)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(have, normalized)
+}
+
+// jsonEqual compares settings as JSON documents, so that numbers decoded
+// from YAML and from the database compare equal and nil equals empty.
+func jsonEqual(a, b map[string]any) bool {
+	normalize := func(m map[string]any) any {
+		var out any
+		raw, err := json.Marshal(lo.CoalesceMapOrEmpty(m))
+		if err != nil {
+			return m
+		}
+		_ = json.Unmarshal(raw, &out)
+		return out
+	}
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// prefixError adds context to the message of usecase errors while keeping
+// their type, and wraps any other error.
+func prefixError(prefix string, err error) error {
+	switch e := err.(type) {
+	case *ValidationError:
+		return &ValidationError{Message: prefix + ": " + e.Message}
+	case *ConflictError:
+		return &ConflictError{Message: prefix + ": " + e.Message}
+	case *PreconditionFailedError:
+		return &PreconditionFailedError{Message: prefix + ": " + e.Message}
+	default:
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+}
@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/util/testutil"
+)
+
+type ConfigUseCaseTestSuite struct {
+	testutil.DBTest
+	dsUC *DataSourceUseCase
+	dtUC *DataTypeUseCase
+	uc   *ConfigUseCase
+}
+
+func TestConfigUseCase(t *testing.T) {
+	suite.Run(t, new(ConfigUseCaseTestSuite))
+}
+
+func (s *ConfigUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+
+	dsRepo := repository.NewDataSourceRepository(db)
+	s.dsUC = NewDataSourceUseCase(dsRepo, testSettingsSchemas)
+	s.dtUC = NewDataTypeUseCase(repository.NewDataTypeRepository(db), dsRepo, testSettingsSchemas)
+	s.uc = NewConfigUseCase(s.dsUC, s.dtUC)
+}
+
+func (s *ConfigUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+func testConfigSpec() *ConfigSpec {
+	return &ConfigSpec{DataSources: []DataSourceSpec{
+		{
+			Name:      "jquants",
+			Kind:      "jquants",
+			Enabled:   true,
+			Timezone:  "Asia/Tokyo",
+			Retention: RetentionInput{SupersededMaxAgeDays: lo.ToPtr(90)},
+			Settings:  map[string]any{"plan": "free"},
+			DataTypes: []DataTypeSpec{
+				{
+					Name:                "brand",
+					Enabled:             true,
+					Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+					StaleTimeoutMinutes: 30,
+					RerunStrategy:       "append",
+					EmptyResponsePolicy: "success",
+					Compression:         "none",
+					Settings:            map[string]any{},
+				},
+				{
+					Name:                "daily_quote",
+					Enabled:             false,
+					Schedule:            ScheduleInput{Type: "daily", Times: []string{"16:30"}},
+					BackfillEnabled:     true,
+					StaleTimeoutMinutes: 60,
+					RerunStrategy:       "overwrite",
+					EmptyResponsePolicy: "retry_later",
+					Compression:         "zstd",
+					Retention:           RetentionInput{KeepLatest: lo.ToPtr(3), SupersededMaxAgeDays: lo.ToPtr(30)},
+					Settings:            map[string]any{},
+					Dependencies:        []DependencySpec{{DataType: "jquants/brand", MaxLagDays: 1}},
+				},
+			},
+		},
+	}}
+}
+
+func (s *ConfigUseCaseTestSuite) TestApply_CreatesAndIsIdempotent() {
+	ctx := context.Background()
+	spec := testConfigSpec()
+
+	plan, err := s.uc.Plan(ctx, spec)
+	s.Require().NoError(err)
+	s.Equal(3, plan.Count(ConfigChangeCreate))
+	s.Equal(1, plan.Count(ConfigChangeUpdate), "dependencies of jquants/daily_quote")
+	s.Require().NoError(s.uc.Apply(ctx, plan))
+
+	exported, err := s.uc.Export(ctx)
+	s.Require().NoError(err)
+	s.True(
+		cmp.Equal(spec, exported, cmpopts.EquateEmpty()),
+		cmp.Diff(spec, exported, cmpopts.EquateEmpty()),
+	)
+
+	again, err := s.uc.Plan(ctx, exported)
+	s.Require().NoError(err)
+	s.Empty(again.Changes)
+}
+
+func (s *ConfigUseCaseTestSuite) TestPlan_UpdatesAndDeletes() {
+	ctx := context.Background()
+	plan, err := s.uc.Plan(ctx, testConfigSpec())
+	s.Require().NoError(err)
+	s.Require().NoError(s.uc.Apply(ctx, plan))
+	_, err = s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Name: "legacy", Enabled: true, Timezone: "UTC", Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+
+	spec := testConfigSpec()
+	spec.DataSources[0].DataTypes[0].Schedule.Times = []string{"20:00", "18:00"}
+	spec.DataSources[0].DataTypes[0].RerunStrategy = "overwrite"
+	spec.DataSources[0].DataTypes[0].EmptyResponsePolicy = "retry_later"
+	spec.DataSources[0].DataTypes[0].Retention = RetentionInput{KeepLatest: lo.ToPtr(2)}
+	spec.DataSources[0].DataTypes = spec.DataSources[0].DataTypes[:1]
+
+	plan, err = s.uc.Plan(ctx, spec)
+	s.Require().NoError(err)
+	expected := []ConfigChange{
+		{
+			Action:     ConfigChangeUpdate,
+			EntityType: "data_type",
+			Name:       "jquants/brand",
+			Fields:     []string{"schedule", "rerunStrategy", "emptyResponsePolicy", "retention"},
+		},
+		{Action: ConfigChangeDelete, EntityType: "data_type", Name: "jquants/daily_quote"},
+		{Action: ConfigChangeDelete, EntityType: "data_source", Name: "legacy"},
+	}
+	opt := cmpopts.IgnoreUnexported(ConfigChange{})
+	s.True(cmp.Equal(expected, plan.Changes, opt), cmp.Diff(expected, plan.Changes, opt))
+
+	s.Require().NoError(s.uc.Apply(ctx, plan))
+	exported, err := s.uc.Export(ctx)
+	s.Require().NoError(err)
+	s.Require().Len(exported.DataSources, 1)
+	s.Require().Len(exported.DataSources[0].DataTypes, 1)
+	s.Equal([]string{"18:00", "20:00"}, exported.DataSources[0].DataTypes[0].Schedule.Times)
+}
+
+func (s *ConfigUseCaseTestSuite) TestPlan_Dependencies() {
+	ctx := context.Background()
+	plan, err := s.uc.Plan(ctx, testConfigSpec())
+	s.Require().NoError(err)
+	s.Require().NoError(s.uc.Apply(ctx, plan))
+
+	// brand is dropped and added back; daily_quote keeps depending on it
+	spec := testConfigSpec()
+	spec.DataSources[0].DataTypes = spec.DataSources[0].DataTypes[1:]
+	spec.DataSources[0].DataTypes[0].Dependencies = nil
+	plan, err = s.uc.Plan(ctx, spec)
+	s.Require().NoError(err)
+	s.Require().NoError(s.uc.Apply(ctx, plan))
+
+	plan, err = s.uc.Plan(ctx, testConfigSpec())
+	s.Require().NoError(err)
+	expected := []ConfigChange{
+		{Action: ConfigChangeCreate, EntityType: "data_type", Name: "jquants/brand"},
+		{Action: ConfigChangeUpdate, EntityType: "data_type_dependencies", Name: "jquants/daily_quote"},
+	}
+	opt := cmpopts.IgnoreUnexported(ConfigChange{})
+	s.True(cmp.Equal(expected, plan.Changes, opt), cmp.Diff(expected, plan.Changes, opt))
+	s.Require().NoError(s.uc.Apply(ctx, plan))
+
+	exported, err := s.uc.Export(ctx)
+	s.Require().NoError(err)
+	s.Equal(
+		[]DependencySpec{{DataType: "jquants/brand", MaxLagDays: 1}},
+		exported.DataSources[0].DataTypes[1].Dependencies,
+	)
+	s.Empty(exported.DataSources[0].DataTypes[0].Dependencies)
+
+	// A changed lag is an update of the dependencies only
+	spec = testConfigSpec()
+	spec.DataSources[0].DataTypes[1].Dependencies[0].MaxLagDays = 2
+	plan, err = s.uc.Plan(ctx, spec)
+	s.Require().NoError(err)
+	expected = []ConfigChange{
+		{Action: ConfigChangeUpdate, EntityType: "data_type_dependencies", Name: "jquants/daily_quote"},
+	}
+	s.True(cmp.Equal(expected, plan.Changes, opt), cmp.Diff(expected, plan.Changes, opt))
+}
+
+func (s *ConfigUseCaseTestSuite) TestApply_DependencyCycle() {
+	ctx := context.Background()
+	spec := testConfigSpec()
+	spec.DataSources[0].DataTypes[0].Dependencies = []DependencySpec{{DataType: "jquants/daily_quote"}}
+
+	plan, err := s.uc.Plan(ctx, spec)
+	s.Require().NoError(err)
+	err = s.uc.Apply(ctx, plan)
+	var vErr *ValidationError
+	s.Require().ErrorAs(err, &vErr)
+	s.Contains(vErr.Message, "update data type dependencies jquants/")
+}
+
+func (s *ConfigUseCaseTestSuite) TestPlan_Validation() {
+	ctx := context.Background()
+	plan, err := s.uc.Plan(ctx, testConfigSpec())
+	s.Require().NoError(err)
+	s.Require().NoError(s.uc.Apply(ctx, plan))
+
+	type testCase struct {
+		name        string
+		mutate      func(spec *ConfigSpec)
+		expectedMsg string
+	}
+	tests := []testCase{
+		{
+			name: "duplicate data source",
+			mutate: func(spec *ConfigSpec) {
+				spec.DataSources = append(spec.DataSources, spec.DataSources[0])
+			},
+			expectedMsg: "data source jquants is defined more than once",
+		},
+		{
+			name: "kind change",
+			mutate: func(spec *ConfigSpec) {
+				spec.DataSources[0].Kind = ""
+			},
+			expectedMsg: "data source jquants: kind cannot change from jquants to generic; delete and recreate it instead",
+		},
+		{
+			name: "unknown dependency",
+			mutate: func(spec *ConfigSpec) {
+				spec.DataSources[0].DataTypes[0].Dependencies = []DependencySpec{{DataType: "jquants/dividend"}}
+			},
+			expectedMsg: "data type jquants/brand depends on jquants/dividend, which is not defined",
+		},
+		{
+			name: "duplicate dependency",
+			mutate: func(spec *ConfigSpec) {
+				dep := DependencySpec{DataType: "jquants/brand"}
+				spec.DataSources[0].DataTypes[1].Dependencies = []DependencySpec{dep, dep}
+			},
+			expectedMsg: "data type jquants/daily_quote lists dependency jquants/brand more than once",
+		},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			spec := testConfigSpec()
+			tc.mutate(spec)
+			_, err := s.uc.Plan(ctx, spec)
+			var vErr *ValidationError
+			s.Require().ErrorAs(err, &vErr)
+			s.Equal(tc.expectedMsg, vErr.Message)
+		})
+	}
+}
+
+func (s *ConfigUseCaseTestSuite) TestApply_InvalidSettings() {
+	ctx := context.Background()
+	spec := testConfigSpec()
+	spec.DataSources[0].Settings = map[string]any{"plan": "gold"}
+
+	plan, err := s.uc.Plan(ctx, spec)
+	s.Require().NoError(err)
+	err = s.uc.Apply(ctx, plan)
+	var vErr *ValidationError
+	s.Require().ErrorAs(err, &vErr)
+	s.Contains(vErr.Message, "create data source jquants: ")
+}
+
+func (s *ConfigUseCaseTestSuite) TestAddMissing() {
+	spec := testConfigSpec()
+	spec.DataSources[0].DataTypes[0].StaleTimeoutMinutes = 5
+
+	spec.AddMissing(DataSourceSpec{
+		Name: "jquants",
+		DataTypes: []DataTypeSpec{
+			{Name: "brand", StaleTimeoutMinutes: 60},
+			{Name: "dividend", StaleTimeoutMinutes: 60},
+		},
+	})
+	spec.AddMissing(DataSourceSpec{Name: "other", Timezone: "UTC"})
+
+	s.Require().Len(spec.DataSources, 2)
+	s.Equal("Asia/Tokyo", spec.DataSources[0].Timezone, "existing source is kept")
+	s.Equal(
+		[]string{"brand", "daily_quote", "dividend"},
+		lo.Map(spec.DataSources[0].DataTypes, func(dt DataTypeSpec, _ int) string { return dt.Name }),
+	)
+	s.Equal(5, spec.DataSources[0].DataTypes[0].StaleTimeoutMinutes, "existing data type is kept")
+	s.Equal("other", spec.DataSources[1].Name)
+}
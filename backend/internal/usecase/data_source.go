@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/util/pagination"
 
 	"github.com/google/uuid"
 	"github.com/samber/lo"
@@ -20,14 +22,23 @@ type DataSourceRepository interface {
 	// FindByID returns the DataSource with the given ID, or (nil, nil) if not found.
 	FindByID(ctx context.Context, id uuid.UUID) (*ingestion.DataSource, error)
 
-	// List returns all DataSource entities.
-	List(ctx context.Context) ([]*ingestion.DataSource, error)
+	// List returns the DataSource entities matching filter, ordered and limited
+	// by page. Returns an empty slice when none are found.
+	List(
+		ctx context.Context,
+		filter ingestion.DataSourceFilter,
+		page pagination.Params,
+	) ([]*ingestion.DataSource, error)
 
-	// Update persists changes to an existing DataSource.
+	// Update persists changes to an existing DataSource and increments its version.
+	// Returns an error wrapping ingestion.ErrVersionMismatch if the stored
+	// version no longer equals src.Version().
 	Update(ctx context.Context, src *ingestion.DataSource) error
 
-	// Delete removes the DataSource with the given ID.
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete removes the DataSource with the given ID. When version is non-nil,
+	// returns an error wrapping ingestion.ErrVersionMismatch unless the stored
+	// version equals it.
+	Delete(ctx context.Context, id uuid.UUID, version *int) error
 }
 
 type ValidationError struct {
@@ -38,54 +49,168 @@ func (e *ValidationError) Error() string {
 	return e.Message
 }
 
+// ConflictError indicates the request conflicts with the current state of a resource.
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// PreconditionFailedError indicates the version the caller expected is not the current one.
+type PreconditionFailedError struct {
+	Message string
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return e.Message
+}
+
+// IfMatch is the precondition of an If-Match header. When Any is set, as by
+// "*", any current version matches; otherwise the current version must be
+// one of Versions, so an IfMatch without versions never matches. A nil
+// *IfMatch sets no precondition.
+type IfMatch struct {
+	Any      bool
+	Versions []int
+}
+
+// IfMatchVersion returns the precondition that the current version is version.
+func IfMatchVersion(version int) *IfMatch {
+	return &IfMatch{Versions: []int{version}}
+}
+
+func (m *IfMatch) matches(current int) bool {
+	return m == nil || m.Any || slices.Contains(m.Versions, current)
+}
+
+// newVersionConflictError reports an update that lost a race. It is a
+// PreconditionFailedError when the caller supplied an expected version and a
+// ConflictError otherwise.
+func newVersionConflictError(ifMatch *IfMatch, err error) error {
+	if ifMatch != nil {
+		return &PreconditionFailedError{Message: err.Error()}
+	}
+	return &ConflictError{Message: err.Error()}
+}
+
+// checkIfMatch returns a PreconditionFailedError when ifMatch is set and
+// does not match the current version.
+func checkIfMatch(ifMatch *IfMatch, current int) error {
+	if !ifMatch.matches(current) {
+		return &PreconditionFailedError{
+			Message: fmt.Sprintf("If-Match does not match current version %d", current),
+		}
+	}
+	return nil
+}
+
 type CreateDataSourceRequest struct {
+	// Kind selects the settings schema; empty means ingestion.SourceKindGeneric.
+	Kind     string
 	Name     string
 	Enabled  bool
 	Timezone string
-	Settings map[string]any
+	// Retention is the default retention policy of the data types of the
+	// source that set no retention rule of their own.
+	Retention RetentionInput
+	Settings  map[string]any
 }
 
 type UpdateDataSourceRequest struct {
-	ID       uuid.UUID
-	Name     string
-	Enabled  bool
-	Timezone string
-	Settings map[string]any
+	ID        uuid.UUID
+	Name      string
+	Enabled   bool
+	Timezone  string
+	Retention RetentionInput
+	Settings  map[string]any
+	// IfMatch, when set, is the precondition on the version to overwrite.
+	IfMatch *IfMatch
+}
+
+// PatchDataSourceRequest carries a JSON Merge Patch. Nil fields are left
+// unchanged; Settings is merged into the current settings key by key.
+type PatchDataSourceRequest struct {
+	ID        uuid.UUID
+	Name      *string
+	Enabled   *bool
+	Timezone  *string
+	Retention *RetentionPatch
+	Settings  map[string]any
+	// ClearSettings, set for a null settings member, removes every current
+	// setting before Settings is merged.
+	ClearSettings bool
+	// IfMatch, when set, is the precondition on the version to overwrite.
+	IfMatch *IfMatch
+}
+
+type ListDataSourcesRequest struct {
+	Enabled    *bool
+	NamePrefix string
+	Page       PageRequest
 }
 
 type DataSourceResponse struct {
 	ID        uuid.UUID
+	Kind      string
 	Name      string
 	Enabled   bool
 	Timezone  string
+	Retention ingestion.RetentionPolicy
 	Settings  map[string]any
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+type DataSourceListResponse struct {
+	Items []*DataSourceResponse
+	// NextCursor is nil on the last page.
+	NextCursor *string
+}
+
 func newDataSourceResponse(e *ingestion.DataSource) *DataSourceResponse {
 	return &DataSourceResponse{
 		ID:        e.ID(),
+		Kind:      string(e.Kind()),
 		Name:      e.Name(),
 		Enabled:   e.Enabled(),
 		Timezone:  e.TimezoneString(),
+		Retention: e.Retention(),
 		Settings:  e.Settings(),
+		Version:   e.Version(),
 		CreatedAt: e.CreatedAt(),
 		UpdatedAt: e.UpdatedAt(),
 	}
 }
 
 type DataSourceUseCase struct {
-	repo DataSourceRepository
+	repo    DataSourceRepository
+	schemas ingestion.SettingsSchemas
 }
 
-func NewDataSourceUseCase(repo DataSourceRepository) *DataSourceUseCase {
-	return &DataSourceUseCase{repo: repo}
+func NewDataSourceUseCase(repo DataSourceRepository, schemas ingestion.SettingsSchemas) *DataSourceUseCase {
+	return &DataSourceUseCase{repo: repo, schemas: schemas}
 }
 
-// Create creates a new data source. Returns a ValidationError on invalid input.
+// Create creates a new data source. Returns a ValidationError on invalid
+// input, including an unknown kind or settings that do not match its schema.
 func (uc *DataSourceUseCase) Create(ctx context.Context, req *CreateDataSourceRequest) (*DataSourceResponse, error) {
-	ds, err := ingestion.NewDataSource(ctx, req.Name, req.Enabled, req.Timezone, req.Settings)
+	kind := ingestion.SourceKind(req.Kind)
+	if kind == "" {
+		kind = ingestion.SourceKindGeneric
+	}
+	if err := validateSourceSettings(uc.schemas, kind, req.Settings); err != nil {
+		return nil, err
+	}
+
+	retention, err := buildRetention(req.Retention)
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := ingestion.NewDataSource(ctx, kind, req.Name, req.Enabled, req.Timezone, retention, req.Settings)
 	if err != nil {
 		return nil, &ValidationError{Message: err.Error()}
 	}
@@ -111,19 +236,35 @@ func (uc *DataSourceUseCase) Get(ctx context.Context, id uuid.UUID) (*DataSource
 	return newDataSourceResponse(found), nil
 }
 
-// List returns all data sources.
-func (uc *DataSourceUseCase) List(ctx context.Context) ([]*DataSourceResponse, error) {
-	sources, err := uc.repo.List(ctx)
+// List returns one page of data sources matching the request filters.
+// Returns a ValidationError on invalid pagination parameters.
+func (uc *DataSourceUseCase) List(ctx context.Context, req *ListDataSourcesRequest) (*DataSourceListResponse, error) {
+	page, err := buildPageParams(req.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ingestion.DataSourceFilter{Enabled: req.Enabled, NamePrefix: req.NamePrefix}
+	sources, err := uc.repo.List(ctx, filter, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list data sources: %w", err)
 	}
-	return lo.Map(sources, func(s *ingestion.DataSource, _ int) *DataSourceResponse {
-		return newDataSourceResponse(s)
-	}), nil
+
+	sources, next := trimPage(sources, page, func(s *ingestion.DataSource) (uuid.UUID, string) {
+		return s.ID(), s.Name()
+	})
+	return &DataSourceListResponse{
+		Items: lo.Map(sources, func(s *ingestion.DataSource, _ int) *DataSourceResponse {
+			return newDataSourceResponse(s)
+		}),
+		NextCursor: next,
+	}, nil
 }
 
 // Update applies changes to an existing data source. Returns (nil, nil) when
-// not found, or a ValidationError on invalid input.
+// not found, a ValidationError on invalid input, a PreconditionFailedError
+// when req.IfMatch is not the current version, or a ConflictError when a
+// concurrent update without IfMatch wins the race.
 func (uc *DataSourceUseCase) Update(ctx context.Context, req *UpdateDataSourceRequest) (*DataSourceResponse, error) {
 	existing, err := uc.repo.FindByID(ctx, req.ID)
 	if err != nil {
@@ -133,13 +274,59 @@ func (uc *DataSourceUseCase) Update(ctx context.Context, req *UpdateDataSourceRe
 		return nil, nil
 	}
 
-	if err := existing.Update(ctx, req.Name, req.Enabled, req.Timezone, req.Settings); err != nil {
+	if err := checkIfMatch(req.IfMatch, existing.Version()); err != nil {
+		return nil, err
+	}
+	return uc.save(ctx, existing, req)
+}
+
+// Patch applies a merge patch to an existing data source. The merged result
+// is validated and persisted exactly as in Update, with the same errors.
+func (uc *DataSourceUseCase) Patch(ctx context.Context, req *PatchDataSourceRequest) (*DataSourceResponse, error) {
+	existing, err := uc.repo.FindByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data source: %w", err)
+	}
+	if existing == nil {
+		return nil, nil
+	}
+	if err := checkIfMatch(req.IfMatch, existing.Version()); err != nil {
+		return nil, err
+	}
+
+	return uc.save(ctx, existing, &UpdateDataSourceRequest{
+		ID:        req.ID,
+		Name:      lo.FromPtrOr(req.Name, existing.Name()),
+		Enabled:   lo.FromPtrOr(req.Enabled, existing.Enabled()),
+		Timezone:  lo.FromPtrOr(req.Timezone, existing.TimezoneString()),
+		Retention: patchRetention(existing.Retention(), req.Retention),
+		Settings:  patchSettings(existing.Settings(), req.Settings, req.ClearSettings),
+		IfMatch:   req.IfMatch,
+	})
+}
+
+func (uc *DataSourceUseCase) save(
+	ctx context.Context,
+	existing *ingestion.DataSource,
+	req *UpdateDataSourceRequest,
+) (*DataSourceResponse, error) {
+	if err := validateSourceSettings(uc.schemas, existing.Kind(), req.Settings); err != nil {
+		return nil, err
+	}
+	retention, err := buildRetention(req.Retention)
+	if err != nil {
+		return nil, err
+	}
+	if err := existing.Update(ctx, req.Name, req.Enabled, req.Timezone, retention, req.Settings); err != nil {
 		return nil, &ValidationError{Message: err.Error()}
 	}
 	if err := uc.repo.Update(ctx, existing); err != nil {
 		if errors.Is(err, ingestion.ErrDataSourceNameConflict) {
 			return nil, &ValidationError{Message: err.Error()}
 		}
+		if errors.Is(err, ingestion.ErrVersionMismatch) {
+			return nil, newVersionConflictError(req.IfMatch, err)
+		}
 		return nil, fmt.Errorf("failed to update data source: %w", err)
 	}
 
@@ -150,7 +337,46 @@ func (uc *DataSourceUseCase) Update(ctx context.Context, req *UpdateDataSourceRe
 	return newDataSourceResponse(result), nil
 }
 
-// Delete removes a data source by ID.
-func (uc *DataSourceUseCase) Delete(ctx context.Context, id uuid.UUID) error {
-	return uc.repo.Delete(ctx, id)
+// Delete removes a data source by ID. When ifMatch is set, returns false if the
+// data source does not exist and a PreconditionFailedError unless its current
+// version matches; without ifMatch, deleting a missing data source succeeds.
+func (uc *DataSourceUseCase) Delete(ctx context.Context, id uuid.UUID, ifMatch *IfMatch) (bool, error) {
+	var version *int
+	if ifMatch != nil {
+		existing, err := uc.repo.FindByID(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to find data source: %w", err)
+		}
+		if existing == nil {
+			return false, nil
+		}
+		if err := checkIfMatch(ifMatch, existing.Version()); err != nil {
+			return false, err
+		}
+		version = lo.ToPtr(existing.Version())
+	}
+	if err := uc.repo.Delete(ctx, id, version); err != nil {
+		if errors.Is(err, ingestion.ErrVersionMismatch) {
+			return false, &PreconditionFailedError{Message: err.Error()}
+		}
+		return false, fmt.Errorf("failed to delete data source: %w", err)
+	}
+	return true, nil
+}
+
+// validateSourceSettings checks settings against the schema registered for
+// kind and reports any violation as a ValidationError.
+func validateSourceSettings(
+	schemas ingestion.SettingsSchemas,
+	kind ingestion.SourceKind,
+	settings map[string]any,
+) error {
+	schema, err := schemas.Lookup(kind)
+	if err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+	if err := schema.ValidateSourceSettings(settings); err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+	return nil
 }
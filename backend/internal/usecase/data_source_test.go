@@ -7,13 +7,18 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/domain/jquants"
 	"stock-tool/internal/infra/repository"
 	"stock-tool/internal/util/testutil"
 )
 
+var testSettingsSchemas = ingestion.SettingsSchemas{jquants.Kind: jquants.SettingsSchema{}}
+
 type DataSourceUseCaseTestSuite struct {
 	testutil.DBTest
 	db   *gorm.DB
@@ -33,7 +38,7 @@ func (s *DataSourceUseCaseTestSuite) SetupTest() {
 
 	s.db = db
 	s.repo = repository.NewDataSourceRepository(db)
-	s.uc = NewDataSourceUseCase(s.repo)
+	s.uc = NewDataSourceUseCase(s.repo, testSettingsSchemas)
 }
 
 func (s *DataSourceUseCaseTestSuite) TearDownTest() {
@@ -42,7 +47,10 @@ func (s *DataSourceUseCaseTestSuite) TearDownTest() {
 
 func (s *DataSourceUseCaseTestSuite) TestCreate() {
 	ctx := context.Background()
-	cmpOpts := []cmp.Option{cmpopts.IgnoreFields(DataSourceResponse{}, "ID", "CreatedAt", "UpdatedAt")}
+	cmpOpts := []cmp.Option{
+		cmpopts.IgnoreFields(DataSourceResponse{}, "ID", "CreatedAt", "UpdatedAt"),
+		cmp.AllowUnexported(ingestion.RetentionPolicy{}),
+	}
 
 	type testCase struct {
 		name        string
@@ -60,11 +68,53 @@ func (s *DataSourceUseCaseTestSuite) TestCreate() {
 				Settings: map[string]any{"key": "val"},
 			},
 			expected: &DataSourceResponse{
+				Kind:     "generic",
 				Name:     "test-source",
 				Enabled:  true,
 				Timezone: "Asia/Tokyo",
 				Settings: map[string]any{"key": "val"},
+				Version:  1,
+			},
+		},
+		{
+			name: "registered kind with valid settings",
+			req: &CreateDataSourceRequest{
+				Kind:     "jquants",
+				Name:     "jquants",
+				Enabled:  true,
+				Timezone: "Asia/Tokyo",
+				Settings: map[string]any{"plan": "light"},
+			},
+			expected: &DataSourceResponse{
+				Kind:     "jquants",
+				Name:     "jquants",
+				Enabled:  true,
+				Timezone: "Asia/Tokyo",
+				Settings: map[string]any{"plan": "light"},
+				Version:  1,
+			},
+		},
+		{
+			name: "settings invalid for kind",
+			req: &CreateDataSourceRequest{
+				Kind:     "jquants",
+				Name:     "jquants-typo",
+				Enabled:  true,
+				Timezone: "Asia/Tokyo",
+				Settings: map[string]any{"plan": "freee"},
+			},
+			expectErrAs: &ValidationError{},
+		},
+		{
+			name: "unknown kind",
+			req: &CreateDataSourceRequest{
+				Kind:     "yahoo",
+				Name:     "yahoo",
+				Enabled:  true,
+				Timezone: "UTC",
+				Settings: map[string]any{},
 			},
+			expectErrAs: &ValidationError{},
 		},
 		{
 			name: "invalid timezone",
@@ -103,7 +153,10 @@ func (s *DataSourceUseCaseTestSuite) TestCreate() {
 
 func (s *DataSourceUseCaseTestSuite) TestGet() {
 	ctx := context.Background()
-	cmpOpts := []cmp.Option{cmpopts.IgnoreFields(DataSourceResponse{}, "ID", "CreatedAt", "UpdatedAt")}
+	cmpOpts := []cmp.Option{
+		cmpopts.IgnoreFields(DataSourceResponse{}, "ID", "CreatedAt", "UpdatedAt"),
+		cmp.AllowUnexported(ingestion.RetentionPolicy{}),
+	}
 
 	type testCase struct {
 		name     string
@@ -131,10 +184,12 @@ func (s *DataSourceUseCaseTestSuite) TestGet() {
 				return created.ID
 			},
 			expected: &DataSourceResponse{
+				Kind:     "generic",
 				Name:     "src",
 				Enabled:  true,
 				Timezone: "UTC",
 				Settings: map[string]any{},
+				Version:  1,
 			},
 		},
 		{
@@ -163,6 +218,7 @@ func (s *DataSourceUseCaseTestSuite) TestList() {
 	cmpOpts := []cmp.Option{
 		cmpopts.IgnoreFields(DataSourceResponse{}, "ID", "CreatedAt", "UpdatedAt"),
 		cmpopts.SortSlices(func(a, b *DataSourceResponse) bool { return a.Name < b.Name }),
+		cmp.AllowUnexported(ingestion.RetentionPolicy{}),
 	}
 
 	_, err := s.uc.Create(ctx, &CreateDataSourceRequest{
@@ -174,26 +230,97 @@ func (s *DataSourceUseCaseTestSuite) TestList() {
 	})
 	s.Require().NoError(err)
 
-	list, err := s.uc.List(ctx)
+	list, err := s.uc.List(ctx, &ListDataSourcesRequest{})
 
 	s.Require().NoError(err)
 	expected := []*DataSourceResponse{
-		{Name: "src1", Enabled: true, Timezone: "UTC", Settings: map[string]any{}},
-		{Name: "src2", Enabled: false, Timezone: "US/Eastern", Settings: map[string]any{}},
+		{Kind: "generic", Name: "src1", Enabled: true, Timezone: "UTC", Settings: map[string]any{}, Version: 1},
+		{Kind: "generic", Name: "src2", Enabled: false, Timezone: "US/Eastern", Settings: map[string]any{}, Version: 1},
+	}
+	s.True(cmp.Equal(expected, list.Items, cmpOpts...), cmp.Diff(expected, list.Items, cmpOpts...))
+	s.Nil(list.NextCursor)
+}
+
+func (s *DataSourceUseCaseTestSuite) TestList_Pages() {
+	ctx := context.Background()
+	for _, name := range []string{"src-c", "src-a", "src-b", "other"} {
+		_, err := s.uc.Create(ctx, &CreateDataSourceRequest{
+			Name: name, Enabled: true, Timezone: "UTC", Settings: map[string]any{},
+		})
+		s.Require().NoError(err)
+	}
+
+	var pages [][]string
+	req := &ListDataSourcesRequest{NamePrefix: "src-", Page: PageRequest{Limit: 2, Sort: "name"}}
+	for {
+		list, err := s.uc.List(ctx, req)
+		s.Require().NoError(err)
+		pages = append(pages, lo.Map(list.Items, func(r *DataSourceResponse, _ int) string { return r.Name }))
+		if list.NextCursor == nil {
+			break
+		}
+		req.Page.Cursor = *list.NextCursor
 	}
-	s.True(cmp.Equal(expected, list, cmpOpts...), cmp.Diff(expected, list, cmpOpts...))
+
+	s.Equal([][]string{{"src-a", "src-b"}, {"src-c"}}, pages)
+}
+
+func (s *DataSourceUseCaseTestSuite) TestList_InvalidPage() {
+	ctx := context.Background()
+	for _, name := range []string{"src1", "src2"} {
+		_, err := s.uc.Create(ctx, &CreateDataSourceRequest{
+			Name: name, Enabled: true, Timezone: "UTC", Settings: map[string]any{},
+		})
+		s.Require().NoError(err)
+	}
+	first, err := s.uc.List(ctx, &ListDataSourcesRequest{Page: PageRequest{Limit: 1, Sort: "name"}})
+	s.Require().NoError(err)
+	s.Require().NotNil(first.NextCursor)
+
+	tests := []struct {
+		name        string
+		page        PageRequest
+		expectedMsg string
+	}{
+		{name: "limit too large", page: PageRequest{Limit: 101}, expectedMsg: "limit must be between 1 and 100"},
+		{name: "negative limit", page: PageRequest{Limit: -1}, expectedMsg: "limit must be between 1 and 100"},
+		{name: "unknown sort", page: PageRequest{Sort: "timezone"}, expectedMsg: "unsupported sort field: timezone"},
+		{
+			name:        "cursor from another sort",
+			page:        PageRequest{Sort: "-name", Cursor: *first.NextCursor},
+			expectedMsg: `cursor was issued for sort "name", not "-name"`,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp, err := s.uc.List(ctx, &ListDataSourcesRequest{Page: tt.page})
+
+			s.Nil(resp)
+			var ve *ValidationError
+			s.Require().ErrorAs(err, &ve)
+			s.Equal(tt.expectedMsg, ve.Message)
+		})
+	}
+
+	_, err = s.uc.List(ctx, &ListDataSourcesRequest{Page: PageRequest{Cursor: "!!"}})
+	var ve *ValidationError
+	s.Require().ErrorAs(err, &ve)
+	s.Contains(ve.Message, "invalid cursor")
 }
 
 func (s *DataSourceUseCaseTestSuite) TestUpdate() {
 	ctx := context.Background()
-	cmpOpts := []cmp.Option{cmpopts.IgnoreFields(DataSourceResponse{}, "ID", "CreatedAt", "UpdatedAt")}
+	cmpOpts := []cmp.Option{
+		cmpopts.IgnoreFields(DataSourceResponse{}, "ID", "CreatedAt", "UpdatedAt"),
+		cmp.AllowUnexported(ingestion.RetentionPolicy{}),
+	}
 
 	type testCase struct {
 		name        string
 		setup       func() uuid.UUID
 		req         func(id uuid.UUID) *UpdateDataSourceRequest
 		expected    *DataSourceResponse
-		expectErrAs *ValidationError
+		expectErrAs error
 		postCheck   func()
 	}
 	var srcOtherID uuid.UUID
@@ -228,10 +355,12 @@ func (s *DataSourceUseCaseTestSuite) TestUpdate() {
 				}
 			},
 			expected: &DataSourceResponse{
+				Kind:     "generic",
 				Name:     "src-updated",
 				Enabled:  false,
 				Timezone: "Asia/Tokyo",
 				Settings: map[string]any{"new": "setting"},
+				Version:  2,
 			},
 			postCheck: func() {
 				resp, err := s.uc.Get(ctx, srcOtherID)
@@ -240,6 +369,69 @@ func (s *DataSourceUseCaseTestSuite) TestUpdate() {
 				s.Equal("src-other", resp.Name)
 			},
 		},
+		{
+			name: "matching If-Match",
+			setup: func() uuid.UUID {
+				created, err := s.uc.Create(ctx, &CreateDataSourceRequest{
+					Name:     "src",
+					Enabled:  true,
+					Timezone: "UTC",
+					Settings: map[string]any{},
+				})
+				s.Require().NoError(err)
+				return created.ID
+			},
+			req: func(id uuid.UUID) *UpdateDataSourceRequest {
+				return &UpdateDataSourceRequest{
+					ID:       id,
+					Name:     "src",
+					Enabled:  false,
+					Timezone: "UTC",
+					Settings: map[string]any{},
+					IfMatch:  IfMatchVersion(1),
+				}
+			},
+			expected: &DataSourceResponse{
+				Kind:     "generic",
+				Name:     "src",
+				Enabled:  false,
+				Timezone: "UTC",
+				Settings: map[string]any{},
+				Version:  2,
+			},
+		},
+		{
+			name: "stale If-Match",
+			setup: func() uuid.UUID {
+				created, err := s.uc.Create(ctx, &CreateDataSourceRequest{
+					Name:     "src",
+					Enabled:  true,
+					Timezone: "UTC",
+					Settings: map[string]any{},
+				})
+				s.Require().NoError(err)
+				_, err = s.uc.Update(ctx, &UpdateDataSourceRequest{
+					ID:       created.ID,
+					Name:     "src",
+					Enabled:  false,
+					Timezone: "UTC",
+					Settings: map[string]any{},
+				})
+				s.Require().NoError(err)
+				return created.ID
+			},
+			req: func(id uuid.UUID) *UpdateDataSourceRequest {
+				return &UpdateDataSourceRequest{
+					ID:       id,
+					Name:     "src",
+					Enabled:  true,
+					Timezone: "UTC",
+					Settings: map[string]any{},
+					IfMatch:  IfMatchVersion(1),
+				}
+			},
+			expectErrAs: &PreconditionFailedError{},
+		},
 		{
 			name:  "not found",
 			setup: func() uuid.UUID { return uuid.Must(uuid.NewV7()) },
@@ -254,6 +446,30 @@ func (s *DataSourceUseCaseTestSuite) TestUpdate() {
 			},
 			expected: nil,
 		},
+		{
+			name: "settings invalid for kind",
+			setup: func() uuid.UUID {
+				created, err := s.uc.Create(ctx, &CreateDataSourceRequest{
+					Kind:     "jquants",
+					Name:     "jquants",
+					Enabled:  true,
+					Timezone: "Asia/Tokyo",
+					Settings: map[string]any{"plan": "free"},
+				})
+				s.Require().NoError(err)
+				return created.ID
+			},
+			req: func(id uuid.UUID) *UpdateDataSourceRequest {
+				return &UpdateDataSourceRequest{
+					ID:       id,
+					Name:     "jquants",
+					Enabled:  true,
+					Timezone: "Asia/Tokyo",
+					Settings: map[string]any{},
+				}
+			},
+			expectErrAs: &ValidationError{},
+		},
 		{
 			name: "invalid timezone",
 			setup: func() uuid.UUID {
@@ -331,6 +547,85 @@ func (s *DataSourceUseCaseTestSuite) TestUpdate() {
 	}
 }
 
+func (s *DataSourceUseCaseTestSuite) TestPatch() {
+	ctx := context.Background()
+	cmpOpts := []cmp.Option{
+		cmpopts.IgnoreFields(DataSourceResponse{}, "ID", "CreatedAt", "UpdatedAt"),
+		cmp.AllowUnexported(ingestion.RetentionPolicy{}),
+	}
+
+	created, err := s.uc.Create(ctx, &CreateDataSourceRequest{
+		Name:     "src",
+		Enabled:  true,
+		Timezone: "Asia/Tokyo",
+		Settings: map[string]any{"token": "secret", "limits": map[string]any{"rps": 5.0, "burst": 10.0}},
+	})
+	s.Require().NoError(err)
+
+	resp, err := s.uc.Patch(ctx, &PatchDataSourceRequest{
+		ID:       created.ID,
+		Enabled:  lo.ToPtr(false),
+		Settings: map[string]any{"token": nil, "limits": map[string]any{"rps": 2.0}},
+		IfMatch:  IfMatchVersion(1),
+	})
+
+	s.Require().NoError(err)
+	expected := &DataSourceResponse{
+		Kind:     "generic",
+		Name:     "src",
+		Enabled:  false,
+		Timezone: "Asia/Tokyo",
+		Settings: map[string]any{"limits": map[string]any{"rps": 2.0, "burst": 10.0}},
+		Version:  2,
+	}
+	s.True(cmp.Equal(expected, resp, cmpOpts...), cmp.Diff(expected, resp, cmpOpts...))
+
+	_, err = s.uc.Patch(ctx, &PatchDataSourceRequest{ID: created.ID, Timezone: lo.ToPtr("Bad/Zone")})
+	s.IsType(&ValidationError{}, err)
+
+	_, err = s.uc.Patch(ctx, &PatchDataSourceRequest{
+		ID:        created.ID,
+		Retention: &RetentionPatch{KeepLatest: RulePatch{Set: true, Value: lo.ToPtr(3)}},
+	})
+	s.Require().NoError(err)
+	resp, err = s.uc.Patch(ctx, &PatchDataSourceRequest{
+		ID:        created.ID,
+		Retention: &RetentionPatch{SupersededMaxAgeDays: RulePatch{Set: true, Value: lo.ToPtr(30)}},
+	})
+	s.Require().NoError(err)
+	s.Equal(lo.ToPtr(3), resp.Retention.KeepLatest(), "rules missing from the patch are kept")
+	s.Equal(lo.ToPtr(30), resp.Retention.SupersededMaxAgeDays())
+
+	resp, err = s.uc.Patch(ctx, &PatchDataSourceRequest{
+		ID:        created.ID,
+		Retention: &RetentionPatch{KeepLatest: RulePatch{Set: true}},
+	})
+	s.Require().NoError(err)
+	s.Nil(resp.Retention.KeepLatest(), "a null rule is removed")
+	s.Equal(lo.ToPtr(30), resp.Retention.SupersededMaxAgeDays())
+
+	_, err = s.uc.Patch(ctx, &PatchDataSourceRequest{
+		ID:        created.ID,
+		Retention: &RetentionPatch{KeepLatest: RulePatch{Set: true, Value: lo.ToPtr(0)}},
+	})
+	s.IsType(&ValidationError{}, err)
+
+	resp, err = s.uc.Patch(ctx, &PatchDataSourceRequest{
+		ID:            created.ID,
+		Settings:      map[string]any{"token": "rotated"},
+		ClearSettings: true,
+	})
+	s.Require().NoError(err)
+	s.Equal(map[string]any{"token": "rotated"}, resp.Settings, "null settings remove every current setting")
+
+	_, err = s.uc.Patch(ctx, &PatchDataSourceRequest{ID: created.ID, Enabled: lo.ToPtr(true), IfMatch: IfMatchVersion(1)})
+	s.IsType(&PreconditionFailedError{}, err)
+
+	notFound, err := s.uc.Patch(ctx, &PatchDataSourceRequest{ID: uuid.Must(uuid.NewV7()), Enabled: lo.ToPtr(true)})
+	s.NoError(err)
+	s.Nil(notFound)
+}
+
 func (s *DataSourceUseCaseTestSuite) TestDelete() {
 	ctx := context.Background()
 
@@ -350,8 +645,9 @@ func (s *DataSourceUseCaseTestSuite) TestDelete() {
 	})
 	s.Require().NoError(err)
 
-	err = s.uc.Delete(ctx, created.ID)
+	deleted, err := s.uc.Delete(ctx, created.ID, nil)
 	s.Require().NoError(err)
+	s.True(deleted)
 
 	resp, err := s.uc.Get(ctx, created.ID)
 	s.NoError(err)
@@ -363,3 +659,40 @@ func (s *DataSourceUseCaseTestSuite) TestDelete() {
 	s.Require().NotNil(otherResp)
 	s.Equal("src-other", otherResp.Name)
 }
+
+func (s *DataSourceUseCaseTestSuite) TestDelete_IfMatch() {
+	ctx := context.Background()
+
+	created, err := s.uc.Create(ctx, &CreateDataSourceRequest{
+		Name:     "src",
+		Enabled:  true,
+		Timezone: "UTC",
+		Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+
+	deleted, err := s.uc.Delete(ctx, created.ID, IfMatchVersion(created.Version+1))
+	s.IsType(&PreconditionFailedError{}, err)
+	s.False(deleted)
+
+	resp, err := s.uc.Get(ctx, created.ID)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+
+	// Any of the listed versions may match.
+	deleted, err = s.uc.Delete(ctx, created.ID, &IfMatch{Versions: []int{created.Version + 1, created.Version}})
+	s.Require().NoError(err)
+	s.True(deleted)
+	resp, err = s.uc.Get(ctx, created.ID)
+	s.NoError(err)
+	s.Nil(resp)
+
+	// A conditional delete of a missing data source reports it as not found,
+	// an unconditional one succeeds.
+	deleted, err = s.uc.Delete(ctx, created.ID, &IfMatch{Any: true})
+	s.NoError(err)
+	s.False(deleted)
+	deleted, err = s.uc.Delete(ctx, created.ID, nil)
+	s.NoError(err)
+	s.True(deleted)
+}
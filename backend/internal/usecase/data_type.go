@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/util/mergepatch"
+	"stock-tool/internal/util/pagination"
 
 	"github.com/google/uuid"
 	"github.com/samber/lo"
@@ -21,15 +23,32 @@ type DataTypeRepository interface {
 	// FindByID returns the DataType with the given ID, or (nil, nil) if not found.
 	FindByID(ctx context.Context, id uuid.UUID) (*ingestion.DataType, error)
 
-	// ListBySourceID returns all DataType entities belonging to the given DataSource.
-	// Returns an empty slice when none are found.
-	ListBySourceID(ctx context.Context, dataSourceID uuid.UUID) ([]*ingestion.DataType, error)
+	// List returns the DataType entities matching filter, ordered and limited
+	// by page. Returns an empty slice when none are found.
+	List(
+		ctx context.Context,
+		filter ingestion.DataTypeFilter,
+		page pagination.Params,
+	) ([]*ingestion.DataType, error)
 
-	// Update persists changes to an existing DataType.
+	// Update persists changes to an existing DataType and increments its version.
+	// Returns an error wrapping ingestion.ErrVersionMismatch if the stored
+	// version no longer equals dt.Version().
 	Update(ctx context.Context, dt *ingestion.DataType) error
 
-	// Delete removes the DataType with the given ID.
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete removes the DataType with the given ID. When version is non-nil,
+	// returns an error wrapping ingestion.ErrVersionMismatch unless the stored
+	// version equals it.
+	Delete(ctx context.Context, id uuid.UUID, version *int) error
+
+	// ListDependencies returns the dependencies of the DataType with the given
+	// ID, ordered by the ID of the DataType depended on.
+	ListDependencies(ctx context.Context, id uuid.UUID) ([]ingestion.Dependency, error)
+
+	// ReplaceDependencies replaces the dependencies of the DataType with the
+	// given ID. Returns an error wrapping ingestion.ErrDependencyCycle if the
+	// dependencies would form a cycle.
+	ReplaceDependencies(ctx context.Context, id uuid.UUID, deps []ingestion.Dependency) error
 }
 
 type CreateDataTypeRequest struct {
@@ -39,7 +58,14 @@ type CreateDataTypeRequest struct {
 	Schedule            ScheduleInput
 	BackfillEnabled     bool
 	StaleTimeoutMinutes int
-	Settings            map[string]any
+	// RerunStrategy is empty for ingestion.RerunStrategyAppend.
+	RerunStrategy string
+	// EmptyResponsePolicy is empty for ingestion.EmptyResponsePolicySuccess.
+	EmptyResponsePolicy string
+	// Compression is empty for ingestion.CompressionNone.
+	Compression string
+	Retention   RetentionInput
+	Settings    map[string]any
 }
 
 type UpdateDataTypeRequest struct {
@@ -49,7 +75,80 @@ type UpdateDataTypeRequest struct {
 	Schedule            ScheduleInput
 	BackfillEnabled     bool
 	StaleTimeoutMinutes int
+	// RerunStrategy is empty for ingestion.RerunStrategyAppend.
+	RerunStrategy string
+	// EmptyResponsePolicy is empty for ingestion.EmptyResponsePolicySuccess.
+	EmptyResponsePolicy string
+	// Compression is empty for ingestion.CompressionNone.
+	Compression string
+	Retention   RetentionInput
+	Settings    map[string]any
+	// IfMatch, when set, is the precondition on the version to overwrite.
+	IfMatch *IfMatch
+}
+
+// PatchDataTypeRequest carries a JSON Merge Patch. Nil fields are left
+// unchanged; Settings is merged into the current settings key by key.
+// ClearSettings, set for a null settings member, removes every current
+// setting before Settings is merged.
+type PatchDataTypeRequest struct {
+	ID                  uuid.UUID
+	Name                *string
+	Enabled             *bool
+	Schedule            *SchedulePatch
+	BackfillEnabled     *bool
+	StaleTimeoutMinutes *int
+	RerunStrategy       *string
+	EmptyResponsePolicy *string
+	Compression         *string
+	Retention           *RetentionPatch
 	Settings            map[string]any
+	ClearSettings       bool
+	// IfMatch, when set, is the precondition on the version to overwrite.
+	IfMatch *IfMatch
+}
+
+// SchedulePatch holds the schedule fields present in a merge patch. A nil
+// Type or Times keeps the current value.
+type SchedulePatch struct {
+	Type  *string
+	Times []string
+}
+
+// RetentionInput holds the raw retention rules before domain validation. A
+// nil rule is not set.
+type RetentionInput struct {
+	KeepLatest           *int
+	SupersededMaxAgeDays *int
+}
+
+// RetentionPatch holds the retention rules of a merge patch.
+type RetentionPatch struct {
+	KeepLatest           RulePatch
+	SupersededMaxAgeDays RulePatch
+}
+
+// RulePatch is a retention rule in a merge patch. The zero value is an
+// omitted rule and keeps the current value; a Set rule with a nil Value is
+// null and removes the rule.
+type RulePatch struct {
+	Set   bool
+	Value *int
+}
+
+// apply returns the rule after the patch is applied to current.
+func (p RulePatch) apply(current *int) *int {
+	if !p.Set {
+		return current
+	}
+	return p.Value
+}
+
+type ListDataTypesRequest struct {
+	DataSourceID *uuid.UUID
+	Enabled      *bool
+	NamePrefix   string
+	Page         PageRequest
 }
 
 type DataTypeResponse struct {
@@ -60,11 +159,22 @@ type DataTypeResponse struct {
 	Schedule            ingestion.Schedule
 	BackfillEnabled     bool
 	StaleTimeoutMinutes int
+	RerunStrategy       ingestion.RerunStrategy
+	EmptyResponsePolicy ingestion.EmptyResponsePolicy
+	Compression         ingestion.Compression
+	Retention           ingestion.RetentionPolicy
 	Settings            map[string]any
+	Version             int
 	CreatedAt           time.Time
 	UpdatedAt           time.Time
 }
 
+type DataTypeListResponse struct {
+	Items []*DataTypeResponse
+	// NextCursor is nil on the last page.
+	NextCursor *string
+}
+
 // ScheduleInput holds the raw schedule parameters before domain validation.
 type ScheduleInput struct {
 	Type  string
@@ -80,26 +190,58 @@ func newDataTypeResponse(e *ingestion.DataType) *DataTypeResponse {
 		Schedule:            e.Schedule(),
 		BackfillEnabled:     e.BackfillEnabled(),
 		StaleTimeoutMinutes: e.StaleTimeoutMinutes(),
+		RerunStrategy:       e.RerunStrategy(),
+		EmptyResponsePolicy: e.EmptyResponsePolicy(),
+		Compression:         e.Compression(),
+		Retention:           e.Retention(),
 		Settings:            e.Settings(),
+		Version:             e.Version(),
 		CreatedAt:           e.CreatedAt(),
 		UpdatedAt:           e.UpdatedAt(),
 	}
 }
 
 type DataTypeUseCase struct {
-	repo DataTypeRepository
+	repo           DataTypeRepository
+	dataSourceRepo DataSourceRepository
+	schemas        ingestion.SettingsSchemas
 }
 
-func NewDataTypeUseCase(repo DataTypeRepository) *DataTypeUseCase {
-	return &DataTypeUseCase{repo: repo}
+func NewDataTypeUseCase(
+	repo DataTypeRepository,
+	dataSourceRepo DataSourceRepository,
+	schemas ingestion.SettingsSchemas,
+) *DataTypeUseCase {
+	return &DataTypeUseCase{repo: repo, dataSourceRepo: dataSourceRepo, schemas: schemas}
 }
 
-// Create creates a new data type. Returns a ValidationError on invalid input.
+// Create creates a new data type. Returns a ValidationError on invalid input,
+// including a missing data source or settings that do not match the schema
+// of the data source's kind.
 func (uc *DataTypeUseCase) Create(ctx context.Context, req *CreateDataTypeRequest) (*DataTypeResponse, error) {
 	schedule, err := buildSchedule(req.Schedule)
 	if err != nil {
 		return nil, err
 	}
+	rerunStrategy, err := buildRerunStrategy(req.RerunStrategy)
+	if err != nil {
+		return nil, err
+	}
+	emptyResponsePolicy, err := buildEmptyResponsePolicy(req.EmptyResponsePolicy)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := buildCompression(req.Compression)
+	if err != nil {
+		return nil, err
+	}
+	retention, err := buildRetention(req.Retention)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.validateSettings(ctx, req.DataSourceID, req.Settings); err != nil {
+		return nil, err
+	}
 
 	entity := ingestion.NewDataType(
 		ctx,
@@ -109,6 +251,10 @@ func (uc *DataTypeUseCase) Create(ctx context.Context, req *CreateDataTypeReques
 		schedule,
 		req.BackfillEnabled,
 		req.StaleTimeoutMinutes,
+		rerunStrategy,
+		emptyResponsePolicy,
+		compression,
+		retention,
 		req.Settings,
 	)
 	created, err := uc.repo.Create(ctx, entity)
@@ -133,19 +279,39 @@ func (uc *DataTypeUseCase) Get(ctx context.Context, id uuid.UUID) (*DataTypeResp
 	return newDataTypeResponse(found), nil
 }
 
-// List returns all data types belonging to the given data source.
-func (uc *DataTypeUseCase) List(ctx context.Context, dataSourceID uuid.UUID) ([]*DataTypeResponse, error) {
-	types, err := uc.repo.ListBySourceID(ctx, dataSourceID)
+// List returns one page of data types matching the request filters.
+// Returns a ValidationError on invalid pagination parameters.
+func (uc *DataTypeUseCase) List(ctx context.Context, req *ListDataTypesRequest) (*DataTypeListResponse, error) {
+	page, err := buildPageParams(req.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := ingestion.DataTypeFilter{
+		DataSourceID: req.DataSourceID,
+		Enabled:      req.Enabled,
+		NamePrefix:   req.NamePrefix,
+	}
+	types, err := uc.repo.List(ctx, filter, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list data types: %w", err)
 	}
-	return lo.Map(types, func(t *ingestion.DataType, _ int) *DataTypeResponse {
-		return newDataTypeResponse(t)
-	}), nil
+
+	types, next := trimPage(types, page, func(t *ingestion.DataType) (uuid.UUID, string) {
+		return t.ID(), t.Name()
+	})
+	return &DataTypeListResponse{
+		Items: lo.Map(types, func(t *ingestion.DataType, _ int) *DataTypeResponse {
+			return newDataTypeResponse(t)
+		}),
+		NextCursor: next,
+	}, nil
 }
 
 // Update applies changes to an existing data type. Returns (nil, nil) when
-// not found, or a ValidationError on invalid input.
+// not found, a ValidationError on invalid input, a PreconditionFailedError
+// when req.IfMatch is not the current version, or a ConflictError when a
+// concurrent update without IfMatch wins the race.
 func (uc *DataTypeUseCase) Update(ctx context.Context, req *UpdateDataTypeRequest) (*DataTypeResponse, error) {
 	schedule, err := buildSchedule(req.Schedule)
 	if err != nil {
@@ -159,7 +325,72 @@ func (uc *DataTypeUseCase) Update(ctx context.Context, req *UpdateDataTypeReques
 	if existing == nil {
 		return nil, nil
 	}
+	if err := checkIfMatch(req.IfMatch, existing.Version()); err != nil {
+		return nil, err
+	}
+	return uc.save(ctx, existing, req, schedule)
+}
+
+// Patch applies a merge patch to an existing data type. The merged result is
+// validated and persisted exactly as in Update, with the same errors.
+func (uc *DataTypeUseCase) Patch(ctx context.Context, req *PatchDataTypeRequest) (*DataTypeResponse, error) {
+	existing, err := uc.repo.FindByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data type: %w", err)
+	}
+	if existing == nil {
+		return nil, nil
+	}
+	if err := checkIfMatch(req.IfMatch, existing.Version()); err != nil {
+		return nil, err
+	}
+
+	merged := &UpdateDataTypeRequest{
+		ID:                  req.ID,
+		Name:                lo.FromPtrOr(req.Name, existing.Name()),
+		Enabled:             lo.FromPtrOr(req.Enabled, existing.Enabled()),
+		Schedule:            patchSchedule(existing.Schedule(), req.Schedule),
+		BackfillEnabled:     lo.FromPtrOr(req.BackfillEnabled, existing.BackfillEnabled()),
+		StaleTimeoutMinutes: lo.FromPtrOr(req.StaleTimeoutMinutes, existing.StaleTimeoutMinutes()),
+		RerunStrategy:       lo.FromPtrOr(req.RerunStrategy, string(existing.RerunStrategy())),
+		EmptyResponsePolicy: lo.FromPtrOr(req.EmptyResponsePolicy, string(existing.EmptyResponsePolicy())),
+		Compression:         lo.FromPtrOr(req.Compression, string(existing.Compression())),
+		Retention:           patchRetention(existing.Retention(), req.Retention),
+		Settings:            patchSettings(existing.Settings(), req.Settings, req.ClearSettings),
+		IfMatch:             req.IfMatch,
+	}
+	schedule, err := buildSchedule(merged.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	return uc.save(ctx, existing, merged, schedule)
+}
 
+func (uc *DataTypeUseCase) save(
+	ctx context.Context,
+	existing *ingestion.DataType,
+	req *UpdateDataTypeRequest,
+	schedule ingestion.Schedule,
+) (*DataTypeResponse, error) {
+	rerunStrategy, err := buildRerunStrategy(req.RerunStrategy)
+	if err != nil {
+		return nil, err
+	}
+	emptyResponsePolicy, err := buildEmptyResponsePolicy(req.EmptyResponsePolicy)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := buildCompression(req.Compression)
+	if err != nil {
+		return nil, err
+	}
+	retention, err := buildRetention(req.Retention)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.validateSettings(ctx, existing.DataSourceID(), req.Settings); err != nil {
+		return nil, err
+	}
 	existing.Update(
 		ctx,
 		req.Name,
@@ -167,12 +398,19 @@ func (uc *DataTypeUseCase) Update(ctx context.Context, req *UpdateDataTypeReques
 		schedule,
 		req.BackfillEnabled,
 		req.StaleTimeoutMinutes,
+		rerunStrategy,
+		emptyResponsePolicy,
+		compression,
+		retention,
 		req.Settings,
 	)
 	if err := uc.repo.Update(ctx, existing); err != nil {
 		if errors.Is(err, ingestion.ErrDataTypeNameConflict) {
 			return nil, &ValidationError{Message: err.Error()}
 		}
+		if errors.Is(err, ingestion.ErrVersionMismatch) {
+			return nil, newVersionConflictError(req.IfMatch, err)
+		}
 		return nil, fmt.Errorf("failed to update data type: %w", err)
 	}
 
@@ -183,9 +421,99 @@ func (uc *DataTypeUseCase) Update(ctx context.Context, req *UpdateDataTypeReques
 	return newDataTypeResponse(result), nil
 }
 
-// Delete removes a data type by ID.
-func (uc *DataTypeUseCase) Delete(ctx context.Context, id uuid.UUID) error {
-	return uc.repo.Delete(ctx, id)
+// Delete removes a data type by ID. When ifMatch is set, returns false if the
+// data type does not exist and a PreconditionFailedError unless its current
+// version matches; without ifMatch, deleting a missing data type succeeds.
+func (uc *DataTypeUseCase) Delete(ctx context.Context, id uuid.UUID, ifMatch *IfMatch) (bool, error) {
+	var version *int
+	if ifMatch != nil {
+		existing, err := uc.repo.FindByID(ctx, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to find data type: %w", err)
+		}
+		if existing == nil {
+			return false, nil
+		}
+		if err := checkIfMatch(ifMatch, existing.Version()); err != nil {
+			return false, err
+		}
+		version = lo.ToPtr(existing.Version())
+	}
+	if err := uc.repo.Delete(ctx, id, version); err != nil {
+		if errors.Is(err, ingestion.ErrVersionMismatch) {
+			return false, &PreconditionFailedError{Message: err.Error()}
+		}
+		return false, fmt.Errorf("failed to delete data type: %w", err)
+	}
+	return true, nil
+}
+
+// validateSettings checks data type settings against the schema of the kind
+// of the data source they belong to.
+func (uc *DataTypeUseCase) validateSettings(
+	ctx context.Context,
+	dataSourceID uuid.UUID,
+	settings map[string]any,
+) error {
+	src, err := uc.dataSourceRepo.FindByID(ctx, dataSourceID)
+	if err != nil {
+		return fmt.Errorf("failed to find data source: %w", err)
+	}
+	if src == nil {
+		return &ValidationError{Message: fmt.Sprintf("data source %s not found", dataSourceID)}
+	}
+	schema, err := uc.schemas.Lookup(src.Kind())
+	if err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+	if err := schema.ValidateDataTypeSettings(settings); err != nil {
+		return &ValidationError{Message: err.Error()}
+	}
+	return nil
+}
+
+// patchSchedule overlays the fields present in patch on the current schedule.
+func patchSchedule(current ingestion.Schedule, patch *SchedulePatch) ScheduleInput {
+	input := ScheduleInput{
+		Type:  string(current.Type()),
+		Times: lo.Map(current.Times(), func(t ingestion.TimeOfDay, _ int) string { return string(t) }),
+	}
+	if patch == nil {
+		return input
+	}
+	if patch.Type != nil {
+		input.Type = *patch.Type
+	}
+	if patch.Times != nil {
+		input.Times = patch.Times
+	}
+	return input
+}
+
+// patchRetention applies the rules present in patch to the current policy.
+func patchRetention(current ingestion.RetentionPolicy, patch *RetentionPatch) RetentionInput {
+	input := RetentionInput{
+		KeepLatest:           current.KeepLatest(),
+		SupersededMaxAgeDays: current.SupersededMaxAgeDays(),
+	}
+	if patch == nil {
+		return input
+	}
+	input.KeepLatest = patch.KeepLatest.apply(input.KeepLatest)
+	input.SupersededMaxAgeDays = patch.SupersededMaxAgeDays.apply(input.SupersededMaxAgeDays)
+	return input
+}
+
+// patchSettings merges patch into the current settings, or into no settings
+// when reset is set.
+func patchSettings(current, patch map[string]any, reset bool) map[string]any {
+	if reset {
+		current = map[string]any{}
+	}
+	if patch == nil {
+		return current
+	}
+	return mergepatch.Apply(current, patch)
 }
 
 func buildSchedule(input ScheduleInput) (ingestion.Schedule, error) {
@@ -208,3 +536,35 @@ func buildSchedule(input ScheduleInput) (ingestion.Schedule, error) {
 	}
 	return s, nil
 }
+
+func buildRerunStrategy(input string) (ingestion.RerunStrategy, error) {
+	s, err := ingestion.NewRerunStrategy(input)
+	if err != nil {
+		return "", &ValidationError{Message: err.Error()}
+	}
+	return s, nil
+}
+
+func buildEmptyResponsePolicy(input string) (ingestion.EmptyResponsePolicy, error) {
+	p, err := ingestion.NewEmptyResponsePolicy(input)
+	if err != nil {
+		return "", &ValidationError{Message: err.Error()}
+	}
+	return p, nil
+}
+
+func buildCompression(input string) (ingestion.Compression, error) {
+	c, err := ingestion.NewCompression(input)
+	if err != nil {
+		return "", &ValidationError{Message: err.Error()}
+	}
+	return c, nil
+}
+
+func buildRetention(input RetentionInput) (ingestion.RetentionPolicy, error) {
+	p, err := ingestion.NewRetentionPolicy(input.KeepLatest, input.SupersededMaxAgeDays)
+	if err != nil {
+		return ingestion.RetentionPolicy{}, &ValidationError{Message: err.Error()}
+	}
+	return p, nil
+}
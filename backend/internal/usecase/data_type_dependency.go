@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"stock-tool/internal/domain/ingestion"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+type DependencyInput struct {
+	DataTypeID uuid.UUID
+	MaxLagDays int
+}
+
+type ReplaceDependenciesRequest struct {
+	DataTypeID   uuid.UUID
+	Dependencies []DependencyInput
+}
+
+type DependencyResponse struct {
+	// DataTypeID is the data type depended on.
+	DataTypeID uuid.UUID
+	MaxLagDays int
+}
+
+func newDependencyResponse(d ingestion.Dependency) *DependencyResponse {
+	return &DependencyResponse{DataTypeID: d.DependsOnID(), MaxLagDays: d.MaxLagDays()}
+}
+
+// ListDependencies returns the data types that must be extracted before the
+// data type with the given ID. Returns (nil, nil) when it is not found.
+func (uc *DataTypeUseCase) ListDependencies(ctx context.Context, id uuid.UUID) ([]*DependencyResponse, error) {
+	found, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data type: %w", err)
+	}
+	if found == nil {
+		return nil, nil
+	}
+	return uc.listDependencies(ctx, id)
+}
+
+// ReplaceDependencies replaces the dependencies of a data type (D9).
+//
+// Returns (nil, nil) when the data type is not found, and a ValidationError
+// when a data type depended on does not exist or is listed twice, a lag is
+// negative, or the dependencies would form a cycle.
+func (uc *DataTypeUseCase) ReplaceDependencies(
+	ctx context.Context,
+	req *ReplaceDependenciesRequest,
+) ([]*DependencyResponse, error) {
+	found, err := uc.repo.FindByID(ctx, req.DataTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data type: %w", err)
+	}
+	if found == nil {
+		return nil, nil
+	}
+
+	deps := make([]ingestion.Dependency, 0, len(req.Dependencies))
+	seen := make(map[uuid.UUID]bool, len(req.Dependencies))
+	for _, input := range req.Dependencies {
+		if seen[input.DataTypeID] {
+			return nil, &ValidationError{
+				Message: fmt.Sprintf("data type %s is listed more than once", input.DataTypeID),
+			}
+		}
+		seen[input.DataTypeID] = true
+
+		dep, err := ingestion.NewDependency(input.DataTypeID, input.MaxLagDays)
+		if err != nil {
+			return nil, &ValidationError{Message: err.Error()}
+		}
+		target, err := uc.repo.FindByID(ctx, input.DataTypeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find data type: %w", err)
+		}
+		if target == nil {
+			return nil, &ValidationError{Message: fmt.Sprintf("data type %s not found", input.DataTypeID)}
+		}
+		deps = append(deps, dep)
+	}
+
+	if err := uc.repo.ReplaceDependencies(ctx, req.DataTypeID, deps); err != nil {
+		if errors.Is(err, ingestion.ErrDependencyCycle) {
+			return nil, &ValidationError{Message: err.Error()}
+		}
+		return nil, fmt.Errorf("failed to replace dependencies: %w", err)
+	}
+	return uc.listDependencies(ctx, req.DataTypeID)
+}
+
+func (uc *DataTypeUseCase) listDependencies(ctx context.Context, id uuid.UUID) ([]*DependencyResponse, error) {
+	deps, err := uc.repo.ListDependencies(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependencies: %w", err)
+	}
+	return lo.Map(deps, func(d ingestion.Dependency, _ int) *DependencyResponse {
+		return newDependencyResponse(d)
+	}), nil
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+// createDependencyTypes creates data types a, b and c under one data source.
+func (s *DataTypeUseCaseTestSuite) createDependencyTypes(ctx context.Context) (a, b, c uuid.UUID) {
+	src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Name:     "src",
+		Enabled:  true,
+		Timezone: "UTC",
+		Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+	ids := make([]uuid.UUID, 0, 3)
+	for _, name := range []string{"a", "b", "c"} {
+		dt, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+			DataSourceID:        src.ID,
+			Name:                name,
+			Enabled:             true,
+			Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+			StaleTimeoutMinutes: 30,
+			Settings:            map[string]any{},
+		})
+		s.Require().NoError(err)
+		ids = append(ids, dt.ID)
+	}
+	return ids[0], ids[1], ids[2]
+}
+
+func (s *DataTypeUseCaseTestSuite) TestReplaceDependencies() {
+	ctx := context.Background()
+	a, b, c := s.createDependencyTypes(ctx)
+	// b already depends on c.
+	_, err := s.dtUC.ReplaceDependencies(ctx, &ReplaceDependenciesRequest{
+		DataTypeID:   b,
+		Dependencies: []DependencyInput{{DataTypeID: c}},
+	})
+	s.Require().NoError(err)
+	unknown := uuid.Must(uuid.NewV7())
+
+	type testCase struct {
+		name        string
+		req         *ReplaceDependenciesRequest
+		expected    []*DependencyResponse
+		expectedMsg string
+	}
+	tests := []testCase{
+		{
+			name: "success",
+			req: &ReplaceDependenciesRequest{
+				DataTypeID:   a,
+				Dependencies: []DependencyInput{{DataTypeID: b, MaxLagDays: 3}},
+			},
+			expected: []*DependencyResponse{{DataTypeID: b, MaxLagDays: 3}},
+		},
+		{
+			name:     "data type not found",
+			req:      &ReplaceDependenciesRequest{DataTypeID: unknown},
+			expected: nil,
+		},
+		{
+			name: "dependency not found",
+			req: &ReplaceDependenciesRequest{
+				DataTypeID:   a,
+				Dependencies: []DependencyInput{{DataTypeID: unknown}},
+			},
+			expectedMsg: fmt.Sprintf("data type %s not found", unknown),
+		},
+		{
+			name: "listed twice",
+			req: &ReplaceDependenciesRequest{
+				DataTypeID:   a,
+				Dependencies: []DependencyInput{{DataTypeID: b}, {DataTypeID: b, MaxLagDays: 1}},
+			},
+			expectedMsg: fmt.Sprintf("data type %s is listed more than once", b),
+		},
+		{
+			name: "negative lag",
+			req: &ReplaceDependenciesRequest{
+				DataTypeID:   a,
+				Dependencies: []DependencyInput{{DataTypeID: b, MaxLagDays: -1}},
+			},
+			expectedMsg: "maxLagDays must not be negative: -1",
+		},
+		{
+			name: "cycle",
+			req: &ReplaceDependenciesRequest{
+				DataTypeID:   c,
+				Dependencies: []DependencyInput{{DataTypeID: b}},
+			},
+			expectedMsg: fmt.Sprintf("%s -> %s -> %s: data type dependencies form a cycle", b, c, b),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp, err := s.dtUC.ReplaceDependencies(ctx, tt.req)
+			if tt.expectedMsg != "" {
+				var ve *ValidationError
+				s.Require().ErrorAs(err, &ve)
+				s.Equal(tt.expectedMsg, ve.Message)
+				return
+			}
+			s.Require().NoError(err)
+			s.True(cmp.Equal(tt.expected, resp), cmp.Diff(tt.expected, resp))
+		})
+	}
+
+	// Rejected requests leave the stored dependencies untouched.
+	got, err := s.dtUC.ListDependencies(ctx, c)
+	s.Require().NoError(err)
+	s.Empty(got)
+	got, err = s.dtUC.ListDependencies(ctx, a)
+	s.Require().NoError(err)
+	expected := []*DependencyResponse{{DataTypeID: b, MaxLagDays: 3}}
+	s.True(cmp.Equal(expected, got), cmp.Diff(expected, got))
+}
+
+func (s *DataTypeUseCaseTestSuite) TestListDependencies_NotFound() {
+	got, err := s.dtUC.ListDependencies(context.Background(), uuid.Must(uuid.NewV7()))
+	s.NoError(err)
+	s.Nil(got)
+}
@@ -7,10 +7,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 
 	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/domain/jquants"
 	"stock-tool/internal/infra/repository"
 	"stock-tool/internal/util/testutil"
 )
@@ -37,8 +39,8 @@ func (s *DataTypeUseCaseTestSuite) SetupTest() {
 	s.db = db
 	s.dsRepo = repository.NewDataSourceRepository(db)
 	s.dtypeRepo = repository.NewDataTypeRepository(db)
-	s.dsUC = NewDataSourceUseCase(s.dsRepo)
-	s.dtUC = NewDataTypeUseCase(s.dtypeRepo)
+	s.dsUC = NewDataSourceUseCase(s.dsRepo, testSettingsSchemas)
+	s.dtUC = NewDataTypeUseCase(s.dtypeRepo, s.dsRepo, testSettingsSchemas)
 }
 
 func (s *DataTypeUseCaseTestSuite) TearDownTest() {
@@ -47,7 +49,7 @@ func (s *DataTypeUseCaseTestSuite) TearDownTest() {
 
 var dataTypeResponseCmpOpts = []cmp.Option{
 	cmpopts.IgnoreFields(DataTypeResponse{}, "ID", "DataSourceID", "CreatedAt", "UpdatedAt"),
-	cmp.AllowUnexported(ingestion.Schedule{}),
+	cmp.AllowUnexported(ingestion.Schedule{}, ingestion.RetentionPolicy{}),
 }
 
 func (s *DataTypeUseCaseTestSuite) mustSchedule(sched ingestion.Schedule, err error) ingestion.Schedule {
@@ -55,6 +57,14 @@ func (s *DataTypeUseCaseTestSuite) mustSchedule(sched ingestion.Schedule, err er
 	return sched
 }
 
+func (s *DataTypeUseCaseTestSuite) mustRetention(
+	policy ingestion.RetentionPolicy,
+	err error,
+) ingestion.RetentionPolicy {
+	s.Require().NoError(err)
+	return policy
+}
+
 func (s *DataTypeUseCaseTestSuite) TestCreate() {
 	ctx := context.Background()
 
@@ -95,7 +105,11 @@ func (s *DataTypeUseCaseTestSuite) TestCreate() {
 				Schedule:            s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})),
 				BackfillEnabled:     true,
 				StaleTimeoutMinutes: 30,
+				RerunStrategy:       ingestion.RerunStrategyAppend,
+				EmptyResponsePolicy: ingestion.EmptyResponsePolicySuccess,
+				Compression:         ingestion.CompressionNone,
 				Settings:            map[string]any{},
+				Version:             1,
 			},
 		},
 		{
@@ -133,6 +147,59 @@ func (s *DataTypeUseCaseTestSuite) TestCreate() {
 			},
 			expectErrAs: &ValidationError{},
 		},
+		{
+			name:  "data source not found",
+			setup: func() uuid.UUID { return uuid.Must(uuid.NewV7()) },
+			req: func(id uuid.UUID) *CreateDataTypeRequest {
+				return &CreateDataTypeRequest{
+					DataSourceID: id,
+					Name:         "dt",
+					Enabled:      true,
+					Schedule:     ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+					Settings:     map[string]any{},
+				}
+			},
+			expectErrAs: &ValidationError{},
+		},
+		{
+			name: "settings invalid for source kind",
+			setup: func() uuid.UUID {
+				src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+					Kind:     string(jquants.Kind),
+					Name:     "jquants",
+					Enabled:  true,
+					Timezone: "Asia/Tokyo",
+					Settings: map[string]any{"plan": "free"},
+				})
+				s.Require().NoError(err)
+				return src.ID
+			},
+			req: func(id uuid.UUID) *CreateDataTypeRequest {
+				return &CreateDataTypeRequest{
+					DataSourceID: id,
+					Name:         "daily-quotes",
+					Enabled:      true,
+					Schedule:     ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+					Settings:     map[string]any{"update_frequency": "hourly"},
+				}
+			},
+			expectErrAs: &ValidationError{},
+		},
+		{
+			name:  "invalid rerun strategy",
+			setup: func() uuid.UUID { return uuid.Must(uuid.NewV7()) },
+			req: func(id uuid.UUID) *CreateDataTypeRequest {
+				return &CreateDataTypeRequest{
+					DataSourceID:  id,
+					Name:          "dt",
+					Enabled:       true,
+					Schedule:      ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+					RerunStrategy: "replace",
+					Settings:      map[string]any{},
+				}
+			},
+			expectErrAs: &ValidationError{},
+		},
 		{
 			name:  "invalid schedule type",
 			setup: func() uuid.UUID { return uuid.Must(uuid.NewV7()) },
@@ -201,10 +268,14 @@ func (s *DataTypeUseCaseTestSuite) TestGet() {
 				return dt.ID
 			},
 			expected: &DataTypeResponse{
-				Name:     "dt",
-				Enabled:  true,
-				Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})),
-				Settings: map[string]any{},
+				Name:                "dt",
+				Enabled:             true,
+				Schedule:            s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})),
+				RerunStrategy:       ingestion.RerunStrategyAppend,
+				EmptyResponsePolicy: ingestion.EmptyResponsePolicySuccess,
+				Compression:         ingestion.CompressionNone,
+				Settings:            map[string]any{},
+				Version:             1,
 			},
 		},
 		{
@@ -275,14 +346,18 @@ func (s *DataTypeUseCaseTestSuite) TestListDataTypes() {
 	})
 	s.Require().NoError(err)
 
-	list, err := s.dtUC.List(ctx, src1.ID)
+	list, err := s.dtUC.List(ctx, &ListDataTypesRequest{DataSourceID: &src1.ID})
 
 	s.Require().NoError(err)
 	expected := []*DataTypeResponse{
-		{Name: "dt1", Enabled: true, Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})), Settings: map[string]any{}},
-		{Name: "dt2", Enabled: false, Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"09:00", "12:00"})), Settings: map[string]any{}},
+		{Name: "dt1", Enabled: true, Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})), RerunStrategy: ingestion.RerunStrategyAppend, EmptyResponsePolicy: ingestion.EmptyResponsePolicySuccess, Compression: ingestion.CompressionNone, Settings: map[string]any{}, Version: 1},
+		{Name: "dt2", Enabled: false, Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"09:00", "12:00"})), RerunStrategy: ingestion.RerunStrategyAppend, EmptyResponsePolicy: ingestion.EmptyResponsePolicySuccess, Compression: ingestion.CompressionNone, Settings: map[string]any{}, Version: 1},
 	}
-	s.True(cmp.Equal(expected, list, cmpOpts...), cmp.Diff(expected, list, cmpOpts...))
+	s.True(cmp.Equal(expected, list.Items, cmpOpts...), cmp.Diff(expected, list.Items, cmpOpts...))
+
+	enabledOnly, err := s.dtUC.List(ctx, &ListDataTypesRequest{DataSourceID: &src1.ID, Enabled: lo.ToPtr(true)})
+	s.Require().NoError(err)
+	s.Equal([]string{"dt1"}, lo.Map(enabledOnly.Items, func(r *DataTypeResponse, _ int) string { return r.Name }))
 }
 
 func (s *DataTypeUseCaseTestSuite) TestUpdate() {
@@ -293,7 +368,7 @@ func (s *DataTypeUseCaseTestSuite) TestUpdate() {
 		setup       func() uuid.UUID
 		req         func(id uuid.UUID) *UpdateDataTypeRequest
 		expected    *DataTypeResponse
-		expectErrAs *ValidationError
+		expectErrAs error
 		postCheck   func()
 	}
 	var dtOtherID uuid.UUID
@@ -329,18 +404,27 @@ func (s *DataTypeUseCaseTestSuite) TestUpdate() {
 			},
 			req: func(id uuid.UUID) *UpdateDataTypeRequest {
 				return &UpdateDataTypeRequest{
-					ID:       id,
-					Name:     "dt-updated",
-					Enabled:  false,
-					Schedule: ScheduleInput{Type: "daily", Times: []string{"09:00", "15:00"}},
-					Settings: map[string]any{"x": "y"},
+					ID:                  id,
+					Name:                "dt-updated",
+					Enabled:             false,
+					Schedule:            ScheduleInput{Type: "daily", Times: []string{"09:00", "15:00"}},
+					RerunStrategy:       "overwrite",
+					EmptyResponsePolicy: "retry_later",
+					Compression:         "gzip",
+					Retention:           RetentionInput{KeepLatest: lo.ToPtr(3)},
+					Settings:            map[string]any{"x": "y"},
 				}
 			},
 			expected: &DataTypeResponse{
-				Name:     "dt-updated",
-				Enabled:  false,
-				Schedule: s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"09:00", "15:00"})),
-				Settings: map[string]any{"x": "y"},
+				Name:                "dt-updated",
+				Enabled:             false,
+				Schedule:            s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"09:00", "15:00"})),
+				RerunStrategy:       ingestion.RerunStrategyOverwrite,
+				EmptyResponsePolicy: ingestion.EmptyResponsePolicyRetryLater,
+				Compression:         ingestion.CompressionGzip,
+				Retention:           s.mustRetention(ingestion.NewRetentionPolicy(lo.ToPtr(3), nil)),
+				Settings:            map[string]any{"x": "y"},
+				Version:             2,
 			},
 			postCheck: func() {
 				resp, err := s.dtUC.Get(ctx, dtOtherID)
@@ -401,6 +485,38 @@ func (s *DataTypeUseCaseTestSuite) TestUpdate() {
 			},
 			expectErrAs: &ValidationError{},
 		},
+		{
+			name: "stale If-Match",
+			setup: func() uuid.UUID {
+				src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+					Name:     "src-stale",
+					Enabled:  true,
+					Timezone: "UTC",
+					Settings: map[string]any{},
+				})
+				s.Require().NoError(err)
+				dt, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+					DataSourceID: src.ID,
+					Name:         "dt",
+					Enabled:      true,
+					Schedule:     ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+					Settings:     map[string]any{},
+				})
+				s.Require().NoError(err)
+				return dt.ID
+			},
+			req: func(id uuid.UUID) *UpdateDataTypeRequest {
+				return &UpdateDataTypeRequest{
+					ID:       id,
+					Name:     "dt",
+					Enabled:  false,
+					Schedule: ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+					Settings: map[string]any{},
+					IfMatch:  IfMatchVersion(2),
+				}
+			},
+			expectErrAs: &PreconditionFailedError{},
+		},
 		{
 			name:  "invalid schedule",
 			setup: func() uuid.UUID { return uuid.Must(uuid.NewV7()) },
@@ -438,6 +554,116 @@ func (s *DataTypeUseCaseTestSuite) TestUpdate() {
 	}
 }
 
+func (s *DataTypeUseCaseTestSuite) TestPatch() {
+	ctx := context.Background()
+
+	src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Name:     "src",
+		Enabled:  true,
+		Timezone: "UTC",
+		Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+	dt, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "dt",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+		BackfillEnabled:     true,
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{"endpoint": "/quotes", "page_size": 100.0},
+	})
+	s.Require().NoError(err)
+
+	resp, err := s.dtUC.Patch(ctx, &PatchDataTypeRequest{
+		ID:       dt.ID,
+		Enabled:  lo.ToPtr(false),
+		Settings: map[string]any{"page_size": nil},
+	})
+
+	s.Require().NoError(err)
+	expected := &DataTypeResponse{
+		Name:                "dt",
+		Enabled:             false,
+		Schedule:            s.mustSchedule(ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})),
+		BackfillEnabled:     true,
+		StaleTimeoutMinutes: 30,
+		RerunStrategy:       ingestion.RerunStrategyAppend,
+		EmptyResponsePolicy: ingestion.EmptyResponsePolicySuccess,
+		Compression:         ingestion.CompressionNone,
+		Settings:            map[string]any{"endpoint": "/quotes"},
+		Version:             2,
+	}
+	s.True(cmp.Equal(expected, resp, dataTypeResponseCmpOpts...), cmp.Diff(expected, resp, dataTypeResponseCmpOpts...))
+
+	resp, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{
+		ID:       dt.ID,
+		Schedule: &SchedulePatch{Times: []string{"09:00", "15:00"}},
+	})
+	s.Require().NoError(err)
+	s.Equal(
+		[]ingestion.TimeOfDay{"09:00", "15:00"},
+		resp.Schedule.Times(),
+	)
+
+	resp, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{ID: dt.ID, RerunStrategy: lo.ToPtr("overwrite")})
+	s.Require().NoError(err)
+	s.Equal(ingestion.RerunStrategyOverwrite, resp.RerunStrategy)
+	s.Equal([]ingestion.TimeOfDay{"09:00", "15:00"}, resp.Schedule.Times(), "other fields are kept")
+
+	_, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{ID: dt.ID, RerunStrategy: lo.ToPtr("replace")})
+	s.IsType(&ValidationError{}, err)
+
+	resp, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{ID: dt.ID, EmptyResponsePolicy: lo.ToPtr("fail")})
+	s.Require().NoError(err)
+	s.Equal(ingestion.EmptyResponsePolicyFail, resp.EmptyResponsePolicy)
+	s.Equal(ingestion.RerunStrategyOverwrite, resp.RerunStrategy, "other fields are kept")
+
+	_, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{ID: dt.ID, EmptyResponsePolicy: lo.ToPtr("skip")})
+	s.IsType(&ValidationError{}, err)
+
+	_, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{
+		ID:        dt.ID,
+		Retention: &RetentionPatch{KeepLatest: RulePatch{Set: true, Value: lo.ToPtr(3)}},
+	})
+	s.Require().NoError(err)
+	resp, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{
+		ID:        dt.ID,
+		Retention: &RetentionPatch{SupersededMaxAgeDays: RulePatch{Set: true, Value: lo.ToPtr(30)}},
+	})
+	s.Require().NoError(err)
+	s.Equal(lo.ToPtr(3), resp.Retention.KeepLatest(), "rules missing from the patch are kept")
+	s.Equal(lo.ToPtr(30), resp.Retention.SupersededMaxAgeDays())
+
+	resp, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{
+		ID:        dt.ID,
+		Retention: &RetentionPatch{SupersededMaxAgeDays: RulePatch{Set: true}},
+	})
+	s.Require().NoError(err)
+	s.Equal(lo.ToPtr(3), resp.Retention.KeepLatest())
+	s.Nil(resp.Retention.SupersededMaxAgeDays(), "a null rule is removed")
+
+	_, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{
+		ID:        dt.ID,
+		Retention: &RetentionPatch{KeepLatest: RulePatch{Set: true, Value: lo.ToPtr(0)}},
+	})
+	s.IsType(&ValidationError{}, err)
+
+	resp, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{ID: dt.ID, ClearSettings: true})
+	s.Require().NoError(err)
+	s.Equal(map[string]any{}, resp.Settings, "null settings remove every current setting")
+
+	_, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{ID: dt.ID, Schedule: &SchedulePatch{Times: []string{"25:00"}}})
+	s.IsType(&ValidationError{}, err)
+
+	_, err = s.dtUC.Patch(ctx, &PatchDataTypeRequest{ID: dt.ID, Schedule: &SchedulePatch{Type: lo.ToPtr("weekly")}})
+	s.IsType(&ValidationError{}, err)
+
+	notFound, err := s.dtUC.Patch(ctx, &PatchDataTypeRequest{ID: uuid.Must(uuid.NewV7()), Enabled: lo.ToPtr(true)})
+	s.NoError(err)
+	s.Nil(notFound)
+}
+
 func (s *DataTypeUseCaseTestSuite) TestDeleteDataType() {
 	ctx := context.Background()
 
@@ -465,8 +691,9 @@ func (s *DataTypeUseCaseTestSuite) TestDeleteDataType() {
 	})
 	s.Require().NoError(err)
 
-	err = s.dtUC.Delete(ctx, dt.ID)
+	deleted, err := s.dtUC.Delete(ctx, dt.ID, nil)
 	s.Require().NoError(err)
+	s.True(deleted)
 
 	resp, err := s.dtUC.Get(ctx, dt.ID)
 	s.NoError(err)
@@ -0,0 +1,399 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/domain/jquants"
+	taskusecase "stock-tool/internal/usecase/task"
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/pagination"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// maxTriggerDays caps the number of target dates a single trigger may cover.
+const maxTriggerDays = 366
+
+// Extractor starts and runs extract task executions.
+type Extractor interface {
+	// Supports reports whether the extractor can fetch dataType of source.
+	Supports(source, dataType string) bool
+
+	// Start registers a running execution for the request. Returns an error
+	// wrapping extract.ErrDependenciesNotMet if a dependency of the data type
+	// has no succeeded execution for the target date, and one wrapping
+	// extract.ErrExecutionAlreadyRunning if an execution for the same target
+	// date is already running.
+	Start(ctx context.Context, req *taskusecase.ExtractTaskRequest) (*extract.ExtractTaskExecution, error)
+
+	// Run performs the extraction for an execution returned by Start and
+	// records its final status.
+	Run(
+		ctx context.Context,
+		execution *extract.ExtractTaskExecution,
+		req *taskusecase.ExtractTaskRequest,
+	) (*taskusecase.ExtractTaskResponse, error)
+}
+
+// ExecutionHistoryRepository lists recorded extract task executions.
+type ExecutionHistoryRepository interface {
+	// ListExecutions returns the executions for source and dataType newest
+	// first, with their S3 files. When beforeID is set, only executions with
+	// a lower ID are returned.
+	ListExecutions(
+		ctx context.Context,
+		source string,
+		dataType string,
+		beforeID *int,
+		limit int,
+	) ([]*extract.ExtractTaskExecution, error)
+}
+
+type TriggerExecutionRequest struct {
+	DataTypeID uuid.UUID
+	StartDate  *time.Time
+	EndDate    *time.Time
+}
+
+type TriggerExecutionResponse struct {
+	ExecutionIDs []int
+	// SkippedTargetDates already had a running execution.
+	SkippedTargetDates []time.Time
+	// BlockedTargetDates were not started because a dependency of the data
+	// type had no succeeded execution for them yet.
+	BlockedTargetDates []time.Time
+}
+
+type ListExecutionsRequest struct {
+	DataTypeID uuid.UUID
+	// Limit is the page size; zero means pagination.DefaultLimit.
+	Limit int
+	// Cursor is the NextCursor of the previous page; empty for the first.
+	Cursor string
+}
+
+type ExecutionResponse struct {
+	ID         int
+	TargetDate time.Time
+	Status     string
+	ErrorInfo  *string
+	// ErrorCategory is nil unless the execution failed for a known reason.
+	ErrorCategory *extract.ErrorCategory
+	StartedAt     *time.Time
+	FinishedAt    *time.Time
+	Files         []*ExecutionFileResponse
+}
+
+type ExecutionFileResponse struct {
+	Key string
+	// Metadata is nil for files recorded before metadata was tracked.
+	Metadata *extract.FileMetadata
+	// ManifestKey is empty for files recorded before manifests were written.
+	ManifestKey string
+	// PurgedAt is nil while the object is kept.
+	PurgedAt  *time.Time
+	CreatedAt time.Time
+}
+
+type ExecutionListResponse struct {
+	Items []*ExecutionResponse
+	// NextCursor is nil on the last page.
+	NextCursor *string
+}
+
+type ExecutionUseCase struct {
+	dataTypeRepo   DataTypeRepository
+	dataSourceRepo DataSourceRepository
+	extractor      Extractor
+	historyRepo    ExecutionHistoryRepository
+
+	// runMu serializes background runs; source API clients are not safe for concurrent use.
+	runMu sync.Mutex
+	wg    sync.WaitGroup
+}
+
+func NewExecutionUseCase(
+	dataTypeRepo DataTypeRepository,
+	dataSourceRepo DataSourceRepository,
+	extractor Extractor,
+	historyRepo ExecutionHistoryRepository,
+) *ExecutionUseCase {
+	return &ExecutionUseCase{
+		dataTypeRepo:   dataTypeRepo,
+		dataSourceRepo: dataSourceRepo,
+		extractor:      extractor,
+		historyRepo:    historyRepo,
+	}
+}
+
+// Trigger starts extractions for a data type on demand and runs them in the background.
+//
+// Processing flow:
+//  1. Resolve the data type and its data source, and reject a data type the
+//     extractor cannot fetch
+//  2. Expand the requested date range into target dates in the source timezone
+//     and reject dates the source cannot serve
+//  3. Start a running execution per target date, skipping dates already
+//     running and dates blocked by the dependencies of the data type
+//  4. Run the started executions sequentially in the background
+//
+// Without dates, today in the source timezone is the only target date. Without
+// an end date, only the start date is targeted. A target date is blocked until
+// every data type the data type depends on has a succeeded execution for it or
+// for a date within the dependency's allowed lag (D9).
+//
+// Returns (nil, nil) when the data type is not found, a ValidationError on an
+// unsupported data type, an invalid date range or a target date outside the
+// J-Quants plan of the source, and a ConflictError when no target date could be
+// started.
+func (uc *ExecutionUseCase) Trigger(
+	ctx context.Context,
+	req *TriggerExecutionRequest,
+) (*TriggerExecutionResponse, error) {
+	// 1. Resolve the data type and its data source
+	dt, err := uc.dataTypeRepo.FindByID(ctx, req.DataTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data type: %w", err)
+	}
+	if dt == nil {
+		return nil, nil
+	}
+	src, err := uc.dataSourceRepo.FindByID(ctx, dt.DataSourceID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data source: %w", err)
+	}
+	if src == nil {
+		return nil, nil
+	}
+	if !uc.extractor.Supports(src.Name(), dt.Name()) {
+		return nil, &ValidationError{
+			Message: fmt.Sprintf("extraction of data type %s/%s is not supported", src.Name(), dt.Name()),
+		}
+	}
+
+	// 2. Expand target dates
+	targetDates, err := resolveTargetDates(ctx, req.StartDate, req.EndDate, src.Timezone())
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targetDates {
+		if err := jquants.CheckSourceTargetDate(src, target, clock.Now(ctx)); err != nil {
+			return nil, &ValidationError{Message: err.Error()}
+		}
+	}
+
+	// 3. Start executions
+	resp := &TriggerExecutionResponse{
+		ExecutionIDs:       []int{},
+		SkippedTargetDates: []time.Time{},
+		BlockedTargetDates: []time.Time{},
+	}
+	var started []startedExecution
+	for _, target := range targetDates {
+		extractReq := &taskusecase.ExtractTaskRequest{
+			Source:     src.Name(),
+			DataType:   dt.Name(),
+			Timing:     string(dt.Schedule().Type()),
+			TargetDate: &target,
+			StartDate:  &target,
+		}
+		execution, err := uc.extractor.Start(ctx, extractReq)
+		if errors.Is(err, extract.ErrExecutionAlreadyRunning) {
+			resp.SkippedTargetDates = append(resp.SkippedTargetDates, target)
+			continue
+		}
+		if errors.Is(err, extract.ErrDependenciesNotMet) {
+			resp.BlockedTargetDates = append(resp.BlockedTargetDates, target)
+			continue
+		}
+		if err != nil {
+			// Executions already registered must still run, or they would stay running forever.
+			uc.runInBackground(ctx, started)
+			return nil, fmt.Errorf("failed to start execution: %w", err)
+		}
+		started = append(started, startedExecution{execution: execution, req: extractReq})
+		resp.ExecutionIDs = append(resp.ExecutionIDs, execution.ID())
+	}
+	if len(started) == 0 {
+		var reasons []string
+		if len(resp.SkippedTargetDates) > 0 {
+			reasons = append(reasons, "execution already running for target dates: "+joinDates(resp.SkippedTargetDates))
+		}
+		if len(resp.BlockedTargetDates) > 0 {
+			reasons = append(reasons, "dependencies not met for target dates: "+joinDates(resp.BlockedTargetDates))
+		}
+		return nil, &ConflictError{Message: strings.Join(reasons, "; ")}
+	}
+
+	// 4. Run in the background
+	uc.runInBackground(ctx, started)
+	return resp, nil
+}
+
+// List returns the executions of a data type newest first, with the
+// integrity metadata of the landing files they wrote. Executions are matched
+// to the data type by the names of it and its data source.
+//
+// Returns (nil, nil) when the data type is not found and a ValidationError on
+// an invalid limit or cursor.
+func (uc *ExecutionUseCase) List(ctx context.Context, req *ListExecutionsRequest) (*ExecutionListResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+	if limit < 1 || limit > pagination.MaxLimit {
+		return nil, &ValidationError{
+			Message: fmt.Sprintf("limit must be between 1 and %d", pagination.MaxLimit),
+		}
+	}
+	var beforeID *int
+	if req.Cursor != "" {
+		cursor, err := pagination.DecodeSeqCursor(req.Cursor)
+		if err != nil {
+			return nil, &ValidationError{Message: err.Error()}
+		}
+		beforeID = &cursor.ID
+	}
+
+	dt, err := uc.dataTypeRepo.FindByID(ctx, req.DataTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data type: %w", err)
+	}
+	if dt == nil {
+		return nil, nil
+	}
+	src, err := uc.dataSourceRepo.FindByID(ctx, dt.DataSourceID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data source: %w", err)
+	}
+	if src == nil {
+		return nil, nil
+	}
+
+	// Fetch one extra execution to learn whether another page follows.
+	executions, err := uc.historyRepo.ListExecutions(ctx, src.Name(), dt.Name(), beforeID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list executions: %w", err)
+	}
+	resp := &ExecutionListResponse{}
+	if len(executions) > limit {
+		executions = executions[:limit]
+		resp.NextCursor = lo.ToPtr(pagination.SeqCursor{ID: executions[limit-1].ID()}.Encode())
+	}
+	resp.Items = lo.Map(executions, func(e *extract.ExtractTaskExecution, _ int) *ExecutionResponse {
+		return newExecutionResponse(e, src.Timezone())
+	})
+	return resp, nil
+}
+
+func newExecutionResponse(e *extract.ExtractTaskExecution, loc *time.Location) *ExecutionResponse {
+	return &ExecutionResponse{
+		ID:            e.ID(),
+		TargetDate:    e.TargetDateTime().In(loc),
+		Status:        string(e.Status()),
+		ErrorInfo:     e.ErrorInfo(),
+		ErrorCategory: e.ErrorCategory(),
+		StartedAt:     e.StartedAt(),
+		FinishedAt:    e.FinishedAt(),
+		Files: lo.Map(e.S3Files(), func(f *extract.ExtractedDataS3, _ int) *ExecutionFileResponse {
+			return &ExecutionFileResponse{
+				Key:         f.Key(),
+				Metadata:    f.Metadata(),
+				ManifestKey: f.ManifestKey(),
+				PurgedAt:    f.PurgedAt(),
+				CreatedAt:   f.CreatedAt(),
+			}
+		}),
+	}
+}
+
+// Wait blocks until all background runs started by Trigger have finished.
+func (uc *ExecutionUseCase) Wait() {
+	uc.wg.Wait()
+}
+
+func joinDates(dates []time.Time) string {
+	return strings.Join(lo.Map(dates, func(d time.Time, _ int) string {
+		return d.Format(time.DateOnly)
+	}), ", ")
+}
+
+type startedExecution struct {
+	execution *extract.ExtractTaskExecution
+	req       *taskusecase.ExtractTaskRequest
+}
+
+func (uc *ExecutionUseCase) runInBackground(ctx context.Context, started []startedExecution) {
+	if len(started) == 0 {
+		return
+	}
+	// The runs outlive the HTTP request, so they must not inherit its cancellation.
+	ctx = context.WithoutCancel(ctx)
+	uc.wg.Go(func() {
+		uc.runMu.Lock()
+		defer uc.runMu.Unlock()
+		for _, s := range started {
+			// Run persists failures on the execution itself; the log is for operators only.
+			if _, err := uc.extractor.Run(ctx, s.execution, s.req); err != nil {
+				slog.ErrorContext(
+					ctx,
+					"extract execution failed",
+					"executionID", s.execution.ID(),
+					"error", err,
+				)
+			}
+		}
+	})
+}
+
+func resolveTargetDates(
+	ctx context.Context,
+	startDate *time.Time,
+	endDate *time.Time,
+	loc *time.Location,
+) ([]time.Time, error) {
+	if startDate == nil {
+		if endDate != nil {
+			return nil, &ValidationError{Message: "endDate requires startDate"}
+		}
+		return []time.Time{startOfDay(clock.Now(ctx), loc)}, nil
+	}
+
+	start := dateIn(*startDate, loc)
+	if endDate == nil {
+		return []time.Time{start}, nil
+	}
+	end := dateIn(*endDate, loc)
+	if end.Before(start) {
+		return nil, &ValidationError{Message: "endDate must not be before startDate"}
+	}
+
+	var dates []time.Time
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if len(dates) == maxTriggerDays {
+			return nil, &ValidationError{
+				Message: fmt.Sprintf("date range must not exceed %d days", maxTriggerDays),
+			}
+		}
+		dates = append(dates, d)
+	}
+	return dates, nil
+}
+
+// dateIn interprets the calendar date of d as midnight in loc.
+func dateIn(d time.Time, loc *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
+
+// startOfDay returns midnight of the day t falls on in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	return dateIn(t.In(loc), loc)
+}
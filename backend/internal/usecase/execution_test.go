@@ -0,0 +1,575 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
+	taskusecase "stock-tool/internal/usecase/task"
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/testutil"
+)
+
+// --- Mock (external service only) ---
+
+type BrandDataFetcherMock struct {
+	mock.Mock
+}
+
+// StreamBrands returns the []byte the call was set up with as the body.
+func (m *BrandDataFetcherMock) StreamBrands(
+	ctx context.Context,
+	code *string,
+	date *time.Time,
+) (io.ReadCloser, int, error) {
+	args := m.Called(ctx, code, date)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return io.NopCloser(bytes.NewReader(args.Get(0).([]byte))), args.Int(1), args.Error(2)
+}
+
+func (m *BrandDataFetcherMock) CountRecords(rawBody []byte) (int, error) {
+	args := m.Called(rawBody)
+	return args.Int(0), args.Error(1)
+}
+
+// --- Suite ---
+
+type ExecutionUseCaseTestSuite struct {
+	testutil.DBTest
+	s3Test      testutil.S3Test
+	db          *gorm.DB
+	s3Client    *storage.S3Client
+	dsUC        *DataSourceUseCase
+	dtUC        *DataTypeUseCase
+	dsRepo      *repository.DataSourceRepository
+	dtypeRepo   *repository.DataTypeRepository
+	extractRepo *repository.ExtractTaskRepository
+}
+
+func TestExecutionUseCase(t *testing.T) {
+	suite.Run(t, new(ExecutionUseCaseTestSuite))
+}
+
+func (s *ExecutionUseCaseTestSuite) SetupSuite() {
+	s.DBTest.SetupSuite()
+	s.s3Test.SetT(s.T())
+	s.s3Test.SetupSuite()
+
+	var err error
+	s.s3Client, err = storage.NewS3Client(storage.S3Config{
+		Endpoint:       s.s3Test.Endpoint,
+		Bucket:         testutil.TestS3Bucket,
+		AccessKey:      testutil.TestS3AccessKey,
+		SecretKey:      testutil.TestS3SecretKey,
+		Region:         testutil.TestS3Region,
+		ForcePathStyle: true,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ExecutionUseCaseTestSuite) TearDownSuite() {
+	s.s3Test.TearDownSuite()
+	s.DBTest.TearDownSuite()
+}
+
+func (s *ExecutionUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+
+	s.db = db
+	s.dsRepo = repository.NewDataSourceRepository(db)
+	s.dtypeRepo = repository.NewDataTypeRepository(db)
+	s.extractRepo = repository.NewExtractTaskRepository(db)
+	s.dsUC = NewDataSourceUseCase(s.dsRepo, testSettingsSchemas)
+	s.dtUC = NewDataTypeUseCase(s.dtypeRepo, s.dsRepo, testSettingsSchemas)
+}
+
+func (s *ExecutionUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+func (s *ExecutionUseCaseTestSuite) newUseCase(fetcher taskusecase.BrandDataFetcher) *ExecutionUseCase {
+	extractor := taskusecase.NewExtractTaskUseCase(fetcher, s.s3Client, s.extractRepo, s.dsRepo, s.dtypeRepo)
+	return NewExecutionUseCase(s.dtypeRepo, s.dsRepo, extractor, s.extractRepo)
+}
+
+func (s *ExecutionUseCaseTestSuite) TestTrigger() {
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	s.Require().NoError(err)
+	// 2026-10-16 23:30 UTC is already 2026-10-17 in JST.
+	now := time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC)
+	d := func(day int) time.Time { return time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC) }
+	jstDay := func(day int) time.Time { return time.Date(2026, 10, day, 0, 0, 0, 0, jst) }
+
+	type testCase struct {
+		name            string
+		startDate       *time.Time
+		endDate         *time.Time
+		expectedTargets []time.Time
+	}
+	tests := []testCase{
+		{
+			name:            "defaults to today in source timezone",
+			expectedTargets: []time.Time{jstDay(17)},
+		},
+		{
+			name:            "single start date",
+			startDate:       lo.ToPtr(d(10)),
+			expectedTargets: []time.Time{jstDay(10)},
+		},
+		{
+			name:            "inclusive date range",
+			startDate:       lo.ToPtr(d(10)),
+			endDate:         lo.ToPtr(d(12)),
+			expectedTargets: []time.Time{jstDay(10), jstDay(11), jstDay(12)},
+		},
+	}
+	ctx := clock.WithFixedTime(context.Background(), now)
+	src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Name:     "jquants",
+		Enabled:  true,
+		Timezone: "Asia/Tokyo",
+		Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+	brand, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "brand",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{},
+	})
+	s.Require().NoError(err)
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			fetcher := new(BrandDataFetcherMock)
+			fetcher.On("StreamBrands", mock.Anything, (*string)(nil), mock.Anything).Return([]byte(`{"info":[]}`), 200, nil)
+
+			uc := s.newUseCase(fetcher)
+			resp, err := uc.Trigger(ctx, &TriggerExecutionRequest{
+				DataTypeID: brand.ID,
+				StartDate:  tt.startDate,
+				EndDate:    tt.endDate,
+			})
+			s.Require().NoError(err)
+			uc.Wait()
+
+			var execs []repository.ExtractTaskExecution
+			s.Require().NoError(
+				s.db.Where("id IN ?", resp.ExecutionIDs).Order("target_date_time").Find(&execs).Error,
+			)
+			s.Require().Len(execs, len(tt.expectedTargets))
+			targets := make([]time.Time, 0, len(execs))
+			for i, e := range execs {
+				s.Equal(e.ID, resp.ExecutionIDs[i])
+				s.Equal("succeeded", e.Status)
+				targets = append(targets, e.TargetDateTime)
+			}
+			s.True(cmp.Equal(tt.expectedTargets, targets), cmp.Diff(tt.expectedTargets, targets))
+			s.Empty(resp.SkippedTargetDates)
+			fetcher.AssertNumberOfCalls(s.T(), "StreamBrands", len(tt.expectedTargets))
+		})
+	}
+}
+
+func (s *ExecutionUseCaseTestSuite) TestTrigger_SkipsRunningTargetDates() {
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	s.Require().NoError(err)
+	ctx := context.Background()
+	src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Name:     "jquants",
+		Enabled:  true,
+		Timezone: "Asia/Tokyo",
+		Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+	brand, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "brand",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{},
+	})
+	s.Require().NoError(err)
+
+	// An execution for 2026-10-11 is still running.
+	running := time.Date(2026, 10, 11, 0, 0, 0, 0, jst)
+	extractor := taskusecase.NewExtractTaskUseCase(
+		new(BrandDataFetcherMock),
+		s.s3Client,
+		s.extractRepo,
+		s.dsRepo,
+		s.dtypeRepo,
+	)
+	_, err = extractor.Start(ctx, &taskusecase.ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   "brand",
+		Timing:     "daily",
+		TargetDate: &running,
+	})
+	s.Require().NoError(err)
+
+	fetcher := new(BrandDataFetcherMock)
+	fetcher.On("StreamBrands", mock.Anything, (*string)(nil), mock.Anything).Return([]byte(`{"info":[]}`), 200, nil)
+	uc := s.newUseCase(fetcher)
+
+	start := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	resp, err := uc.Trigger(ctx, &TriggerExecutionRequest{DataTypeID: brand.ID, StartDate: &start, EndDate: &end})
+	s.Require().NoError(err)
+	uc.Wait()
+
+	s.Len(resp.ExecutionIDs, 2)
+	expectedSkipped := []time.Time{running}
+	s.True(cmp.Equal(expectedSkipped, resp.SkippedTargetDates), cmp.Diff(expectedSkipped, resp.SkippedTargetDates))
+	fetcher.AssertNumberOfCalls(s.T(), "StreamBrands", 2)
+
+	// Every target date is already running.
+	_, err = uc.Trigger(ctx, &TriggerExecutionRequest{DataTypeID: brand.ID, StartDate: &running})
+	var ce *ConflictError
+	s.ErrorAs(err, &ce)
+	s.Equal("execution already running for target dates: 2026-10-11", ce.Message)
+}
+
+func (s *ExecutionUseCaseTestSuite) TestTrigger_BlocksUnmetDependencies() {
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	s.Require().NoError(err)
+	ctx := context.Background()
+	src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Name:     "jquants",
+		Enabled:  true,
+		Timezone: "Asia/Tokyo",
+		Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+	brand, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "brand",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{},
+	})
+	s.Require().NoError(err)
+	dailyQuotes, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "daily_quotes",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"16:30"}},
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{},
+	})
+	s.Require().NoError(err)
+	_, err = s.dtUC.ReplaceDependencies(ctx, &ReplaceDependenciesRequest{
+		DataTypeID:   brand.ID,
+		Dependencies: []DependencyInput{{DataTypeID: dailyQuotes.ID}},
+	})
+	s.Require().NoError(err)
+
+	// daily_quotes has only succeeded for 2026-10-10.
+	ready := time.Date(2026, 10, 10, 0, 0, 0, 0, jst)
+	extractor := taskusecase.NewExtractTaskUseCase(
+		new(BrandDataFetcherMock),
+		s.s3Client,
+		s.extractRepo,
+		s.dsRepo,
+		s.dtypeRepo,
+	)
+	execution, err := extractor.Start(ctx, &taskusecase.ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   "daily_quotes",
+		Timing:     "daily",
+		TargetDate: &ready,
+	})
+	s.Require().NoError(err)
+	execution.Succeed(ctx)
+	s.Require().NoError(s.extractRepo.UpdateExecution(ctx, execution))
+
+	fetcher := new(BrandDataFetcherMock)
+	fetcher.On("StreamBrands", mock.Anything, (*string)(nil), mock.Anything).Return([]byte(`{"info":[]}`), 200, nil)
+	uc := s.newUseCase(fetcher)
+
+	start := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC)
+	resp, err := uc.Trigger(ctx, &TriggerExecutionRequest{DataTypeID: brand.ID, StartDate: &start, EndDate: &end})
+	s.Require().NoError(err)
+	uc.Wait()
+
+	s.Len(resp.ExecutionIDs, 1)
+	expectedBlocked := []time.Time{time.Date(2026, 10, 11, 0, 0, 0, 0, jst)}
+	s.True(cmp.Equal(expectedBlocked, resp.BlockedTargetDates), cmp.Diff(expectedBlocked, resp.BlockedTargetDates))
+	fetcher.AssertNumberOfCalls(s.T(), "StreamBrands", 1)
+
+	// Every target date is blocked.
+	_, err = uc.Trigger(ctx, &TriggerExecutionRequest{DataTypeID: brand.ID, StartDate: &end})
+	var ce *ConflictError
+	s.Require().ErrorAs(err, &ce)
+	s.Equal("dependencies not met for target dates: 2026-10-11", ce.Message)
+}
+
+func (s *ExecutionUseCaseTestSuite) TestTrigger_RunFailureIsRecorded() {
+	ctx := context.Background()
+	src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Name:     "jquants",
+		Enabled:  true,
+		Timezone: "Asia/Tokyo",
+		Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+	brand, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "brand",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{},
+	})
+	s.Require().NoError(err)
+
+	fetcher := new(BrandDataFetcherMock)
+	fetcher.On("StreamBrands", mock.Anything, (*string)(nil), mock.Anything).
+		Return(nil, 0, errors.New("API connection timeout"))
+	uc := s.newUseCase(fetcher)
+
+	resp, err := uc.Trigger(ctx, &TriggerExecutionRequest{DataTypeID: brand.ID})
+	s.Require().NoError(err)
+	uc.Wait()
+
+	var execs []repository.ExtractTaskExecution
+	s.Require().NoError(s.db.Where("id IN ?", resp.ExecutionIDs).Find(&execs).Error)
+	s.Require().Len(execs, 1)
+	s.Equal(resp.ExecutionIDs[0], execs[0].ID)
+	s.Equal(string(extract.ExecutionStatusFailed), execs[0].Status)
+}
+
+func (s *ExecutionUseCaseTestSuite) TestList() {
+	ctx := context.Background()
+	src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Name:     "jquants",
+		Enabled:  true,
+		Timezone: "Asia/Tokyo",
+		Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+	brand, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "brand",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{},
+	})
+	s.Require().NoError(err)
+
+	rawBody := []byte(`{"info":[]}`)
+	fetcher := new(BrandDataFetcherMock)
+	fetcher.On("StreamBrands", mock.Anything, (*string)(nil), mock.Anything).Return(rawBody, 200, nil)
+	uc := s.newUseCase(fetcher)
+
+	start := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	triggered, err := uc.Trigger(ctx, &TriggerExecutionRequest{DataTypeID: brand.ID, StartDate: &start, EndDate: &end})
+	s.Require().NoError(err)
+	uc.Wait()
+
+	page1, err := uc.List(ctx, &ListExecutionsRequest{DataTypeID: brand.ID, Limit: 2})
+	s.Require().NoError(err)
+	s.Require().Len(page1.Items, 2)
+	s.Equal(triggered.ExecutionIDs[2], page1.Items[0].ID, "newest first")
+	s.Equal("2026-10-12T00:00:00+09:00", page1.Items[0].TargetDate.Format(time.RFC3339))
+	s.Equal("succeeded", page1.Items[0].Status)
+	s.Require().Len(page1.Items[0].Files, 1)
+	expected := extract.NewFileMetadata(rawBody, extract.FormatJSON, 200)
+	s.Equal(&expected, page1.Items[0].Files[0].Metadata)
+	s.Require().NotNil(page1.NextCursor)
+
+	page2, err := uc.List(ctx, &ListExecutionsRequest{DataTypeID: brand.ID, Limit: 2, Cursor: *page1.NextCursor})
+	s.Require().NoError(err)
+	s.Require().Len(page2.Items, 1)
+	s.Equal(triggered.ExecutionIDs[0], page2.Items[0].ID)
+	s.Nil(page2.NextCursor)
+
+	notFound, err := uc.List(ctx, &ListExecutionsRequest{DataTypeID: uuid.New()})
+	s.NoError(err)
+	s.Nil(notFound)
+
+	_, err = uc.List(ctx, &ListExecutionsRequest{DataTypeID: brand.ID, Limit: 101})
+	var ve *ValidationError
+	s.Require().ErrorAs(err, &ve)
+	s.Equal("limit must be between 1 and 100", ve.Message)
+
+	_, err = uc.List(ctx, &ListExecutionsRequest{DataTypeID: brand.ID, Cursor: "%%%"})
+	s.Require().ErrorAs(err, &ve)
+	s.Contains(ve.Message, "invalid cursor")
+}
+
+func (s *ExecutionUseCaseTestSuite) TestTrigger_Errors() {
+	ctx := context.Background()
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	farEnd := start.AddDate(1, 1, 0)
+	src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Name:     "jquants",
+		Enabled:  true,
+		Timezone: "Asia/Tokyo",
+		Settings: map[string]any{},
+	})
+	s.Require().NoError(err)
+	brand, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "brand",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{},
+	})
+	s.Require().NoError(err)
+	dailyQuotes, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "daily_quotes",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"16:30"}},
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{},
+	})
+	s.Require().NoError(err)
+
+	type testCase struct {
+		name        string
+		dataTypeID  func(id uuid.UUID) uuid.UUID
+		startDate   *time.Time
+		endDate     *time.Time
+		expectNil   bool
+		expectedMsg string
+	}
+	tests := []testCase{
+		{
+			name:       "data type not found",
+			dataTypeID: func(uuid.UUID) uuid.UUID { return uuid.Must(uuid.NewV7()) },
+			expectNil:  true,
+		},
+		{
+			name:        "unsupported data type",
+			dataTypeID:  func(uuid.UUID) uuid.UUID { return dailyQuotes.ID },
+			expectedMsg: "extraction of data type jquants/daily_quotes is not supported",
+		},
+		{
+			name:        "end date without start date",
+			endDate:     &end,
+			expectedMsg: "endDate requires startDate",
+		},
+		{
+			name:        "end date before start date",
+			startDate:   &start,
+			endDate:     &end,
+			expectedMsg: "endDate must not be before startDate",
+		},
+		{
+			name:        "range too long",
+			startDate:   &start,
+			endDate:     &farEnd,
+			expectedMsg: "date range must not exceed 366 days",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			dtID := brand.ID
+			if tt.dataTypeID != nil {
+				dtID = tt.dataTypeID(dtID)
+			}
+
+			uc := s.newUseCase(new(BrandDataFetcherMock))
+			resp, err := uc.Trigger(ctx, &TriggerExecutionRequest{
+				DataTypeID: dtID,
+				StartDate:  tt.startDate,
+				EndDate:    tt.endDate,
+			})
+			s.Nil(resp)
+			if tt.expectNil {
+				s.NoError(err)
+			} else {
+				var ve *ValidationError
+				s.Require().ErrorAs(err, &ve)
+				s.Equal(tt.expectedMsg, ve.Message)
+			}
+		})
+	}
+
+	var execCount int64
+	s.db.Model(&repository.ExtractTaskExecution{}).Count(&execCount)
+	s.Equal(int64(0), execCount)
+}
+
+func (s *ExecutionUseCaseTestSuite) TestTrigger_OutsidePlanRange() {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	ctx := clock.WithFixedTime(context.Background(), now)
+	src, err := s.dsUC.Create(ctx, &CreateDataSourceRequest{
+		Kind:     "jquants",
+		Name:     "jquants",
+		Enabled:  true,
+		Timezone: "Asia/Tokyo",
+		Settings: map[string]any{"plan": "free"},
+	})
+	s.Require().NoError(err)
+	dt, err := s.dtUC.Create(ctx, &CreateDataTypeRequest{
+		DataSourceID:        src.ID,
+		Name:                "brand",
+		Enabled:             true,
+		Schedule:            ScheduleInput{Type: "daily", Times: []string{"18:00"}},
+		StaleTimeoutMinutes: 30,
+		Settings:            map[string]any{},
+	})
+	s.Require().NoError(err)
+
+	tests := []struct {
+		name        string
+		startDate   time.Time
+		expectedMsg string
+	}{
+		{
+			name:      "inside the free plan delay",
+			startDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+			expectedMsg: "target date is outside the plan range: " +
+				"2026-09-01 is not between 2024-10-18 and 2026-07-26 on the free plan",
+		},
+		{
+			name:      "before the historical limit",
+			startDate: time.Date(2024, 10, 17, 0, 0, 0, 0, time.UTC),
+			expectedMsg: "target date is outside the plan range: " +
+				"2024-10-17 is not between 2024-10-18 and 2026-07-26 on the free plan",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			uc := s.newUseCase(new(BrandDataFetcherMock))
+			resp, err := uc.Trigger(ctx, &TriggerExecutionRequest{DataTypeID: dt.ID, StartDate: &tt.startDate})
+			s.Nil(resp)
+			var ve *ValidationError
+			s.Require().ErrorAs(err, &ve)
+			s.Equal(tt.expectedMsg, ve.Message)
+		})
+	}
+
+	var execCount int64
+	s.db.Model(&repository.ExtractTaskExecution{}).Count(&execCount)
+	s.Equal(int64(0), execCount)
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"stock-tool/internal/domain/lineage"
+
+	"github.com/samber/lo"
+)
+
+// LineageRepository finds the processing executions that produced data.
+type LineageRepository interface {
+	// ListProducers returns the succeeded executions with an output reference
+	// matching pattern, newest first. In pattern, '*' matches any sequence of
+	// characters.
+	ListProducers(ctx context.Context, pattern string) ([]*lineage.ProcessingExecution, error)
+}
+
+type TraceLineageRequest struct {
+	// Ref is the output reference to trace, e.g. the key of a bronze partition.
+	Ref string
+}
+
+type ProcessingExecutionResponse struct {
+	ID               int
+	SourceZone       string
+	TargetZone       string
+	SourceIdentifier string
+	TargetIdentifier string
+	InputKeyPatterns []string
+	OutputRefs       []string
+	RecordsRead      int
+	RecordsWritten   int
+	StartedAt        *time.Time
+	FinishedAt       *time.Time
+}
+
+type LineageResponse struct {
+	Ref string
+	// Executions are the executions the output was derived through, the one
+	// that wrote it first and each further one after those it fed.
+	Executions []*ProcessingExecutionResponse
+	// LandingKeys are the inputs read from the landing zone, sorted.
+	LandingKeys []string
+}
+
+type LineageUseCase struct {
+	repo LineageRepository
+}
+
+func NewLineageUseCase(repo LineageRepository) *LineageUseCase {
+	return &LineageUseCase{repo: repo}
+}
+
+// Trace walks back from an output to the landing files it was derived from.
+//
+// Processing flow:
+//  1. Find the latest succeeded execution that wrote the output
+//  2. For each execution reached, collect the inputs it read from the landing
+//     zone, or resolve each of its input key patterns to the latest
+//     succeeded execution that wrote each matching output
+//  3. Repeat step 2 until every path has reached the landing zone
+//
+// Returns (nil, nil) when no succeeded execution wrote the output, and a
+// ValidationError on an empty reference or one containing '*'.
+func (uc *LineageUseCase) Trace(ctx context.Context, req *TraceLineageRequest) (*LineageResponse, error) {
+	if req.Ref == "" {
+		return nil, &ValidationError{Message: "key is required"}
+	}
+	if strings.Contains(req.Ref, "*") {
+		return nil, &ValidationError{Message: "key must not contain '*'"}
+	}
+
+	// 1. Find the execution that wrote the output
+	first, err := uc.producers(ctx, req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(first) == 0 {
+		return nil, nil
+	}
+
+	// 2.-3. Walk back to the landing zone
+	resp := &LineageResponse{Ref: req.Ref, Executions: []*ProcessingExecutionResponse{}}
+	landingKeys := map[string]struct{}{}
+	visited := map[int]struct{}{first[0].ID(): {}}
+	queue := first
+	for len(queue) > 0 {
+		exec := queue[0]
+		queue = queue[1:]
+		resp.Executions = append(resp.Executions, newProcessingExecutionResponse(exec))
+
+		if exec.SourceZone() == lineage.ZoneLanding {
+			for _, pattern := range exec.InputKeyPatterns() {
+				landingKeys[pattern] = struct{}{}
+			}
+			continue
+		}
+		for _, pattern := range exec.InputKeyPatterns() {
+			producers, err := uc.producers(ctx, pattern)
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range producers {
+				if _, ok := visited[p.ID()]; ok {
+					continue
+				}
+				visited[p.ID()] = struct{}{}
+				queue = append(queue, p)
+			}
+		}
+	}
+
+	resp.LandingKeys = lo.Keys(landingKeys)
+	slices.Sort(resp.LandingKeys)
+	return resp, nil
+}
+
+// producers returns the latest succeeded execution that wrote each output
+// matching pattern, newest first. An execution superseded for every output
+// it shares with the pattern is left out.
+func (uc *LineageUseCase) producers(ctx context.Context, pattern string) ([]*lineage.ProcessingExecution, error) {
+	execs, err := uc.repo.ListProducers(ctx, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list producers of %s: %w", pattern, err)
+	}
+	claimed := map[string]struct{}{}
+	var latest []*lineage.ProcessingExecution
+	for _, exec := range execs {
+		current := false
+		for _, ref := range exec.OutputRefs() {
+			if !lineage.MatchKeyPattern(pattern, ref) {
+				continue
+			}
+			if _, ok := claimed[ref]; !ok {
+				claimed[ref] = struct{}{}
+				current = true
+			}
+		}
+		if current {
+			latest = append(latest, exec)
+		}
+	}
+	return latest, nil
+}
+
+func newProcessingExecutionResponse(e *lineage.ProcessingExecution) *ProcessingExecutionResponse {
+	return &ProcessingExecutionResponse{
+		ID:               e.ID(),
+		SourceZone:       string(e.SourceZone()),
+		TargetZone:       string(e.TargetZone()),
+		SourceIdentifier: e.SourceIdentifier(),
+		TargetIdentifier: e.TargetIdentifier(),
+		InputKeyPatterns: e.InputKeyPatterns(),
+		OutputRefs:       e.OutputRefs(),
+		RecordsRead:      e.RecordsRead(),
+		RecordsWritten:   e.RecordsWritten(),
+		StartedAt:        e.StartedAt(),
+		FinishedAt:       e.FinishedAt(),
+	}
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/lineage"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/util/testutil"
+)
+
+type LineageUseCaseTestSuite struct {
+	testutil.DBTest
+	repo *repository.ProcessingExecutionRepository
+	uc   *LineageUseCase
+}
+
+func TestLineageUseCase(t *testing.T) {
+	suite.Run(t, new(LineageUseCaseTestSuite))
+}
+
+func (s *LineageUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+	s.repo = repository.NewProcessingExecutionRepository(db)
+	s.uc = NewLineageUseCase(s.repo)
+}
+
+func (s *LineageUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+// record records a succeeded execution from sourceZone to targetZone.
+func (s *LineageUseCaseTestSuite) record(
+	sourceZone lineage.Zone,
+	targetZone lineage.Zone,
+	inputs []string,
+	outputs []string,
+) *lineage.ProcessingExecution {
+	ctx := context.Background()
+	exec, err := s.repo.Create(ctx, lineage.NewRunningProcessingExecution(
+		ctx, sourceZone, targetZone, "jquants/daily_quotes", "jquants_daily_quotes", inputs,
+	))
+	s.Require().NoError(err)
+	exec.Succeed(ctx, outputs, 1, 1)
+	s.Require().NoError(s.repo.Update(ctx, exec))
+	return exec
+}
+
+func (s *LineageUseCaseTestSuite) TestTrace() {
+	ctx := context.Background()
+	day1 := "bronze/jquants_daily_quotes/date=2025-06-02/data.parquet"
+	day2 := "bronze/jquants_daily_quotes/date=2025-06-03/data.parquet"
+
+	// day 1 converted twice; the second conversion read a re-landed file
+	s.record(lineage.ZoneLanding, lineage.ZoneBronze, []string{"landing/jquants/daily_quotes/a.json"}, []string{day1})
+	reconverted := s.record(lineage.ZoneLanding, lineage.ZoneBronze,
+		[]string{"landing/jquants/daily_quotes/b.json"}, []string{day1})
+	converted := s.record(lineage.ZoneLanding, lineage.ZoneBronze,
+		[]string{"landing/jquants/daily_quotes/c.json"}, []string{day2})
+	silver := s.record(lineage.ZoneBronze, lineage.ZoneSilver,
+		[]string{"bronze/jquants_daily_quotes/*"}, []string{"silver/daily_quotes/snapshot-1"})
+
+	ids := func(resp *LineageResponse) []int {
+		return lo.Map(resp.Executions, func(e *ProcessingExecutionResponse, _ int) int { return e.ID })
+	}
+
+	s.Run("from bronze", func() {
+		resp, err := s.uc.Trace(ctx, &TraceLineageRequest{Ref: day1})
+		s.Require().NoError(err)
+		s.Equal(day1, resp.Ref)
+		s.Equal([]int{reconverted.ID()}, ids(resp))
+		s.Equal([]string{"landing/jquants/daily_quotes/b.json"}, resp.LandingKeys)
+		s.Equal("landing", resp.Executions[0].SourceZone)
+		s.Equal("bronze", resp.Executions[0].TargetZone)
+		s.Equal([]string{day1}, resp.Executions[0].OutputRefs)
+	})
+
+	s.Run("from silver through a pattern", func() {
+		resp, err := s.uc.Trace(ctx, &TraceLineageRequest{Ref: "silver/daily_quotes/snapshot-1"})
+		s.Require().NoError(err)
+		// Superseded conversions are not part of the lineage
+		s.Equal([]int{silver.ID(), converted.ID(), reconverted.ID()}, ids(resp))
+		s.Equal([]string{
+			"landing/jquants/daily_quotes/b.json",
+			"landing/jquants/daily_quotes/c.json",
+		}, resp.LandingKeys)
+	})
+
+	s.Run("unknown output", func() {
+		resp, err := s.uc.Trace(ctx, &TraceLineageRequest{Ref: "bronze/jquants_daily_quotes/date=2025-06-04/data.parquet"})
+		s.Require().NoError(err)
+		s.Nil(resp)
+	})
+}
+
+func (s *LineageUseCaseTestSuite) TestTrace_Validation() {
+	ctx := context.Background()
+
+	_, err := s.uc.Trace(ctx, &TraceLineageRequest{Ref: ""})
+	s.EqualError(err, "key is required")
+
+	_, err = s.uc.Trace(ctx, &TraceLineageRequest{Ref: "bronze/*"})
+	s.EqualError(err, "key must not contain '*'")
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"fmt"
+
+	"stock-tool/internal/util/pagination"
+
+	"github.com/google/uuid"
+)
+
+// PageRequest holds the raw pagination parameters of a list request.
+// Zero values select the first page of DefaultLimit items in ID order.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+	Sort   string
+}
+
+// buildPageParams validates req and returns params that fetch one extra
+// item, so that trimPage can tell whether a next page exists.
+func buildPageParams(req PageRequest) (pagination.Params, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+	if limit < 1 || limit > pagination.MaxLimit {
+		return pagination.Params{}, &ValidationError{
+			Message: fmt.Sprintf("limit must be between 1 and %d", pagination.MaxLimit),
+		}
+	}
+
+	sort, err := pagination.ParseSort(req.Sort)
+	if err != nil {
+		return pagination.Params{}, &ValidationError{Message: err.Error()}
+	}
+
+	params := pagination.Params{Limit: limit + 1, Sort: sort}
+	if req.Cursor != "" {
+		cursor, err := pagination.DecodeCursor(req.Cursor)
+		if err != nil {
+			return pagination.Params{}, &ValidationError{Message: err.Error()}
+		}
+		if cursor.Sort != sort {
+			return pagination.Params{}, &ValidationError{
+				Message: fmt.Sprintf("cursor was issued for sort %q, not %q", cursor.Sort, sort),
+			}
+		}
+		params.After = &cursor
+	}
+	return params, nil
+}
+
+// trimPage drops the extra item fetched by buildPageParams and returns the
+// cursor for the next page, or nil on the last page. key returns the ID and
+// name of an item.
+func trimPage[T any](
+	items []T,
+	params pagination.Params,
+	key func(T) (uuid.UUID, string),
+) ([]T, *string) {
+	limit := params.Limit - 1
+	if len(items) <= limit {
+		return items, nil
+	}
+	items = items[:limit]
+	id, name := key(items[limit-1])
+	cursor := pagination.Cursor{Sort: params.Sort, ID: id}
+	if params.Sort.Field == pagination.SortFieldName {
+		cursor.Name = name
+	}
+	next := cursor.Encode()
+	return items, &next
+}
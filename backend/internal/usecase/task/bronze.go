@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"stock-tool/internal/domain/bronze"
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/domain/lineage"
+	"stock-tool/internal/util/clock"
+)
+
+// BronzeConverter converts landing files to Parquet.
+type BronzeConverter interface {
+	// Convert decodes the landing files of source and dataType read from rs,
+	// oldest first, and writes their records to w as one Parquet file, a
+	// record of a later file replacing the same record of an earlier one.
+	// Returns the number of rows.
+	Convert(source string, dataType string, rs []io.Reader, w io.Writer) (int, error)
+}
+
+// BronzeObjectStore reads landing files and writes bronze partitions.
+type BronzeObjectStore interface {
+	// GetObject opens the content of the object under key for reading, or
+	// returns (nil, nil) if it does not exist. The caller must close it.
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+	// PutObject stores data under key and returns its version ID.
+	PutObject(
+		ctx context.Context,
+		key string,
+		data []byte,
+		contentType string,
+		metadata map[string]string,
+	) (string, error)
+}
+
+// CurrentFileFinder finds the landing files holding the current data of a
+// target date.
+type CurrentFileFinder interface {
+	// FindCurrentFiles returns, for each window of the calendar date of
+	// targetDate, the latest file of the latest succeeded execution that
+	// fetched it, oldest first. Returns an empty slice if there is none.
+	FindCurrentFiles(
+		ctx context.Context,
+		source string,
+		dataType string,
+		targetDate time.Time,
+	) ([]*extract.ExtractedDataS3, error)
+}
+
+// ProcessingExecutionRepository records the processing executions that
+// convert landing files, for lineage.
+type ProcessingExecutionRepository interface {
+	// Create registers a new execution and returns it with its ID.
+	Create(ctx context.Context, exec *lineage.ProcessingExecution) (*lineage.ProcessingExecution, error)
+	// Update persists status changes to an existing execution.
+	Update(ctx context.Context, exec *lineage.ProcessingExecution) error
+}
+
+// parquetContentType is the content type of bronze partitions.
+const parquetContentType = "application/vnd.apache.parquet"
+
+type BronzeRequest struct {
+	Source   string
+	DataType string
+	// StartDate and EndDate bound the target dates to convert, both
+	// inclusive. Their calendar dates are read in their own location, which
+	// should be the source timezone.
+	StartDate time.Time
+	EndDate   time.Time
+	// Force converts partitions that are already up to date.
+	Force bool
+}
+
+// BronzePartition is a partition written by a conversion.
+type BronzePartition struct {
+	Date time.Time
+	Key  string
+	Rows int
+	// ExecutionID is the ID of the processing execution that wrote it.
+	ExecutionID int
+	// LandingKeys are the landing files the partition was converted from.
+	LandingKeys []string
+}
+
+type BronzeReport struct {
+	Converted []BronzePartition
+	// UpToDate is the number of partitions already converted from the
+	// current landing files.
+	UpToDate int
+	// Missing are the target dates without any current landing file.
+	Missing []time.Time
+}
+
+type BronzeUseCase struct {
+	objects    BronzeObjectStore
+	files      CurrentFileFinder
+	converter  BronzeConverter
+	executions ProcessingExecutionRepository
+}
+
+func NewBronzeUseCase(
+	objects BronzeObjectStore,
+	files CurrentFileFinder,
+	converter BronzeConverter,
+	executions ProcessingExecutionRepository,
+) *BronzeUseCase {
+	return &BronzeUseCase{
+		objects:    objects,
+		files:      files,
+		converter:  converter,
+		executions: executions,
+	}
+}
+
+// Convert converts the current landing files of each target date in the
+// request to a Parquet partition of the bronze table of the data type.
+//
+// A date fetched in several windows, such as the whole date and then a
+// single code, has a current file for each. They are merged into one
+// partition, where a record of a newer file replaces the record of the same
+// date and code from an older one.
+//
+// Processing flow, for each target date:
+//  1. Find the current landing files of the date
+//  2. Skip the date if its bronze manifest lists exactly those files
+//  3. Record a running processing execution reading the landing files
+//  4. Read the landing files and convert them to Parquet
+//  5. Write the partition, replacing any earlier conversion
+//  6. Write the manifest recording the landing files consumed
+//  7. Mark the execution succeeded with the partition as its output
+//
+// A partition is written before its manifest, so a manifest never lists
+// inputs its partition was not converted from; an interrupted run converts
+// the date again. A failed conversion is recorded as a failed execution.
+func (uc *BronzeUseCase) Convert(ctx context.Context, req *BronzeRequest) (*BronzeReport, error) {
+	if req.EndDate.Before(req.StartDate) {
+		return nil, fmt.Errorf("end date %s is before start date %s",
+			req.EndDate.Format("2006-01-02"), req.StartDate.Format("2006-01-02"))
+	}
+	report := &BronzeReport{Converted: []BronzePartition{}, Missing: []time.Time{}}
+
+	start := req.StartDate
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for ; !day.After(req.EndDate); day = day.AddDate(0, 0, 1) {
+		// 1. Find the current landing files
+		files, err := uc.files.FindCurrentFiles(ctx, req.Source, req.DataType, day)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find landing files of %s: %w", day.Format("2006-01-02"), err)
+		}
+		if len(files) == 0 {
+			report.Missing = append(report.Missing, day)
+			continue
+		}
+		inputs := make([]bronze.Input, 0, len(files))
+		for _, f := range files {
+			inputs = append(inputs, bronze.NewInput(f))
+		}
+
+		// 2. Skip up to date partitions
+		if !req.Force {
+			upToDate, err := uc.upToDate(ctx, req, day, inputs)
+			if err != nil {
+				return nil, err
+			}
+			if upToDate {
+				report.UpToDate++
+				continue
+			}
+		}
+
+		// 3.-7. Convert and record
+		partition, err := uc.convertPartition(ctx, req, day, files, inputs)
+		if err != nil {
+			return nil, err
+		}
+		report.Converted = append(report.Converted, *partition)
+	}
+
+	return report, nil
+}
+
+// upToDate reports whether the partition of day was converted from inputs.
+func (uc *BronzeUseCase) upToDate(
+	ctx context.Context,
+	req *BronzeRequest,
+	day time.Time,
+	inputs []bronze.Input,
+) (bool, error) {
+	key := bronze.GenerateManifestKey(req.Source, req.DataType, day)
+	body, err := uc.objects.GetObject(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to read bronze manifest %s: %w", key, err)
+	}
+	if body == nil {
+		return false, nil
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return false, fmt.Errorf("failed to read bronze manifest %s: %w", key, err)
+	}
+	manifest, err := bronze.ParseManifest(data)
+	if err != nil {
+		// An unreadable manifest is replaced by converting again
+		return false, nil
+	}
+	return manifest.Consumed(inputs), nil
+}
+
+func (uc *BronzeUseCase) convertPartition(
+	ctx context.Context,
+	req *BronzeRequest,
+	day time.Time,
+	files []*extract.ExtractedDataS3,
+	inputs []bronze.Input,
+) (*BronzePartition, error) {
+	// 3. Record a running execution
+	landingKeys := make([]string, 0, len(inputs))
+	for _, in := range inputs {
+		landingKeys = append(landingKeys, in.Key)
+	}
+	execution, err := uc.executions.Create(ctx, lineage.NewRunningProcessingExecution(
+		ctx,
+		lineage.ZoneLanding,
+		lineage.ZoneBronze,
+		req.Source+"/"+req.DataType,
+		bronze.TableName(req.Source, req.DataType),
+		landingKeys,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record processing execution: %w", err)
+	}
+
+	// 4.-6. Convert and write
+	key, rows, err := uc.writePartition(ctx, req, day, files, inputs)
+	if err != nil {
+		execution.Fail(ctx, err.Error())
+		if updateErr := uc.executions.Update(ctx, execution); updateErr != nil {
+			return nil, fmt.Errorf(
+				"failed to update processing execution status after error: %w (original: %w)",
+				updateErr, err,
+			)
+		}
+		return nil, err
+	}
+
+	// 7. Mark the execution succeeded
+	execution.Succeed(ctx, []string{key}, rows, rows)
+	if err := uc.executions.Update(ctx, execution); err != nil {
+		return nil, fmt.Errorf("failed to update processing execution status: %w", err)
+	}
+
+	return &BronzePartition{Date: day, Key: key, Rows: rows, ExecutionID: execution.ID(), LandingKeys: landingKeys}, nil
+}
+
+// writePartition converts files and writes the partition of day and its
+// manifest. Returns the key of the partition and the number of rows.
+func (uc *BronzeUseCase) writePartition(
+	ctx context.Context,
+	req *BronzeRequest,
+	day time.Time,
+	files []*extract.ExtractedDataS3,
+	inputs []bronze.Input,
+) (string, int, error) {
+	// 4. Read and convert
+	bodies := make([]io.Reader, 0, len(files))
+	for _, file := range files {
+		body, err := uc.objects.GetObject(ctx, file.Key())
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to read landing file %s: %w", file.Key(), err)
+		}
+		if body == nil {
+			return "", 0, fmt.Errorf("landing file %s is recorded but missing from storage", file.Key())
+		}
+		defer body.Close()
+		bodies = append(bodies, body)
+	}
+	var out bytes.Buffer
+	rows, err := uc.converter.Convert(req.Source, req.DataType, bodies, &out)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to convert landing files of %s: %w", day.Format("2006-01-02"), err)
+	}
+
+	// 5. Write the partition
+	key := bronze.GeneratePartitionKey(req.Source, req.DataType, day)
+	if _, err := uc.objects.PutObject(ctx, key, out.Bytes(), parquetContentType, nil); err != nil {
+		return "", 0, fmt.Errorf("failed to write %s: %w", key, err)
+	}
+
+	// 6. Write the manifest
+	manifest := bronze.NewManifest(req.Source, req.DataType, day, rows, inputs, clock.Now(ctx))
+	data, err := manifest.Marshal()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to encode bronze manifest: %w", err)
+	}
+	manifestKey := bronze.GenerateManifestKey(req.Source, req.DataType, day)
+	if _, err := uc.objects.PutObject(ctx, manifestKey, data, "application/json", nil); err != nil {
+		return "", 0, fmt.Errorf("failed to write %s: %w", manifestKey, err)
+	}
+	return key, rows, nil
+}
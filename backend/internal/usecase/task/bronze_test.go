@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/bronze"
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/domain/lineage"
+	infrabronze "stock-tool/internal/infra/bronze"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/testutil"
+)
+
+type BronzeUseCaseTestSuite struct {
+	testutil.DBTest
+	repo       *repository.ExtractTaskRepository
+	executions *repository.ProcessingExecutionRepository
+	objects    *storage.FSClient
+	uc         *BronzeUseCase
+}
+
+func TestBronzeUseCase(t *testing.T) {
+	suite.Run(t, new(BronzeUseCaseTestSuite))
+}
+
+func (s *BronzeUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+	s.repo = repository.NewExtractTaskRepository(db)
+	s.executions = repository.NewProcessingExecutionRepository(db)
+	s.objects = storage.NewFSClient(s.T().TempDir())
+	s.uc = NewBronzeUseCase(s.objects, s.repo, infrabronze.NewConverter(), s.executions)
+}
+
+func (s *BronzeUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+// land records a succeeded execution of brand for target that wrote body to
+// key for window, and stores the object.
+func (s *BronzeUseCaseTestSuite) land(target time.Time, key string, window string, body string) {
+	ctx := context.Background()
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+	s.Require().NoError(err)
+	if task == nil {
+		s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", "brand", "daily")))
+		task, err = s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+		s.Require().NoError(err)
+	}
+	exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, target))
+	s.Require().NoError(err)
+	md := extract.NewFileMetadata([]byte(body), extract.FormatJSON, 200)
+	file := extract.NewExtractedDataS3(ctx, key, window, md, extract.ObjectVersion{}, "")
+	_, err = s.repo.CreateExtractedDataS3(ctx, exec.ID(), file)
+	s.Require().NoError(err)
+	exec.Succeed(ctx)
+	s.Require().NoError(s.repo.UpdateExecution(ctx, exec))
+	_, err = s.objects.PutObject(ctx, key, []byte(body), "application/json", md.ObjectMetadata())
+	s.Require().NoError(err)
+}
+
+func (s *BronzeUseCaseTestSuite) manifest(day time.Time) *bronze.Manifest {
+	body, err := s.objects.GetObject(context.Background(), bronze.GenerateManifestKey("jquants", "brand", day))
+	s.Require().NoError(err)
+	s.Require().NotNil(body)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	s.Require().NoError(err)
+	manifest, err := bronze.ParseManifest(data)
+	s.Require().NoError(err)
+	return manifest
+}
+
+func (s *BronzeUseCaseTestSuite) TestConvert() {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	ctx := clock.WithFixedTime(context.Background(), now)
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	day1 := time.Date(2025, 6, 2, 0, 0, 0, 0, jst)
+	day2 := day1.AddDate(0, 0, 1)
+	day3 := day1.AddDate(0, 0, 2)
+
+	first := extract.GenerateOverwriteS3Key("jquants", "brand", day1, "all", "json")
+	s.land(day1, first, "all", `{"info":[
+		{"Date":"2025-06-02","Code":"86970","CompanyName":"日本取引所グループ"},
+		{"Date":"2025-06-02","Code":"13010","CompanyName":"極洋"}
+	]}`)
+	second := extract.GenerateOverwriteS3Key("jquants", "brand", day3, "all", "json")
+	s.land(day3, second, "all", `{"info":[{"Date":"2025-06-04","Code":"86970","CompanyName":"日本取引所グループ"}]}`)
+
+	req := &BronzeRequest{Source: "jquants", DataType: "brand", StartDate: day1, EndDate: day3}
+
+	s.Run("convert", func() {
+		report, err := s.uc.Convert(ctx, req)
+		s.Require().NoError(err)
+		s.Require().Len(report.Converted, 2)
+		s.Equal(&BronzeReport{
+			Converted: []BronzePartition{
+				{
+					Date:        day1,
+					Key:         "bronze/jquants_brand/date=2025-06-02/data.parquet",
+					Rows:        2,
+					ExecutionID: report.Converted[0].ExecutionID,
+					LandingKeys: []string{first},
+				},
+				{
+					Date:        day3,
+					Key:         "bronze/jquants_brand/date=2025-06-04/data.parquet",
+					Rows:        1,
+					ExecutionID: report.Converted[1].ExecutionID,
+					LandingKeys: []string{second},
+				},
+			},
+			Missing: []time.Time{day2},
+		}, report)
+
+		// Each partition is recorded as the output of a processing execution
+		producers, err := s.executions.ListProducers(ctx, report.Converted[0].Key)
+		s.Require().NoError(err)
+		s.Require().Len(producers, 1)
+		s.Equal(report.Converted[0].ExecutionID, producers[0].ID())
+		s.Equal(lineage.ZoneLanding, producers[0].SourceZone())
+		s.Equal(lineage.ZoneBronze, producers[0].TargetZone())
+		s.Equal("jquants/brand", producers[0].SourceIdentifier())
+		s.Equal("jquants_brand", producers[0].TargetIdentifier())
+		s.Equal([]string{first}, producers[0].InputKeyPatterns())
+		s.Equal(2, producers[0].RecordsRead())
+		s.Equal(2, producers[0].RecordsWritten())
+
+		info, err := s.objects.HeadObject(ctx, report.Converted[0].Key)
+		s.Require().NoError(err)
+		s.Require().NotNil(info)
+
+		manifest := s.manifest(day1)
+		s.Equal(2, manifest.Rows)
+		s.Equal(now, manifest.ConvertedAt.UTC())
+		s.Require().Len(manifest.Inputs, 1)
+		s.Equal(first, manifest.Inputs[0].Key)
+		s.NotEmpty(manifest.Inputs[0].SHA256)
+	})
+
+	s.Run("up to date", func() {
+		report, err := s.uc.Convert(ctx, req)
+		s.Require().NoError(err)
+		s.Equal(&BronzeReport{Converted: []BronzePartition{}, UpToDate: 2, Missing: []time.Time{day2}}, report)
+	})
+
+	s.Run("new landing file", func() {
+		rerun := extract.GenerateS3Key("jquants", "brand", now, "json")
+		s.land(day1, rerun, "all", `{"info":[{"Date":"2025-06-02","Code":"86970","CompanyName":"日本取引所グループ"}]}`)
+
+		report, err := s.uc.Convert(ctx, req)
+		s.Require().NoError(err)
+		s.Require().Len(report.Converted, 1)
+		s.Equal(day1, report.Converted[0].Date)
+		s.Equal(1, report.Converted[0].Rows)
+		s.Equal([]string{rerun}, report.Converted[0].LandingKeys)
+		s.Equal(1, report.UpToDate)
+		s.Equal(rerun, s.manifest(day1).Inputs[0].Key)
+	})
+
+	s.Run("new window", func() {
+		code := extract.GenerateOverwriteS3Key("jquants", "brand", day3, "code-13010", "json")
+		s.land(day3, code, "code-13010", `{"info":[{"Date":"2025-06-04","Code":"13010","CompanyName":"極洋"}]}`)
+
+		// Every current file of the date is converted into the partition
+		report, err := s.uc.Convert(ctx, req)
+		s.Require().NoError(err)
+		s.Require().Len(report.Converted, 1)
+		s.Equal(day3, report.Converted[0].Date)
+		s.Equal(2, report.Converted[0].Rows)
+		s.Equal([]string{second, code}, report.Converted[0].LandingKeys)
+		s.Equal(1, report.UpToDate)
+		inputs := s.manifest(day3).Inputs
+		s.Require().Len(inputs, 2)
+		s.Equal(second, inputs[0].Key)
+		s.Equal(code, inputs[1].Key)
+	})
+
+	s.Run("force", func() {
+		force := *req
+		force.Force = true
+		report, err := s.uc.Convert(ctx, &force)
+		s.Require().NoError(err)
+		s.Len(report.Converted, 2)
+		s.Zero(report.UpToDate)
+	})
+}
+
+func (s *BronzeUseCaseTestSuite) TestConvertErrors() {
+	ctx := context.Background()
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	_, err := s.uc.Convert(ctx, &BronzeRequest{
+		Source: "jquants", DataType: "brand", StartDate: day, EndDate: day.AddDate(0, 0, -1),
+	})
+	s.EqualError(err, "end date 2025-06-01 is before start date 2025-06-02")
+
+	// A recorded file missing from storage
+	key := extract.GenerateOverwriteS3Key("jquants", "brand", day, "all", "json")
+	s.land(day, key, "all", `{"info":[]}`)
+	s.Require().NoError(s.objects.DeleteObject(ctx, key))
+	_, err = s.uc.Convert(ctx, &BronzeRequest{Source: "jquants", DataType: "brand", StartDate: day, EndDate: day})
+	s.EqualError(err, "landing file "+key+" is recorded but missing from storage")
+
+	// The failed execution produced nothing
+	producers, err := s.executions.ListProducers(ctx, "bronze/*")
+	s.Require().NoError(err)
+	s.Empty(producers)
+}
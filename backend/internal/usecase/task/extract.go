@@ -1,22 +1,48 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/domain/jquants"
 	"stock-tool/internal/util/clock"
+
+	"github.com/google/uuid"
 )
 
 // BrandDataFetcher fetches raw brand data from an external API.
 type BrandDataFetcher interface {
-	FetchBrands(ctx context.Context, code *string, date *time.Time) (rawBody []byte, err error)
+	// StreamBrands returns the response body unread. The caller must close it.
+	StreamBrands(ctx context.Context, code *string, date *time.Time) (body io.ReadCloser, statusCode int, err error)
+	// CountRecords returns the number of records in a body returned by StreamBrands.
+	CountRecords(rawBody []byte) (int, error)
 }
 
 // ObjectWriter writes data to object storage.
 type ObjectWriter interface {
-	PutObject(ctx context.Context, key string, data []byte) error
+	// PutObjectStream stores everything read from body under key with the
+	// given content type, content encoding of an already compressed body and
+	// user-defined object metadata, without holding it in memory. Returns the
+	// version ID of the written object, or an empty string if the bucket is
+	// not versioned.
+	PutObjectStream(
+		ctx context.Context,
+		key string,
+		body io.Reader,
+		contentType string,
+		contentEncoding extract.Encoding,
+		metadata map[string]string,
+	) (string, error)
+
+	// HeadObject returns what storage reports about the object under key,
+	// or (nil, nil) if it does not exist.
+	HeadObject(ctx context.Context, key string) (*extract.ObjectInfo, error)
 }
 
 // ExtractTaskRepository provides persistence for extract task entities and their executions.
@@ -41,6 +67,14 @@ type ExtractTaskRepository interface {
 		exec *extract.ExtractTaskExecution,
 	) (*extract.ExtractTaskExecution, error)
 
+	// FindRunningExecution returns the running execution of the task with
+	// the given ID for targetDateTime, or (nil, nil) if there is none.
+	FindRunningExecution(
+		ctx context.Context,
+		taskID int,
+		targetDateTime time.Time,
+	) (*extract.ExtractTaskExecution, error)
+
 	// UpdateExecution persists status changes to an existing execution.
 	UpdateExecution(ctx context.Context, exec *extract.ExtractTaskExecution) error
 
@@ -50,57 +84,200 @@ type ExtractTaskRepository interface {
 		executionID int,
 		s3File *extract.ExtractedDataS3,
 	) (*extract.ExtractedDataS3, error)
+
+	// HasSucceededExecution reports whether source and dataType have a
+	// succeeded execution whose target date time is in [from, to).
+	HasSucceededExecution(ctx context.Context, source string, dataType string, from, to time.Time) (bool, error)
+}
+
+// DataSourceRepository finds the data source an extraction is configured under.
+type DataSourceRepository interface {
+	// FindByID returns the DataSource with the given ID, or (nil, nil) if
+	// not found.
+	FindByID(ctx context.Context, id uuid.UUID) (*ingestion.DataSource, error)
+
+	// FindByName returns the DataSource with the given name, or (nil, nil)
+	// if not found.
+	FindByName(ctx context.Context, name string) (*ingestion.DataSource, error)
 }
 
+// DataTypeRepository finds the configuration of the data type an extraction
+// is for.
+type DataTypeRepository interface {
+	// FindByID returns the DataType with the given ID, or (nil, nil) if not
+	// found.
+	FindByID(ctx context.Context, id uuid.UUID) (*ingestion.DataType, error)
+
+	// FindByName returns the DataType with the given name under the data
+	// source with the given ID, or (nil, nil) if not found.
+	FindByName(ctx context.Context, dataSourceID uuid.UUID, name string) (*ingestion.DataType, error)
+
+	// ListDependencies returns the dependencies of the DataType with the
+	// given ID.
+	ListDependencies(ctx context.Context, id uuid.UUID) ([]ingestion.Dependency, error)
+}
+
+// defaultStreamThreshold is the largest response body held in memory. Larger
+// bodies are streamed to S3 as they are read.
+const defaultStreamThreshold int64 = 8 << 20
+
 type ExtractTaskUseCase struct {
-	brandFetcher BrandDataFetcher
-	objectWriter ObjectWriter
-	repo         ExtractTaskRepository
+	brandFetcher    BrandDataFetcher
+	objectWriter    ObjectWriter
+	repo            ExtractTaskRepository
+	dataSourceRepo  DataSourceRepository
+	dataTypeRepo    DataTypeRepository
+	streamThreshold int64
 }
 
 func NewExtractTaskUseCase(
 	brandFetcher BrandDataFetcher,
 	objectWriter ObjectWriter,
 	repo ExtractTaskRepository,
+	dataSourceRepo DataSourceRepository,
+	dataTypeRepo DataTypeRepository,
 ) *ExtractTaskUseCase {
 	return &ExtractTaskUseCase{
-		brandFetcher: brandFetcher,
-		objectWriter: objectWriter,
-		repo:         repo,
+		brandFetcher:    brandFetcher,
+		objectWriter:    objectWriter,
+		repo:            repo,
+		dataSourceRepo:  dataSourceRepo,
+		dataTypeRepo:    dataTypeRepo,
+		streamThreshold: defaultStreamThreshold,
 	}
 }
 
 // Extract fetches raw data from a source API and stores it in S3.
 //
 // Processing flow:
-//  1. Find or create ExtractTask for (source, dataType, timing)
-//  2. Create a running ExtractTaskExecution
-//  3. Fetch raw data from the source API
-//  4. Upload raw data to S3
-//  5. Record S3 key in ExtractedDataS3
-//  6. Mark execution as succeeded
-//
-// On failure at steps 3-5, the execution is marked as failed before
-// returning the error.
+//  1. Start a running execution (see Start)
+//  2. Run the extraction for that execution (see Run)
 //
 // See doc/spec/data-ingestion/usecase/ingest-data.md for requirements.
 func (uc *ExtractTaskUseCase) Extract(ctx context.Context, req *ExtractTaskRequest) (*ExtractTaskResponse, error) {
-	// 1. Find-or-create the ExtractTask
+	execution, err := uc.Start(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return uc.Run(ctx, execution, req)
+}
+
+// Start registers a running execution for the request without fetching any data.
+//
+// Processing flow:
+//  1. Resolve the configured data type of the request (see resolveConfig)
+//  2. Check the dependencies of the data type for the target date
+//     (see checkDependencies)
+//  3. Find or create ExtractTask for (source, dataType, timing)
+//  4. Fail a running execution of the task for the target date that has
+//     exceeded the stale timeout of the data type (FR-8), releasing its
+//     exclusion, then create a running ExtractTaskExecution for the date
+//  5. Fail the execution when the target date is outside the plan range of
+//     the source (see jquants.CheckSourceTargetDate)
+//
+// Returns an error wrapping extract.ErrDependenciesNotMet if a dependency has
+// no succeeded execution for the target date, and one wrapping
+// extract.ErrExecutionAlreadyRunning if an execution for the same task and
+// target date is running and not stale. No execution is created in either
+// case.
+// A target date outside the plan range is recorded as a failed execution with
+// extract.ErrorCategoryOutOfPlan, and the returned error wraps
+// jquants.ErrTargetDateOutOfPlanRange.
+func (uc *ExtractTaskUseCase) Start(
+	ctx context.Context,
+	req *ExtractTaskRequest,
+) (*extract.ExtractTaskExecution, error) {
+	// 1. Resolve the configured data type
+	config, err := uc.resolveConfig(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Check dependencies
+	targetDate := clock.Now(ctx)
+	if req.TargetDate != nil {
+		targetDate = *req.TargetDate
+	}
+	if err := uc.checkDependencies(ctx, config, targetDate); err != nil {
+		return nil, err
+	}
+	planErr := jquants.CheckSourceTargetDate(config.source, targetDate, clock.Now(ctx))
+	if planErr != nil && !errors.Is(planErr, jquants.ErrTargetDateOutOfPlanRange) {
+		return nil, planErr
+	}
+
+	// 3. Find-or-create the ExtractTask
 	task, err := uc.findOrCreateTask(ctx, req.Source, req.DataType, req.Timing)
 	if err != nil {
 		return nil, err
 	}
 
-	// 2. Create a running execution
-	now := clock.Now(ctx)
-	execution := extract.NewRunningExecution(ctx, now)
+	// 4. Recover a stale execution and create a running execution
+	if err := uc.failStaleExecution(ctx, task.ID(), targetDate, config.dataType.StaleTimeout()); err != nil {
+		return nil, err
+	}
+	execution := extract.NewRunningExecution(ctx, targetDate)
 	execution, err = uc.repo.CreateExecution(ctx, task.ID(), execution)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create execution: %w", err)
 	}
 
-	// 3. Fetch raw data from API
-	rawBody, err := uc.fetchRawData(ctx, req)
+	// 5. Fail a target date outside the plan range
+	if planErr != nil {
+		execution.FailWithCategory(ctx, extract.ErrorCategoryOutOfPlan, planErr.Error())
+		if updateErr := uc.repo.UpdateExecution(ctx, execution); updateErr != nil {
+			return nil, fmt.Errorf(
+				"failed to update execution status after error: %w (original: %w)",
+				updateErr, planErr,
+			)
+		}
+		return nil, planErr
+	}
+	return execution, nil
+}
+
+// Run fetches raw data for a running execution created by Start and stores it in S3.
+//
+// Processing flow:
+//  1. Resolve the configured data type of the request, whose re-run
+//     strategy, empty response policy and compression apply to the run
+//  2. Fetch raw data from the source API, reading at most streamThreshold
+//     bytes of the body into memory
+//  3. Apply the empty response policy
+//  4. Compute the file metadata (size, SHA-256, format, HTTP status),
+//     compress raw data as the data type is configured and upload it to S3
+//     under a key chosen by the re-run strategy (see store)
+//  5. Write the manifest of the execution (see writeManifest)
+//  6. Record S3 key, metadata, object versions and manifest key in
+//     ExtractedDataS3
+//  7. Mark execution as succeeded
+//
+// On failure at steps 1-6, the execution is marked as failed before
+// returning the error. A response with no records rejected by the policy
+// lands no file; the execution fails with an extract.ErrorCategory and the
+// returned error wraps extract.ErrEmptyResponse.
+func (uc *ExtractTaskUseCase) Run(
+	ctx context.Context,
+	execution *extract.ExtractTaskExecution,
+	req *ExtractTaskRequest,
+) (*ExtractTaskResponse, error) {
+	// 1. Resolve the configured data type
+	config, err := uc.resolveConfig(ctx, req)
+	if err != nil {
+		execution.Fail(ctx, err.Error())
+		if updateErr := uc.repo.UpdateExecution(ctx, execution); updateErr != nil {
+			return nil, fmt.Errorf(
+				"failed to update execution status after error: %w (original: %w)",
+				updateErr, err,
+			)
+		}
+		return nil, err
+	}
+	dataType := config.dataType
+
+	// 2. Fetch raw data from API
+	fetchStartedAt := clock.Now(ctx)
+	head, body, statusCode, err := uc.fetchRawData(ctx, req)
 	if err != nil {
 		execution.Fail(ctx, err.Error())
 		if updateErr := uc.repo.UpdateExecution(ctx, execution); updateErr != nil {
@@ -111,10 +288,29 @@ func (uc *ExtractTaskUseCase) Extract(ctx context.Context, req *ExtractTaskReque
 		}
 		return nil, err
 	}
+	defer body.Close()
+	streamed := int64(len(head)) > uc.streamThreshold
+
+	// 3. Apply the empty response policy. A body too large to hold in memory
+	// is not empty.
+	if category, err := uc.checkEmptyResponse(req, dataType.EmptyResponsePolicy(), head, streamed); err != nil {
+		if category != nil {
+			execution.FailWithCategory(ctx, *category, err.Error())
+		} else {
+			execution.Fail(ctx, err.Error())
+		}
+		if updateErr := uc.repo.UpdateExecution(ctx, execution); updateErr != nil {
+			return nil, fmt.Errorf(
+				"failed to update execution status after error: %w (original: %w)",
+				updateErr, err,
+			)
+		}
+		return nil, err
+	}
 
 	// 4. Upload to S3
-	s3Key := extract.GenerateS3Key(req.Source, req.DataType, now, "json")
-	if err := uc.objectWriter.PutObject(ctx, s3Key, rawBody); err != nil {
+	s3Key, metadata, version, err := uc.store(ctx, execution, req, dataType, head, body, statusCode, streamed)
+	if err != nil {
 		err = fmt.Errorf("failed to upload to S3: %w", err)
 		execution.Fail(ctx, err.Error())
 		if updateErr := uc.repo.UpdateExecution(ctx, execution); updateErr != nil {
@@ -126,26 +322,318 @@ func (uc *ExtractTaskUseCase) Extract(ctx context.Context, req *ExtractTaskReque
 		return nil, err
 	}
 
-	// 5. Record S3 file in DB
-	s3File := extract.NewExtractedDataS3(ctx, s3Key)
+	fetchFinishedAt := clock.Now(ctx)
+
+	// 5. Write manifest
+	objects := []extract.ManifestObject{extract.NewManifestObject(s3Key, metadata, version)}
+	manifestKey, err := uc.writeManifest(ctx, execution, req, fetchStartedAt, fetchFinishedAt, objects)
+	if err != nil {
+		err = fmt.Errorf("failed to write manifest: %w", err)
+		execution.Fail(ctx, err.Error())
+		if updateErr := uc.repo.UpdateExecution(ctx, execution); updateErr != nil {
+			return nil, fmt.Errorf(
+				"failed to update execution status after error: %w (original: %w)",
+				updateErr, err,
+			)
+		}
+		return nil, err
+	}
+
+	// 6. Record S3 file in DB
+	window := extract.FileWindow(req.Code, req.StartDate, req.EndDate)
+	s3File := extract.NewExtractedDataS3(ctx, s3Key, window, metadata, version, manifestKey)
 	if _, err := uc.repo.CreateExtractedDataS3(ctx, execution.ID(), s3File); err != nil {
 		execution.Fail(ctx, fmt.Sprintf("failed to record S3 file: %s", err.Error()))
 		_ = uc.repo.UpdateExecution(ctx, execution)
 		return nil, fmt.Errorf("failed to record S3 file: %w", err)
 	}
 
-	// 6. Mark execution as succeeded
+	// 7. Mark execution as succeeded
 	execution.Succeed(ctx)
 	if err := uc.repo.UpdateExecution(ctx, execution); err != nil {
 		return nil, fmt.Errorf("failed to update execution status: %w", err)
 	}
 
 	return &ExtractTaskResponse{
-		S3Key:  s3Key,
-		Status: extract.ExecutionStatusSucceeded,
+		ExecutionID: execution.ID(),
+		S3Key:       s3Key,
+		Status:      extract.ExecutionStatusSucceeded,
 	}, nil
 }
 
+// checkEmptyResponse returns an error wrapping extract.ErrEmptyResponse,
+// along with the category to fail the execution with, when rawBody holds no
+// records and policy rejects it. Records are only counted when the policy is
+// not EmptyResponsePolicySuccess and the body was not streamed. Errors while
+// counting are returned without a category.
+func (uc *ExtractTaskUseCase) checkEmptyResponse(
+	req *ExtractTaskRequest,
+	policy ingestion.EmptyResponsePolicy,
+	rawBody []byte,
+	streamed bool,
+) (*extract.ErrorCategory, error) {
+	var category extract.ErrorCategory
+	switch policy {
+	case "", ingestion.EmptyResponsePolicySuccess:
+		return nil, nil
+	case ingestion.EmptyResponsePolicyFail:
+		category = extract.ErrorCategoryEmptyResponse
+	case ingestion.EmptyResponsePolicyRetryLater:
+		category = extract.ErrorCategoryDeferred
+	default:
+		return nil, fmt.Errorf("unsupported empty response policy: %s", policy)
+	}
+	if streamed {
+		return nil, nil
+	}
+
+	count, err := uc.countRecords(req, rawBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count records: %w", err)
+	}
+	if count > 0 {
+		return nil, nil
+	}
+	return &category, fmt.Errorf("%w (empty response policy: %s)", extract.ErrEmptyResponse, policy)
+}
+
+// store uploads a response body whose first bytes were read into head, and
+// returns its key, metadata and versions. The compression and re-run
+// strategy of dataType apply. A body that fit in head is compressed in
+// memory and uploaded with its full metadata as object metadata. A streamed
+// body is uploaded as head followed by the rest of body, compressed as it is
+// read, while the sizes and SHA-256 digests are computed, so they are only in
+// the returned metadata, not the object metadata. The bytes stored decompress
+// to the bytes read either way.
+func (uc *ExtractTaskUseCase) store(
+	ctx context.Context,
+	execution *extract.ExtractTaskExecution,
+	req *ExtractTaskRequest,
+	dataType *ingestion.DataType,
+	head []byte,
+	body io.Reader,
+	statusCode int,
+	streamed bool,
+) (string, extract.FileMetadata, extract.ObjectVersion, error) {
+	strategy := dataType.RerunStrategy()
+	encoding := extract.Encoding(dataType.Compression().ContentEncoding())
+	if !streamed {
+		metadata := extract.NewFileMetadata(head, extract.FormatJSON, statusCode)
+		data := head
+		if encoding != extract.EncodingNone {
+			compressed, err := encoding.Compress(head)
+			if err != nil {
+				return "", metadata, extract.ObjectVersion{}, fmt.Errorf("failed to compress: %w", err)
+			}
+			data = compressed
+			metadata = metadata.Compressed(encoding, extract.NewFileMetadata(data, extract.FormatJSON, statusCode))
+		}
+		s3Key, version, err := uc.upload(ctx, execution, req, strategy, bytes.NewReader(data), metadata)
+		return s3Key, metadata, version, err
+	}
+
+	digest := extract.NewFileDigest(extract.FormatJSON, statusCode)
+	var r io.Reader = io.TeeReader(io.MultiReader(bytes.NewReader(head), body), digest)
+	var compressed io.ReadCloser
+	var stored *extract.FileDigest
+	if encoding != extract.EncodingNone {
+		compressed = encoding.CompressStream(r)
+		stored = extract.NewFileDigest(extract.FormatJSON, statusCode)
+		r = io.TeeReader(compressed, stored)
+	}
+	partial := extract.FileMetadata{
+		Format:      extract.FormatJSON,
+		ContentType: extract.FormatJSON.ContentType(),
+		HTTPStatus:  statusCode,
+		Encoding:    encoding,
+	}
+	s3Key, version, err := uc.upload(ctx, execution, req, strategy, r, partial)
+	if compressed != nil {
+		// The compression writes to digest until it has stopped
+		_ = compressed.Close()
+	}
+	if err != nil {
+		return "", partial, version, err
+	}
+	metadata := digest.Metadata()
+	if stored != nil {
+		metadata = metadata.Compressed(encoding, stored.Metadata())
+	}
+	return s3Key, metadata, version, nil
+}
+
+// upload writes body to the key chosen by strategy. Under
+// RerunStrategyAppend every run gets a new key. Under RerunStrategyOverwrite
+// every run of the same target date and window shares one key, and the
+// version of the object it replaces is returned as superseded. Replacing an
+// object without a version ID is refused before anything is written: the
+// store does not keep versions, so a run failing after the write would lose
+// the last good file.
+func (uc *ExtractTaskUseCase) upload(
+	ctx context.Context,
+	execution *extract.ExtractTaskExecution,
+	req *ExtractTaskRequest,
+	strategy ingestion.RerunStrategy,
+	body io.Reader,
+	metadata extract.FileMetadata,
+) (string, extract.ObjectVersion, error) {
+	var version extract.ObjectVersion
+	ext := string(metadata.Format) + metadata.Encoding.Extension()
+	s3Key := extract.GenerateS3Key(req.Source, req.DataType, clock.Now(ctx), ext)
+	if strategy == ingestion.RerunStrategyOverwrite {
+		window := extract.FileWindow(req.Code, req.StartDate, req.EndDate)
+		s3Key = extract.GenerateOverwriteS3Key(req.Source, req.DataType, execution.TargetDateTime(), window, ext)
+		current, err := uc.objectWriter.HeadObject(ctx, s3Key)
+		if err != nil {
+			return "", version, err
+		}
+		if current != nil {
+			if current.VersionID == "" {
+				return "", version, fmt.Errorf(
+					"refusing to overwrite unversioned object %s: %s",
+					s3Key, "enable bucket versioning or use the append re-run strategy",
+				)
+			}
+			version.SupersededID = current.VersionID
+		}
+	}
+
+	versionID, err := uc.objectWriter.PutObjectStream(
+		ctx, s3Key, body, metadata.ContentType, metadata.Encoding, metadata.ObjectMetadata(),
+	)
+	if err != nil {
+		return "", version, err
+	}
+	version.ID = versionID
+	return s3Key, version, nil
+}
+
+// writeManifest stores the manifest of execution, listing the objects it
+// landed, and returns its key. The manifest is written before the execution
+// is recorded as succeeded, so every succeeded execution has one.
+func (uc *ExtractTaskUseCase) writeManifest(
+	ctx context.Context,
+	execution *extract.ExtractTaskExecution,
+	req *ExtractTaskRequest,
+	fetchStartedAt time.Time,
+	fetchFinishedAt time.Time,
+	objects []extract.ManifestObject,
+) (string, error) {
+	target := execution.TargetDateTime()
+	manifest := &extract.Manifest{
+		ManifestVersion: extract.ManifestVersion,
+		Source:          req.Source,
+		DataType:        req.DataType,
+		TargetDate:      target.Format("2006-01-02"),
+		TargetDateTime:  target,
+		ExecutionID:     execution.ID(),
+		Request:         extract.NewManifestRequest(req.Timing, req.Code, req.StartDate, req.EndDate),
+		FetchStartedAt:  fetchStartedAt,
+		FetchFinishedAt: fetchFinishedAt,
+		Objects:         objects,
+	}
+	data, err := manifest.Marshal()
+	if err != nil {
+		return "", err
+	}
+	key := extract.GenerateManifestKey(req.Source, req.DataType, target, execution.ID())
+	_, err = uc.objectWriter.PutObjectStream(
+		ctx, key, bytes.NewReader(data), extract.FormatJSON.ContentType(), extract.EncodingNone, nil,
+	)
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// extractConfig is the configuration an extraction runs with.
+type extractConfig struct {
+	source   *ingestion.DataSource
+	dataType *ingestion.DataType
+}
+
+// resolveConfig returns the configured data source and data type named by
+// req, so that every entry point extracts with the stored configuration.
+// Returns an error when either is not configured.
+func (uc *ExtractTaskUseCase) resolveConfig(ctx context.Context, req *ExtractTaskRequest) (*extractConfig, error) {
+	src, err := uc.dataSourceRepo.FindByName(ctx, req.Source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data source: %w", err)
+	}
+	if src == nil {
+		return nil, fmt.Errorf("data source %s is not configured", req.Source)
+	}
+	dt, err := uc.dataTypeRepo.FindByName(ctx, src.ID(), req.DataType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data type: %w", err)
+	}
+	if dt == nil {
+		return nil, fmt.Errorf("data type %s/%s is not configured", req.Source, req.DataType)
+	}
+	return &extractConfig{source: src, dataType: dt}, nil
+}
+
+// checkDependencies returns an error wrapping extract.ErrDependenciesNotMet
+// unless every data type the configured data type depends on has a succeeded
+// execution for the calendar date of target in the source timezone, or for
+// a date within the dependency's allowed lag (D9).
+func (uc *ExtractTaskUseCase) checkDependencies(ctx context.Context, config *extractConfig, target time.Time) error {
+	deps, err := uc.dataTypeRepo.ListDependencies(ctx, config.dataType.ID())
+	if err != nil {
+		return fmt.Errorf("failed to list dependencies: %w", err)
+	}
+	target = target.In(config.source.Timezone())
+	for _, dep := range deps {
+		depType, err := uc.dataTypeRepo.FindByID(ctx, dep.DependsOnID())
+		if err != nil {
+			return fmt.Errorf("failed to find data type: %w", err)
+		}
+		if depType == nil {
+			return fmt.Errorf("dependency %s not found", dep.DependsOnID())
+		}
+		depSource, err := uc.dataSourceRepo.FindByID(ctx, depType.DataSourceID())
+		if err != nil {
+			return fmt.Errorf("failed to find data source: %w", err)
+		}
+		if depSource == nil {
+			return fmt.Errorf("data source %s of dependency %s not found", depType.DataSourceID(), depType.ID())
+		}
+		from, to := dep.Window(target)
+		ok, err := uc.repo.HasSucceededExecution(ctx, depSource.Name(), depType.Name(), from, to)
+		if err != nil {
+			return fmt.Errorf("failed to check dependency %s.%s: %w", depSource.Name(), depType.Name(), err)
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s.%s", extract.ErrDependenciesNotMet, depSource.Name(), depType.Name())
+		}
+	}
+	return nil
+}
+
+// failStaleExecution fails the running execution of the task for
+// targetDate if it has been running for timeout or longer. A process that
+// died mid-run leaves its execution running, which would otherwise block the
+// target date for good.
+func (uc *ExtractTaskUseCase) failStaleExecution(
+	ctx context.Context,
+	taskID int,
+	targetDate time.Time,
+	timeout time.Duration,
+) error {
+	running, err := uc.repo.FindRunningExecution(ctx, taskID, targetDate)
+	if err != nil {
+		return fmt.Errorf("failed to find running execution: %w", err)
+	}
+	if running == nil || !running.IsStale(clock.Now(ctx), timeout) {
+		return nil
+	}
+	running.Fail(ctx, fmt.Sprintf("stale: still running after %s", timeout))
+	if err := uc.repo.UpdateExecution(ctx, running); err != nil {
+		return fmt.Errorf("failed to fail stale execution: %w", err)
+	}
+	return nil
+}
+
 func (uc *ExtractTaskUseCase) findOrCreateTask(
 	ctx context.Context,
 	source string,
@@ -173,16 +661,65 @@ func (uc *ExtractTaskUseCase) findOrCreateTask(
 	return task, nil
 }
 
-func (uc *ExtractTaskUseCase) fetchRawData(ctx context.Context, req *ExtractTaskRequest) ([]byte, error) {
+// Supports reports whether the extractor can fetch dataType of source.
+// Executions of any other data type fail when they run.
+func (uc *ExtractTaskUseCase) Supports(source, dataType string) bool {
+	switch source {
+	case "jquants":
+		switch dataType {
+		case "brand":
+			return true
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+func (uc *ExtractTaskUseCase) countRecords(req *ExtractTaskRequest, rawBody []byte) (int, error) {
+	switch req.Source {
+	case "jquants":
+		switch req.DataType {
+		case "brand":
+			return uc.brandFetcher.CountRecords(rawBody)
+		default:
+			return 0, fmt.Errorf("unsupported data type: %s.%s", req.Source, req.DataType)
+		}
+	default:
+		return 0, fmt.Errorf("unsupported source: %s", req.Source)
+	}
+}
+
+// fetchRawData fetches the response body for req and reads it into head, up
+// to one byte past streamThreshold. The returned body holds the unread rest
+// and must be closed.
+func (uc *ExtractTaskUseCase) fetchRawData(
+	ctx context.Context,
+	req *ExtractTaskRequest,
+) (head []byte, body io.ReadCloser, statusCode int, err error) {
+	body, statusCode, err = uc.openRawData(ctx, req)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	head, err = io.ReadAll(io.LimitReader(body, uc.streamThreshold+1))
+	if err != nil {
+		_ = body.Close()
+		return nil, nil, 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return head, body, statusCode, nil
+}
+
+func (uc *ExtractTaskUseCase) openRawData(ctx context.Context, req *ExtractTaskRequest) (io.ReadCloser, int, error) {
 	switch req.Source {
 	case "jquants":
 		switch req.DataType {
 		case "brand":
-			return uc.brandFetcher.FetchBrands(ctx, req.Code, req.StartDate)
+			return uc.brandFetcher.StreamBrands(ctx, req.Code, req.StartDate)
 		default:
-			return nil, fmt.Errorf("unsupported data type: %s.%s", req.Source, req.DataType)
+			return nil, 0, fmt.Errorf("unsupported data type: %s.%s", req.Source, req.DataType)
 		}
 	default:
-		return nil, fmt.Errorf("unsupported source: %s", req.Source)
+		return nil, 0, fmt.Errorf("unsupported source: %s", req.Source)
 	}
 }
@@ -1,22 +1,32 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/domain/jquants"
 	"stock-tool/internal/infra/repository"
 	"stock-tool/internal/infra/storage"
+	"stock-tool/internal/util/clock"
 	"stock-tool/internal/util/testutil"
 )
 
@@ -26,12 +36,22 @@ type BrandDataFetcherMock struct {
 	mock.Mock
 }
 
-func (m *BrandDataFetcherMock) FetchBrands(ctx context.Context, code *string, date *time.Time) ([]byte, error) {
+// StreamBrands returns the []byte the call was set up with as the body.
+func (m *BrandDataFetcherMock) StreamBrands(
+	ctx context.Context,
+	code *string,
+	date *time.Time,
+) (io.ReadCloser, int, error) {
 	args := m.Called(ctx, code, date)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, args.Int(1), args.Error(2)
 	}
-	return args.Get(0).([]byte), args.Error(1)
+	return io.NopCloser(bytes.NewReader(args.Get(0).([]byte))), args.Int(1), args.Error(2)
+}
+
+func (m *BrandDataFetcherMock) CountRecords(rawBody []byte) (int, error) {
+	args := m.Called(rawBody)
+	return args.Int(0), args.Error(1)
 }
 
 // --- Suite ---
@@ -41,7 +61,12 @@ type ExtractTaskUseCaseTestSuite struct {
 	s3Test   testutil.S3Test
 	db       *gorm.DB
 	repo     *repository.ExtractTaskRepository
+	dsRepo   *repository.DataSourceRepository
+	dtRepo   *repository.DataTypeRepository
 	s3Client *storage.S3Client
+	// brand is the configured jquants/brand data type, with the default
+	// re-run strategy, empty response policy and compression.
+	brand *ingestion.DataType
 }
 
 func TestExtractTaskUseCase(t *testing.T) {
@@ -53,7 +78,8 @@ func (s *ExtractTaskUseCaseTestSuite) SetupSuite() {
 	s.s3Test.SetT(s.T())
 	s.s3Test.SetupSuite()
 
-	s.s3Client = storage.NewS3Client(storage.S3Config{
+	var err error
+	s.s3Client, err = storage.NewS3Client(storage.S3Config{
 		Endpoint:       s.s3Test.Endpoint,
 		Bucket:         testutil.TestS3Bucket,
 		AccessKey:      testutil.TestS3AccessKey,
@@ -61,6 +87,7 @@ func (s *ExtractTaskUseCaseTestSuite) SetupSuite() {
 		Region:         testutil.TestS3Region,
 		ForcePathStyle: true,
 	})
+	s.Require().NoError(err)
 }
 
 func (s *ExtractTaskUseCaseTestSuite) TearDownSuite() {
@@ -76,6 +103,33 @@ func (s *ExtractTaskUseCaseTestSuite) SetupTest() {
 
 	s.db = db
 	s.repo = repository.NewExtractTaskRepository(db)
+	s.dsRepo = repository.NewDataSourceRepository(db)
+	s.dtRepo = repository.NewDataTypeRepository(db)
+
+	ctx := context.Background()
+	src, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "jquants", true, "Asia/Tokyo", ingestion.RetentionPolicy{}, map[string]any{},
+	)
+	s.Require().NoError(err)
+	_, err = s.dsRepo.Create(ctx, src)
+	s.Require().NoError(err)
+	schedule, err := ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})
+	s.Require().NoError(err)
+	s.brand, err = s.dtRepo.Create(ctx, ingestion.NewDataType(
+		ctx,
+		src.ID(),
+		"brand",
+		true,
+		schedule,
+		false,
+		30,
+		ingestion.RerunStrategyAppend,
+		ingestion.EmptyResponsePolicySuccess,
+		ingestion.CompressionNone,
+		ingestion.RetentionPolicy{},
+		map[string]any{},
+	))
+	s.Require().NoError(err)
 }
 
 func (s *ExtractTaskUseCaseTestSuite) TearDownTest() {
@@ -83,7 +137,7 @@ func (s *ExtractTaskUseCaseTestSuite) TearDownTest() {
 }
 
 func (s *ExtractTaskUseCaseTestSuite) newUseCase(fetcher BrandDataFetcher) *ExtractTaskUseCase {
-	return NewExtractTaskUseCase(fetcher, s.s3Client, s.repo)
+	return NewExtractTaskUseCase(fetcher, s.s3Client, s.repo, s.dsRepo, s.dtRepo)
 }
 
 func (s *ExtractTaskUseCaseTestSuite) TestExtract_Success() {
@@ -91,8 +145,8 @@ func (s *ExtractTaskUseCaseTestSuite) TestExtract_Success() {
 	rawBody := []byte(`{"info":[{"Code":"86970","CompanyName":"日本取引所グループ"}]}`)
 
 	fetcher := new(BrandDataFetcherMock)
-	fetcher.On("FetchBrands", ctx, (*string)(nil), (*time.Time)(nil)).
-		Return(rawBody, nil)
+	fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).
+		Return(rawBody, 200, nil)
 
 	uc := s.newUseCase(fetcher)
 	resp, err := uc.Extract(ctx, &ExtractTaskRequest{
@@ -126,21 +180,144 @@ func (s *ExtractTaskUseCaseTestSuite) TestExtract_Success() {
 	)
 	s.Require().Len(dbS3Files, 1)
 	s.Equal(resp.S3Key, dbS3Files[0].Key)
+	expected := extract.NewFileMetadata(rawBody, extract.FormatJSON, 200)
+	s.Equal(&expected, dbS3Files[0].ToEntity().Metadata())
 
-	// Verify S3: object content matches raw body
-	body := s.getS3Object(ctx, resp.S3Key)
+	// Verify S3: object content and metadata match raw body
+	body, obj := s.getS3Object(ctx, resp.S3Key)
 	s.Equal(rawBody, body)
+	s.Equal("application/json", aws.ToString(obj.ContentType))
+	s.Equal(expected.ObjectMetadata(), obj.Metadata)
+
+	// Verify S3: the manifest of the execution lists the object
+	manifestKey := lo.FromPtr(dbS3Files[0].ManifestKey)
+	s.Regexp(fmt.Sprintf(`^landing/_manifests/jquants/brand/\d{4}/\d{2}/\d{2}/execution-%d\.json$`, dbExecs[0].ID),
+		manifestKey)
+	body, _ = s.getS3Object(ctx, manifestKey)
+	var manifest extract.Manifest
+	s.Require().NoError(json.Unmarshal(body, &manifest))
+	s.Equal(extract.ManifestVersion, manifest.ManifestVersion)
+	s.Equal(dbExecs[0].ID, manifest.ExecutionID)
+	s.Equal(extract.ManifestRequest{Timing: "daily"}, manifest.Request)
+	s.False(manifest.FetchFinishedAt.Before(manifest.FetchStartedAt))
+	s.Equal([]extract.ManifestObject{
+		extract.NewManifestObject(resp.S3Key, expected, dbS3Files[0].ToEntity().Version()),
+	}, manifest.Objects)
 
 	fetcher.AssertExpectations(s.T())
 }
 
+func (s *ExtractTaskUseCaseTestSuite) TestExtract_StreamsLargeBody() {
+	ctx := context.Background()
+	rawBody := []byte(`{"info":[{"Code":"86970"},{"Code":"72030"},{"Code":"67580"}]}`)
+
+	fetcher := new(BrandDataFetcherMock)
+	fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).
+		Return(rawBody, 200, nil)
+
+	s.Require().NoError(s.db.Model(&repository.DataType{}).Where("id = ?", s.brand.ID()).
+		Update("empty_response_policy", string(ingestion.EmptyResponsePolicyFail)).Error)
+
+	uc := s.newUseCase(fetcher)
+	uc.streamThreshold = 16
+	resp, err := uc.Extract(ctx, &ExtractTaskRequest{
+		Source:   "jquants",
+		DataType: "brand",
+		Timing:   "daily",
+	})
+	s.Require().NoError(err)
+	s.Equal(extract.ExecutionStatusSucceeded, resp.Status)
+
+	// The DB records the full metadata, computed while streaming.
+	var dbS3File repository.ExtractedDataS3
+	s.Require().NoError(s.db.Where("key = ?", resp.S3Key).First(&dbS3File).Error)
+	expected := extract.NewFileMetadata(rawBody, extract.FormatJSON, 200)
+	s.Equal(&expected, dbS3File.ToEntity().Metadata())
+
+	// The object holds the raw bytes, without the size and SHA-256 that were
+	// unknown when the upload started.
+	body, obj := s.getS3Object(ctx, resp.S3Key)
+	s.Equal(rawBody, body)
+	s.Equal(map[string]string{"format": "json", "http-status": "200"}, obj.Metadata)
+
+	// A body too large to buffer is not counted for the empty response policy.
+	fetcher.AssertNotCalled(s.T(), "CountRecords", mock.Anything)
+}
+
+func (s *ExtractTaskUseCaseTestSuite) TestExtract_Compressed() {
+	ctx := context.Background()
+	rawBody := []byte(`{"info":[{"Code":"86970"},{"Code":"72030"},{"Code":"67580"}]}`)
+
+	for _, tt := range []struct {
+		name            string
+		encoding        extract.Encoding
+		compression     ingestion.Compression
+		streamThreshold int64
+	}{
+		{name: "gzip buffered", encoding: extract.EncodingGzip, compression: ingestion.CompressionGzip},
+		{name: "zstd buffered", encoding: extract.EncodingZstd, compression: ingestion.CompressionZstd},
+		{
+			name:            "gzip streamed",
+			encoding:        extract.EncodingGzip,
+			compression:     ingestion.CompressionGzip,
+			streamThreshold: 16,
+		},
+	} {
+		s.Run(tt.name, func() {
+			fetcher := new(BrandDataFetcherMock)
+			fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).
+				Return(rawBody, 200, nil)
+			fetcher.On("CountRecords", rawBody).Return(3, nil).Maybe()
+			s.Require().NoError(s.db.Model(&repository.DataType{}).Where("id = ?", s.brand.ID()).
+				Update("compression", string(tt.compression)).Error)
+
+			uc := s.newUseCase(fetcher)
+			if tt.streamThreshold > 0 {
+				uc.streamThreshold = tt.streamThreshold
+			}
+			resp, err := uc.Extract(ctx, &ExtractTaskRequest{
+				Source:   "jquants",
+				DataType: "brand",
+				Timing:   "daily",
+			})
+			s.Require().NoError(err)
+			s.Equal(extract.ExecutionStatusSucceeded, resp.Status)
+			s.True(strings.HasSuffix(resp.S3Key, ".json"+tt.encoding.Extension()), resp.S3Key)
+
+			// The object holds the compressed bytes with the encoding set.
+			stored, obj := s.getS3Object(ctx, resp.S3Key)
+			s.Equal(string(tt.encoding), aws.ToString(obj.ContentEncoding))
+			r, err := tt.encoding.NewReader(bytes.NewReader(stored))
+			s.Require().NoError(err)
+			decompressed, err := io.ReadAll(r)
+			s.Require().NoError(err)
+			s.Equal(rawBody, decompressed)
+
+			// The DB records checksums of both the fetched and stored bytes.
+			var dbS3File repository.ExtractedDataS3
+			s.Require().NoError(s.db.Where("key = ?", resp.S3Key).First(&dbS3File).Error)
+			expected := extract.NewFileMetadata(rawBody, extract.FormatJSON, 200).
+				Compressed(tt.encoding, extract.NewFileMetadata(stored, extract.FormatJSON, 200))
+			s.Equal(&expected, dbS3File.ToEntity().Metadata())
+
+			// Reads through storage return the fetched bytes.
+			body, err := s.s3Client.GetObject(ctx, resp.S3Key)
+			s.Require().NoError(err)
+			got, err := io.ReadAll(body)
+			s.Require().NoError(err)
+			s.Require().NoError(body.Close())
+			s.Equal(rawBody, got)
+		})
+	}
+}
+
 func (s *ExtractTaskUseCaseTestSuite) TestExtract_ReusesExistingTask() {
 	ctx := context.Background()
 	rawBody := []byte(`{"info":[]}`)
 
 	fetcher := new(BrandDataFetcherMock)
-	fetcher.On("FetchBrands", ctx, (*string)(nil), (*time.Time)(nil)).
-		Return(rawBody, nil)
+	fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).
+		Return(rawBody, 200, nil)
 
 	uc := s.newUseCase(fetcher)
 	req := &ExtractTaskRequest{Source: "jquants", DataType: "brand", Timing: "daily"}
@@ -161,12 +338,191 @@ func (s *ExtractTaskUseCaseTestSuite) TestExtract_ReusesExistingTask() {
 	s.Equal(int64(2), execCount)
 }
 
+func (s *ExtractTaskUseCaseTestSuite) TestExtract_OverwriteStrategy() {
+	ctx := context.Background()
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	targetDate := time.Date(2026, 10, 16, 0, 0, 0, 0, jst)
+	first, second := []byte(`{"info":[]}`), []byte(`{"info":[{"Code":"86970"}]}`)
+
+	// Overwrite needs a versioned bucket to keep the replaced object
+	rawClient := s3.New(s3.Options{
+		BaseEndpoint: aws.String(s.s3Test.Endpoint),
+		Region:       testutil.TestS3Region,
+		Credentials: credentials.NewStaticCredentialsProvider(
+			testutil.TestS3AccessKey, testutil.TestS3SecretKey, "",
+		),
+		UsePathStyle: true,
+	})
+	bucket := "versioned-bucket"
+	_, err := rawClient.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(bucket)})
+	s.Require().NoError(err)
+	_, err = rawClient.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(bucket),
+		VersioningConfiguration: &types.VersioningConfiguration{Status: types.BucketVersioningStatusEnabled},
+	})
+	s.Require().NoError(err)
+	versioned, err := storage.NewS3Client(storage.S3Config{
+		Endpoint:       s.s3Test.Endpoint,
+		Bucket:         bucket,
+		AccessKey:      testutil.TestS3AccessKey,
+		SecretKey:      testutil.TestS3SecretKey,
+		Region:         testutil.TestS3Region,
+		ForcePathStyle: true,
+	})
+	s.Require().NoError(err)
+
+	fetcher := new(BrandDataFetcherMock)
+	fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).Return(first, 200, nil).Once()
+	fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).Return(second, 200, nil).Once()
+
+	s.Require().NoError(s.db.Model(&repository.DataType{}).Where("id = ?", s.brand.ID()).
+		Update("rerun_strategy", string(ingestion.RerunStrategyOverwrite)).Error)
+
+	uc := NewExtractTaskUseCase(fetcher, versioned, s.repo, s.dsRepo, s.dtRepo)
+	req := &ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   "brand",
+		Timing:     "daily",
+		TargetDate: &targetDate,
+	}
+	resp1, err := uc.Extract(ctx, req)
+	s.Require().NoError(err)
+	resp2, err := uc.Extract(ctx, req)
+	s.Require().NoError(err)
+
+	// Both runs write the deterministic key of the business date
+	s.Equal("landing/jquants/brand/2026/10/16/all.json", resp1.S3Key)
+	s.Equal(resp1.S3Key, resp2.S3Key)
+	body, err := versioned.GetObject(ctx, resp2.S3Key)
+	s.Require().NoError(err)
+	got, err := io.ReadAll(body)
+	s.Require().NoError(err)
+	s.Require().NoError(body.Close())
+	s.Equal(second, got)
+
+	// Both runs are recorded; the latest is the current file for the date
+	var dbS3Files []repository.ExtractedDataS3
+	s.Require().NoError(s.db.Order("id").Find(&dbS3Files).Error)
+	s.Require().Len(dbS3Files, 2)
+	files, err := s.repo.FindCurrentFiles(ctx, "jquants", "brand", targetDate)
+	s.Require().NoError(err)
+	s.Require().Len(files, 1)
+	current := files[0]
+	s.Equal(dbS3Files[1].ID, current.ID())
+	s.Equal("all", current.Window())
+	s.Equal(extract.NewFileMetadata(second, extract.FormatJSON, 200).SHA256, current.Metadata().SHA256)
+	// The second write replaced the version the first one wrote
+	s.NotEmpty(current.Version().SupersededID)
+	s.Equal(dbS3Files[0].ToEntity().Version().ID, current.Version().SupersededID)
+}
+
+func (s *ExtractTaskUseCaseTestSuite) TestExtract_OverwriteStrategy_Unversioned() {
+	ctx := context.Background()
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	targetDate := time.Date(2026, 10, 16, 0, 0, 0, 0, jst)
+	first, second := []byte(`{"info":[]}`), []byte(`{"info":[{"Code":"86970"}]}`)
+
+	fetcher := new(BrandDataFetcherMock)
+	fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).Return(first, 200, nil).Once()
+	fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).Return(second, 200, nil).Once()
+
+	s.Require().NoError(s.db.Model(&repository.DataType{}).Where("id = ?", s.brand.ID()).
+		Update("rerun_strategy", string(ingestion.RerunStrategyOverwrite)).Error)
+
+	uc := s.newUseCase(fetcher)
+	req := &ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   "brand",
+		Timing:     "daily",
+		TargetDate: &targetDate,
+	}
+	resp, err := uc.Extract(ctx, req)
+	s.Require().NoError(err)
+	_, err = uc.Extract(ctx, req)
+	s.ErrorContains(err, "refusing to overwrite unversioned object landing/jquants/brand/2026/10/16/all.json")
+
+	// The first file is kept and stays current
+	body, _ := s.getS3Object(ctx, resp.S3Key)
+	s.Equal(first, body)
+	var dbExecs []repository.ExtractTaskExecution
+	s.Require().NoError(s.db.Order("id").Find(&dbExecs).Error)
+	s.Require().Len(dbExecs, 2)
+	s.Equal("succeeded", dbExecs[0].Status)
+	s.Equal("failed", dbExecs[1].Status)
+	current, err := s.repo.FindCurrentFiles(ctx, "jquants", "brand", targetDate)
+	s.Require().NoError(err)
+	s.Require().Len(current, 1)
+	s.Equal(extract.NewFileMetadata(first, extract.FormatJSON, 200).SHA256, current[0].Metadata().SHA256)
+}
+
+func (s *ExtractTaskUseCaseTestSuite) TestExtract_EmptyResponsePolicy() {
+	type testCase struct {
+		name             string
+		policy           ingestion.EmptyResponsePolicy
+		count            int
+		expectedStatus   string
+		expectedCategory *string
+	}
+	tests := []testCase{
+		{
+			name:           "success lands the empty response",
+			policy:         ingestion.EmptyResponsePolicySuccess,
+			expectedStatus: "succeeded",
+		},
+		{
+			name:             "fail",
+			policy:           ingestion.EmptyResponsePolicyFail,
+			expectedStatus:   "failed",
+			expectedCategory: lo.ToPtr("empty_response"),
+		},
+		{
+			name:             "retry_later defers",
+			policy:           ingestion.EmptyResponsePolicyRetryLater,
+			expectedStatus:   "failed",
+			expectedCategory: lo.ToPtr("deferred"),
+		},
+		{name: "records are landed", policy: ingestion.EmptyResponsePolicyFail, count: 1, expectedStatus: "succeeded"},
+	}
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			ctx := context.Background()
+			rawBody := []byte(`{"info":[]}`)
+			fetcher := new(BrandDataFetcherMock)
+			fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).Return(rawBody, 200, nil)
+			fetcher.On("CountRecords", rawBody).Return(tc.count, nil)
+			s.Require().NoError(s.db.Model(&repository.DataType{}).Where("id = ?", s.brand.ID()).
+				Update("empty_response_policy", string(tc.policy)).Error)
+
+			_, err := s.newUseCase(fetcher).Extract(ctx, &ExtractTaskRequest{
+				Source:   "jquants",
+				DataType: "brand",
+				Timing:   "daily",
+			})
+
+			var dbExec repository.ExtractTaskExecution
+			s.Require().NoError(s.db.Order("id DESC").Preload("ExtractedDataS3s").First(&dbExec).Error)
+			s.Equal(tc.expectedStatus, dbExec.Status)
+			s.Equal(tc.expectedCategory, dbExec.ErrorCategory)
+			if tc.expectedCategory != nil {
+				s.ErrorIs(err, extract.ErrEmptyResponse)
+				s.Empty(dbExec.ExtractedDataS3s, "no file is landed")
+			} else {
+				s.Require().NoError(err)
+				s.Len(dbExec.ExtractedDataS3s, 1)
+			}
+			if tc.policy == ingestion.EmptyResponsePolicySuccess {
+				fetcher.AssertNotCalled(s.T(), "CountRecords", rawBody)
+			}
+		})
+	}
+}
+
 func (s *ExtractTaskUseCaseTestSuite) TestExtract_APIError_MarksExecutionFailed() {
 	ctx := context.Background()
 
 	fetcher := new(BrandDataFetcherMock)
-	fetcher.On("FetchBrands", ctx, (*string)(nil), (*time.Time)(nil)).
-		Return(nil, errors.New("API connection timeout"))
+	fetcher.On("StreamBrands", ctx, (*string)(nil), (*time.Time)(nil)).
+		Return(nil, 0, errors.New("API connection timeout"))
 
 	uc := s.newUseCase(fetcher)
 	_, err := uc.Extract(ctx, &ExtractTaskRequest{
@@ -188,11 +544,32 @@ func (s *ExtractTaskUseCaseTestSuite) TestExtract_APIError_MarksExecutionFailed(
 
 func (s *ExtractTaskUseCaseTestSuite) TestExtract_UnsupportedSource_MarksExecutionFailed() {
 	ctx := context.Background()
+	src, err := ingestion.NewDataSource(
+		ctx, ingestion.SourceKindGeneric, "unknown", true, "UTC", ingestion.RetentionPolicy{}, map[string]any{},
+	)
+	s.Require().NoError(err)
+	_, err = s.dsRepo.Create(ctx, src)
+	s.Require().NoError(err)
+	_, err = s.dtRepo.Create(ctx, ingestion.NewDataType(
+		ctx,
+		src.ID(),
+		"brand",
+		true,
+		s.brand.Schedule(),
+		false,
+		30,
+		ingestion.RerunStrategyAppend,
+		ingestion.EmptyResponsePolicySuccess,
+		ingestion.CompressionNone,
+		ingestion.RetentionPolicy{},
+		map[string]any{},
+	))
+	s.Require().NoError(err)
 
 	fetcher := new(BrandDataFetcherMock)
 
 	uc := s.newUseCase(fetcher)
-	_, err := uc.Extract(ctx, &ExtractTaskRequest{
+	_, err = uc.Extract(ctx, &ExtractTaskRequest{
 		Source:   "unknown",
 		DataType: "brand",
 		Timing:   "daily",
@@ -207,7 +584,183 @@ func (s *ExtractTaskUseCaseTestSuite) TestExtract_UnsupportedSource_MarksExecuti
 	s.Equal("failed", dbExec.Status)
 }
 
-func (s *ExtractTaskUseCaseTestSuite) getS3Object(ctx context.Context, key string) []byte {
+func (s *ExtractTaskUseCaseTestSuite) TestStart_NotConfigured() {
+	ctx := context.Background()
+	uc := s.newUseCase(new(BrandDataFetcherMock))
+
+	_, err := uc.Start(ctx, &ExtractTaskRequest{Source: "unknown", DataType: "brand", Timing: "daily"})
+	s.EqualError(err, "data source unknown is not configured")
+
+	_, err = uc.Start(ctx, &ExtractTaskRequest{Source: "jquants", DataType: "unknown", Timing: "daily"})
+	s.EqualError(err, "data type jquants/unknown is not configured")
+
+	var execCount int64
+	s.db.Model(&repository.ExtractTaskExecution{}).Count(&execCount)
+	s.Equal(int64(0), execCount)
+}
+
+func (s *ExtractTaskUseCaseTestSuite) TestStart_DependenciesNotMet() {
+	ctx := context.Background()
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	s.Require().NoError(err)
+	schedule, err := ingestion.NewDailySchedule([]ingestion.TimeOfDay{"18:00"})
+	s.Require().NoError(err)
+	quotes, err := s.dtRepo.Create(ctx, ingestion.NewDataType(
+		ctx,
+		s.brand.DataSourceID(),
+		"daily_quotes",
+		true,
+		schedule,
+		false,
+		30,
+		ingestion.RerunStrategyAppend,
+		ingestion.EmptyResponsePolicySuccess,
+		ingestion.CompressionNone,
+		ingestion.RetentionPolicy{},
+		map[string]any{},
+	))
+	s.Require().NoError(err)
+	dep, err := ingestion.NewDependency(quotes.ID(), 0)
+	s.Require().NoError(err)
+	s.Require().NoError(s.dtRepo.ReplaceDependencies(ctx, s.brand.ID(), []ingestion.Dependency{dep}))
+
+	// daily_quotes has succeeded for 2026-10-10 only; a failed execution
+	// for 2026-10-11 does not count
+	task := extract.NewExtractTask(ctx, "jquants", "daily_quotes", "daily")
+	s.Require().NoError(s.repo.Create(ctx, task))
+	task, err = s.repo.FindBySourceAndDataType(ctx, "jquants", "daily_quotes", "daily")
+	s.Require().NoError(err)
+	succeeded, err := s.repo.CreateExecution(ctx, task.ID(),
+		extract.NewRunningExecution(ctx, time.Date(2026, 10, 10, 0, 0, 0, 0, jst)))
+	s.Require().NoError(err)
+	succeeded.Succeed(ctx)
+	s.Require().NoError(s.repo.UpdateExecution(ctx, succeeded))
+	failed, err := s.repo.CreateExecution(ctx, task.ID(),
+		extract.NewRunningExecution(ctx, time.Date(2026, 10, 11, 0, 0, 0, 0, jst)))
+	s.Require().NoError(err)
+	failed.Fail(ctx, "API connection timeout")
+	s.Require().NoError(s.repo.UpdateExecution(ctx, failed))
+
+	uc := s.newUseCase(new(BrandDataFetcherMock))
+	// A target date in another timezone is checked as its date in the source timezone
+	blocked := time.Date(2026, 10, 10, 15, 0, 0, 0, time.UTC)
+	_, err = uc.Start(ctx, &ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   "brand",
+		Timing:     "daily",
+		TargetDate: &blocked,
+	})
+	s.ErrorIs(err, extract.ErrDependenciesNotMet)
+
+	var execCount int64
+	s.db.Model(&repository.ExtractTaskExecution{}).Count(&execCount)
+	s.Equal(int64(2), execCount)
+
+	ready := time.Date(2026, 10, 10, 0, 0, 0, 0, jst)
+	execution, err := uc.Start(ctx, &ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   "brand",
+		Timing:     "daily",
+		TargetDate: &ready,
+	})
+	s.Require().NoError(err)
+	s.Equal(extract.ExecutionStatusRunning, execution.Status())
+}
+
+func (s *ExtractTaskUseCaseTestSuite) TestStart_OutOfPlan() {
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	s.Require().NoError(err)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, jst)
+	ctx := clock.WithGenerator(context.Background(), func() time.Time { return now })
+	s.Require().NoError(s.db.Model(&repository.DataSource{}).Where("id = ?", s.brand.DataSourceID()).
+		Updates(map[string]any{
+			"kind":     "jquants",
+			"settings": datatypes.NewJSONType(map[string]any{"plan": "free"}),
+		}).Error)
+	uc := s.newUseCase(new(BrandDataFetcherMock))
+
+	// The free plan serves up to 12 weeks ago
+	inside := time.Date(2026, 7, 26, 0, 0, 0, 0, jst)
+	execution, err := uc.Start(ctx, &ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   "brand",
+		Timing:     "daily",
+		TargetDate: &inside,
+	})
+	s.Require().NoError(err)
+	s.Equal(extract.ExecutionStatusRunning, execution.Status())
+
+	outside := time.Date(2026, 7, 27, 0, 0, 0, 0, jst)
+	_, err = uc.Start(ctx, &ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   "brand",
+		Timing:     "daily",
+		TargetDate: &outside,
+	})
+	s.ErrorIs(err, jquants.ErrTargetDateOutOfPlanRange)
+
+	var dbExec repository.ExtractTaskExecution
+	s.Require().NoError(s.db.Order("id DESC").First(&dbExec).Error)
+	s.Equal("failed", dbExec.Status)
+	s.Equal(lo.ToPtr("out_of_plan"), dbExec.ErrorCategory)
+	s.Equal(outside.UTC(), dbExec.TargetDateTime.UTC())
+}
+
+func (s *ExtractTaskUseCaseTestSuite) TestStart_DuplicateRunningTargetDate() {
+	ctx := context.Background()
+	targetDate := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	otherDate := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	uc := s.newUseCase(new(BrandDataFetcherMock))
+	req := &ExtractTaskRequest{Source: "jquants", DataType: "brand", Timing: "daily", TargetDate: &targetDate}
+
+	_, err := uc.Start(ctx, req)
+	s.Require().NoError(err)
+
+	// A different target date is not a duplicate
+	_, err = uc.Start(ctx, &ExtractTaskRequest{
+		Source:     "jquants",
+		DataType:   "brand",
+		Timing:     "daily",
+		TargetDate: &otherDate,
+	})
+	s.Require().NoError(err)
+
+	_, err = uc.Start(ctx, req)
+	s.ErrorIs(err, extract.ErrExecutionAlreadyRunning)
+
+	var execCount int64
+	s.db.Model(&repository.ExtractTaskExecution{}).Count(&execCount)
+	s.Equal(int64(2), execCount)
+}
+
+func (s *ExtractTaskUseCaseTestSuite) TestStart_RecoversStaleExecution() {
+	startedAt := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	targetDate := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	req := &ExtractTaskRequest{Source: "jquants", DataType: "brand", Timing: "daily", TargetDate: &targetDate}
+	uc := s.newUseCase(new(BrandDataFetcherMock))
+
+	stale, err := uc.Start(clock.WithFixedTime(context.Background(), startedAt), req)
+	s.Require().NoError(err)
+
+	// brand has a stale timeout of 30 minutes; before it passes the run is still exclusive
+	_, err = uc.Start(clock.WithFixedTime(context.Background(), startedAt.Add(29*time.Minute)), req)
+	s.Require().ErrorIs(err, extract.ErrExecutionAlreadyRunning)
+
+	started, err := uc.Start(clock.WithFixedTime(context.Background(), startedAt.Add(30*time.Minute)), req)
+	s.Require().NoError(err)
+	s.NotEqual(stale.ID(), started.ID())
+
+	var dbExecs []repository.ExtractTaskExecution
+	s.Require().NoError(s.db.Order("id").Find(&dbExecs).Error)
+	s.Require().Len(dbExecs, 2)
+	s.Equal(string(extract.ExecutionStatusFailed), dbExecs[0].Status)
+	s.Require().NotNil(dbExecs[0].ErrorInfo)
+	s.Contains(*dbExecs[0].ErrorInfo, "stale")
+	s.Equal(string(extract.ExecutionStatusRunning), dbExecs[1].Status)
+}
+
+func (s *ExtractTaskUseCaseTestSuite) getS3Object(ctx context.Context, key string) ([]byte, *s3.GetObjectOutput) {
 	rawClient := s3.New(s3.Options{
 		BaseEndpoint: aws.String(s.s3Test.Endpoint),
 		Region:       testutil.TestS3Region,
@@ -226,5 +779,5 @@ func (s *ExtractTaskUseCaseTestSuite) getS3Object(ctx context.Context, key strin
 
 	body, err := io.ReadAll(result.Body)
 	s.Require().NoError(err)
-	return body
+	return body, result
 }
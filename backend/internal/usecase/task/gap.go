@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/domain/jquants"
+	"stock-tool/internal/util/clock"
+)
+
+// GapRepository finds the target dates a data type has already landed.
+type GapRepository interface {
+	// ListSucceededTargetDates returns the distinct target date times of the
+	// succeeded executions of source and dataType in [from, to).
+	ListSucceededTargetDates(
+		ctx context.Context,
+		source string,
+		dataType string,
+		from time.Time,
+		to time.Time,
+	) ([]time.Time, error)
+}
+
+type GapRequest struct {
+	Source   string
+	DataType string
+	// StartDate and EndDate bound the target dates to check, both inclusive,
+	// as calendar dates in the timezone of the data source.
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+type GapReport struct {
+	// Expected is the number of target dates in the range the data type is
+	// expected to have.
+	Expected int
+	// Gaps are the expected target dates without a succeeded execution, as
+	// midnight in the timezone of the data source, oldest first.
+	Gaps []time.Time
+}
+
+type GapUseCase struct {
+	repo           GapRepository
+	dataSourceRepo DataSourceRepository
+	dataTypeRepo   DataTypeRepository
+}
+
+func NewGapUseCase(
+	repo GapRepository,
+	dataSourceRepo DataSourceRepository,
+	dataTypeRepo DataTypeRepository,
+) *GapUseCase {
+	return &GapUseCase{repo: repo, dataSourceRepo: dataSourceRepo, dataTypeRepo: dataTypeRepo}
+}
+
+// Detect returns the gaps of a data type (FR-4): the expected target dates
+// of the range without a succeeded execution. A data type that is not a
+// backfill target (D5) expects no dates.
+//
+// See expectedDates for the dates a data type is expected to have.
+func (uc *GapUseCase) Detect(ctx context.Context, req *GapRequest) (*GapReport, error) {
+	src, err := uc.dataSourceRepo.FindByName(ctx, req.Source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data source: %w", err)
+	}
+	if src == nil {
+		return nil, fmt.Errorf("data source %s is not configured", req.Source)
+	}
+	dt, err := uc.dataTypeRepo.FindByName(ctx, src.ID(), req.DataType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find data type: %w", err)
+	}
+	if dt == nil {
+		return nil, fmt.Errorf("data type %s/%s is not configured", req.Source, req.DataType)
+	}
+
+	loc := src.Timezone()
+	start := dateIn(req.StartDate, loc)
+	end := dateIn(req.EndDate, loc)
+	if end.Before(start) {
+		return nil, fmt.Errorf(
+			"end date %s is before start date %s",
+			end.Format(time.DateOnly), start.Format(time.DateOnly),
+		)
+	}
+	report := &GapReport{Gaps: []time.Time{}}
+	if !dt.BackfillEnabled() {
+		return report, nil
+	}
+	expected, err := expectedDates(src, start, end, clock.Now(ctx).In(loc))
+	if err != nil {
+		return nil, err
+	}
+	report.Expected = len(expected)
+
+	succeeded, err := uc.repo.ListSucceededTargetDates(ctx, src.Name(), dt.Name(), start, end.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list succeeded executions: %w", err)
+	}
+	landed := make(map[string]bool, len(succeeded))
+	for _, d := range succeeded {
+		landed[d.In(loc).Format(time.DateOnly)] = true
+	}
+	for _, d := range expected {
+		if !landed[d.Format(time.DateOnly)] {
+			report.Gaps = append(report.Gaps, d)
+		}
+	}
+	return report, nil
+}
+
+// expectedDates returns the target dates from start through end that a data
+// type of src is expected to have, as of now. J-Quants publishes on weekdays
+// only and its plan bounds what can be fetched, so weekends and dates outside
+// the plan are left out; holidays need the trading calendar and are still
+// expected. Other sources expect every date.
+func expectedDates(src *ingestion.DataSource, start, end, now time.Time) ([]time.Time, error) {
+	var dates []time.Time
+	plan, ok, err := jquants.SourcePlan(src)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			dates = append(dates, d)
+		}
+		return dates, nil
+	}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			dates = append(dates, d)
+		}
+	}
+	return plan.FilterTargetDates(dates, now), nil
+}
+
+// dateIn interprets the calendar date of t as midnight in loc.
+func dateIn(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/domain/jquants"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/testutil"
+)
+
+type GapUseCaseTestSuite struct {
+	testutil.DBTest
+	repo *repository.ExtractTaskRepository
+	uc   *GapUseCase
+	jst  *time.Location
+}
+
+func TestGapUseCase(t *testing.T) {
+	suite.Run(t, new(GapUseCaseTestSuite))
+}
+
+func (s *GapUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+	s.repo = repository.NewExtractTaskRepository(db)
+	dsRepo := repository.NewDataSourceRepository(db)
+	dtRepo := repository.NewDataTypeRepository(db)
+	s.uc = NewGapUseCase(s.repo, dsRepo, dtRepo)
+	s.jst, err = time.LoadLocation("Asia/Tokyo")
+	s.Require().NoError(err)
+
+	ctx := context.Background()
+	src, err := ingestion.NewDataSource(
+		ctx, jquants.Kind, "jquants", true, "Asia/Tokyo", ingestion.RetentionPolicy{}, map[string]any{"plan": "free"},
+	)
+	s.Require().NoError(err)
+	_, err = dsRepo.Create(ctx, src)
+	s.Require().NoError(err)
+	schedule, err := ingestion.NewDailySchedule([]ingestion.TimeOfDay{"16:30"})
+	s.Require().NoError(err)
+	for name, backfill := range map[string]bool{"daily_quotes": true, "earnings_calendar": false} {
+		_, err = dtRepo.Create(ctx, ingestion.NewDataType(
+			ctx,
+			src.ID(),
+			name,
+			true,
+			schedule,
+			backfill,
+			30,
+			ingestion.RerunStrategyAppend,
+			ingestion.EmptyResponsePolicySuccess,
+			ingestion.CompressionNone,
+			ingestion.RetentionPolicy{},
+			map[string]any{},
+		))
+		s.Require().NoError(err)
+	}
+}
+
+func (s *GapUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+// run records a succeeded or failed execution of dataType for target.
+func (s *GapUseCaseTestSuite) run(dataType string, target time.Time, succeed bool) {
+	ctx := context.Background()
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", dataType, "daily")
+	s.Require().NoError(err)
+	if task == nil {
+		s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", dataType, "daily")))
+		task, err = s.repo.FindBySourceAndDataType(ctx, "jquants", dataType, "daily")
+		s.Require().NoError(err)
+	}
+	exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, target))
+	s.Require().NoError(err)
+	if succeed {
+		exec.Succeed(ctx)
+	} else {
+		exec.Fail(ctx, "failed")
+	}
+	s.Require().NoError(s.repo.UpdateExecution(ctx, exec))
+}
+
+func (s *GapUseCaseTestSuite) TestDetect() {
+	// Sunday; the free plan serves up to 2026-07-26, 12 weeks ago.
+	ctx := clock.WithFixedTime(context.Background(), time.Date(2026, 10, 18, 12, 0, 0, 0, s.jst))
+	date := func(d int) time.Time { return time.Date(2026, 7, d, 0, 0, 0, 0, s.jst) }
+	s.run("daily_quotes", date(21), true)
+	s.run("daily_quotes", date(23), true)
+	// distractors: a failed execution and an execution of another data type
+	s.run("daily_quotes", date(22), false)
+	s.run("earnings_calendar", date(20), true)
+
+	// Monday 2026-07-20 through Tuesday 2026-07-28
+	report, err := s.uc.Detect(ctx, &GapRequest{
+		Source:    "jquants",
+		DataType:  "daily_quotes",
+		StartDate: time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 7, 28, 0, 0, 0, 0, time.UTC),
+	})
+	s.Require().NoError(err)
+	// The weekend and the dates after the free plan delay are not expected.
+	s.Equal(5, report.Expected)
+	s.Equal(
+		[]string{"2026-07-20", "2026-07-22", "2026-07-24"},
+		lo.Map(report.Gaps, func(d time.Time, _ int) string { return d.Format(time.DateOnly) }),
+	)
+	s.Equal(s.jst.String(), report.Gaps[0].Location().String())
+
+	report, err = s.uc.Detect(ctx, &GapRequest{
+		Source:    "jquants",
+		DataType:  "earnings_calendar",
+		StartDate: date(20),
+		EndDate:   date(28),
+	})
+	s.Require().NoError(err)
+	s.Equal(&GapReport{Gaps: []time.Time{}}, report, "a data type that is not a backfill target has no gaps")
+
+	_, err = s.uc.Detect(ctx, &GapRequest{
+		Source:    "jquants",
+		DataType:  "daily_quotes",
+		StartDate: date(28),
+		EndDate:   date(20),
+	})
+	s.EqualError(err, "end date 2026-07-20 is before start date 2026-07-28")
+
+	_, err = s.uc.Detect(ctx, &GapRequest{Source: "jquants", DataType: "brand", StartDate: date(20), EndDate: date(20)})
+	s.EqualError(err, "data type jquants/brand is not configured")
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/util/clock"
+)
+
+// gcPageSize is the number of executions read per page while collecting the
+// landing files of a data type.
+const gcPageSize = 100
+
+// ObjectDeleter removes objects from object storage.
+type ObjectDeleter interface {
+	// DeleteObjectVersion permanently removes one version of the object under
+	// key. An empty versionID removes the object of an unversioned store.
+	DeleteObjectVersion(ctx context.Context, key, versionID string) error
+}
+
+// RetentionRepository reads the landing files of a data type and records
+// the ones that were purged.
+type RetentionRepository interface {
+	// ListExecutions returns the executions of source and dataType with their
+	// files, newest first, a page at a time.
+	ListExecutions(
+		ctx context.Context,
+		source string,
+		dataType string,
+		beforeID *int,
+		limit int,
+	) ([]*extract.ExtractTaskExecution, error)
+	UpdateExtractedDataS3(ctx context.Context, s3File *extract.ExtractedDataS3) error
+}
+
+// RetentionPolicyLister lists the data types that have a retention policy of
+// their own or from their data source.
+type RetentionPolicyLister interface {
+	ListRetentionPolicies(ctx context.Context) ([]ingestion.RetentionTarget, error)
+}
+
+type GCRequest struct {
+	// Source limits collection to one data source. Empty means every source.
+	Source string
+	// DataType limits collection to one data type. Empty means every type.
+	DataType string
+	// DryRun reports what would be purged without deleting anything.
+	DryRun bool
+}
+
+type GCFile struct {
+	Source         string
+	DataType       string
+	Key            string
+	VersionID      string
+	TargetDateTime time.Time
+	// ObjectDeleted is false when only the record was marked purged, since a
+	// kept file of an unversioned bucket still holds the object under the key.
+	ObjectDeleted bool
+}
+
+type GCReport struct {
+	DryRun bool
+	// Checked is the number of unpurged files of the data types with a policy.
+	Checked int
+	// Kept is the number of checked files that were not purged.
+	Kept   int
+	Purged []GCFile
+}
+
+type GCUseCase struct {
+	objects  ObjectDeleter
+	repo     RetentionRepository
+	policies RetentionPolicyLister
+}
+
+func NewGCUseCase(objects ObjectDeleter, repo RetentionRepository, policies RetentionPolicyLister) *GCUseCase {
+	return &GCUseCase{
+		objects:  objects,
+		repo:     repo,
+		policies: policies,
+	}
+}
+
+// GC purges the superseded landing files that the retention policy of their
+// data type, or of its data source when the data type sets no rule, no longer
+// keeps.
+//
+// Processing flow:
+//  1. List the data types with a retention policy matching the request
+//  2. Collect the unpurged files of each, grouped by target date in the
+//     timezone of the data source and window
+//  3. Rank the files of each group newest first and select the superseded
+//     ones the policy does not keep
+//  4. Delete each selected object, or its version in a versioned bucket, and
+//     mark its record purged
+//
+// The current file of a date and window, the newest file of a succeeded
+// execution, is never purged, and neither are files written after it. A
+// window without a current file is left alone, so a failed re-run never costs
+// the last good copy, and a file of one window never supersedes another.
+func (uc *GCUseCase) GC(ctx context.Context, req *GCRequest) (*GCReport, error) {
+	report := &GCReport{DryRun: req.DryRun, Purged: []GCFile{}}
+
+	// 1. List policies
+	targets, err := uc.policies.ListRetentionPolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list retention policies: %w", err)
+	}
+
+	for _, target := range targets {
+		if req.Source != "" && target.Source != req.Source {
+			continue
+		}
+		if req.DataType != "" && target.DataType != req.DataType {
+			continue
+		}
+
+		// 2. Collect files
+		groups, err := uc.collect(ctx, target.Source, target.DataType, target.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect files of %s/%s: %w", target.Source, target.DataType, err)
+		}
+
+		// 3. Select files to purge
+		now := clock.Now(ctx)
+		var purge []gcCandidate
+		keptKeys := map[string]bool{}
+		for _, files := range groups {
+			for i, f := range files {
+				report.Checked++
+				if f.superseded && !target.Policy.Keeps(i+1, now.Sub(f.file.CreatedAt())) {
+					purge = append(purge, f)
+					continue
+				}
+				report.Kept++
+				keptKeys[f.file.Key()] = true
+			}
+		}
+
+		// 4. Purge
+		for _, f := range purge {
+			key, versionID := f.file.Key(), f.file.Version().ID
+			deleteObject := versionID != "" || !keptKeys[key]
+			if !req.DryRun {
+				if err := uc.purge(ctx, f.file, deleteObject); err != nil {
+					return nil, fmt.Errorf("failed to purge %s: %w", key, err)
+				}
+			}
+			report.Purged = append(report.Purged, GCFile{
+				Source:         target.Source,
+				DataType:       target.DataType,
+				Key:            key,
+				VersionID:      versionID,
+				TargetDateTime: f.targetDateTime,
+				ObjectDeleted:  deleteObject,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+type gcCandidate struct {
+	file           *extract.ExtractedDataS3
+	targetDateTime time.Time
+	// superseded is whether a newer file of a succeeded execution holds the
+	// data of the same date and window.
+	superseded bool
+}
+
+// gcGroup is the target date and window the files of a group hold.
+type gcGroup struct {
+	date   string
+	window string
+}
+
+// collect returns the unpurged files of source and dataType grouped by the
+// date of their execution's target in loc, the timezone of the source, and
+// their window, each group ordered newest first.
+func (uc *GCUseCase) collect(
+	ctx context.Context,
+	source string,
+	dataType string,
+	loc *time.Location,
+) ([][]gcCandidate, error) {
+	var order []gcGroup
+	byGroup := map[gcGroup][]gcCandidate{}
+	current := map[gcGroup]bool{}
+
+	var beforeID *int
+	for {
+		executions, err := uc.repo.ListExecutions(ctx, source, dataType, beforeID, gcPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range executions {
+			date := e.TargetDateTime().In(loc).Format(time.DateOnly)
+			files := e.S3Files()
+			// Files are ordered by ID; walk them newest first
+			for i := len(files) - 1; i >= 0; i-- {
+				f := files[i]
+				if f.PurgedAt() != nil {
+					continue
+				}
+				group := gcGroup{date: date, window: f.Window()}
+				if _, ok := byGroup[group]; !ok {
+					order = append(order, group)
+				}
+				byGroup[group] = append(byGroup[group], gcCandidate{
+					file:           f,
+					targetDateTime: e.TargetDateTime().UTC(),
+					superseded:     current[group],
+				})
+				if e.Status() == extract.ExecutionStatusSucceeded {
+					current[group] = true
+				}
+			}
+		}
+		if len(executions) < gcPageSize {
+			break
+		}
+		lastID := executions[len(executions)-1].ID()
+		beforeID = &lastID
+	}
+
+	groups := make([][]gcCandidate, 0, len(order))
+	for _, group := range order {
+		groups = append(groups, byGroup[group])
+	}
+	return groups, nil
+}
+
+// purge removes the object of f, if deleteObject is set, and marks f purged.
+// The object is removed first, so a failure leaves the record to retry.
+func (uc *GCUseCase) purge(ctx context.Context, f *extract.ExtractedDataS3, deleteObject bool) error {
+	if deleteObject {
+		if err := uc.objects.DeleteObjectVersion(ctx, f.Key(), f.Version().ID); err != nil {
+			return fmt.Errorf("failed to delete object: %w", err)
+		}
+	}
+	f.Purge(ctx)
+	if err := uc.repo.UpdateExtractedDataS3(ctx, f); err != nil {
+		return fmt.Errorf("failed to mark file purged: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/domain/ingestion"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
+	"stock-tool/internal/util/clock"
+	"stock-tool/internal/util/testutil"
+)
+
+type stubPolicyLister []ingestion.RetentionTarget
+
+func (l stubPolicyLister) ListRetentionPolicies(context.Context) ([]ingestion.RetentionTarget, error) {
+	return l, nil
+}
+
+type GCUseCaseTestSuite struct {
+	testutil.DBTest
+	repo    *repository.ExtractTaskRepository
+	objects *storage.FSClient
+	now     time.Time
+}
+
+func TestGCUseCase(t *testing.T) {
+	suite.Run(t, new(GCUseCaseTestSuite))
+}
+
+func (s *GCUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+	s.repo = repository.NewExtractTaskRepository(db)
+	s.objects = storage.NewFSClient(s.T().TempDir())
+	s.now = time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *GCUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+// run records an execution of brand for target that wrote key ageDays days
+// before s.now, and stores its object.
+func (s *GCUseCaseTestSuite) run(target time.Time, key string, ageDays int, succeed bool) {
+	s.runWindow(target, key, "all", ageDays, succeed)
+}
+
+// runWindow is run for a file of window.
+func (s *GCUseCaseTestSuite) runWindow(target time.Time, key string, window string, ageDays int, succeed bool) {
+	ctx := context.Background()
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+	s.Require().NoError(err)
+	if task == nil {
+		s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", "brand", "daily")))
+		task, err = s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+		s.Require().NoError(err)
+	}
+	exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, target))
+	s.Require().NoError(err)
+	createdAt := s.now.AddDate(0, 0, -ageDays)
+	_, err = s.repo.CreateExtractedDataS3(ctx, exec.ID(),
+		extract.NewExtractedDataS3Directly(0, key, window, nil, extract.ObjectVersion{}, "", nil, createdAt, createdAt))
+	s.Require().NoError(err)
+	if succeed {
+		exec.Succeed(ctx)
+	} else {
+		exec.Fail(ctx, "failed")
+	}
+	s.Require().NoError(s.repo.UpdateExecution(ctx, exec))
+	_, err = s.objects.PutObject(ctx, key, []byte(`{"info":[]}`), "application/json", nil)
+	s.Require().NoError(err)
+}
+
+func (s *GCUseCaseTestSuite) storedKeys() []string {
+	var keys []string
+	for obj, err := range s.objects.ListObjects(context.Background(), "landing/") {
+		s.Require().NoError(err)
+		keys = append(keys, obj.Key)
+	}
+	return keys
+}
+
+func (s *GCUseCaseTestSuite) TestGC() {
+	ctx := clock.WithFixedTime(context.Background(), s.now)
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// day 1: three superseded files and the current one, then a failed re-run
+	s.run(day, "landing/jquants/brand/1-a.json", 40, true)
+	s.run(day, "landing/jquants/brand/1-b.json", 35, true)
+	s.run(day, "landing/jquants/brand/1-c.json", 10, true)
+	s.run(day, "landing/jquants/brand/1-d.json", 5, true)
+	s.run(day, "landing/jquants/brand/1-e.json", 1, false)
+	// day 2: no succeeded execution, so nothing is superseded
+	s.run(day.AddDate(0, 0, 1), "landing/jquants/brand/2-a.json", 40, false)
+	s.run(day.AddDate(0, 0, 1), "landing/jquants/brand/2-b.json", 35, false)
+	// day 3: overwrite re-runs recording the same key
+	s.run(day.AddDate(0, 0, 2), "landing/jquants/brand/3.json", 45, true)
+	s.run(day.AddDate(0, 0, 2), "landing/jquants/brand/3.json", 40, true)
+	s.run(day.AddDate(0, 0, 2), "landing/jquants/brand/3.json", 35, true)
+
+	keepLatest, maxAgeDays := 2, 30
+	policy, err := ingestion.NewRetentionPolicy(&keepLatest, &maxAgeDays)
+	s.Require().NoError(err)
+	lister := stubPolicyLister{{Source: "jquants", DataType: "brand", Timezone: time.UTC, Policy: policy}}
+	uc := NewGCUseCase(s.objects, s.repo, lister)
+
+	expected := &GCReport{
+		Checked: 10,
+		Kept:    7,
+		Purged: []GCFile{
+			// the key is still held by the kept re-runs
+			{
+				Source: "jquants", DataType: "brand", Key: "landing/jquants/brand/3.json",
+				TargetDateTime: day.AddDate(0, 0, 2), ObjectDeleted: false,
+			},
+			{
+				Source: "jquants", DataType: "brand", Key: "landing/jquants/brand/1-b.json",
+				TargetDateTime: day, ObjectDeleted: true,
+			},
+			{
+				Source: "jquants", DataType: "brand", Key: "landing/jquants/brand/1-a.json",
+				TargetDateTime: day, ObjectDeleted: true,
+			},
+		},
+	}
+	allKeys := s.storedKeys()
+
+	s.Run("dry run", func() {
+		report, err := uc.GC(ctx, &GCRequest{DryRun: true})
+		s.Require().NoError(err)
+		dryRun := *expected
+		dryRun.DryRun = true
+		s.Equal(&dryRun, report)
+		s.Equal(allKeys, s.storedKeys())
+	})
+
+	s.Run("filtered out", func() {
+		report, err := uc.GC(ctx, &GCRequest{DataType: "listed_info"})
+		s.Require().NoError(err)
+		s.Equal(&GCReport{Purged: []GCFile{}}, report)
+	})
+
+	s.Run("purge", func() {
+		report, err := uc.GC(ctx, &GCRequest{Source: "jquants", DataType: "brand"})
+		s.Require().NoError(err)
+		s.Equal(expected, report)
+		s.Equal([]string{
+			"landing/jquants/brand/1-c.json",
+			"landing/jquants/brand/1-d.json",
+			"landing/jquants/brand/1-e.json",
+			"landing/jquants/brand/2-a.json",
+			"landing/jquants/brand/2-b.json",
+			"landing/jquants/brand/3.json",
+		}, s.storedKeys())
+
+		executions, err := s.repo.ListExecutions(ctx, "jquants", "brand", nil, 10)
+		s.Require().NoError(err)
+		purged := lo.FilterMap(executions, func(e *extract.ExtractTaskExecution, _ int) (int, bool) {
+			return e.ID(), e.S3Files()[0].PurgedAt() != nil
+		})
+		s.Len(purged, 3)
+	})
+
+	s.Run("idempotent", func() {
+		report, err := uc.GC(ctx, &GCRequest{})
+		s.Require().NoError(err)
+		s.Equal(&GCReport{Checked: 7, Kept: 7, Purged: []GCFile{}}, report)
+	})
+}
+
+func (s *GCUseCaseTestSuite) TestGC_GroupsByDateInSourceTimezone() {
+	ctx := clock.WithFixedTime(context.Background(), s.now)
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	s.Require().NoError(err)
+
+	// Both runs target 2026-10-02 in JST, though their UTC dates differ
+	s.run(time.Date(2026, 10, 2, 0, 0, 0, 0, jst), "landing/jquants/brand/2-a.json", 10, true)
+	s.run(time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), "landing/jquants/brand/2-b.json", 5, true)
+	// distractor: the only file of 2026-10-01 in JST
+	s.run(time.Date(2026, 10, 1, 0, 0, 0, 0, jst), "landing/jquants/brand/1.json", 20, true)
+
+	keepLatest := 1
+	policy, err := ingestion.NewRetentionPolicy(&keepLatest, nil)
+	s.Require().NoError(err)
+	lister := stubPolicyLister{{Source: "jquants", DataType: "brand", Timezone: jst, Policy: policy}}
+	uc := NewGCUseCase(s.objects, s.repo, lister)
+
+	report, err := uc.GC(ctx, &GCRequest{})
+	s.Require().NoError(err)
+	expected := &GCReport{
+		Checked: 3,
+		Kept:    2,
+		Purged: []GCFile{{
+			Source: "jquants", DataType: "brand", Key: "landing/jquants/brand/2-a.json",
+			TargetDateTime: time.Date(2026, 10, 1, 15, 0, 0, 0, time.UTC), ObjectDeleted: true,
+		}},
+	}
+	s.Equal(expected, report)
+	s.Equal([]string{"landing/jquants/brand/1.json", "landing/jquants/brand/2-b.json"}, s.storedKeys())
+}
+
+func (s *GCUseCaseTestSuite) TestGC_GroupsByWindow() {
+	ctx := clock.WithFixedTime(context.Background(), s.now)
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// The only file of one code; newer files of the whole market do not
+	// supersede it
+	s.runWindow(day, "landing/jquants/brand/2026/10/01/code-86970.json", "code-86970", 20, true)
+	s.runWindow(day, "landing/jquants/brand/2026/10/01/all-a.json", "all", 10, true)
+	s.runWindow(day, "landing/jquants/brand/2026/10/01/all-b.json", "all", 5, true)
+
+	keepLatest := 1
+	policy, err := ingestion.NewRetentionPolicy(&keepLatest, nil)
+	s.Require().NoError(err)
+	lister := stubPolicyLister{{Source: "jquants", DataType: "brand", Timezone: time.UTC, Policy: policy}}
+	uc := NewGCUseCase(s.objects, s.repo, lister)
+
+	report, err := uc.GC(ctx, &GCRequest{})
+	s.Require().NoError(err)
+	expected := &GCReport{
+		Checked: 3,
+		Kept:    2,
+		Purged: []GCFile{{
+			Source: "jquants", DataType: "brand", Key: "landing/jquants/brand/2026/10/01/all-a.json",
+			TargetDateTime: day, ObjectDeleted: true,
+		}},
+	}
+	s.Equal(expected, report)
+	s.Equal([]string{
+		"landing/jquants/brand/2026/10/01/all-b.json",
+		"landing/jquants/brand/2026/10/01/code-86970.json",
+	}, s.storedKeys())
+}
@@ -0,0 +1,422 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"stock-tool/internal/domain/extract"
+)
+
+// ReindexRepository recreates the records of landed files.
+type ReindexRepository interface {
+	// Create persists a new ExtractTask.
+	Create(ctx context.Context, task *extract.ExtractTask) error
+	// FindBySourceAndDataType returns the task matching the given key,
+	// or (nil, nil) if not found.
+	FindBySourceAndDataType(
+		ctx context.Context,
+		source string,
+		dataType string,
+		timing string,
+	) (*extract.ExtractTask, error)
+	// CreateExecution persists a new execution, with its S3 files, under the
+	// given task.
+	CreateExecution(
+		ctx context.Context,
+		taskID int,
+		exec *extract.ExtractTaskExecution,
+	) (*extract.ExtractTaskExecution, error)
+	// ListExtractedDataS3sByKeyPrefix returns every record whose key starts
+	// with prefix, purged or not.
+	ListExtractedDataS3sByKeyPrefix(ctx context.Context, prefix string) ([]*extract.ExtractedDataS3, error)
+}
+
+type ReindexRequest struct {
+	Source string
+	// DataType limits the rebuild to one data type. Empty means every type.
+	DataType string
+	// Timing is the task timing of files without a manifest.
+	Timing string
+	// Location is the source timezone, in which the date of an overwrite key
+	// without a manifest is read. Nil means UTC.
+	Location *time.Location
+	// DryRun reports what would be created without writing anything.
+	DryRun bool
+}
+
+type ReindexConflict struct {
+	Key    string
+	Detail string
+}
+
+type ReindexReport struct {
+	DryRun            bool
+	CreatedTasks      int
+	CreatedExecutions int
+	CreatedFiles      int
+	// Existing is the number of files that already had a record.
+	Existing int
+	// Skipped is the number of files listed by a manifest whose object no
+	// longer exists, such as files purged by task gc.
+	Skipped   int
+	Conflicts []ReindexConflict
+}
+
+type ReindexUseCase struct {
+	objects ObjectInspector
+	repo    ReindexRepository
+}
+
+func NewReindexUseCase(objects ObjectInspector, repo ReindexRepository) *ReindexUseCase {
+	return &ReindexUseCase{
+		objects: objects,
+		repo:    repo,
+	}
+}
+
+// reindexVersion identifies a version of a landed object, as recorded.
+type reindexVersion struct {
+	key       string
+	versionID string
+}
+
+// reindexRun is an execution to recreate, with the files it landed.
+type reindexRun struct {
+	// key is the manifest key, or the object key of a file without one.
+	key            string
+	source         string
+	dataType       string
+	timing         string
+	targetDateTime time.Time
+	startedAt      time.Time
+	finishedAt     time.Time
+	files          []*extract.ExtractedDataS3
+}
+
+// Reindex recreates the task, execution and file records of landed files
+// that have none, from the landing keys and manifests of a source.
+//
+// Processing flow:
+//  1. List the landed objects and the existing records under the source's
+//     landing prefix
+//  2. Read each manifest; one whose execution has no records becomes an
+//     execution with the files whose key and version have no record either,
+//     taking everything from the manifest
+//  3. Parse the key of each object no manifest lists and that has no record;
+//     it becomes an execution of its own, with the metadata of the object
+//  4. Create the executions in the order they were fetched, finding or
+//     creating their tasks
+//
+// Existing records are left alone, so running it again creates nothing.
+// A manifest that disagrees with the records of its execution, an invalid
+// manifest and a key that is not a landing key are reported as conflicts.
+func (uc *ReindexUseCase) Reindex(ctx context.Context, req *ReindexRequest) (*ReindexReport, error) {
+	report := &ReindexReport{DryRun: req.DryRun, Conflicts: []ReindexConflict{}}
+	prefix := extract.FileFilter{Source: req.Source, DataType: req.DataType}.KeyPrefix()
+
+	// 1. List objects and records
+	objects := map[string]extract.ObjectSummary{}
+	for obj, err := range uc.objects.ListObjects(ctx, prefix) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+		}
+		objects[obj.Key] = obj
+	}
+	records, err := uc.repo.ListExtractedDataS3sByKeyPrefix(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list records under %s: %w", prefix, err)
+	}
+	recordedKeys := map[string]bool{}
+	recordedVersions := map[reindexVersion]bool{}
+	byManifest := map[string][]*extract.ExtractedDataS3{}
+	for _, r := range records {
+		recordedKeys[r.Key()] = true
+		recordedVersions[reindexVersion{key: r.Key(), versionID: r.Version().ID}] = true
+		if r.ManifestKey() != "" {
+			byManifest[r.ManifestKey()] = append(byManifest[r.ManifestKey()], r)
+		}
+	}
+
+	// 2. Read manifests
+	var runs []reindexRun
+	listed := map[string]bool{}
+	manifestPrefix := extract.ManifestKeyPrefix + strings.TrimPrefix(prefix, "landing/")
+	for obj, err := range uc.objects.ListObjects(ctx, manifestPrefix) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list manifests under %s: %w", manifestPrefix, err)
+		}
+		manifest, detail, err := uc.readManifest(ctx, obj.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest %s: %w", obj.Key, err)
+		}
+		if detail != "" {
+			report.Conflicts = append(report.Conflicts, ReindexConflict{Key: obj.Key, Detail: detail})
+			continue
+		}
+		for _, o := range manifest.Objects {
+			listed[o.Key] = true
+		}
+		window, err := manifest.Request.Window()
+		if err != nil {
+			report.Conflicts = append(report.Conflicts, ReindexConflict{Key: obj.Key, Detail: err.Error()})
+			continue
+		}
+		if recorded, ok := byManifest[obj.Key]; ok {
+			report.Existing += len(recorded)
+			report.Conflicts = append(report.Conflicts, compareManifest(obj.Key, manifest, recorded)...)
+			continue
+		}
+		run := reindexRun{
+			key:            obj.Key,
+			source:         manifest.Source,
+			dataType:       manifest.DataType,
+			timing:         manifest.Request.Timing,
+			targetDateTime: manifest.TargetDateTime,
+			startedAt:      manifest.FetchStartedAt,
+			finishedAt:     manifest.FetchFinishedAt,
+		}
+		for _, o := range manifest.Objects {
+			if _, ok := objects[o.Key]; !ok {
+				report.Skipped++
+				continue
+			}
+			// A file recorded without this manifest, such as one recorded
+			// before manifests were written, already has its record
+			if recordedVersions[reindexVersion{key: o.Key, versionID: o.VersionID}] {
+				report.Existing++
+				continue
+			}
+			file := reindexedFile(o.Key, window, o.Metadata(), o.Version(), obj.Key, run.finishedAt)
+			run.files = append(run.files, file)
+		}
+		if len(run.files) > 0 {
+			runs = append(runs, run)
+		}
+	}
+
+	// 3. Parse keys without a manifest
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if listed[key] || strings.HasPrefix(key, extract.ManifestKeyPrefix) {
+			continue
+		}
+		if recordedKeys[key] {
+			report.Existing++
+			continue
+		}
+		run, detail, err := uc.parseObject(ctx, req, objects[key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", key, err)
+		}
+		if detail != "" {
+			report.Conflicts = append(report.Conflicts, ReindexConflict{Key: key, Detail: detail})
+			continue
+		}
+		if run != nil {
+			runs = append(runs, *run)
+		}
+	}
+
+	// 4. Create records
+	slices.SortStableFunc(runs, func(a, b reindexRun) int {
+		return cmp.Or(a.startedAt.Compare(b.startedAt), strings.Compare(a.key, b.key))
+	})
+	tasks := map[string]int{}
+	for _, run := range runs {
+		taskID, created, err := uc.findOrCreateTask(ctx, tasks, run, req.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			report.CreatedTasks++
+		}
+		if !req.DryRun {
+			execution := extract.NewReindexedExecution(ctx, run.targetDateTime, run.startedAt, run.finishedAt)
+			for _, f := range run.files {
+				execution.AddS3File(f)
+			}
+			if _, err := uc.repo.CreateExecution(ctx, taskID, execution); err != nil {
+				return nil, fmt.Errorf("failed to create execution for %s: %w", run.key, err)
+			}
+		}
+		report.CreatedExecutions++
+		report.CreatedFiles += len(run.files)
+	}
+
+	return report, nil
+}
+
+// readManifest reads the manifest under key. A manifest that cannot be used
+// is returned as a conflict detail instead of an error.
+func (uc *ReindexUseCase) readManifest(ctx context.Context, key string) (*extract.Manifest, string, error) {
+	body, err := uc.objects.GetObject(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	if body == nil {
+		return nil, "manifest was deleted while listing", nil
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, "", err
+	}
+	manifest, err := extract.ParseManifest(data)
+	if err != nil {
+		return nil, err.Error(), nil
+	}
+	want := extract.GenerateManifestKey(
+		manifest.Source, manifest.DataType, manifest.TargetDateTime, manifest.ExecutionID,
+	)
+	if want != key {
+		return nil, fmt.Sprintf("manifest content belongs under %s", want), nil
+	}
+	return manifest, "", nil
+}
+
+// compareManifest reports the files of manifest that its execution's records
+// do not hold as listed.
+func compareManifest(key string, manifest *extract.Manifest, recorded []*extract.ExtractedDataS3) []ReindexConflict {
+	var conflicts []ReindexConflict
+	for _, o := range manifest.Objects {
+		i := slices.IndexFunc(recorded, func(r *extract.ExtractedDataS3) bool { return r.Key() == o.Key })
+		if i < 0 {
+			conflicts = append(conflicts, ReindexConflict{
+				Key:    key,
+				Detail: fmt.Sprintf("manifest lists %s, which the records of its execution do not", o.Key),
+			})
+			continue
+		}
+		if md := recorded[i].Metadata(); md != nil && md.SHA256 != o.SHA256 {
+			conflicts = append(conflicts, ReindexConflict{
+				Key:    o.Key,
+				Detail: fmt.Sprintf("recorded sha256 is %s, manifest %s has %s", md.SHA256, key, o.SHA256),
+			})
+		}
+	}
+	return conflicts
+}
+
+// parseObject returns the execution of a landed object without a manifest,
+// taking the target date from its key and the metadata from the object. An
+// object that cannot be used is returned as a conflict detail; one deleted
+// since it was listed returns nothing.
+func (uc *ReindexUseCase) parseObject(
+	ctx context.Context,
+	req *ReindexRequest,
+	obj extract.ObjectSummary,
+) (*reindexRun, string, error) {
+	parsed, err := extract.ParseLandingKey(obj.Key)
+	if err != nil {
+		return nil, err.Error(), nil
+	}
+	info, err := uc.objects.HeadObject(ctx, obj.Key)
+	if err != nil {
+		return nil, "", err
+	}
+	if info == nil {
+		return nil, "", nil
+	}
+	metadata, err := info.FileMetadata()
+	if err != nil {
+		return nil, err.Error(), nil
+	}
+
+	// An append key is dated by its run time, an overwrite key by the
+	// target date in the source timezone. Only an overwrite key names its
+	// window; an append key is taken to hold the whole date.
+	var target time.Time
+	window := parsed.Window
+	if parsed.ExecutedAt != nil {
+		target = *parsed.ExecutedAt
+		window = extract.FileWindow(nil, nil, nil)
+	} else {
+		loc := req.Location
+		if loc == nil {
+			loc = time.UTC
+		}
+		target = time.Date(parsed.Date.Year(), parsed.Date.Month(), parsed.Date.Day(), 0, 0, 0, 0, loc)
+	}
+
+	var file *extract.ExtractedDataS3
+	version := extract.ObjectVersion{ID: info.VersionID}
+	if metadata != nil {
+		file = reindexedFile(obj.Key, window, *metadata, version, "", obj.LastModified)
+	} else {
+		file = extract.NewExtractedDataS3Directly(
+			0, obj.Key, window, nil, version, "", nil, obj.LastModified, obj.LastModified,
+		)
+	}
+	return &reindexRun{
+		key:            obj.Key,
+		source:         parsed.Source,
+		dataType:       parsed.DataType,
+		timing:         req.Timing,
+		targetDateTime: target,
+		startedAt:      obj.LastModified,
+		finishedAt:     obj.LastModified,
+		files:          []*extract.ExtractedDataS3{file},
+	}, "", nil
+}
+
+// reindexedFile returns the record of a file written at writtenAt, so that
+// retention ages it from when it landed rather than from the rebuild.
+func reindexedFile(
+	key string,
+	window string,
+	metadata extract.FileMetadata,
+	version extract.ObjectVersion,
+	manifestKey string,
+	writtenAt time.Time,
+) *extract.ExtractedDataS3 {
+	return extract.NewExtractedDataS3Directly(
+		0, key, window, &metadata, version, manifestKey, nil, writtenAt, writtenAt,
+	)
+}
+
+// findOrCreateTask returns the ID of the task of run, creating it if needed,
+// and whether it was created. IDs are cached in tasks. In a dry run a task
+// that would be created has ID 0.
+func (uc *ReindexUseCase) findOrCreateTask(
+	ctx context.Context,
+	tasks map[string]int,
+	run reindexRun,
+	dryRun bool,
+) (int, bool, error) {
+	cacheKey := run.source + "/" + run.dataType + "/" + run.timing
+	if id, ok := tasks[cacheKey]; ok {
+		return id, false, nil
+	}
+	task, err := uc.repo.FindBySourceAndDataType(ctx, run.source, run.dataType, run.timing)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to find extract task: %w", err)
+	}
+	if task != nil {
+		tasks[cacheKey] = task.ID()
+		return task.ID(), false, nil
+	}
+	if dryRun {
+		tasks[cacheKey] = 0
+		return 0, true, nil
+	}
+
+	if err := uc.repo.Create(ctx, extract.NewExtractTask(ctx, run.source, run.dataType, run.timing)); err != nil {
+		return 0, false, fmt.Errorf("failed to create extract task: %w", err)
+	}
+	// Re-fetch to get the assigned ID
+	task, err = uc.repo.FindBySourceAndDataType(ctx, run.source, run.dataType, run.timing)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to find created extract task: %w", err)
+	}
+	tasks[cacheKey] = task.ID()
+	return task.ID(), true, nil
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
+	"stock-tool/internal/util/testutil"
+)
+
+type ReindexUseCaseTestSuite struct {
+	testutil.DBTest
+	repo    *repository.ExtractTaskRepository
+	objects *storage.FSClient
+}
+
+func TestReindexUseCase(t *testing.T) {
+	suite.Run(t, new(ReindexUseCaseTestSuite))
+}
+
+func (s *ReindexUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+	s.repo = repository.NewExtractTaskRepository(db)
+	s.objects = storage.NewFSClient(s.T().TempDir())
+}
+
+func (s *ReindexUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+func (s *ReindexUseCaseTestSuite) put(key string, data []byte, metadata map[string]string) {
+	_, err := s.objects.PutObject(context.Background(), key, data, "application/json", metadata)
+	s.Require().NoError(err)
+}
+
+func (s *ReindexUseCaseTestSuite) TestReindex() {
+	ctx := context.Background()
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	data := []byte(`{"info":[]}`)
+	metadata := extract.NewFileMetadata(data, extract.FormatJSON, 200)
+
+	// A manifest whose second file was purged
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, jst)
+	fetchedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	listed := extract.GenerateOverwriteS3Key("jquants", "brand", day, "all", "json")
+	purged := extract.GenerateOverwriteS3Key("jquants", "brand", day, "code-86970", "json")
+	manifestKey := extract.GenerateManifestKey("jquants", "brand", day, 7)
+	manifest := &extract.Manifest{
+		ManifestVersion: extract.ManifestVersion,
+		Source:          "jquants",
+		DataType:        "brand",
+		TargetDate:      "2025-06-01",
+		TargetDateTime:  day,
+		ExecutionID:     7,
+		Request:         extract.NewManifestRequest("daily", nil, nil, nil),
+		FetchStartedAt:  fetchedAt,
+		FetchFinishedAt: fetchedAt.Add(time.Second),
+		Objects: []extract.ManifestObject{
+			extract.NewManifestObject(listed, metadata, extract.ObjectVersion{}),
+			extract.NewManifestObject(purged, metadata, extract.ObjectVersion{}),
+		},
+	}
+	manifestData, err := manifest.Marshal()
+	s.Require().NoError(err)
+	s.put(manifestKey, manifestData, nil)
+	s.put(listed, data, metadata.ObjectMetadata())
+
+	// An append key with its metadata, and a streamed overwrite key without
+	appended := extract.GenerateS3Key("jquants", "listed_info", time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC), "json")
+	s.put(appended, data, metadata.ObjectMetadata())
+	streamed := extract.GenerateOverwriteS3Key("jquants", "brand", time.Date(2025, 6, 3, 0, 0, 0, 0, jst), "all", "json")
+	_, err = s.objects.PutObjectStream(ctx, streamed, bytes.NewReader(data), "application/json",
+		extract.EncodingNone, map[string]string{extract.MetadataKeyFormat: "json"})
+	s.Require().NoError(err)
+
+	// Conflicts
+	invalidManifest := extract.GenerateManifestKey("jquants", "brand", day, 8)
+	s.put(invalidManifest, []byte(`{"manifest_version": 2}`), nil)
+	s.put("landing/jquants/brand/notes.json", data, nil)
+
+	conflicts := []ReindexConflict{
+		{Key: invalidManifest, Detail: "unsupported manifest version: 2"},
+		{Key: "landing/jquants/brand/notes.json", Detail: "unrecognized landing key: landing/jquants/brand/notes.json"},
+	}
+	uc := NewReindexUseCase(s.objects, s.repo)
+	req := &ReindexRequest{Source: "jquants", Timing: "daily", Location: jst}
+
+	s.Run("dry run", func() {
+		dryRun := *req
+		dryRun.DryRun = true
+		report, err := uc.Reindex(ctx, &dryRun)
+		s.Require().NoError(err)
+		s.Equal(&ReindexReport{
+			DryRun:            true,
+			CreatedTasks:      2,
+			CreatedExecutions: 3,
+			CreatedFiles:      3,
+			Skipped:           1,
+			Conflicts:         conflicts,
+		}, report)
+
+		task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+		s.Require().NoError(err)
+		s.Nil(task)
+	})
+
+	s.Run("reindex", func() {
+		report, err := uc.Reindex(ctx, req)
+		s.Require().NoError(err)
+		s.Equal(&ReindexReport{
+			CreatedTasks:      2,
+			CreatedExecutions: 3,
+			CreatedFiles:      3,
+			Skipped:           1,
+			Conflicts:         conflicts,
+		}, report)
+
+		// Newest first: the streamed file, then the manifest's execution
+		executions, err := s.repo.ListExecutions(ctx, "jquants", "brand", nil, 10)
+		s.Require().NoError(err)
+		s.Require().Len(executions, 2)
+
+		fromKey := executions[0]
+		s.Equal(extract.ExecutionStatusSucceeded, fromKey.Status())
+		s.True(fromKey.TargetDateTime().Equal(time.Date(2025, 6, 3, 0, 0, 0, 0, jst)))
+		s.Require().Len(fromKey.S3Files(), 1)
+		s.Equal(streamed, fromKey.S3Files()[0].Key())
+		s.Equal("all", fromKey.S3Files()[0].Window())
+		s.Nil(fromKey.S3Files()[0].Metadata())
+
+		fromManifest := executions[1]
+		s.Equal(extract.ExecutionStatusSucceeded, fromManifest.Status())
+		s.True(fromManifest.TargetDateTime().Equal(day))
+		s.True(fromManifest.StartedAt().Equal(fetchedAt))
+		s.Require().Len(fromManifest.S3Files(), 1)
+		file := fromManifest.S3Files()[0]
+		s.Equal(listed, file.Key())
+		s.Equal(manifestKey, file.ManifestKey())
+		s.Equal("all", file.Window())
+		s.Equal(metadata.SHA256, file.Metadata().SHA256)
+		s.True(file.CreatedAt().Equal(manifest.FetchFinishedAt))
+
+		executions, err = s.repo.ListExecutions(ctx, "jquants", "listed_info", nil, 10)
+		s.Require().NoError(err)
+		s.Require().Len(executions, 1)
+		s.Equal(appended, executions[0].S3Files()[0].Key())
+		s.Equal("all", executions[0].S3Files()[0].Window())
+		s.True(executions[0].TargetDateTime().Equal(time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)))
+		s.Equal(metadata, *executions[0].S3Files()[0].Metadata())
+	})
+
+	s.Run("idempotent", func() {
+		report, err := uc.Reindex(ctx, req)
+		s.Require().NoError(err)
+		s.Equal(&ReindexReport{Existing: 3, Conflicts: conflicts}, report)
+	})
+}
+
+func (s *ReindexUseCaseTestSuite) TestReindex_RecordedWithoutManifest() {
+	ctx := context.Background()
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	data := []byte(`{"info":[]}`)
+	metadata := extract.NewFileMetadata(data, extract.FormatJSON, 200)
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, jst)
+	fetchedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	// A file recorded without its manifest key, and one the manifest lists
+	// that has no record
+	recorded := extract.GenerateOverwriteS3Key("jquants", "brand", day, "all", "json")
+	unrecorded := extract.GenerateOverwriteS3Key("jquants", "brand", day, "code-86970", "json")
+	s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", "brand", "daily")))
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", "brand", "daily")
+	s.Require().NoError(err)
+	exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, day))
+	s.Require().NoError(err)
+	_, err = s.repo.CreateExtractedDataS3(
+		ctx, exec.ID(), extract.NewExtractedDataS3(ctx, recorded, "all", metadata, extract.ObjectVersion{}, ""),
+	)
+	s.Require().NoError(err)
+	exec.Succeed(ctx)
+	s.Require().NoError(s.repo.UpdateExecution(ctx, exec))
+
+	manifestKey := extract.GenerateManifestKey("jquants", "brand", day, 7)
+	manifest := &extract.Manifest{
+		ManifestVersion: extract.ManifestVersion,
+		Source:          "jquants",
+		DataType:        "brand",
+		TargetDate:      "2025-06-01",
+		TargetDateTime:  day,
+		ExecutionID:     7,
+		Request:         extract.NewManifestRequest("daily", nil, nil, nil),
+		FetchStartedAt:  fetchedAt,
+		FetchFinishedAt: fetchedAt.Add(time.Second),
+		Objects: []extract.ManifestObject{
+			extract.NewManifestObject(recorded, metadata, extract.ObjectVersion{}),
+			extract.NewManifestObject(unrecorded, metadata, extract.ObjectVersion{}),
+		},
+	}
+	manifestData, err := manifest.Marshal()
+	s.Require().NoError(err)
+	s.put(manifestKey, manifestData, nil)
+	s.put(recorded, data, metadata.ObjectMetadata())
+	s.put(unrecorded, data, metadata.ObjectMetadata())
+
+	uc := NewReindexUseCase(s.objects, s.repo)
+	report, err := uc.Reindex(ctx, &ReindexRequest{Source: "jquants", Timing: "daily", Location: jst})
+	s.Require().NoError(err)
+	s.Equal(&ReindexReport{
+		CreatedExecutions: 1,
+		CreatedFiles:      1,
+		Existing:          1,
+		Conflicts:         []ReindexConflict{},
+	}, report)
+
+	// The recorded file is not recorded again
+	executions, err := s.repo.ListExecutions(ctx, "jquants", "brand", nil, 10)
+	s.Require().NoError(err)
+	s.Require().Len(executions, 2)
+	s.Require().Len(executions[0].S3Files(), 1)
+	s.Equal(unrecorded, executions[0].S3Files()[0].Key())
+	s.Equal(manifestKey, executions[0].S3Files()[0].ManifestKey())
+	s.Require().Len(executions[1].S3Files(), 1)
+	s.Equal(recorded, executions[1].S3Files()[0].Key())
+}
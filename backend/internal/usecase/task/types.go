@@ -7,15 +7,20 @@ import (
 )
 
 type ExtractTaskRequest struct {
-	Source    string
-	DataType  string
-	Timing    string
-	Code      *string
-	StartDate *time.Time
-	EndDate   *time.Time
+	// Source and DataType name the configured data source and data type,
+	// whose settings the extraction runs with.
+	Source   string
+	DataType string
+	Timing   string
+	// TargetDate is the date the execution covers. Nil means the current time.
+	TargetDate *time.Time
+	Code       *string
+	StartDate  *time.Time
+	EndDate    *time.Time
 }
 
 type ExtractTaskResponse struct {
-	S3Key  string
-	Status extract.ExecutionStatus
+	ExecutionID int
+	S3Key       string
+	Status      extract.ExecutionStatus
 }
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"stock-tool/internal/domain/extract"
+)
+
+// ObjectInspector reads objects back from object storage.
+type ObjectInspector interface {
+	// HeadObject returns what storage reports about the object under key,
+	// or (nil, nil) if it does not exist.
+	HeadObject(ctx context.Context, key string) (*extract.ObjectInfo, error)
+	// GetObject opens the content of the object under key for reading, or
+	// returns (nil, nil) if it does not exist. The caller must close it.
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+	// ListObjects iterates over every object whose key starts with prefix.
+	ListObjects(ctx context.Context, prefix string) iter.Seq2[extract.ObjectSummary, error]
+}
+
+// LandingFileRepository lists the landing files recorded in the database.
+type LandingFileRepository interface {
+	// ListExtractedDataS3s returns the files written by executions matching
+	// filter, ordered by ID.
+	ListExtractedDataS3s(ctx context.Context, filter extract.FileFilter) ([]*extract.ExtractedDataS3, error)
+	// ListExtractedDataS3Keys returns every recorded key that starts with prefix.
+	ListExtractedDataS3Keys(ctx context.Context, prefix string) ([]string, error)
+}
+
+// VerifyMode selects how much of each object is read during verification.
+type VerifyMode string
+
+const (
+	// VerifyModeHead compares the size and the SHA-256 stored as object
+	// metadata without downloading the object.
+	VerifyModeHead VerifyMode = "head"
+	// VerifyModeGet downloads each object and recomputes its size and SHA-256.
+	VerifyModeGet VerifyMode = "get"
+)
+
+// IssueKind classifies a landing integrity problem.
+type IssueKind string
+
+const (
+	// IssueKindMissing is a recorded file with no object in storage.
+	IssueKindMissing IssueKind = "missing"
+	// IssueKindCorrupted is an object whose size or SHA-256 differs from the record.
+	IssueKindCorrupted IssueKind = "corrupted"
+	// IssueKindOrphaned is an object under landing/ with no record.
+	IssueKindOrphaned IssueKind = "orphaned"
+)
+
+type VerifyRequest struct {
+	Filter extract.FileFilter
+	Mode   VerifyMode
+}
+
+type VerifyIssue struct {
+	Kind   IssueKind
+	Key    string
+	Detail string
+}
+
+type VerifyReport struct {
+	// Checked is the number of recorded files that were compared with storage.
+	Checked int
+	// OK is the number of checked files that exist and match their record.
+	OK int
+	// Unverified is the number of checked files that exist but were recorded
+	// before metadata was tracked, so only their existence is known.
+	Unverified int
+	Issues     []VerifyIssue
+}
+
+type VerifyUseCase struct {
+	objects ObjectInspector
+	repo    LandingFileRepository
+}
+
+func NewVerifyUseCase(objects ObjectInspector, repo LandingFileRepository) *VerifyUseCase {
+	return &VerifyUseCase{
+		objects: objects,
+		repo:    repo,
+	}
+}
+
+// Verify compares the landing files recorded in the database with object
+// storage.
+//
+// Processing flow:
+//  1. Check every recorded file matching the filter for existence, size and
+//     SHA-256, streaming the object in get mode
+//  2. Report objects under the filter's key prefix that have no record
+//
+// A key recorded more than once, as under the overwrite re-run strategy, is
+// checked against its latest record only, since the object holds the latest
+// write. Orphans are looked up by key prefix only, since an object without a
+// record has no execution to take a target date from. Manifests are not
+// orphans.
+func (uc *VerifyUseCase) Verify(ctx context.Context, req *VerifyRequest) (*VerifyReport, error) {
+	report := &VerifyReport{Issues: []VerifyIssue{}}
+
+	// 1. Check recorded files
+	files, err := uc.repo.ListExtractedDataS3s(ctx, req.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list landing files: %w", err)
+	}
+	for _, f := range latestPerKey(files) {
+		issue, verified, err := uc.check(ctx, f, req.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", f.Key(), err)
+		}
+		report.Checked++
+		switch {
+		case issue != nil:
+			report.Issues = append(report.Issues, *issue)
+		case verified:
+			report.OK++
+		default:
+			report.Unverified++
+		}
+	}
+
+	// 2. Find orphans
+	prefix := req.Filter.KeyPrefix()
+	recordedKeys, err := uc.repo.ListExtractedDataS3Keys(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recorded keys under %s: %w", prefix, err)
+	}
+	recorded := make(map[string]bool, len(recordedKeys))
+	for _, k := range recordedKeys {
+		recorded[k] = true
+	}
+	for obj, err := range uc.objects.ListObjects(ctx, prefix) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, err)
+		}
+		// Manifests describe the files and have no record of their own
+		if !recorded[obj.Key] && !strings.HasPrefix(obj.Key, extract.ManifestKeyPrefix) {
+			report.Issues = append(report.Issues, VerifyIssue{
+				Kind:   IssueKindOrphaned,
+				Key:    obj.Key,
+				Detail: "object has no extracted_data_s3s record",
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// check compares one recorded file with storage. It returns the issue found,
+// if any, and whether the file could be compared beyond existence.
+func (uc *VerifyUseCase) check(
+	ctx context.Context,
+	f *extract.ExtractedDataS3,
+	mode VerifyMode,
+) (*VerifyIssue, bool, error) {
+	missing := &VerifyIssue{Kind: IssueKindMissing, Key: f.Key(), Detail: "object does not exist"}
+	expected := f.Metadata()
+
+	// Get mode reads objects decompressed, so it checks the bytes as fetched;
+	// head mode checks the size of the bytes stored.
+	var actualSize, expectedSize int64
+	var actualSHA256 string
+	if mode == VerifyModeGet {
+		body, err := uc.objects.GetObject(ctx, f.Key())
+		if err != nil {
+			return nil, false, err
+		}
+		if body == nil {
+			return missing, false, nil
+		}
+		defer body.Close()
+		if expected == nil {
+			return nil, false, nil
+		}
+		h := sha256.New()
+		if actualSize, err = io.Copy(h, body); err != nil {
+			return nil, false, err
+		}
+		actualSHA256 = hex.EncodeToString(h.Sum(nil))
+		expectedSize = expected.SizeBytes
+	} else {
+		info, err := uc.objects.HeadObject(ctx, f.Key())
+		if err != nil {
+			return nil, false, err
+		}
+		if info == nil {
+			return missing, false, nil
+		}
+		if expected == nil {
+			return nil, false, nil
+		}
+		actualSize, actualSHA256 = info.SizeBytes, info.Metadata[extract.MetadataKeySHA256]
+		expectedSize = expected.StoredSizeBytes()
+		compressedSHA256 := info.Metadata[extract.MetadataKeyCompressedSHA256]
+		if compressedSHA256 != "" && compressedSHA256 != expected.CompressedSHA256 {
+			return &VerifyIssue{
+				Kind: IssueKindCorrupted,
+				Key:  f.Key(),
+				Detail: fmt.Sprintf(
+					"compressed sha256 is %s, expected %s", compressedSHA256, expected.CompressedSHA256,
+				),
+			}, true, nil
+		}
+	}
+
+	if actualSize != expectedSize {
+		return &VerifyIssue{
+			Kind:   IssueKindCorrupted,
+			Key:    f.Key(),
+			Detail: fmt.Sprintf("size is %d, expected %d", actualSize, expectedSize),
+		}, true, nil
+	}
+	// An object without SHA-256 metadata can only be compared by size in head mode
+	if actualSHA256 != "" && actualSHA256 != expected.SHA256 {
+		return &VerifyIssue{
+			Kind:   IssueKindCorrupted,
+			Key:    f.Key(),
+			Detail: fmt.Sprintf("sha256 is %s, expected %s", actualSHA256, expected.SHA256),
+		}, true, nil
+	}
+	return nil, true, nil
+}
+
+// latestPerKey drops every file that a later file in files, which is ordered
+// by ID, recorded under the same key.
+func latestPerKey(files []*extract.ExtractedDataS3) []*extract.ExtractedDataS3 {
+	latest := make(map[string]int, len(files))
+	for i, f := range files {
+		latest[f.Key()] = i
+	}
+	result := make([]*extract.ExtractedDataS3, 0, len(latest))
+	for i, f := range files {
+		if latest[f.Key()] == i {
+			result = append(result, f)
+		}
+	}
+	return result
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+
+	"stock-tool/internal/domain/extract"
+	"stock-tool/internal/infra/repository"
+	"stock-tool/internal/infra/storage"
+	"stock-tool/internal/util/testutil"
+)
+
+type VerifyUseCaseTestSuite struct {
+	testutil.DBTest
+	s3Test   testutil.S3Test
+	repo     *repository.ExtractTaskRepository
+	s3Client *storage.S3Client
+}
+
+func TestVerifyUseCase(t *testing.T) {
+	suite.Run(t, new(VerifyUseCaseTestSuite))
+}
+
+func (s *VerifyUseCaseTestSuite) SetupSuite() {
+	s.DBTest.SetupSuite()
+	s.s3Test.SetT(s.T())
+	s.s3Test.SetupSuite()
+
+	var err error
+	s.s3Client, err = storage.NewS3Client(storage.S3Config{
+		Endpoint:       s.s3Test.Endpoint,
+		Bucket:         testutil.TestS3Bucket,
+		AccessKey:      testutil.TestS3AccessKey,
+		SecretKey:      testutil.TestS3SecretKey,
+		Region:         testutil.TestS3Region,
+		ForcePathStyle: true,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *VerifyUseCaseTestSuite) TearDownSuite() {
+	s.s3Test.TearDownSuite()
+	s.DBTest.TearDownSuite()
+}
+
+func (s *VerifyUseCaseTestSuite) SetupTest() {
+	s.ApplyMigrations()
+
+	db, err := s.RawDB().CreateGormDB()
+	s.Require().NoError(err)
+	s.repo = repository.NewExtractTaskRepository(db)
+}
+
+func (s *VerifyUseCaseTestSuite) TearDownTest() {
+	s.Require().NoError(s.CleanupMigrations())
+}
+
+// record stores a file record under a new execution of the given data type
+// and target date.
+func (s *VerifyUseCaseTestSuite) record(
+	dataType string,
+	target time.Time,
+	key string,
+	metadata *extract.FileMetadata,
+) {
+	ctx := context.Background()
+	task, err := s.repo.FindBySourceAndDataType(ctx, "jquants", dataType, "daily")
+	s.Require().NoError(err)
+	if task == nil {
+		s.Require().NoError(s.repo.Create(ctx, extract.NewExtractTask(ctx, "jquants", dataType, "daily")))
+		task, err = s.repo.FindBySourceAndDataType(ctx, "jquants", dataType, "daily")
+		s.Require().NoError(err)
+	}
+	exec, err := s.repo.CreateExecution(ctx, task.ID(), extract.NewRunningExecution(ctx, target))
+	s.Require().NoError(err)
+	now := time.Now()
+	_, err = s.repo.CreateExtractedDataS3(ctx, exec.ID(),
+		extract.NewExtractedDataS3Directly(0, key, "all", metadata, extract.ObjectVersion{}, "", nil, now, now))
+	s.Require().NoError(err)
+}
+
+func (s *VerifyUseCaseTestSuite) put(key string, data []byte, metadata map[string]string) {
+	_, err := s.s3Client.PutObject(context.Background(), key, data, "application/json", metadata)
+	s.Require().NoError(err)
+}
+
+func (s *VerifyUseCaseTestSuite) TestVerify() {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	data := []byte(`{"info":[]}`)
+	metadata := extract.NewFileMetadata(data, extract.FormatJSON, 200)
+
+	// ok
+	s.record("brand", day, "landing/jquants/brand/ok.json", &metadata)
+	s.put("landing/jquants/brand/ok.json", data, metadata.ObjectMetadata())
+	// missing
+	s.record("brand", day.AddDate(0, 0, 1), "landing/jquants/brand/missing.json", &metadata)
+	// corrupted content, with the original metadata copied along
+	s.record("brand", day.AddDate(0, 0, 2), "landing/jquants/brand/corrupted.json", &metadata)
+	s.put("landing/jquants/brand/corrupted.json", []byte(`{"info":{}}`), metadata.ObjectMetadata())
+	// legacy row without metadata
+	s.record("brand", day.AddDate(0, 0, 3), "landing/jquants/brand/legacy.json", nil)
+	s.put("landing/jquants/brand/legacy.json", data, nil)
+	// orphan
+	s.put("landing/jquants/brand/orphan.json", data, nil)
+	// outside the filter
+	s.put("landing/jquants/other/orphan.json", data, nil)
+	// manifests are never orphans
+	s.put("landing/_manifests/jquants/brand/2026/10/01/execution-1.json", data, nil)
+
+	uc := NewVerifyUseCase(s.s3Client, s.repo)
+	filter := extract.FileFilter{Source: "jquants", DataType: "brand"}
+
+	s.Run("get", func() {
+		report, err := uc.Verify(ctx, &VerifyRequest{Filter: filter, Mode: VerifyModeGet})
+		s.Require().NoError(err)
+		s.Equal(&VerifyReport{
+			Checked:    4,
+			OK:         1,
+			Unverified: 1,
+			Issues: []VerifyIssue{
+				{Kind: IssueKindMissing, Key: "landing/jquants/brand/missing.json", Detail: "object does not exist"},
+				{
+					Kind: IssueKindCorrupted,
+					Key:  "landing/jquants/brand/corrupted.json",
+					Detail: "sha256 is " + extract.NewFileMetadata([]byte(`{"info":{}}`), extract.FormatJSON, 0).SHA256 +
+						", expected " + metadata.SHA256,
+				},
+				{
+					Kind:   IssueKindOrphaned,
+					Key:    "landing/jquants/brand/orphan.json",
+					Detail: "object has no extracted_data_s3s record",
+				},
+			},
+		}, report)
+	})
+
+	s.Run("head", func() {
+		// Same size and copied metadata, so head mode cannot see the corruption
+		report, err := uc.Verify(ctx, &VerifyRequest{Filter: filter, Mode: VerifyModeHead})
+		s.Require().NoError(err)
+		s.Equal(4, report.Checked)
+		s.Equal(2, report.OK)
+		s.Equal(1, report.Unverified)
+		s.Len(report.Issues, 2)
+	})
+
+	s.Run("all sources", func() {
+		report, err := uc.Verify(ctx, &VerifyRequest{Mode: VerifyModeHead})
+		s.Require().NoError(err)
+		orphans := lo.FilterMap(report.Issues, func(issue VerifyIssue, _ int) (string, bool) {
+			return issue.Key, issue.Kind == IssueKindOrphaned
+		})
+		s.Equal([]string{"landing/jquants/brand/orphan.json", "landing/jquants/other/orphan.json"}, orphans)
+	})
+
+	s.Run("date range", func() {
+		from, to := day.AddDate(0, 0, 1), day.AddDate(0, 0, 2)
+		report, err := uc.Verify(ctx, &VerifyRequest{
+			Filter: extract.FileFilter{Source: "jquants", DataType: "brand", From: &from, To: &to},
+			Mode:   VerifyModeHead,
+		})
+		s.Require().NoError(err)
+		s.Equal(1, report.Checked)
+		s.Equal(IssueKindMissing, report.Issues[0].Kind)
+	})
+}
+
+func (s *VerifyUseCaseTestSuite) TestVerify_Compressed() {
+	ctx := context.Background()
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	data := []byte(`{"info":[]}`)
+	compressed, err := extract.EncodingGzip.Compress(data)
+	s.Require().NoError(err)
+	metadata := extract.NewFileMetadata(data, extract.FormatJSON, 200).
+		Compressed(extract.EncodingGzip, extract.NewFileMetadata(compressed, extract.FormatJSON, 200))
+
+	key := "landing/jquants/brand/ok.json.gz"
+	s.record("brand", day, key, &metadata)
+	_, err = s.s3Client.PutObjectStream(ctx, key, bytes.NewReader(compressed), "application/json",
+		extract.EncodingGzip, metadata.ObjectMetadata())
+	s.Require().NoError(err)
+
+	uc := NewVerifyUseCase(s.s3Client, s.repo)
+	filter := extract.FileFilter{Source: "jquants", DataType: "brand"}
+	for _, mode := range []VerifyMode{VerifyModeGet, VerifyModeHead} {
+		s.Run(string(mode), func() {
+			report, err := uc.Verify(ctx, &VerifyRequest{Filter: filter, Mode: mode})
+			s.Require().NoError(err)
+			s.Equal(&VerifyReport{Checked: 1, OK: 1}, report)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Package mergepatch implements JSON Merge Patch (RFC 7396) over decoded JSON
+// objects. A patch member set to null removes the key from the target, a
+// nested object is merged recursively, and any other value replaces the
+// target value wholesale (arrays included).
+package mergepatch
+
+import "maps"
+
+// Apply returns the result of merging patch into target. Neither argument is
+// modified; nested objects in the result share no maps with patch.
+func Apply(target, patch map[string]any) map[string]any {
+	result := make(map[string]any, len(target)+len(patch))
+	maps.Copy(result, target)
+	for key, value := range patch {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = merge(result[key], value)
+	}
+	return result
+}
+
+func merge(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObj, _ := target.(map[string]any)
+	return Apply(targetObj, patchObj)
+}
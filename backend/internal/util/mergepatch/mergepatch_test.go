@@ -0,0 +1,67 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Cases from RFC 7396 Appendix A, restricted to object targets and patches.
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		patch    string
+		expected string
+	}{
+		{name: "replace value", target: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "add member", target: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{name: "remove member", target: `{"a":"b"}`, patch: `{"a":null}`, expected: `{}`},
+		{name: "remove one of two", target: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{name: "array replaces value", target: `{"a":["b"]}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "value replaces array", target: `{"a":"c"}`, patch: `{"a":["b"]}`, expected: `{"a":["b"]}`},
+		{
+			name:     "nested merge",
+			target:   `{"a":{"b":"c"}}`,
+			patch:    `{"a":{"b":"d","c":null}}`,
+			expected: `{"a":{"b":"d"}}`,
+		},
+		{name: "array is not merged", target: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, expected: `{"a":[1]}`},
+		{name: "null inside new object is dropped", target: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, expected: `{"a":{"bb":{}}}`},
+		{name: "null value in target kept", target: `{"e":null}`, patch: `{"a":1}`, expected: `{"e":null,"a":1}`},
+		{name: "empty patch", target: `{"a":"b"}`, patch: `{}`, expected: `{"a":"b"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Apply(decode(t, tt.target), decode(t, tt.patch))
+			expected := decode(t, tt.expected)
+			if !cmp.Equal(expected, got) {
+				t.Error(cmp.Diff(expected, got))
+			}
+		})
+	}
+}
+
+func TestApply_DoesNotModifyArguments(t *testing.T) {
+	target := map[string]any{"a": map[string]any{"b": "c"}}
+	patch := map[string]any{"a": map[string]any{"b": nil, "d": "e"}}
+
+	Apply(target, patch)
+
+	if diff := cmp.Diff(map[string]any{"a": map[string]any{"b": "c"}}, target); diff != "" {
+		t.Errorf("target modified: %s", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"a": map[string]any{"b": nil, "d": "e"}}, patch); diff != "" {
+		t.Errorf("patch modified: %s", diff)
+	}
+}
+
+func decode(t *testing.T, s string) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("decode %s: %v", s, err)
+	}
+	return m
+}
@@ -0,0 +1,142 @@
+// Package pagination provides keyset pagination over lists keyed by UUIDv7.
+// Because UUIDv7 values are time-ordered, sorting by ID lists items in
+// creation order. Cursors are opaque strings that encode the sort key of the
+// last item on a page, so later pages are stable under concurrent inserts.
+// Lists keyed by an integer sequence use SeqCursor instead.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultLimit is the page size used when the caller does not specify one.
+	DefaultLimit = 50
+	// MaxLimit is the largest page size a caller may request.
+	MaxLimit = 100
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type SortField string
+
+const (
+	SortFieldID   SortField = "id"
+	SortFieldName SortField = "name"
+)
+
+// Sort is an ordering over a SortField. Ties are always broken by ID.
+type Sort struct {
+	Field SortField
+	Desc  bool
+}
+
+// ParseSort parses "field" or "-field" (descending). An empty string
+// yields ascending ID order.
+func ParseSort(s string) (Sort, error) {
+	if s == "" {
+		return Sort{Field: SortFieldID}, nil
+	}
+	desc := strings.HasPrefix(s, "-")
+	field := SortField(strings.TrimPrefix(s, "-"))
+	switch field {
+	case SortFieldID, SortFieldName:
+		return Sort{Field: field, Desc: desc}, nil
+	default:
+		return Sort{}, fmt.Errorf("unsupported sort field: %s", field)
+	}
+}
+
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + string(s.Field)
+	}
+	return string(s.Field)
+}
+
+// Cursor identifies the last item of a page under a given Sort.
+type Cursor struct {
+	Sort Sort
+	ID   uuid.UUID
+	// Name is set only when Sort.Field is SortFieldName.
+	Name string
+}
+
+type cursorJSON struct {
+	Sort string    `json:"s"`
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"n,omitempty"`
+}
+
+// Encode returns the opaque string form of the cursor.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(cursorJSON{Sort: c.Sort.String(), ID: c.ID, Name: c.Name})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a string produced by Cursor.Encode.
+// Returns an error wrapping ErrInvalidCursor on malformed input.
+func DecodeCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	var cj cursorJSON
+	if err := json.Unmarshal(b, &cj); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	sort, err := ParseSort(cj.Sort)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return Cursor{Sort: sort, ID: cj.ID, Name: cj.Name}, nil
+}
+
+// Params selects one page of a list.
+type Params struct {
+	// Limit is the maximum number of items to return.
+	Limit int
+	Sort  Sort
+	// After, when set, restricts the page to items strictly after the cursor.
+	After *Cursor
+}
+
+// SeqCursor identifies the last item of a page of a list keyed by an integer
+// sequence and listed newest first, such as extract task executions.
+type SeqCursor struct {
+	ID int
+}
+
+type seqCursorJSON struct {
+	ID int `json:"seq"`
+}
+
+// Encode returns the opaque string form of the cursor.
+func (c SeqCursor) Encode() string {
+	b, _ := json.Marshal(seqCursorJSON(c))
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeSeqCursor parses a string produced by SeqCursor.Encode.
+// Returns an error wrapping ErrInvalidCursor on malformed input.
+func DecodeSeqCursor(s string) (SeqCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return SeqCursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	var cj seqCursorJSON
+	if err := json.Unmarshal(b, &cj); err != nil {
+		return SeqCursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if cj.ID < 1 {
+		return SeqCursor{}, fmt.Errorf("%w: sequence must be positive", ErrInvalidCursor)
+	}
+	return SeqCursor(cj), nil
+}
@@ -0,0 +1,78 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type PaginationTestSuite struct {
+	suite.Suite
+}
+
+func TestPagination(t *testing.T) {
+	suite.Run(t, new(PaginationTestSuite))
+}
+
+func (s *PaginationTestSuite) TestParseSort() {
+	type testCase struct {
+		name      string
+		input     string
+		expected  Sort
+		expectErr bool
+	}
+	tests := []testCase{
+		{name: "empty defaults to id ascending", input: "", expected: Sort{Field: SortFieldID}},
+		{name: "name ascending", input: "name", expected: Sort{Field: SortFieldName}},
+		{name: "id descending", input: "-id", expected: Sort{Field: SortFieldID, Desc: true}},
+		{name: "unknown field", input: "-created", expectErr: true},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			got, err := ParseSort(tt.input)
+			if tt.expectErr {
+				s.Error(err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tt.expected, got)
+			s.Equal(tt.input != "", got.String() == tt.input)
+		})
+	}
+}
+
+func (s *PaginationTestSuite) TestCursor_RoundTrip() {
+	c := Cursor{
+		Sort: Sort{Field: SortFieldName, Desc: true},
+		ID:   uuid.MustParse("01961a3d-0000-7000-8000-000000000001"),
+		Name: "daily_quotes",
+	}
+
+	got, err := DecodeCursor(c.Encode())
+
+	s.Require().NoError(err)
+	s.True(cmp.Equal(c, got), cmp.Diff(c, got))
+}
+
+func (s *PaginationTestSuite) TestDecodeCursor_Invalid() {
+	for _, input := range []string{"%%%", "bm90LWpzb24", Cursor{Sort: Sort{Field: "created"}}.Encode()} {
+		_, err := DecodeCursor(input)
+		s.ErrorIs(err, ErrInvalidCursor, input)
+	}
+}
+
+func (s *PaginationTestSuite) TestSeqCursor_RoundTrip() {
+	c := SeqCursor{ID: 42}
+	got, err := DecodeSeqCursor(c.Encode())
+	s.Require().NoError(err)
+	s.Equal(c, got)
+}
+
+func (s *PaginationTestSuite) TestDecodeSeqCursor_Invalid() {
+	for _, input := range []string{"%%%", "bm90LWpzb24", SeqCursor{}.Encode()} {
+		_, err := DecodeSeqCursor(input)
+		s.ErrorIs(err, ErrInvalidCursor, input)
+	}
+}
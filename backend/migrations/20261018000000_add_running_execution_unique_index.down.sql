@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP INDEX IF EXISTS stock.extract_task_executions_running_target_key;
+
+COMMIT;
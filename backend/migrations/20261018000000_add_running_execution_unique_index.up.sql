@@ -0,0 +1,7 @@
+BEGIN;
+
+CREATE UNIQUE INDEX extract_task_executions_running_target_key
+    ON stock.extract_task_executions (extract_task_id, target_date_time)
+    WHERE status = 'running';
+
+COMMIT;
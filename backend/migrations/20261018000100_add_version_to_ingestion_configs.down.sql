@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE stock.data_types DROP COLUMN IF EXISTS version;
+ALTER TABLE stock.data_sources DROP COLUMN IF EXISTS version;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE stock.data_sources ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE stock.data_types ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE stock.data_sources DROP COLUMN IF EXISTS kind;
+
+COMMIT;
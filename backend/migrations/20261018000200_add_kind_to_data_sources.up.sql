@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE stock.data_sources ADD COLUMN kind TEXT NOT NULL DEFAULT 'generic';
+
+COMMIT;
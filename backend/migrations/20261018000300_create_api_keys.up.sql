@@ -0,0 +1,19 @@
+BEGIN;
+
+--
+-- api_keys
+--
+CREATE TABLE stock.api_keys (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    role TEXT NOT NULL,
+    key_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    CONSTRAINT api_keys_key_hash_key UNIQUE (key_hash),
+    CONSTRAINT api_keys_role_check CHECK (role IN ('read_only', 'admin'))
+);
+
+CREATE INDEX ON stock.api_keys (created_at);
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+--
+-- audit_events
+--
+-- entity_id has no foreign key: events must outlive the entities they describe.
+CREATE TABLE stock.audit_events (
+    id UUID PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+    before JSONB,
+    after JSONB,
+    CONSTRAINT audit_events_action_check CHECK (action IN ('create', 'update', 'delete')),
+    CONSTRAINT audit_events_entity_type_check CHECK (entity_type IN ('data_source', 'data_type'))
+);
+
+CREATE INDEX ON stock.audit_events (entity_type, entity_id, occurred_at);
+CREATE INDEX ON stock.audit_events (occurred_at);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE stock.extracted_data_s3s
+    DROP COLUMN IF EXISTS size_bytes,
+    DROP COLUMN IF EXISTS sha256,
+    DROP COLUMN IF EXISTS format,
+    DROP COLUMN IF EXISTS content_type,
+    DROP COLUMN IF EXISTS http_status;
+
+COMMIT;
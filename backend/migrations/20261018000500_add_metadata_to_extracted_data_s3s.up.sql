@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Metadata is NULL for files recorded before it was tracked.
+ALTER TABLE stock.extracted_data_s3s
+    ADD COLUMN size_bytes BIGINT CHECK (size_bytes >= 0),
+    ADD COLUMN sha256 TEXT CHECK (sha256 ~ '^[0-9a-f]{64}$'),
+    ADD COLUMN format TEXT,
+    ADD COLUMN content_type TEXT,
+    ADD COLUMN http_status INTEGER;
+
+CREATE INDEX ON stock.extracted_data_s3s (sha256);
+
+COMMIT;
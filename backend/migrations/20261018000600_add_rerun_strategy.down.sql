@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE stock.extracted_data_s3s
+    DROP COLUMN IF EXISTS version_id,
+    DROP COLUMN IF EXISTS superseded_version_id;
+
+ALTER TABLE stock.data_types DROP COLUMN IF EXISTS rerun_strategy;
+
+COMMIT;
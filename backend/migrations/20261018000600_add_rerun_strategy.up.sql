@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE stock.data_types
+    ADD COLUMN rerun_strategy TEXT NOT NULL DEFAULT 'append'
+        CHECK (rerun_strategy IN ('append', 'overwrite'));
+
+-- Version IDs are NULL when the bucket is not versioned or for files
+-- recorded before they were tracked.
+ALTER TABLE stock.extracted_data_s3s
+    ADD COLUMN version_id TEXT,
+    ADD COLUMN superseded_version_id TEXT;
+
+COMMIT;
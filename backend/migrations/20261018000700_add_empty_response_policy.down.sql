@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE stock.extract_task_executions DROP COLUMN IF EXISTS error_category;
+
+ALTER TABLE stock.data_types DROP COLUMN IF EXISTS empty_response_policy;
+
+COMMIT;
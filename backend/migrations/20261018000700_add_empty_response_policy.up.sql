@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE stock.data_types
+    ADD COLUMN empty_response_policy TEXT NOT NULL DEFAULT 'success'
+        CHECK (empty_response_policy IN ('success', 'fail', 'retry_later'));
+
+-- NULL for failures that are not categorized and for executions that did
+-- not fail.
+ALTER TABLE stock.extract_task_executions
+    ADD COLUMN error_category TEXT
+        CHECK (error_category IN ('empty_response', 'deferred'));
+
+COMMIT;
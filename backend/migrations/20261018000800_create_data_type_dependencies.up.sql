@@ -0,0 +1,16 @@
+BEGIN;
+
+--
+-- data_type_dependencies
+--
+CREATE TABLE stock.data_type_dependencies (
+    data_type_id UUID NOT NULL REFERENCES stock.data_types(id) ON DELETE CASCADE,
+    depends_on_id UUID NOT NULL REFERENCES stock.data_types(id) ON DELETE CASCADE,
+    max_lag_days INTEGER NOT NULL DEFAULT 0 CHECK (max_lag_days >= 0),
+    PRIMARY KEY (data_type_id, depends_on_id),
+    CHECK (data_type_id <> depends_on_id)
+);
+
+CREATE INDEX ON stock.data_type_dependencies (depends_on_id);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE stock.extracted_data_s3s
+    DROP COLUMN IF EXISTS compressed_sha256,
+    DROP COLUMN IF EXISTS compressed_size_bytes,
+    DROP COLUMN IF EXISTS content_encoding;
+
+ALTER TABLE stock.data_types DROP COLUMN IF EXISTS compression;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+ALTER TABLE stock.data_types
+    ADD COLUMN compression TEXT NOT NULL DEFAULT 'none'
+        CHECK (compression IN ('none', 'gzip', 'zstd'));
+
+-- NULL for files stored as fetched. size_bytes and sha256 keep describing
+-- the bytes as fetched; the compressed columns describe the bytes stored.
+ALTER TABLE stock.extracted_data_s3s
+    ADD COLUMN content_encoding TEXT
+        CHECK (content_encoding IN ('gzip', 'zstd')),
+    ADD COLUMN compressed_size_bytes BIGINT,
+    ADD COLUMN compressed_sha256 TEXT;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE stock.extracted_data_s3s DROP COLUMN IF EXISTS purged_at;
+
+ALTER TABLE stock.data_types
+    DROP COLUMN IF EXISTS retention_superseded_max_age_days,
+    DROP COLUMN IF EXISTS retention_keep_latest;
+
+COMMIT;
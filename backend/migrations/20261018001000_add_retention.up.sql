@@ -0,0 +1,15 @@
+BEGIN;
+
+-- NULL for retention rules that are not set; with neither set, every file
+-- is kept.
+ALTER TABLE stock.data_types
+    ADD COLUMN retention_keep_latest INTEGER
+        CHECK (retention_keep_latest >= 1),
+    ADD COLUMN retention_superseded_max_age_days INTEGER
+        CHECK (retention_superseded_max_age_days >= 0);
+
+-- Set when task gc removes the object; the row is kept for lineage.
+ALTER TABLE stock.extracted_data_s3s
+    ADD COLUMN purged_at TIMESTAMPTZ;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE stock.extracted_data_s3s DROP COLUMN IF EXISTS manifest_key;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Key of the manifest written under landing/_manifests/ by the execution
+-- that landed the file; NULL for files recorded before manifests existed.
+ALTER TABLE stock.extracted_data_s3s
+    ADD COLUMN manifest_key TEXT;
+
+COMMIT;
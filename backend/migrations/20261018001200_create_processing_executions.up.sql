@@ -0,0 +1,39 @@
+BEGIN;
+
+--
+-- processing_executions
+--
+-- One row per batch run moving data from one zone to the next. Lineage is
+-- traced by matching input_key_patterns ('*' matches any sequence) to the
+-- output_refs of the executions of the source zone; both are JSON arrays of
+-- strings.
+CREATE TABLE stock.processing_executions (
+    id SERIAL PRIMARY KEY,
+    source_zone TEXT NOT NULL,
+    target_zone TEXT NOT NULL,
+    source_identifier TEXT NOT NULL,
+    target_identifier TEXT NOT NULL,
+    input_key_patterns JSONB NOT NULL DEFAULT '[]',
+    output_refs JSONB NOT NULL DEFAULT '[]',
+    records_read INTEGER NOT NULL DEFAULT 0,
+    records_written INTEGER NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    error_info TEXT,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CONSTRAINT processing_executions_source_zone_check
+        CHECK (source_zone IN ('landing', 'bronze', 'silver')),
+    CONSTRAINT processing_executions_target_zone_check
+        CHECK (target_zone IN ('bronze', 'silver', 'gold')),
+    CONSTRAINT processing_executions_status_check
+        CHECK (status IN ('running', 'succeeded', 'failed'))
+);
+
+CREATE INDEX ON stock.processing_executions (target_zone, target_identifier);
+CREATE INDEX ON stock.processing_executions (status);
+CREATE INDEX ON stock.processing_executions USING GIN (output_refs jsonb_path_ops);
+CREATE INDEX ON stock.processing_executions (created_at);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+-- The backfill cannot be told apart from a kind set on purpose, so it is kept.
+
+COMMIT;
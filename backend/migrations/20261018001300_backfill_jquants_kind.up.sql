@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Sources created before kinds existed defaulted to 'generic'. The J-Quants
+-- source is recognized by its name; without a plan it is on the free plan.
+UPDATE stock.data_sources
+SET kind = 'jquants',
+    settings = CASE WHEN settings ? 'plan' THEN settings ELSE settings || '{"plan": "free"}' END
+WHERE name = 'jquants' AND kind = 'generic';
+
+COMMIT;
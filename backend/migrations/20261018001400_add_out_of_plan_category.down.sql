@@ -0,0 +1,10 @@
+BEGIN;
+
+UPDATE stock.extract_task_executions SET error_category = NULL WHERE error_category = 'out_of_plan';
+
+ALTER TABLE stock.extract_task_executions
+    DROP CONSTRAINT extract_task_executions_error_category_check,
+    ADD CONSTRAINT extract_task_executions_error_category_check
+        CHECK (error_category IN ('empty_response', 'deferred'));
+
+COMMIT;
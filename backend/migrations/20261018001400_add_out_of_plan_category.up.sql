@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE stock.extract_task_executions
+    DROP CONSTRAINT extract_task_executions_error_category_check,
+    ADD CONSTRAINT extract_task_executions_error_category_check
+        CHECK (error_category IN ('empty_response', 'deferred', 'out_of_plan'));
+
+COMMIT;
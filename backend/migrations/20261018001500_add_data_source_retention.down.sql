@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE stock.data_sources
+    DROP COLUMN IF EXISTS retention_superseded_max_age_days,
+    DROP COLUMN IF EXISTS retention_keep_latest;
+
+COMMIT;
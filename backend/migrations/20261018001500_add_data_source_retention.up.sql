@@ -0,0 +1,11 @@
+BEGIN;
+
+-- The default retention rules of the data types of the source that set no
+-- rule of their own; NULL for rules that are not set.
+ALTER TABLE stock.data_sources
+    ADD COLUMN retention_keep_latest INTEGER
+        CHECK (retention_keep_latest >= 1),
+    ADD COLUMN retention_superseded_max_age_days INTEGER
+        CHECK (retention_superseded_max_age_days >= 0);
+
+COMMIT;
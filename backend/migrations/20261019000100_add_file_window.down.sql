@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE stock.extracted_data_s3s
+    DROP COLUMN IF EXISTS file_window;
+
+COMMIT;
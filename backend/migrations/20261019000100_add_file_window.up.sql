@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Window of the target date the file holds, as named by FileWindow. An
+-- overwrite key names its window; a file under an append key recorded before
+-- windows were tracked is taken to hold the whole date.
+ALTER TABLE stock.extracted_data_s3s
+    ADD COLUMN file_window TEXT NOT NULL DEFAULT 'all';
+
+UPDATE stock.extracted_data_s3s
+SET file_window = split_part(substring(key FROM '[^/]+$'), '.', 1)
+WHERE key LIKE 'landing/%'
+  AND substring(key FROM '[^/]+$') !~ '^\d{8}T\d{6}Z_[0-9a-f]{8}\.';
+
+ALTER TABLE stock.extracted_data_s3s
+    ALTER COLUMN file_window DROP DEFAULT;
+
+COMMIT;